@@ -0,0 +1,73 @@
+// Command cash_balance_daily_rebuild recomputes the cash_balance_daily table
+// from the cash_launch table and reports every day that had drifted.
+package main
+
+import (
+	"os"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/migration"
+	repository "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/postgres"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/hashicorp/go-hclog"
+)
+
+func main() {
+	// create a new logger
+	log := hclog.New(&hclog.LoggerOptions{
+		Name:       "minsait-cash-balance-daily-rebuild",
+		JSONFormat: false,
+		Level:      hclog.LevelFromString("DEBUG"),
+	})
+
+	// load configs
+	config, err := util.LoadConfig(".")
+
+	if err != nil {
+		log.Error("Cannot load application configs", "error", err)
+		os.Exit(1)
+	}
+
+	// run database migration
+	err = migration.Run(config)
+
+	if err != nil {
+		log.Error("Cannot run db migration", "error", err)
+		os.Exit(1)
+	}
+
+	// create a new repository
+	repository, err := repository.NewPostgres(config)
+
+	if err != nil {
+		log.Error("Cannot connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	defer repository.Close()
+
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repository.CashBalanceDaily())
+
+	modelCashBalanceDailyDrifts, err := usecaseCashBalanceDaily.Rebuild()
+
+	if err != nil {
+		log.Error("Cannot rebuild cash balance daily", "error", err)
+		os.Exit(1)
+	}
+
+	for _, modelCashBalanceDailyDrift := range modelCashBalanceDailyDrifts {
+		log.Warn(
+			"Cash balance daily drift",
+			"account_id", modelCashBalanceDailyDrift.AccountID,
+			"reference_date", modelCashBalanceDailyDrift.ReferenceDate.Format("2006-01-02"),
+			"stored_total_credit", modelCashBalanceDailyDrift.Stored.TotalCredit,
+			"rebuilt_total_credit", modelCashBalanceDailyDrift.Rebuilt.TotalCredit,
+			"stored_total_debit", modelCashBalanceDailyDrift.Stored.TotalDebit,
+			"rebuilt_total_debit", modelCashBalanceDailyDrift.Rebuilt.TotalDebit,
+			"stored_closing_balance", modelCashBalanceDailyDrift.Stored.ClosingBalance,
+			"rebuilt_closing_balance", modelCashBalanceDailyDrift.Rebuilt.ClosingBalance,
+		)
+	}
+
+	log.Info("Cash balance daily rebuilt successfuly", "drifts", len(modelCashBalanceDailyDrifts))
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashAccount struct {
+	Title              string
+	Log                hclog.Logger
+	UseCaseCashAccount usecase.CashAccount
+}
+
+func NewCashAccount(log hclog.Logger, useCaseCashAccount usecase.CashAccount) *CashAccount {
+	return &CashAccount{
+		Title:              "CashAccount",
+		Log:                log,
+		UseCaseCashAccount: useCaseCashAccount,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona Conta (conta bancária ou caixa)
+// @Tags         Contas
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashAccountWrapper  true  "Conta"
+// @Success      201  {object}  model.CashAccount
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/account [post]
+func (controllerCashAccount *CashAccount) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashAccount := &model.CashAccount{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashAccount)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashAccount.Title)
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashAccountInsert, err := controllerCashAccount.UseCaseCashAccount.Insert(modelCashAccount)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashAccount.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashAccount.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashAccount.Title)
+
+			logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashAccountInsert)
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna a lista de Contas ordenada pelo Nome
+// @Tags         Contas
+// @Accept       json
+// @Produce      json
+// @Success      200 {object}  model.CashAccounts
+// @Failure      500  {object}  model.Error
+// @Router       /cash/account [get]
+func (controllerCashAccount *CashAccount) List(rw http.ResponseWriter, req *http.Request) {
+	modelCashAccounts, err := controllerCashAccount.UseCaseCashAccount.List()
+
+	if err != nil {
+		responseError := model.InternalServerErrorRepositoryLoad(controllerCashAccount.Title)
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashAccounts)
+}
+
+// GetByID godoc
+// @Summary      Consultar
+// @Description  Retorna uma Conta
+// @Tags         Contas
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Conta" example("1")
+// @Success      200 {object}  model.CashAccount
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/account/{id} [get]
+func (controllerCashAccount *CashAccount) GetByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashAccount, err := controllerCashAccount.UseCaseCashAccount.GetByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashAccount.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashAccount.Title)
+
+			logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashAccount)
+}
+
+// Update godoc
+// @Summary      Alterar
+// @Description  Altera uma Conta
+// @Tags         Contas
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Conta" example("1")
+// @Param        request   body      model.parametersCashAccountWrapper  true  "Conta"
+// @Success      200 {object}  model.CashAccount
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/account/{id} [put]
+func (controllerCashAccount *CashAccount) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashAccount := &model.CashAccount{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashAccount)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashAccount.Title)
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashAccount.ID = id
+
+	modelCashAccountUpdate, err := controllerCashAccount.UseCaseCashAccount.Update(modelCashAccount)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashAccount.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashAccount.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashAccount.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashAccount.Title)
+
+			logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashAccountUpdate)
+}
+
+// DeleteByID godoc
+// @Summary      Excluir
+// @Description  Exclui uma Conta. Não é possível excluir uma Conta que possui Lançamentos.
+// @Tags         Contas
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Conta" example("1")
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/account/{id} [delete]
+func (controllerCashAccount *CashAccount) DeleteByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	err = controllerCashAccount.UseCaseCashAccount.DeleteByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashAccount.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else if errReferenced, ok := err.(repository.ErrReferenced); ok {
+			responseError = model.ConflictRepositoryReferenced(controllerCashAccount.Title, referencedMessage("account", errReferenced))
+
+			rw.WriteHeader(http.StatusConflict)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashAccount.Title)
+
+			logger.LogErrorRequest(controllerCashAccount.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// referencedMessage names the table still referencing the record that can not
+// be deleted, when the repository informs it
+func referencedMessage(name string, errReferenced repository.ErrReferenced) string {
+	if errReferenced.Table == "" {
+		return fmt.Sprintf("the %s is still referenced", name)
+	}
+
+	return fmt.Sprintf("the %s is still referenced from table %s", name, errReferenced.Table)
+}
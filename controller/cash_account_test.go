@@ -0,0 +1,189 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashAccountTitle = "CashAccount"
+
+func TestCashAccountInsert(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      interface{}
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	tests := []test{
+		{
+			name:         "DeserializeError",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashAccountTitle),
+		},
+		{
+			name:         "ModelValidateError",
+			reqBody:      &model.CashAccount{},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashAccountTitle, usecase.CashAccountMessageNameEmptyError),
+		},
+		{
+			name:         "DuplicateKeyError",
+			reqBody:      &model.CashAccount{Name: "caixa"},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestRepositoryPersist(controllerCashAccountTitle, "Key (name)=(CAIXA) already exists."),
+		},
+		{
+			name:         "RepositoryError",
+			reqBody:      &model.CashAccount{Name: "CONTA"},
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashAccountTitle),
+		},
+		{
+			name:        "Success",
+			reqBody:     &model.CashAccount{Name: "conta investimento"},
+			wantResCode: http.StatusCreated,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashAccount := &model.CashAccount{}
+				json.NewDecoder(res.Body).Decode(resultCashAccount)
+
+				assert.NotEqual(t, int64(0), resultCashAccount.ID)
+				assert.Equal(t, "CONTA INVESTIMENTO", resultCashAccount.Name)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashAccount := usecase.NewCashAccount(repository.CashAccount())
+			controllerCashAccount := controller.NewCashAccount(log, usecaseCashAccount)
+
+			reqBody, _ := json.Marshal(tt.reqBody)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/account", bytes.NewBuffer(reqBody))
+			handler := http.HandlerFunc(controllerCashAccount.Insert)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Insert() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashAccountDeleteByID(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	modelCashAccount, err := usecase.NewCashAccount(repositoryInMemory.CashAccount()).Insert(&model.CashAccount{Name: "CONTA SEM LANCAMENTOS"})
+	assert.Nil(t, err)
+
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashAccountTitle),
+		},
+		{
+			name:         "ReferencedError",
+			reqParam:     "1",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusConflict,
+			wantResBody:  model.ConflictRepositoryReferenced(controllerCashAccountTitle, "the account is still referenced from table cash_launch"),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     fmt.Sprint(modelCashAccount.ID),
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashAccountTitle),
+		},
+		{
+			name:        "Success",
+			reqParam:    fmt.Sprint(modelCashAccount.ID),
+			wantResCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashAccount := usecase.NewCashAccount(repository.CashAccount())
+			controllerCashAccount := controller.NewCashAccount(log, usecaseCashAccount)
+
+			url := fmt.Sprintf("/api/cash/account/%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodDelete, url, nil)
+			handler := http.HandlerFunc(controllerCashAccount.DeleteByID)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("DeleteByID() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.resBodyModel != nil {
+				json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("DeleteByID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
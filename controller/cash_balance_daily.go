@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,11 +30,15 @@ func NewCashBalanceDaily(log hclog.Logger, useCaseCashBalanceDaily usecase.CashB
 
 // GetByReferenceDate godoc
 // @Summary      Consultar
-// @Description  Retorna o Saldo de todos os Lançamentos realizado na Data Informada. Se não for encontrado nenhum lançamento então será retornado com saldo zero.
+// @Description  Retorna o Saldo Inicial, os Totais de Créditos e Débitos, o Saldo do Dia e o Saldo Final na Data Informada convertidos para a Moeda Base. O Saldo Inicial acumula todos os Lançamentos anteriores à Data Informada. Se não for encontrado nenhum lançamento então será retornado o saldo acumulado até a data.
 // @Tags         Saldo Diário
 // @Accept       json
 // @Produce      json
 // @Param        date   path      string  false  "Data de Referencia (AAAA-MM-DD)" example("2020-05-23")
+// @Param        account_id query  int     false  "Id da Conta (quando não informado retorna o saldo de todas as Contas)" example(1)
+// @Param        breakdown query   string  false  "Informar currency para detalhar os Totais do Dia por Moeda original" Enums(currency)
+// @Param        include_deleted query  bool  false  "Inclui os Lançamentos excluídos" default(false)
+// @Param        projected query  bool  false  "Saldo projetado incluindo os Lançamentos pendentes de aprovação" default(false)
 // @Success      200  {object}  model.CashBalanceDaily
 // @Failure      400  {object}  model.Error
 // @Failure      500  {object}  model.Error
@@ -59,7 +64,38 @@ func (controllerCashBalanceDaily *CashBalanceDaily) GetByReferenceDate(rw http.R
 		return
 	}
 
-	modelCashBalanceDaily, err := controllerCashBalanceDaily.UseCaseCashBalanceDaily.GetByReferenceDate(referenceDate)
+	accountID, err := extractURLQueryParamAccountID(req)
+
+	includeDeleted := false
+	projected := false
+
+	if err == nil {
+		includeDeleted, err = extractURLQueryParamIncludeDeleted(req)
+	}
+
+	if err == nil {
+		projected, err = extractURLQueryParamProjected(req)
+	}
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashBalanceDaily.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBalanceDaily, err := controllerCashBalanceDaily.UseCaseCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, includeDeleted, projected)
+
+	if err == nil && cashBalanceDailyBreakdownCurrency(req) {
+		modelCashBalanceDailies := model.CashBalanceDailies{*modelCashBalanceDaily}
+
+		err = controllerCashBalanceDaily.currenciesAppend(modelCashBalanceDailies, &model.CashBalanceDailyRangeReferenceDate{From: referenceDate, To: referenceDate, AccountID: accountID, IncludeDeleted: includeDeleted, Projected: projected})
+
+		modelCashBalanceDaily = &modelCashBalanceDailies[0]
+	}
 
 	if err != nil {
 		var responseError *model.Error
@@ -86,12 +122,17 @@ func (controllerCashBalanceDaily *CashBalanceDaily) GetByReferenceDate(rw http.R
 
 // GetByRangeReferenceDate godoc
 // @Summary      Consultar por Período
-// @Description  Retorna o Saldo Diário de todos os Lançamentos realizado no Período informado. O período não pode ser superior a 31 dias.
+// @Description  Retorna o Saldo Diário (Saldo Inicial, Créditos, Débitos, Saldo do Dia e Saldo Final) dos dias com Lançamentos no Período informado em ordem crescente de data. Com fill=true retorna todos os dias do Período, os dias sem Lançamentos com o saldo do dia anterior. O período não pode ser superior a 31 dias.
 // @Tags         Saldo Diário
 // @Accept       json
 // @Produce      json
 // @Param        from query      string  true  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-05-23")
 // @Param        to   query      string  true  "Data de Referencia Final (AAAA-MM-DD)" example("2020-05-23")
+// @Param        account_id query  int     false  "Id da Conta (quando não informado retorna o saldo de todas as Contas)" example(1)
+// @Param        breakdown query   string  false  "Informar currency para detalhar os Totais do Dia por Moeda original" Enums(currency)
+// @Param        include_deleted query  bool  false  "Inclui os Lançamentos excluídos" default(false)
+// @Param        projected query  bool  false  "Saldo projetado incluindo os Lançamentos pendentes de aprovação" default(false)
+// @Param        fill query  bool  false  "Retorna todos os dias do Período, inclusive os dias sem Lançamentos" default(false)
 // @Success      200  {object}  model.CashBalanceDailies
 // @Failure      400  {object}  model.Error
 // @Failure      500  {object}  model.Error
@@ -99,6 +140,22 @@ func (controllerCashBalanceDaily *CashBalanceDaily) GetByReferenceDate(rw http.R
 func (controllerCashBalanceDaily *CashBalanceDaily) GetByRangeReferenceDate(rw http.ResponseWriter, req *http.Request) {
 	cashBalanceDailyRangeReferenceDate, err := extractURLQueryParamsRangeReferenceDate(req)
 
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.AccountID, err = extractURLQueryParamAccountID(req)
+	}
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.IncludeDeleted, err = extractURLQueryParamIncludeDeleted(req)
+	}
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.Projected, err = extractURLQueryParamProjected(req)
+	}
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.Fill, err = extractURLQueryParamFill(req)
+	}
+
 	if err != nil {
 		responseError := model.BadRequestParamValidate(err.Error())
 
@@ -111,6 +168,10 @@ func (controllerCashBalanceDaily *CashBalanceDaily) GetByRangeReferenceDate(rw h
 
 	modelCashBalanceDailies, err := controllerCashBalanceDaily.UseCaseCashBalanceDaily.GetByRangeReferenceDate(cashBalanceDailyRangeReferenceDate)
 
+	if err == nil && cashBalanceDailyBreakdownCurrency(req) {
+		err = controllerCashBalanceDaily.currenciesAppend(modelCashBalanceDailies, cashBalanceDailyRangeReferenceDate)
+	}
+
 	if err != nil {
 		var responseError *model.Error
 
@@ -135,6 +196,171 @@ func (controllerCashBalanceDaily *CashBalanceDaily) GetByRangeReferenceDate(rw h
 
 }
 
+// GetSummaryByRangeReferenceDate godoc
+// @Summary      Consultar Resumo por Período
+// @Description  Retorna o Saldo agrupado por dia, semana (iniciando na segunda-feira), mês, trimestre ou ano com o Saldo Inicial, os Totais de Créditos e Débitos, o Saldo do Período e o Saldo Final dos períodos com Lançamentos. O período não pode ser superior a 31 dias, 53 semanas, 60 meses, 40 trimestres ou 20 anos conforme o agrupamento.
+// @Tags         Saldo Diário
+// @Accept       json
+// @Produce      json
+// @Param        from query      string  true  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-01-01")
+// @Param        to   query      string  true  "Data de Referencia Final (AAAA-MM-DD)" example("2020-12-31")
+// @Param        granularity query  string  false  "Agrupamento" Enums(day, week, month, quarter, year) default(day)
+// @Param        account_id query  int     false  "Id da Conta (quando não informado retorna o saldo de todas as Contas)" example(1)
+// @Param        include_deleted query  bool  false  "Inclui os Lançamentos excluídos" default(false)
+// @Param        projected query  bool  false  "Saldo projetado incluindo os Lançamentos pendentes de aprovação" default(false)
+// @Success      200  {object}  model.CashBalanceSummaries
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/balance/summary [get]
+func (controllerCashBalanceDaily *CashBalanceDaily) GetSummaryByRangeReferenceDate(rw http.ResponseWriter, req *http.Request) {
+	cashBalanceDailyRangeReferenceDate, err := extractURLQueryParamsRangeReferenceDate(req)
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.AccountID, err = extractURLQueryParamAccountID(req)
+	}
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.IncludeDeleted, err = extractURLQueryParamIncludeDeleted(req)
+	}
+
+	if err == nil {
+		cashBalanceDailyRangeReferenceDate.Projected, err = extractURLQueryParamProjected(req)
+	}
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashBalanceDaily.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	cashBalanceDailyRangeReferenceDate.Granularity = req.URL.Query().Get("granularity")
+
+	modelCashBalanceSummaries, err := controllerCashBalanceDaily.UseCaseCashBalanceDaily.GetSummaryByRangeReferenceDate(cashBalanceDailyRangeReferenceDate)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashBalanceDaily.Title)
+
+			logger.LogErrorRequest(controllerCashBalanceDaily.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashBalanceSummaries)
+}
+
+// currenciesAppend fills the breakdown by original currency of each balance
+func (controllerCashBalanceDaily *CashBalanceDaily) currenciesAppend(modelCashBalanceDailies model.CashBalanceDailies, cashBalanceDailyRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) error {
+	modelCashBalanceDailyCurrencies, err := controllerCashBalanceDaily.UseCaseCashBalanceDaily.GetCurrenciesByRangeReferenceDate(cashBalanceDailyRangeReferenceDate)
+
+	if err != nil {
+		return err
+	}
+
+	for idx := range modelCashBalanceDailies {
+		modelCashBalanceDailies[idx].Currencies = model.CashBalanceDailyCurrencies{}
+
+		for _, modelCashBalanceDailyCurrency := range modelCashBalanceDailyCurrencies {
+			if modelCashBalanceDailyCurrency.ReferenceDate.Equal(modelCashBalanceDailies[idx].ReferenceDate) {
+				modelCashBalanceDailies[idx].Currencies = append(modelCashBalanceDailies[idx].Currencies, modelCashBalanceDailyCurrency)
+			}
+		}
+	}
+
+	return nil
+}
+
+func cashBalanceDailyBreakdownCurrency(req *http.Request) bool {
+	return req.URL.Query().Get("breakdown") == "currency"
+}
+
+// extractURLQueryParamIncludeDeleted returns whether the deleted launches are
+// included, they are left out by default
+func extractURLQueryParamIncludeDeleted(req *http.Request) (bool, error) {
+	includeDeletedParam := req.URL.Query().Get("include_deleted")
+
+	if includeDeletedParam == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(includeDeletedParam)
+
+	if err != nil {
+		return false, errors.New("The param include_deleted is invalid")
+	}
+
+	return includeDeleted, nil
+}
+
+// extractURLQueryParamProjected returns whether the launches pending approval
+// are included, only the approved launches are counted by default
+func extractURLQueryParamProjected(req *http.Request) (bool, error) {
+	projectedParam := req.URL.Query().Get("projected")
+
+	if projectedParam == "" {
+		return false, nil
+	}
+
+	projected, err := strconv.ParseBool(projectedParam)
+
+	if err != nil {
+		return false, errors.New("The param projected is invalid")
+	}
+
+	return projected, nil
+}
+
+// extractURLQueryParamFill returns whether the days without launches are
+// included, only the days with launches are returned by default
+func extractURLQueryParamFill(req *http.Request) (bool, error) {
+	fillParam := req.URL.Query().Get("fill")
+
+	if fillParam == "" {
+		return false, nil
+	}
+
+	fill, err := strconv.ParseBool(fillParam)
+
+	if err != nil {
+		return false, errors.New("The param fill is invalid")
+	}
+
+	return fill, nil
+}
+
+// extractURLQueryParamAccountID returns the account of the query or zero for
+// all accounts combined
+func extractURLQueryParamAccountID(req *http.Request) (int64, error) {
+	accountIDParam := req.URL.Query().Get("account_id")
+
+	if accountIDParam == "" {
+		return 0, nil
+	}
+
+	accountID, err := strconv.ParseInt(accountIDParam, 10, 64)
+
+	if err != nil {
+		return 0, errors.New("The param account_id is invalid")
+	}
+
+	return accountID, nil
+}
+
 func extractURLQueryParamsRangeReferenceDate(req *http.Request) (*model.CashBalanceDailyRangeReferenceDate, error) {
 	fromParam := req.URL.Query().Get("from")
 	toParam := req.URL.Query().Get("to")
@@ -69,7 +69,7 @@ func TestIntegrationCashLaunchBalanceDailyByReferenceDate(t *testing.T) {
 
 			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("GetByReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
@@ -14,6 +14,7 @@ import (
 	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
 )
 
 func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
@@ -41,6 +42,20 @@ func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
 			wantResCode:  http.StatusBadRequest,
 			wantResBody:  model.BadRequestParamValidate(usecase.CashLaunchMessageReferenceDateBetweenError),
 		},
+		{
+			name:         "ParamAccountIDInvalidError",
+			reqParam:     usecase.CashLaunchReferenceDateMin.Format("2006-01-02") + "?account_id=x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param account_id is invalid"),
+		},
+		{
+			name:         "ParamAccountIDNegativeError",
+			reqParam:     usecase.CashLaunchReferenceDateMin.Format("2006-01-02") + "?account_id=-1",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashBalanceDailyAccountIDInvalidError),
+		},
 		{
 			name:         "RepositoryError",
 			reqParam:     usecase.CashLaunchReferenceDateMin.Format("2006-01-02"),
@@ -81,7 +96,7 @@ func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
 
 			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("GetByReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
@@ -128,8 +143,62 @@ func TestCashBalanceDailyGetByRangeReferenceDate(t *testing.T) {
 			wantResCode:  http.StatusOK,
 			wantResBody: &model.CashBalanceDailies{
 				{
-					ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
-					Value:         975.31,
+					ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+			},
+		},
+		{
+			name:         "ParamFillError",
+			reqParam:     "?from=2000-11-22&to=2000-11-22&fill=x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param fill is invalid"),
+		},
+		{
+			name:         "SuccessFill",
+			reqParam:     "?from=2000-11-22&to=2000-11-23&fill=true",
+			resBodyModel: &model.CashBalanceDailies{},
+			wantResCode:  http.StatusOK,
+			wantResBody: &model.CashBalanceDailies{
+				{
+					ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+				{
+					ReferenceDate:  time.Date(2000, 11, 23, 00, 00, 00, 000, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+			},
+		},
+		{
+			name:         "SuccessBreakdownCurrency",
+			reqParam:     "?from=2000-11-22&to=2000-11-22&breakdown=currency",
+			resBodyModel: &model.CashBalanceDailies{},
+			wantResCode:  http.StatusOK,
+			wantResBody: &model.CashBalanceDailies{
+				{
+					ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+					Currencies: model.CashBalanceDailyCurrencies{
+						{
+							Currency:    "BRL",
+							TotalCredit: decimal.RequireFromString("987.65"),
+							TotalDebit:  decimal.RequireFromString("12.34"),
+							Value:       decimal.RequireFromString("975.31"),
+							BaseValue:   decimal.RequireFromString("975.31"),
+						},
+					},
 				},
 			},
 		},
@@ -158,9 +227,99 @@ func TestCashBalanceDailyGetByRangeReferenceDate(t *testing.T) {
 
 			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("GetByReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
 	}
 }
+
+func TestCashBalanceDailyGetSummaryByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamEmptyError",
+			reqParam:     "",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param from is empty;The param to is empty"),
+		},
+		{
+			name:         "ParamGranularityError",
+			reqParam:     "?from=2020-01-01&to=2020-01-01&granularity=semester",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashBalanceSummaryGranularityInvalidError),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "?from=2020-01-01&to=2020-01-01",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashBalanceDailyTitle),
+		},
+		{
+			name:         "Success",
+			reqParam:     "?from=2000-10-15&to=2001-12-31&granularity=quarter",
+			resBodyModel: &model.CashBalanceSummaries{},
+			wantResCode:  http.StatusOK,
+			wantResBody: &model.CashBalanceSummaries{
+				{
+					Period:         time.Date(2000, 10, 1, 0, 0, 0, 0, time.UTC),
+					From:           time.Date(2000, 10, 15, 0, 0, 0, 0, time.UTC),
+					To:             time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+				{
+					Period:         time.Date(2001, 10, 1, 0, 0, 0, 0, time.UTC),
+					From:           time.Date(2001, 10, 1, 0, 0, 0, 0, time.UTC),
+					To:             time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("-12.34"),
+					ClosingBalance: decimal.RequireFromString("962.97"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repository.CashBalanceDaily())
+
+			controllerCashBalanceDaily := controller.NewCashBalanceDaily(log, usecaseCashBalanceDaily)
+
+			url := fmt.Sprintf("/api/cash/balance/summary%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			handler := http.HandlerFunc(controllerCashBalanceDaily.GetSummaryByRangeReferenceDate)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("GetSummaryByRangeReferenceDate() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("GetSummaryByRangeReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
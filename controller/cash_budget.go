@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashBudget struct {
+	Title             string
+	Log               hclog.Logger
+	UseCaseCashBudget usecase.CashBudget
+}
+
+func NewCashBudget(log hclog.Logger, useCaseCashBudget usecase.CashBudget) *CashBudget {
+	return &CashBudget{
+		Title:             "CashBudget",
+		Log:               log,
+		UseCaseCashBudget: useCaseCashBudget,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona o Orçamento de um mês para os Lançamentos de uma Categoria (e das suas Subcategorias) ou para os Lançamentos cuja Descrição atende um Padrão. Deve ser informada a Categoria ou o Padrão. Só pode haver um Orçamento de cada Categoria ou Padrão e Tipo no mês.
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashBudgetWrapper  true  "Orçamento"
+// @Success      201  {object}  model.CashBudget
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget [post]
+func (controllerCashBudget *CashBudget) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashBudget := &model.CashBudget{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashBudget)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashBudget.Title)
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudgetInsert, err := controllerCashBudget.UseCaseCashBudget.Insert(modelCashBudget)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashBudget.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashBudget.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashBudgetInsert)
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna os Orçamentos dos meses do intervalo informado ordenados por Período, Tipo, Categoria e Padrão. Quando não informado retorna os meses do ano atual. O intervalo não pode ser superior a 120 meses.
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        from query      string  false  "Período Inicial (AAAA-MM)" example("2020-01")
+// @Param        to   query      string  false  "Período Final (AAAA-MM)" example("2020-12")
+// @Success      200 {object}  model.CashBudgets
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget [get]
+func (controllerCashBudget *CashBudget) List(rw http.ResponseWriter, req *http.Request) {
+	from, to, err := extractURLQueryParamsRangePeriod(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudgets, err := controllerCashBudget.UseCaseCashBudget.List(from, to)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashBudgets)
+}
+
+// GetByID godoc
+// @Summary      Consultar
+// @Description  Retorna um Orçamento
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Orçamento" example("1")
+// @Success      200 {object}  model.CashBudget
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget/{id} [get]
+func (controllerCashBudget *CashBudget) GetByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	// the variance report shares the route of the budget id, see
+	// CashBudgetRoute
+	if param == "variance" {
+		controllerCashBudget.Variance(rw, req)
+		return
+	}
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudget, err := controllerCashBudget.UseCaseCashBudget.GetByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashBudget.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashBudget)
+}
+
+// Update godoc
+// @Summary      Alterar
+// @Description  Altera um Orçamento
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Orçamento" example("1")
+// @Param        request   body      model.parametersCashBudgetWrapper  true  "Orçamento"
+// @Success      200 {object}  model.CashBudget
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget/{id} [put]
+func (controllerCashBudget *CashBudget) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudget := &model.CashBudget{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashBudget)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashBudget.Title)
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudget.ID = id
+
+	modelCashBudgetUpdate, err := controllerCashBudget.UseCaseCashBudget.Update(modelCashBudget)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashBudget.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashBudget.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashBudget.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashBudgetUpdate)
+}
+
+// DeleteByID godoc
+// @Summary      Excluir
+// @Description  Exclui um Orçamento
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Orçamento" example("1")
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget/{id} [delete]
+func (controllerCashBudget *CashBudget) DeleteByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	err = controllerCashBudget.UseCaseCashBudget.DeleteByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashBudget.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Variance godoc
+// @Summary      Desvio
+// @Description  Compara os Orçamentos com os Lançamentos aprovados do mesmo Tipo convertidos para a Moeda Base, retornando o desvio absoluto (Realizado - Orçado) e percentual de cada Categoria ou Padrão. Os Orçamentos de uma Categoria incluem os Lançamentos das suas Subcategorias. Na visão month compara somente o mês informado e na visão ytd soma os Orçamentos e os Lançamentos de janeiro até o mês informado.
+// @Tags         Orçamentos
+// @Accept       json
+// @Produce      json
+// @Param        period query      string  true   "Período (AAAA-MM)" example("2020-08")
+// @Param        view   query      string  false  "Visão (month=mês ytd=acumulado do ano), padrão month" Enums(month, ytd)
+// @Success      200 {object}  model.CashBudgetVariance
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/budget/variance [get]
+func (controllerCashBudget *CashBudget) Variance(rw http.ResponseWriter, req *http.Request) {
+	period, err := extractURLQueryParamBudgetPeriod(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashBudgetVariance, err := controllerCashBudget.UseCaseCashBudget.Variance(period, strings.ToLower(req.URL.Query().Get("view")))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashBudget.Title)
+
+			logger.LogErrorRequest(controllerCashBudget.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashBudgetVariance)
+}
+
+// extractURLQueryParamBudgetPeriod returns the month of the query, zero when
+// not informed
+func extractURLQueryParamBudgetPeriod(req *http.Request) (time.Time, error) {
+	param := req.URL.Query().Get("period")
+
+	if param == "" {
+		return time.Time{}, nil
+	}
+
+	period, err := time.Parse("2006-01", param)
+
+	if err != nil {
+		return period, errors.New("The param period is invalid")
+	}
+
+	return period, nil
+}
@@ -0,0 +1,230 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashBudgetTitle = "CashBudget"
+
+func TestCashBudgetInsert(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      interface{}
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	tests := []test{
+		{
+			name:         "DeserializeError",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashBudgetTitle),
+		},
+		{
+			name:         "ModelValidateError",
+			reqBody:      &model.CashBudget{CategoryID: 2, Type: "D", Period: time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC)},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashBudgetTitle, usecase.CashBudgetMessageValueError),
+		},
+		{
+			name:         "DuplicateKeyError",
+			reqBody:      &model.CashBudget{CategoryID: 2, Type: "D", Period: time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC), Value: decimal.RequireFromString("10")},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestRepositoryPersist(controllerCashBudgetTitle, "Key (period, type, category_id, pattern_type, pattern)=(2019-08-01, D, 2, , ) already exists."),
+		},
+		{
+			name:         "RepositoryError",
+			reqBody:      &model.CashBudget{Pattern: "ENERGIA", PatternType: "substring", Type: "D", Period: time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC), Value: decimal.RequireFromString("10")},
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashBudgetTitle),
+		},
+		{
+			name:        "Success",
+			reqBody:     &model.CashBudget{Pattern: "ENERGIA", PatternType: "substring", Type: "D", Period: time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC), Value: decimal.RequireFromString("10")},
+			wantResCode: http.StatusCreated,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashBudget := &model.CashBudget{}
+				json.NewDecoder(res.Body).Decode(resultCashBudget)
+
+				assert.NotEqual(t, int64(0), resultCashBudget.ID)
+				assert.Equal(t, "ENERGIA", resultCashBudget.Pattern)
+
+				repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+				assert.Nil(t, repositoryInMemory.CashBudget().DeleteByID(resultCashBudget.ID))
+			},
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashBudget := usecase.NewCashBudget(repository.CashBudget(), repository.CashCategory())
+			controllerCashBudget := controller.NewCashBudget(log, usecaseCashBudget)
+
+			reqBody, _ := json.Marshal(tt.reqBody)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/budget", bytes.NewBuffer(reqBody))
+			handler := http.HandlerFunc(controllerCashBudget.Insert)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Insert() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashBudgetGetByID(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "999",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashBudgetTitle),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "1",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashBudgetTitle),
+		},
+		{
+			name:        "Success",
+			reqParam:    "1",
+			wantResCode: http.StatusOK,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashBudget := &model.CashBudget{}
+				json.NewDecoder(res.Body).Decode(resultCashBudget)
+
+				assert.Equal(t, int64(1), resultCashBudget.ID)
+				assert.Equal(t, int64(2), resultCashBudget.CategoryID)
+			},
+		},
+		{
+			name:         "VarianceParamError",
+			reqParam:     "variance?period=2019-13",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param period is invalid"),
+		},
+		{
+			name:         "VarianceValidateError",
+			reqParam:     "variance?view=week",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashBudgetMessageVariancePeriodEmptyError + ";" + usecase.CashBudgetMessageVarianceViewInvalidError),
+		},
+		{
+			name:         "VarianceRepositoryError",
+			reqParam:     "variance?period=2019-08",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashBudgetTitle),
+		},
+		{
+			name:        "VarianceSuccess",
+			reqParam:    "variance?period=2019-08&view=YTD",
+			wantResCode: http.StatusOK,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashBudgetVariance := &model.CashBudgetVariance{}
+				json.NewDecoder(res.Body).Decode(resultCashBudgetVariance)
+
+				assert.Equal(t, usecase.CashBudgetViewYTD, resultCashBudgetVariance.View)
+				assert.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), resultCashBudgetVariance.From)
+				assert.Len(t, resultCashBudgetVariance.Items, 1)
+				assert.Equal(t, "DESPESAS / ALUGUEL", resultCashBudgetVariance.Items[0].Path)
+				assert.Equal(t, "1500", resultCashBudgetVariance.Items[0].Budgeted.String())
+			},
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashBudget := usecase.NewCashBudget(repository.CashBudget(), repository.CashCategory())
+			controllerCashBudget := controller.NewCashBudget(log, usecaseCashBudget)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/cash/budget/"+tt.reqParam, nil)
+			handler := http.HandlerFunc(controllerCashBudget.GetByID)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("GetByID() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("GetByID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashCategory struct {
+	Title               string
+	Log                 hclog.Logger
+	UseCaseCashCategory usecase.CashCategory
+}
+
+func NewCashCategory(log hclog.Logger, useCaseCashCategory usecase.CashCategory) *CashCategory {
+	return &CashCategory{
+		Title:               "CashCategory",
+		Log:                 log,
+		UseCaseCashCategory: useCaseCashCategory,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona Categoria ao Plano de Contas. Sem o parent_id a Categoria é uma raiz do Plano de Contas.
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashCategoryWrapper  true  "Categoria"
+// @Success      201  {object}  model.CashCategory
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category [post]
+func (controllerCashCategory *CashCategory) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashCategory := &model.CashCategory{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashCategory)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashCategory.Title)
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryInsert, err := controllerCashCategory.UseCaseCashCategory.Insert(modelCashCategory)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashCategory.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashCategory.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategory.Title)
+
+			logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashCategoryInsert)
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna a lista de Categorias ordenada pelo Nome
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Success      200 {object}  model.CashCategories
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category [get]
+func (controllerCashCategory *CashCategory) List(rw http.ResponseWriter, req *http.Request) {
+	modelCashCategories, err := controllerCashCategory.UseCaseCashCategory.List()
+
+	if err != nil {
+		responseError := model.InternalServerErrorRepositoryLoad(controllerCashCategory.Title)
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashCategories)
+}
+
+// GetByID godoc
+// @Summary      Consultar
+// @Description  Retorna uma Categoria
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Categoria" example("1")
+// @Success      200 {object}  model.CashCategory
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category/{id} [get]
+func (controllerCashCategory *CashCategory) GetByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategory, err := controllerCashCategory.UseCaseCashCategory.GetByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategory.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashCategory.Title)
+
+			logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashCategory)
+}
+
+// Update godoc
+// @Summary      Alterar
+// @Description  Altera uma Categoria. A Categoria não pode ser movida para dentro de si mesma ou de uma de suas subcategorias.
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Categoria" example("1")
+// @Param        request   body      model.parametersCashCategoryWrapper  true  "Categoria"
+// @Success      200 {object}  model.CashCategory
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category/{id} [put]
+func (controllerCashCategory *CashCategory) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategory := &model.CashCategory{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashCategory)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashCategory.Title)
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategory.ID = id
+
+	modelCashCategoryUpdate, err := controllerCashCategory.UseCaseCashCategory.Update(modelCashCategory)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashCategory.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrDuplicateKey); ok {
+			responseError = model.BadRequestRepositoryPersist(controllerCashCategory.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategory.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategory.Title)
+
+			logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashCategoryUpdate)
+}
+
+// DeleteByID godoc
+// @Summary      Excluir
+// @Description  Exclui uma Categoria. Não é possível excluir uma Categoria que possui Lançamentos, subcategorias, Regras de Categorização ou Orçamentos.
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Categoria" example("1")
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category/{id} [delete]
+func (controllerCashCategory *CashCategory) DeleteByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	err = controllerCashCategory.UseCaseCashCategory.DeleteByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategory.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else if errReferenced, ok := err.(repository.ErrReferenced); ok {
+			responseError = model.ConflictRepositoryReferenced(controllerCashCategory.Title, referencedMessage("category", errReferenced))
+
+			rw.WriteHeader(http.StatusConflict)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategory.Title)
+
+			logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetTotalsByRangeReferenceDate godoc
+// @Summary      Totais por Categoria
+// @Description  Retorna os Créditos, Débitos e o Valor de cada Categoria no Período informado. Os totais rollup somam as subcategorias nas categorias superiores até a raiz do Plano de Contas. Os Lançamentos sem Categoria são totalizados na Categoria 0 (SEM CATEGORIA).
+// @Tags         Categorias
+// @Accept       json
+// @Produce      json
+// @Param        from query      string  true  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-05-23")
+// @Param        to   query      string  true  "Data de Referencia Final (AAAA-MM-DD)" example("2020-05-23")
+// @Param        account_id query  int     false  "Id da Conta (quando não informado totaliza todas as Contas)" example(1)
+// @Param        include_deleted query  bool  false  "Inclui os Lançamentos excluídos" default(false)
+// @Param        projected query  bool  false  "Saldo projetado incluindo os Lançamentos pendentes de aprovação" default(false)
+// @Success      200  {object}  model.CashCategoryTotals
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/balance/category [get]
+func (controllerCashCategory *CashCategory) GetTotalsByRangeReferenceDate(rw http.ResponseWriter, req *http.Request) {
+	cashCategoryRangeReferenceDate, err := extractURLQueryParamsRangeReferenceDate(req)
+
+	if err == nil {
+		cashCategoryRangeReferenceDate.AccountID, err = extractURLQueryParamAccountID(req)
+	}
+
+	if err == nil {
+		cashCategoryRangeReferenceDate.IncludeDeleted, err = extractURLQueryParamIncludeDeleted(req)
+	}
+
+	if err == nil {
+		cashCategoryRangeReferenceDate.Projected, err = extractURLQueryParamProjected(req)
+	}
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryTotals, err := controllerCashCategory.UseCaseCashCategory.GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashCategory.Title)
+
+			logger.LogErrorRequest(controllerCashCategory.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashCategoryTotals)
+}
@@ -0,0 +1,334 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashCategoryRule struct {
+	Title                   string
+	Log                     hclog.Logger
+	UseCaseCashCategoryRule usecase.CashCategoryRule
+}
+
+func NewCashCategoryRule(log hclog.Logger, useCaseCashCategoryRule usecase.CashCategoryRule) *CashCategoryRule {
+	return &CashCategoryRule{
+		Title:                   "CashCategoryRule",
+		Log:                     log,
+		UseCaseCashCategoryRule: useCaseCashCategoryRule,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona Regra de Categorização automática dos Lançamentos incluídos sem Categoria
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashCategoryRuleWrapper  true  "Regra"
+// @Success      201  {object}  model.CashCategoryRule
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule [post]
+func (controllerCashCategoryRule *CashCategoryRule) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashCategoryRule := &model.CashCategoryRule{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashCategoryRule)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashCategoryRule.Title)
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryRuleInsert, err := controllerCashCategoryRule.UseCaseCashCategoryRule.Insert(modelCashCategoryRule)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashCategoryRule.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategoryRule.Title)
+
+			logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashCategoryRuleInsert)
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna a lista de Regras na ordem em que são aplicadas (Prioridade e Id)
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Success      200 {object}  model.CashCategoryRules
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule [get]
+func (controllerCashCategoryRule *CashCategoryRule) List(rw http.ResponseWriter, req *http.Request) {
+	modelCashCategoryRules, err := controllerCashCategoryRule.UseCaseCashCategoryRule.List()
+
+	if err != nil {
+		responseError := model.InternalServerErrorRepositoryLoad(controllerCashCategoryRule.Title)
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashCategoryRules)
+}
+
+// GetByID godoc
+// @Summary      Consultar
+// @Description  Retorna uma Regra
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Regra" example("1")
+// @Success      200 {object}  model.CashCategoryRule
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule/{id} [get]
+func (controllerCashCategoryRule *CashCategoryRule) GetByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryRule, err := controllerCashCategoryRule.UseCaseCashCategoryRule.GetByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategoryRule.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashCategoryRule.Title)
+
+			logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashCategoryRule)
+}
+
+// Update godoc
+// @Summary      Alterar
+// @Description  Altera uma Regra
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Regra" example("1")
+// @Param        request   body      model.parametersCashCategoryRuleWrapper  true  "Regra"
+// @Success      200 {object}  model.CashCategoryRule
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule/{id} [put]
+func (controllerCashCategoryRule *CashCategoryRule) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryRule := &model.CashCategoryRule{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashCategoryRule)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashCategoryRule.Title)
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryRule.ID = id
+
+	modelCashCategoryRuleUpdate, err := controllerCashCategoryRule.UseCaseCashCategoryRule.Update(modelCashCategoryRule)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashCategoryRule.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategoryRule.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategoryRule.Title)
+
+			logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashCategoryRuleUpdate)
+}
+
+// DeleteByID godoc
+// @Summary      Excluir
+// @Description  Exclui uma Regra. Os Lançamentos já categorizados pela Regra não são alterados.
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Regra" example("1")
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule/{id} [delete]
+func (controllerCashCategoryRule *CashCategoryRule) DeleteByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	err = controllerCashCategoryRule.UseCaseCashCategoryRule.DeleteByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategoryRule.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashCategoryRule.Title)
+
+			logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Match godoc
+// @Summary      Simular
+// @Description  Retorna a Regra que categorizaria um Lançamento com o Tipo, Descrição e Valor na moeda base informados, sem incluir o Lançamento. Retorna 404 quando nenhuma Regra é atendida.
+// @Tags         Regras de Categorização
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashCategoryRuleMatchWrapper  true  "Lançamento de exemplo"
+// @Success      200 {object}  model.CashCategoryRule
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/category-rule/match [post]
+func (controllerCashCategoryRule *CashCategoryRule) Match(rw http.ResponseWriter, req *http.Request) {
+	modelCashLaunch := &model.CashLaunch{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashLaunch)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashCategoryRule.Title)
+
+		logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashCategoryRule, err := controllerCashCategoryRule.UseCaseCashCategoryRule.Match(modelCashLaunch)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashCategoryRule.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashCategoryRule.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashCategoryRule.Title)
+
+			logger.LogErrorRequest(controllerCashCategoryRule.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashCategoryRule)
+}
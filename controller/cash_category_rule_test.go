@@ -0,0 +1,108 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashCategoryRuleTitle = "CashCategoryRule"
+
+func TestCashCategoryRuleMatch(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      interface{}
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	tests := []test{
+		{
+			name:         "DeserializeError",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashCategoryRuleTitle),
+		},
+		{
+			name:         "ModelValidateError",
+			reqBody:      &model.CashLaunch{Type: "D", Description: "ALUGUEL"},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashCategoryRuleTitle, usecase.CashLaunchMessageValueError),
+		},
+		{
+			name:         "NotFoundError",
+			reqBody:      &model.CashLaunch{Type: "C", Description: "VENDA", Value: decimal.RequireFromString("10")},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashCategoryRuleTitle),
+		},
+		{
+			name:         "RepositoryError",
+			reqBody:      &model.CashLaunch{Type: "D", Description: "ALUGUEL", Value: decimal.RequireFromString("10")},
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashCategoryRuleTitle),
+		},
+		{
+			name:        "Success",
+			reqBody:     &model.CashLaunch{Type: "D", Description: "aluguel maio", Value: decimal.RequireFromString("10")},
+			wantResCode: http.StatusOK,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashCategoryRule := &model.CashCategoryRule{}
+				json.NewDecoder(res.Body).Decode(resultCashCategoryRule)
+
+				assert.Equal(t, int64(1), resultCashCategoryRule.ID)
+				assert.Equal(t, int64(2), resultCashCategoryRule.CategoryID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashCategoryRule := usecase.NewCashCategoryRule(repository.CashCategoryRule(), repository.CashCategory())
+			controllerCashCategoryRule := controller.NewCashCategoryRule(log, usecaseCashCategoryRule)
+
+			reqBody, _ := json.Marshal(tt.reqBody)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/category-rule/match", bytes.NewBuffer(reqBody))
+			handler := http.HandlerFunc(controllerCashCategoryRule.Match)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Match() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Match() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashCategoryTitle = "CashCategory"
+
+func TestCashCategoryDeleteByID(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashCategoryTitle),
+		},
+		{
+			name:         "ReferencedError",
+			reqParam:     "1",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusConflict,
+			wantResBody:  model.ConflictRepositoryReferenced(controllerCashCategoryTitle, "the category is still referenced from table cash_category"),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "1",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashCategoryTitle),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashCategory := usecase.NewCashCategory(repository.CashCategory())
+			controllerCashCategory := controller.NewCashCategory(log, usecaseCashCategory)
+
+			url := fmt.Sprintf("/api/cash/category/%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodDelete, url, nil)
+			handler := http.HandlerFunc(controllerCashCategory.DeleteByID)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("DeleteByID() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("DeleteByID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashCategoryGetTotalsByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name         string
+		reqQuery     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, res *httptest.ResponseRecorder)
+	}
+
+	tests := []test{
+		{
+			name:         "ParamEmptyError",
+			reqQuery:     "",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param from is empty;The param to is empty"),
+		},
+		{
+			name:         "AccountIDError",
+			reqQuery:     "?from=2001-11-01&to=2001-11-30&account_id=-1",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashBalanceDailyAccountIDInvalidError),
+		},
+		{
+			name:         "RepositoryError",
+			reqQuery:     "?from=2001-11-01&to=2001-11-30",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashCategoryTitle),
+		},
+		{
+			name:        "Success",
+			reqQuery:    "?from=2001-11-01&to=2001-11-30&account_id=1",
+			wantResCode: http.StatusOK,
+			assert: func(t *testing.T, res *httptest.ResponseRecorder) {
+				resultCashCategoryTotals := model.CashCategoryTotals{}
+				json.NewDecoder(res.Body).Decode(&resultCashCategoryTotals)
+
+				for _, resultCashCategoryTotal := range resultCashCategoryTotals {
+					if resultCashCategoryTotal.CategoryID == 2 {
+						assert.Equal(t, "12.34", resultCashCategoryTotal.TotalDebit.String())
+						assert.Equal(t, "-12.34", resultCashCategoryTotal.RollupValue.String())
+						return
+					}
+				}
+
+				t.Errorf("GetTotalsByRangeReferenceDate() category 2 not found in %v", resultCashCategoryTotals)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashCategory := usecase.NewCashCategory(repository.CashCategory())
+			controllerCashCategory := controller.NewCashCategory(log, usecaseCashCategory)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/cash/balance/category"+tt.reqQuery, nil)
+			handler := http.HandlerFunc(controllerCashCategory.GetTotalsByRangeReferenceDate)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("GetTotalsByRangeReferenceDate() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("GetTotalsByRangeReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashForecast struct {
+	Title               string
+	Log                 hclog.Logger
+	UseCaseCashForecast usecase.CashForecast
+}
+
+func NewCashForecast(log hclog.Logger, useCaseCashForecast usecase.CashForecast) *CashForecast {
+	return &CashForecast{
+		Title:               "CashForecast",
+		Log:                 log,
+		UseCaseCashForecast: useCaseCashForecast,
+	}
+}
+
+// Forecast godoc
+// @Summary      Previsão
+// @Description  Projeta o Saldo de cada dia após a Data atual até a Data final informada, a partir do Saldo Final atual dos Lançamentos aprovados, somando os Lançamentos aprovados e pendentes de aprovação com Data de Referencia futura e as ocorrências dos Lançamentos Recorrentes ainda não geradas (as já vencidas no primeiro dia), convertidos para a Moeda Base pela última cotação publicada. Retorna o primeiro dia com o Saldo projetado negativo. A Data final não pode ser superior a 366 dias da Data atual.
+// @Tags         Previsão
+// @Accept       json
+// @Produce      json
+// @Param        to          query  string  true   "Data Final (AAAA-MM-DD)" example("2020-06-30")
+// @Param        account_id  query  int     false  "Id da Conta (quando não informado projeta o saldo de todas as Contas)" example(1)
+// @Success      200  {object}  model.CashForecast
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/forecast [get]
+func (controllerCashForecast *CashForecast) Forecast(rw http.ResponseWriter, req *http.Request) {
+	to, err := extractURLQueryParamForecastTo(req)
+
+	accountID := int64(0)
+
+	if err == nil {
+		accountID, err = extractURLQueryParamAccountID(req)
+	}
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashForecast.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashForecast, err := controllerCashForecast.UseCaseCashForecast.Forecast(accountID, to, time.Now())
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrModelValidate); ok {
+			// the exchange rate of the currency of a recurring template
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashForecast.Title)
+
+			logger.LogErrorRequest(controllerCashForecast.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashForecast)
+}
+
+// extractURLQueryParamForecastTo returns the final date of the forecast, the
+// empty date is validated by the use case
+func extractURLQueryParamForecastTo(req *http.Request) (time.Time, error) {
+	toParam := req.URL.Query().Get("to")
+
+	if toParam == "" {
+		return time.Time{}, nil
+	}
+
+	to, err := time.Parse("2006-01-02", toParam)
+
+	if err != nil {
+		return to, errors.New("The param to is invalid")
+	}
+
+	return to, nil
+}
@@ -0,0 +1,102 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashForecastTitle = "CashForecast"
+
+func TestCashForecast(t *testing.T) {
+	type test struct {
+		name         string
+		reqQuery     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	to := time.Now().UTC().AddDate(0, 0, 30).Format("2006-01-02")
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqQuery:     "to=2012-13-01",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param to is invalid"),
+		},
+		{
+			name:         "AccountIDError",
+			reqQuery:     "to=" + to + "&account_id=x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param account_id is invalid"),
+		},
+		{
+			name:         "ToEmptyError",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashForecastMessageToEmptyError),
+		},
+		{
+			name:         "RepositoryError",
+			reqQuery:     "to=" + to,
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashForecastTitle),
+		},
+		{
+			name:         "Success",
+			reqQuery:     "to=" + to + "&account_id=1",
+			resBodyModel: &model.CashForecast{},
+			wantResCode:  http.StatusOK,
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashForecast := usecase.NewCashForecast(repository.CashBalanceDaily(), repository.CashLaunch(), repository.CashRecurrence(), repository.CashAccount(), repository.ExchangeRate(), baseCurrencyDefault)
+			controllerCashForecast := controller.NewCashForecast(log, usecaseCashForecast)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/cash/forecast?"+tt.reqQuery, nil)
+			handler := http.HandlerFunc(controllerCashForecast.Forecast)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Forecast() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashForecast, ok := tt.resBodyModel.(*model.CashForecast); ok {
+				assert.Equal(t, int64(1), resultCashForecast.AccountID)
+				assert.Len(t, resultCashForecast.Days, 30)
+				assert.Equal(t, to, resultCashForecast.To.Format("2006-01-02"))
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Forecast() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
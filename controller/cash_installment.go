@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashInstallment struct {
+	Title                  string
+	Log                    hclog.Logger
+	UseCaseCashInstallment usecase.CashInstallment
+}
+
+func NewCashInstallment(log hclog.Logger, useCaseCashInstallment usecase.CashInstallment) *CashInstallment {
+	return &CashInstallment{
+		Title:                  "CashInstallment",
+		Log:                    log,
+		UseCaseCashInstallment: useCaseCashInstallment,
+	}
+}
+
+// ListByGroupID godoc
+// @Summary      Listar Parcelas
+// @Description  Retorna todas as Parcelas do Parcelamento ordenadas pelo número da Parcela. As Parcelas excluídas ou canceladas somente são retornadas informando include_deleted.
+// @Tags         Parcelamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Parcelamento" example("1")
+// @Param        include_deleted query  bool  false  "Inclui as Parcelas excluídas" default(false)
+// @Success      200 {object}  model.CashLaunches
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/installment/{id} [get]
+func (controllerCashInstallment *CashInstallment) ListByGroupID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	includeDeleted, err := extractURLQueryParamIncludeDeleted(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunches, err := controllerCashInstallment.UseCaseCashInstallment.ListByGroupID(id, includeDeleted)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashInstallment.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashInstallment.Title)
+
+			logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashLaunches)
+}
+
+// Update godoc
+// @Summary      Alterar Parcelas restantes
+// @Description  Altera a Conta, Categoria e Descrição das Parcelas restantes (com vencimento a partir da data atual) e divide o Valor informado entre elas. As Parcelas já vencidas, excluídas ou estornadas não são alteradas.
+// @Tags         Parcelamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Parcelamento" example("1")
+// @Param        request   body      model.parametersCashInstallmentWrapper  true  "Parcelas restantes"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
+// @Success      200 {object}  model.CashLaunches
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/installment/{id} [put]
+func (controllerCashInstallment *CashInstallment) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashInstallment := &model.CashInstallment{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashInstallment)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashInstallment.Title)
+
+		logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashInstallment.ID = id
+
+	modelCashLaunchesUpdate, err := controllerCashInstallment.UseCaseCashInstallment.Update(modelCashInstallment, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashInstallment.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashInstallment.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashInstallment.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashInstallment.Title)
+
+			logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashLaunchesUpdate)
+}
+
+// DeleteByGroupID godoc
+// @Summary      Cancelar Parcelas restantes
+// @Description  Cancela o Parcelamento excluindo as Parcelas restantes (com vencimento a partir da data atual). As Parcelas excluídas são marcadas com a Data de Exclusão e as Parcelas já vencidas ou estornadas são mantidas.
+// @Tags         Parcelamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Parcelamento" example("1")
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/installment/{id} [delete]
+func (controllerCashInstallment *CashInstallment) DeleteByGroupID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	_, err = controllerCashInstallment.UseCaseCashInstallment.DeleteByGroupID(id, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashInstallment.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashInstallment.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashInstallment.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashInstallment.Title)
+
+			logger.LogErrorRequest(controllerCashInstallment.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
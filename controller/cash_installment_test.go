@@ -0,0 +1,109 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashInstallmentTitle = "CashInstallment"
+
+func TestCashInstallmentDeleteByGroupID(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), "BRL", nil)
+
+	modelCashLaunchDue, err := usecaseCashLaunch.Insert(&model.CashLaunch{AccountID: 1, ReferenceDate: time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), Type: "D", Description: "NOTEBOOK", Value: decimal.RequireFromString("300"), InstallmentCount: 3}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashLaunchRemaining, err := usecaseCashLaunch.Insert(&model.CashLaunch{AccountID: 1, ReferenceDate: time.Now().UTC().AddDate(0, 1, 0), Type: "D", Description: "NOTEBOOK", Value: decimal.RequireFromString("300"), InstallmentCount: 3}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashInstallmentTitle),
+		},
+		{
+			name:         "RemainingNotFoundError",
+			reqParam:     fmt.Sprint(modelCashLaunchDue.InstallmentGroupID),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashInstallmentTitle, usecase.CashInstallmentMessageRemainingNotFoundError),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     fmt.Sprint(modelCashLaunchRemaining.InstallmentGroupID),
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashInstallmentTitle),
+		},
+		{
+			name:        "Success",
+			reqParam:    fmt.Sprint(modelCashLaunchRemaining.InstallmentGroupID),
+			wantResCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashInstallment := usecase.NewCashInstallment(repository.CashInstallment(), repository.CashAccount(), repository.CashCategory(), repository.ExchangeRate(), "BRL", nil)
+			controllerCashInstallment := controller.NewCashInstallment(log, usecaseCashInstallment)
+
+			url := fmt.Sprintf("/api/cash/installment/%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodDelete, url, nil)
+			handler := http.HandlerFunc(controllerCashInstallment.DeleteByGroupID)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("DeleteByGroupID() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.resBodyModel != nil {
+				json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("DeleteByGroupID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+
+	repositoryInMemory.CashInstallment().DeleteByGroupID(modelCashLaunchDue.InstallmentGroupID, time.Time{}, modelAuditDefault)
+}
@@ -2,15 +2,19 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
 )
 
 type CashLaunch struct {
@@ -29,13 +33,15 @@ func NewCashLaunch(log hclog.Logger, useCaseCashLaunch usecase.CashLaunch) *Cash
 
 // Insert godoc
 // @Summary      Adicionar
-// @Description  Adiciona Lançamento
+// @Description  Adiciona Lançamento. O Valor é convertido para a Moeda Base pela última Cotação publicada até a Data de Referencia. O Lançamento incluído sem Categoria recebe a Categoria da primeira Regra de Categorização atendida. Informando installment_count o Valor total é dividido em Parcelas mensais a partir de first_due_date (centavos restantes na primeira Parcela) vinculadas pelo installment_group_id e a primeira Parcela é retornada.
 // @Tags         Lançamentos
 // @Accept       json
 // @Produce      json
 // @Param        request   body      model.parametersCashLaunchWrapper  true  "Lançamento"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
 // @Success      201  {object}  model.CashLaunch
 // @Failure      400  {object}  model.Error
+// @Failure      409  {object}  model.Error
 // @Failure      500  {object}  model.Error
 // @Router       /cash/launch [post]
 func (controllerCashLaunch *CashLaunch) Insert(rw http.ResponseWriter, req *http.Request) {
@@ -54,7 +60,7 @@ func (controllerCashLaunch *CashLaunch) Insert(rw http.ResponseWriter, req *http
 		return
 	}
 
-	modelCashLaunchInsert, err := controllerCashLaunch.UseCaseCashLaunch.Insert(modelCashLaunch)
+	modelCashLaunchInsert, err := controllerCashLaunch.UseCaseCashLaunch.Insert(modelCashLaunch, extractRequestAudit(req))
 
 	if err != nil {
 		var responseError *model.Error
@@ -63,6 +69,10 @@ func (controllerCashLaunch *CashLaunch) Insert(rw http.ResponseWriter, req *http
 			responseError = model.BadRequestModelValidate(controllerCashLaunch.Title, err.Error())
 
 			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
 		} else {
 			responseError = model.InternalServerErrorRepositoryPersist(controllerCashLaunch.Title)
 
@@ -81,32 +91,74 @@ func (controllerCashLaunch *CashLaunch) Insert(rw http.ResponseWriter, req *http
 
 // List godoc
 // @Summary      Listar
-// @Description  Retorna uma lista de Lançamentos
+// @Description  Retorna uma lista paginada de Lançamentos. Os Lançamentos excluídos somente são retornados informando include_deleted. Quando existir uma próxima página o token para consultá-la é retornado no cabeçalho X-Next e deve ser informado no parâmetro next mantendo os demais parâmetros.
 // @Tags         Lançamentos
 // @Accept       json
 // @Produce      json
+// @Param        account_id          query  int     false  "Id da Conta" example(1)
+// @Param        transfer_id         query  int     false  "Id da Transferência" example(1)
+// @Param        category_id         query  int     false  "Id da Categoria" example(1)
+// @Param        reference_date_from query  string  false  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-05-23")
+// @Param        reference_date_to   query  string  false  "Data de Referencia Final (AAAA-MM-DD)" example("2020-05-23")
+// @Param        type                query  string  false  "Tipo do Lançamento (C=Crédito D=Débito)" Enums(C, D)
+// @Param        status              query  string  false  "Situação da aprovação do Lançamento" Enums(pending, approved, rejected)
+// @Param        value_from          query  number  false  "Valor Mínimo" example(1.23)
+// @Param        value_to            query  number  false  "Valor Máximo" example(1.23)
+// @Param        description         query  string  false  "Trecho da Descrição"
+// @Param        include_deleted     query  bool    false  "Inclui os Lançamentos excluídos" default(false)
+// @Param        sort                query  string  false  "Campo de ordenação" Enums(reference_date, type, description, value, id) default(reference_date)
+// @Param        order               query  string  false  "Direção da ordenação" Enums(asc, desc) default(asc)
+// @Param        limit               query  int     false  "Quantidade máxima de Lançamentos" minimum(1) maximum(1000) default(100)
+// @Param        next                query  string  false  "Token da próxima página (cabeçalho X-Next da consulta anterior)"
 // @Success      200 {object}  model.CashLaunches
+// @Header       200 {string}  X-Next "Token da próxima página (somente quando existir)"
+// @Failure      400  {object}  model.Error
 // @Failure      500  {object}  model.Error
 // @Router       /cash/launch [get]
 func (controllerCashLaunch *CashLaunch) List(rw http.ResponseWriter, req *http.Request) {
-	modelCashLaunches, err := controllerCashLaunch.UseCaseCashLaunch.List()
+	modelCashLaunchFilter, err := extractURLQueryParamsCashLaunchFilter(req)
 
 	if err != nil {
-		responseError := model.InternalServerErrorRepositoryLoad(controllerCashLaunch.Title)
+		responseError := model.BadRequestParamValidate(err.Error())
 
 		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
 
-		rw.WriteHeader(http.StatusInternalServerError)
+		rw.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(rw).Encode(responseError)
 		return
 	}
 
+	modelCashLaunches, next, err := controllerCashLaunch.UseCaseCashLaunch.List(modelCashLaunchFilter)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashLaunch.Title)
+
+			logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	if next != "" {
+		rw.Header().Set("X-Next", next)
+	}
+
 	json.NewEncoder(rw).Encode(modelCashLaunches)
 }
 
 // GetByID godoc
 // @Summary      Consultar
-// @Description  Retorna um Lançamento
+// @Description  Retorna um Lançamento, inclusive quando excluído
 // @Tags         Lançamentos
 // @Accept       json
 // @Produce      json
@@ -157,15 +209,17 @@ func (controllerCashLaunch *CashLaunch) GetByID(rw http.ResponseWriter, req *htt
 
 // Update godoc
 // @Summary      Alterar
-// @Description  Altera um Lançamento
+// @Description  Altera um Lançamento. O Valor é convertido novamente para a Moeda Base pela última Cotação publicada até a Data de Referencia. Quando o Lançamento é de uma Transferência a Data de Referencia, Descrição, Valor e Moeda são alterados também no outro Lançamento da Transferência. Os Lançamentos excluídos, os estornados e os Estornos não podem ser alterados.
 // @Tags         Lançamentos
 // @Accept       json
 // @Produce      json
 // @Param        param   path      string  false  "Id do Lançamento" example("1")
 // @Param        request   body      model.parametersCashLaunchWrapper  true  "Lançamento"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
 // @Success      200 {object}  model.CashLaunch
 // @Failure      400  {object}  model.Error
 // @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
 // @Failure      500  {object}  model.Error
 // @Router       /cash/launch/{id} [put]
 func (controllerCashLaunch *CashLaunch) Update(rw http.ResponseWriter, req *http.Request) {
@@ -199,7 +253,7 @@ func (controllerCashLaunch *CashLaunch) Update(rw http.ResponseWriter, req *http
 
 	modelCashLaunch.ID = id
 
-	modelCashLaunchUpdate, err := controllerCashLaunch.UseCaseCashLaunch.Update(modelCashLaunch)
+	modelCashLaunchUpdate, err := controllerCashLaunch.UseCaseCashLaunch.Update(modelCashLaunch, extractRequestAudit(req))
 
 	if err != nil {
 		var responseError *model.Error
@@ -208,6 +262,10 @@ func (controllerCashLaunch *CashLaunch) Update(rw http.ResponseWriter, req *http
 			responseError = model.BadRequestModelValidate(controllerCashLaunch.Title, err.Error())
 
 			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
 		} else if _, ok := err.(repository.ErrNotFound); ok {
 			responseError = model.NotFound(controllerCashLaunch.Title)
 
@@ -230,14 +288,16 @@ func (controllerCashLaunch *CashLaunch) Update(rw http.ResponseWriter, req *http
 
 // DeleteByID godoc
 // @Summary      Excluir
-// @Description  Exclui um Lançamento
+// @Description  Exclui um Lançamento marcando a Data de Exclusão, o Lançamento excluído deixa de compor as listas e os saldos mas é mantido no histórico. Quando o Lançamento é de uma Transferência o outro Lançamento da Transferência também é excluído. Os Lançamentos estornados e os Estornos não podem ser excluídos.
 // @Tags         Lançamentos
 // @Accept       json
 // @Produce      json
 // @Param        param   path      string  false  "Id do Lançamento" example("1")
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
 // @Success      204
 // @Failure      400  {object}  model.Error
 // @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
 // @Failure      500  {object}  model.Error
 // @Router       /cash/launch/{id} [delete]
 func (controllerCashLaunch *CashLaunch) DeleteByID(rw http.ResponseWriter, req *http.Request) {
@@ -255,12 +315,20 @@ func (controllerCashLaunch *CashLaunch) DeleteByID(rw http.ResponseWriter, req *
 		return
 	}
 
-	err = controllerCashLaunch.UseCaseCashLaunch.DeleteByID(id)
+	err = controllerCashLaunch.UseCaseCashLaunch.DeleteByID(id, extractRequestAudit(req))
 
 	if err != nil {
 		var responseError *model.Error
 
-		if _, ok := err.(repository.ErrNotFound); ok {
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
 			responseError = model.NotFound(controllerCashLaunch.Title)
 
 			rw.WriteHeader(http.StatusNotFound)
@@ -278,3 +346,194 @@ func (controllerCashLaunch *CashLaunch) DeleteByID(rw http.ResponseWriter, req *
 
 	rw.WriteHeader(http.StatusNoContent)
 }
+
+// Reverse godoc
+// @Summary      Estornar
+// @Description  Estorna um Lançamento incluindo um Lançamento de Tipo oposto com a mesma Conta, Categoria, Valor, Moeda e Cotação vinculado pelo reversal_of_id, o Lançamento estornado recebe o reversed_by_id. Quando o Lançamento é de uma Transferência os dois Lançamentos da Transferência são estornados. Um Lançamento excluído, já estornado ou que é um Estorno não pode ser estornado.
+// @Tags         Lançamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento" example("1")
+// @Param        request   body      model.parametersCashLaunchReversalWrapper  false  "Estorno"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
+// @Success      201 {object}  model.CashLaunches
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/launch/{id}/reversal [post]
+func (controllerCashLaunch *CashLaunch) Reverse(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchReversal := &model.CashLaunchReversal{}
+
+	// the body is optional
+	err = json.NewDecoder(req.Body).Decode(modelCashLaunchReversal)
+
+	if err != nil && err != io.EOF {
+		responseError := model.BadRequestDeserialize(controllerCashLaunch.Title)
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchReversal.ID = id
+
+	modelCashLaunchReversals, err := controllerCashLaunch.UseCaseCashLaunch.Reverse(modelCashLaunchReversal, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashLaunch.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashLaunch.Title)
+
+			logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashLaunchReversals)
+}
+
+func extractURLQueryParamsCashLaunchFilter(req *http.Request) (*model.CashLaunchFilter, error) {
+	query := req.URL.Query()
+
+	modelCashLaunchFilter := &model.CashLaunchFilter{
+		Type:        query.Get("type"),
+		Status:      query.Get("status"),
+		Description: query.Get("description"),
+		Sort:        query.Get("sort"),
+		Order:       query.Get("order"),
+		Next:        query.Get("next"),
+	}
+
+	messages := []string{}
+
+	if accountIDParam := query.Get("account_id"); accountIDParam != "" {
+		accountID, err := strconv.ParseInt(accountIDParam, 10, 64)
+
+		if err != nil {
+			messages = append(messages, "The param account_id is invalid")
+		}
+
+		modelCashLaunchFilter.AccountID = accountID
+	}
+
+	if transferIDParam := query.Get("transfer_id"); transferIDParam != "" {
+		transferID, err := strconv.ParseInt(transferIDParam, 10, 64)
+
+		if err != nil {
+			messages = append(messages, "The param transfer_id is invalid")
+		}
+
+		modelCashLaunchFilter.TransferID = transferID
+	}
+
+	if categoryIDParam := query.Get("category_id"); categoryIDParam != "" {
+		categoryID, err := strconv.ParseInt(categoryIDParam, 10, 64)
+
+		if err != nil {
+			messages = append(messages, "The param category_id is invalid")
+		}
+
+		modelCashLaunchFilter.CategoryID = categoryID
+	}
+
+	if referenceDateFromParam := query.Get("reference_date_from"); referenceDateFromParam != "" {
+		referenceDateFrom, err := time.Parse("2006-01-02", referenceDateFromParam)
+
+		if err != nil {
+			messages = append(messages, "The param reference_date_from is invalid")
+		}
+
+		modelCashLaunchFilter.ReferenceDateFrom = referenceDateFrom
+	}
+
+	if referenceDateToParam := query.Get("reference_date_to"); referenceDateToParam != "" {
+		referenceDateTo, err := time.Parse("2006-01-02", referenceDateToParam)
+
+		if err != nil {
+			messages = append(messages, "The param reference_date_to is invalid")
+		}
+
+		modelCashLaunchFilter.ReferenceDateTo = referenceDateTo
+	}
+
+	if valueFromParam := query.Get("value_from"); valueFromParam != "" {
+		valueFrom, err := decimal.NewFromString(valueFromParam)
+
+		if err != nil {
+			messages = append(messages, "The param value_from is invalid")
+		}
+
+		modelCashLaunchFilter.ValueFrom = decimal.NewNullDecimal(valueFrom)
+	}
+
+	if valueToParam := query.Get("value_to"); valueToParam != "" {
+		valueTo, err := decimal.NewFromString(valueToParam)
+
+		if err != nil {
+			messages = append(messages, "The param value_to is invalid")
+		}
+
+		modelCashLaunchFilter.ValueTo = decimal.NewNullDecimal(valueTo)
+	}
+
+	if includeDeletedParam := query.Get("include_deleted"); includeDeletedParam != "" {
+		includeDeleted, err := strconv.ParseBool(includeDeletedParam)
+
+		if err != nil {
+			messages = append(messages, "The param include_deleted is invalid")
+		}
+
+		modelCashLaunchFilter.IncludeDeleted = includeDeleted
+	}
+
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+
+		if err != nil {
+			messages = append(messages, "The param limit is invalid")
+		}
+
+		modelCashLaunchFilter.Limit = limit
+	}
+
+	if len(messages) > 0 {
+		return nil, errors.New(strings.Join(messages, ";"))
+	}
+
+	return modelCashLaunchFilter, nil
+}
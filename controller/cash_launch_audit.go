@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+)
+
+// History godoc
+// @Summary      Histórico
+// @Description  Retorna o histórico de alterações de um Lançamento na ordem das alterações, cada inclusão, alteração, exclusão e estorno registra o Lançamento antes e depois da Ação, o Responsável (header X-User-ID) e a Requisição (header X-Request-ID). O histórico de um Lançamento excluído também é retornado.
+// @Tags         Lançamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento" example("1")
+// @Success      200 {object}  model.CashLaunchAudits
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/launch/{id}/history [get]
+func (controllerCashLaunch *CashLaunch) History(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchAudits, err := controllerCashLaunch.UseCaseCashLaunch.ListAuditByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashLaunch.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashLaunch.Title)
+
+			logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashLaunchAudits)
+}
+
+// extractRequestAudit identifies the change made by the request with the actor
+// of the X-User-ID header, anonymous when it is not informed, and the request
+// id of the X-Request-ID header
+func extractRequestAudit(req *http.Request) model.Audit {
+	modelAudit := model.Audit{
+		Actor:     auditHeaderValue(req.Header.Get("X-User-ID")),
+		RequestID: auditHeaderValue(req.Header.Get("X-Request-ID")),
+	}
+
+	if modelAudit.Actor == "" {
+		modelAudit.Actor = usecase.AuditActorAnonymous
+	}
+
+	return modelAudit
+}
+
+// auditHeaderValue trims the header value and cuts it to the size stored in
+// the history keeping whole characters
+func auditHeaderValue(value string) string {
+	runes := []rune(strings.TrimSpace(value))
+
+	if len(runes) > usecase.AuditActorMaxLen {
+		runes = runes[:usecase.AuditActorMaxLen]
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	launch_import_cnab "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/cnab"
+	launch_import_csv "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/csv"
+	launch_import_ofx "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/ofx"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+)
+
+// Import godoc
+// @Summary      Importar
+// @Description  Inclui os Lançamentos de um arquivo CSV, de um extrato OFX 1.x (SGML) ou 2.x (XML) ou de um arquivo retorno CNAB 240 ou 400 enviado no corpo da requisição ou no campo file de um formulário multipart, com as mesmas validações da inclusão. No modo all_or_nothing nenhum Lançamento é incluído quando alguma linha falha, no modo best_effort as linhas válidas são incluídas. O resultado de cada linha é retornado no relatório. Sem a coluna type do CSV o sinal do valor define o Tipo (negativo=Débito). Cada STMTTRN do OFX é uma linha com DTPOSTED na Data de Referencia, o sinal do TRNAMT no Tipo, MEMO (ou NAME quando vazio) na Descrição e FITID no Identificador externo, na Conta informada em account_id. Do retorno CNAB (layout identificado pelo tamanho do header) os títulos liquidados dos segmentos T/U do CNAB 240 e dos detalhes do CNAB 400 são Créditos e os pagamentos efetuados dos segmentos A/J do CNAB 240 são Débitos, com o banco e o nosso número no Identificador externo, na Conta informada em account_id. Os registros não liquidados são retornados como ignored e os registros não mapeados ou os headers e trailers inválidos como failed. As linhas com o Identificador externo de um Lançamento da Conta são retornadas como duplicate e não são incluídas novamente.
+// @Tags         Lançamentos
+// @Accept       text/csv
+// @Accept       application/x-ofx
+// @Accept       text/plain
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format             query  string  false  "Formato do arquivo" Enums(csv, ofx, cnab) default(csv)
+// @Param        mode               query  string  false  "Modo da importação" Enums(all_or_nothing, best_effort) default(all_or_nothing)
+// @Param        delimiter          query  string  false  "Separador das colunas" default(,)
+// @Param        header             query  bool    false  "A primeira linha contém os nomes das colunas" default(true)
+// @Param        columns            query  string  false  "Mapeamento campo:coluna separado por vírgula, a coluna é o nome no cabeçalho ou a posição a partir de 1 sem cabeçalho (campos account_id, category_id, reference_date, type, description, value, currency, external_id)" example(reference_date:data,description:historico,value:valor)
+// @Param        date_format        query  string  false  "Formato das datas" Enums(YYYY-MM-DD, DD/MM/YYYY, MM/DD/YYYY, YYYYMMDD) default(YYYY-MM-DD)
+// @Param        decimal_separator  query  string  false  "Separador decimal dos valores (. ou , quando o . separa os milhares)" default(.)
+// @Param        account_id         query  int     false  "Id da Conta dos Lançamentos (obrigatório no OFX e no CNAB, no CSV somente sem a coluna account_id)" example(1)
+// @Param        file               formData  file  false  "Arquivo CSV, OFX ou CNAB (multipart)"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
+// @Success      200  {object}  model.CashLaunchImportReport
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/launch/import [post]
+func (controllerCashLaunch *CashLaunch) Import(rw http.ResponseWriter, req *http.Request) {
+	extendImportDeadline(rw)
+
+	file, err := extractRequestImportFile(req)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashLaunch.Title)
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	launchImportReader, err := newLaunchImportReader(req, file)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchImportReport, err := controllerCashLaunch.UseCaseCashLaunch.Import(launchImportReader, req.URL.Query().Get("mode"), extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashLaunch.Title)
+
+			logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashLaunchImportReport)
+}
+
+// ImportTimeout is the time to read the file and write the report of an
+// import, longer than the timeouts of the server for the other requests
+var ImportTimeout = 5 * time.Minute
+
+// extendImportDeadline extends the deadlines of the connection of an import,
+// a writer without deadlines keeps the timeouts of the server
+func extendImportDeadline(rw http.ResponseWriter) {
+	responseController := http.NewResponseController(rw)
+	deadline := time.Now().Add(ImportTimeout)
+
+	responseController.SetReadDeadline(deadline)
+	responseController.SetWriteDeadline(deadline)
+}
+
+// extractRequestImportFile returns the file field of a multipart form or the
+// body itself, both streamed without being loaded in memory
+func extractRequestImportFile(req *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	if mediaType != "multipart/form-data" {
+		return req.Body, nil
+	}
+
+	multipartReader, err := req.MultipartReader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := multipartReader.NextPart()
+
+		if err == io.EOF {
+			return nil, errors.New("The multipart form has no file field")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// newLaunchImportReader returns the parser of the format of the file
+func newLaunchImportReader(req *http.Request, file io.Reader) (launch_import.Reader, error) {
+	switch strings.ToLower(req.URL.Query().Get("format")) {
+	case "", "csv":
+		options, err := extractURLQueryParamsCashLaunchImportCSV(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return launch_import_csv.NewCSV(file, options)
+	case "ofx":
+		accountID, err := extractURLQueryParamAccountID(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return launch_import_ofx.NewOFX(file, &launch_import_ofx.OFXOptions{AccountID: accountID})
+	case "cnab":
+		accountID, err := extractURLQueryParamAccountID(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return launch_import_cnab.NewCNAB(file, &launch_import_cnab.CNABOptions{AccountID: accountID})
+	default:
+		return nil, errors.New("The param format not in ['csv', 'ofx', 'cnab']")
+	}
+}
+
+func extractURLQueryParamsCashLaunchImportCSV(req *http.Request) (*launch_import_csv.CSVOptions, error) {
+	query := req.URL.Query()
+	options := &launch_import_csv.CSVOptions{
+		Header:           true,
+		Columns:          map[string]string{},
+		DateFormat:       query.Get("date_format"),
+		DecimalSeparator: query.Get("decimal_separator"),
+	}
+
+	messages := []string{}
+
+	if delimiter := query.Get("delimiter"); delimiter != "" {
+		options.Delimiter, _ = utf8.DecodeRuneInString(delimiter)
+
+		if utf8.RuneCountInString(delimiter) != 1 || options.Delimiter == '"' || options.Delimiter == '\r' || options.Delimiter == '\n' {
+			messages = append(messages, "The param delimiter is invalid")
+		}
+	}
+
+	if header := query.Get("header"); header != "" {
+		var err error
+
+		options.Header, err = strconv.ParseBool(header)
+
+		if err != nil {
+			messages = append(messages, "The param header is invalid")
+		}
+	}
+
+	if columns := query.Get("columns"); columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			fields := strings.SplitN(column, ":", 2)
+
+			if len(fields) != 2 || strings.TrimSpace(fields[0]) == "" || strings.TrimSpace(fields[1]) == "" {
+				messages = append(messages, "The param columns is not in the field:column format")
+				break
+			}
+
+			options.Columns[strings.ToLower(strings.TrimSpace(fields[0]))] = fields[1]
+		}
+	}
+
+	accountID, err := extractURLQueryParamAccountID(req)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	}
+
+	options.AccountID = accountID
+
+	if len(messages) > 0 {
+		return nil, errors.New(strings.Join(messages, ";"))
+	}
+
+	return options, nil
+}
@@ -21,7 +21,7 @@ var (
 	config, _            = util.LoadConfig("./../")
 	log                  = hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 	repositoryTest, _    = repository.NewPostgres(config)
-	usecaseCashLaunch    = usecase.NewCashLaunch(repositoryTest.CashLaunch())
+	usecaseCashLaunch    = usecase.NewCashLaunch(repositoryTest.CashLaunch(), repositoryTest.CashAccount(), repositoryTest.CashCategory(), repositoryTest.CashCategoryRule(), repositoryTest.CashInstallment(), repositoryTest.CashPeriod(), repositoryTest.ExchangeRate(), config.BaseCurrency, nil)
 	controllerCashLaunch = controller.NewCashLaunch(log, usecaseCashLaunch)
 	controllerTitle      = "CashLaunch"
 )
@@ -49,7 +49,7 @@ func TestIntegrationCashLaunchInsert(t *testing.T) {
 			reqBodyModelCashLaunch: &model.CashLaunch{},
 			resBodyModel:           &model.Error{},
 			wantResCode:            http.StatusBadRequest,
-			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
+			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The account_id is empty;The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
 		},
 		{
 			name:                   "Success",
@@ -63,7 +63,7 @@ func TestIntegrationCashLaunchInsert(t *testing.T) {
 				assert.Equal(t, reqBodyModelCashLaunch.ReferenceDate, resBodyModel.ReferenceDate)
 				assert.Equal(t, reqBodyModelCashLaunch.Type, resBodyModel.Type)
 				assert.Equal(t, reqBodyModelCashLaunch.Description, resBodyModel.Description)
-				assert.Equal(t, reqBodyModelCashLaunch.Value, resBodyModel.Value)
+				assert.Equal(t, reqBodyModelCashLaunch.Value.String(), resBodyModel.Value.String())
 				assert.NotEqual(t, reqBodyModelCashLaunch.CreatedAt, resBodyModel.CreatedAt)
 				assert.NotEqual(t, reqBodyModelCashLaunch.UpdatedAt, resBodyModel.UpdatedAt)
 			},
@@ -97,7 +97,7 @@ func TestIntegrationCashLaunchInsert(t *testing.T) {
 			if tt.assert != nil {
 				tt.assert(t, tt.reqBodyModelCashLaunch, tt.resBodyModel.(*model.CashLaunch))
 			} else {
-				if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+				if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 					t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 				}
 			}
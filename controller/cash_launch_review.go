@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+)
+
+// Approve godoc
+// @Summary      Aprovar
+// @Description  Aprova um Lançamento pendente de aprovação, a partir da aprovação o Lançamento é considerado nos Saldos. Os Lançamentos com Valor na Moeda Base acima do limite do seu Tipo configurado em CASH_LAUNCH_APPROVAL_THRESHOLDS são incluídos pendentes de aprovação.
+// @Tags         Lançamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento" example("1")
+// @Param        request   body      model.parametersCashLaunchReviewWrapper  false  "Revisão"
+// @Param        X-User-ID   header    string  false  "Responsável pela aprovação (anonymous quando não informado)"
+// @Success      200 {object}  model.CashLaunch
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/launch/{id}/approve [post]
+func (controllerCashLaunch *CashLaunch) Approve(rw http.ResponseWriter, req *http.Request) {
+	controllerCashLaunch.review(rw, req, repository.CashLaunchStatusApproved)
+}
+
+// Reject godoc
+// @Summary      Rejeitar
+// @Description  Rejeita um Lançamento pendente de aprovação, o Lançamento rejeitado nunca é considerado nos Saldos. O comentário é obrigatório na rejeição.
+// @Tags         Lançamentos
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento" example("1")
+// @Param        request   body      model.parametersCashLaunchReviewWrapper  true  "Revisão"
+// @Param        X-User-ID   header    string  false  "Responsável pela rejeição (anonymous quando não informado)"
+// @Success      200 {object}  model.CashLaunch
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/launch/{id}/reject [post]
+func (controllerCashLaunch *CashLaunch) Reject(rw http.ResponseWriter, req *http.Request) {
+	controllerCashLaunch.review(rw, req, repository.CashLaunchStatusRejected)
+}
+
+// review assigns the status to the launch pending approval
+func (controllerCashLaunch *CashLaunch) review(rw http.ResponseWriter, req *http.Request, status string) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchReview := &model.CashLaunchReview{}
+
+	// the body is optional on the approval
+	err = json.NewDecoder(req.Body).Decode(modelCashLaunchReview)
+
+	if err != nil && err != io.EOF {
+		responseError := model.BadRequestDeserialize(controllerCashLaunch.Title)
+
+		logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchReview.ID = id
+	modelCashLaunchReview.Status = status
+
+	modelCashLaunch, err := controllerCashLaunch.UseCaseCashLaunch.Review(modelCashLaunchReview, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashLaunch.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashLaunch.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashLaunch.Title)
+
+			logger.LogErrorRequest(controllerCashLaunch.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashLaunch)
+}
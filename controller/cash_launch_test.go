@@ -4,25 +4,31 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 var controllerCashLaunchTitle = "CashLaunch"
+var baseCurrencyDefault = "BRL"
+var modelAuditDefault = model.Audit{Actor: "test", RequestID: "test"}
 var modelCashLaunchDefault = &model.CashLaunch{
+	AccountID:     1,
 	ReferenceDate: usecase.CashLaunchReferenceDateMin,
 	Type:          "c",
 	Description:   "Description Test",
-	Value:         0.01,
+	Value:         decimal.RequireFromString("0.01"),
 }
 
 func TestCashLaunchInsert(t *testing.T) {
@@ -48,7 +54,7 @@ func TestCashLaunchInsert(t *testing.T) {
 			reqBodyModelCashLaunch: &model.CashLaunch{},
 			resBodyModel:           &model.Error{},
 			wantResCode:            http.StatusBadRequest,
-			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
+			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The account_id is empty;The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
 		},
 		{
 			name:                   "RepositoryError",
@@ -70,7 +76,7 @@ func TestCashLaunchInsert(t *testing.T) {
 				assert.Equal(t, reqBodyModelCashLaunch.ReferenceDate, resBodyModel.ReferenceDate)
 				assert.Equal(t, reqBodyModelCashLaunch.Type, resBodyModel.Type)
 				assert.Equal(t, reqBodyModelCashLaunch.Description, resBodyModel.Description)
-				assert.Equal(t, reqBodyModelCashLaunch.Value, resBodyModel.Value)
+				assert.Equal(t, reqBodyModelCashLaunch.Value.String(), resBodyModel.Value.String())
 				assert.NotEqual(t, reqBodyModelCashLaunch.CreatedAt, resBodyModel.CreatedAt)
 				assert.NotEqual(t, reqBodyModelCashLaunch.UpdatedAt, resBodyModel.UpdatedAt)
 			},
@@ -82,7 +88,7 @@ func TestCashLaunchInsert(t *testing.T) {
 			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
 
 			var bytesBody []byte
@@ -110,7 +116,7 @@ func TestCashLaunchInsert(t *testing.T) {
 			if tt.assert != nil {
 				tt.assert(t, tt.reqBodyModelCashLaunch, tt.resBodyModel.(*model.CashLaunch))
 			} else {
-				if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+				if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 					t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 				}
 			}
@@ -118,16 +124,80 @@ func TestCashLaunchInsert(t *testing.T) {
 	}
 }
 
+func TestCashLaunchInsertValueJSON(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      string
+		wantResCode  int
+		wantResValue string
+	}
+
+	tests := []test{
+		{
+			name:         "Number",
+			reqBody:      `{"account_id":1,"reference_date":"1900-01-01T00:00:00Z","type":"C","description":"Description Test","value":1234567890123.45}`,
+			wantResCode:  http.StatusCreated,
+			wantResValue: `"value":1234567890123.45`,
+		},
+		{
+			name:         "NumberRound",
+			reqBody:      `{"account_id":1,"reference_date":"1900-01-01T00:00:00Z","type":"C","description":"Description Test","value":0.125}`,
+			wantResCode:  http.StatusCreated,
+			wantResValue: `"value":0.13`,
+		},
+		{
+			name:         "String",
+			reqBody:      `{"account_id":1,"reference_date":"1900-01-01T00:00:00Z","type":"C","description":"Description Test","value":"98765432109876.54"}`,
+			wantResCode:  http.StatusCreated,
+			wantResValue: `"value":98765432109876.54`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/launch", bytes.NewBufferString(tt.reqBody))
+			handler := http.HandlerFunc(controllerCashLaunch.Insert)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, tt.wantResCode, res.Code)
+			assert.Contains(t, res.Body.String(), tt.wantResValue)
+		})
+	}
+}
+
 func TestCashLaunchList(t *testing.T) {
 	type test struct {
 		name         string
+		reqQuery     string
 		resBodyModel interface{}
 		repoError    bool
 		wantResCode  int
 		wantResBody  interface{}
+		wantResNext  bool
 	}
 
 	tests := []test{
+		{
+			name:         "ParamInvalidError",
+			reqQuery:     "?transfer_id=x&category_id=x&reference_date_from=2000-13-01&reference_date_to=x&value_from=x&value_to=1,5&limit=x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param transfer_id is invalid;The param category_id is invalid;The param reference_date_from is invalid;The param reference_date_to is invalid;The param value_from is invalid;The param value_to is invalid;The param limit is invalid"),
+		},
+		{
+			name:         "ParamValidateError",
+			reqQuery:     "?sort=currency",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashLaunchMessageListSortInvalidError),
+		},
 		{
 			name:         "RepositoryError",
 			repoError:    true,
@@ -137,9 +207,18 @@ func TestCashLaunchList(t *testing.T) {
 		},
 		{
 			name:         "Success",
+			reqQuery:     "?reference_date_from=2000-01-01&reference_date_to=2001-12-31&type=D&value_from=12.34&value_to=12.34&description=inmemory",
+			resBodyModel: &model.CashLaunches{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunches{repository_in_memory.InMemoryCashLaunches[2], repository_in_memory.InMemoryCashLaunches[0]},
+		},
+		{
+			name:         "SuccessNext",
+			reqQuery:     "?reference_date_from=2000-01-01&reference_date_to=2001-12-31&sort=value&order=desc&limit=1",
 			resBodyModel: &model.CashLaunches{},
 			wantResCode:  http.StatusOK,
-			wantResBody:  &repository_in_memory.InMemoryCashLaunches,
+			wantResBody:  &model.CashLaunches{repository_in_memory.InMemoryCashLaunches[1]},
+			wantResNext:  true,
 		},
 	}
 
@@ -148,10 +227,10 @@ func TestCashLaunchList(t *testing.T) {
 			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
 
-			req, _ := http.NewRequest(http.MethodGet, "/api/cash/launch", nil)
+			req, _ := http.NewRequest(http.MethodGet, "/api/cash/launch"+tt.reqQuery, nil)
 			handler := http.HandlerFunc(controllerCashLaunch.List)
 			res := httptest.NewRecorder()
 
@@ -161,9 +240,13 @@ func TestCashLaunchList(t *testing.T) {
 				t.Errorf("List() got res.code = %v, want %v", res.Code, tt.wantResCode)
 			}
 
+			if resNext := res.Header().Get("X-Next"); (resNext != "") != tt.wantResNext {
+				t.Errorf("List() got res.header X-Next = %v, want %v", resNext, tt.wantResNext)
+			}
+
 			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("List() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
@@ -219,7 +302,7 @@ func TestCashLaunchGetByID(t *testing.T) {
 			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
 
 			url := fmt.Sprintf("/api/cash/launch/%v", tt.reqParam)
@@ -236,7 +319,7 @@ func TestCashLaunchGetByID(t *testing.T) {
 
 			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("GetByID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
@@ -248,7 +331,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 
 	modelCashLaunchRes.Description = fmt.Sprintf("%v Test Update", modelCashLaunchRes.Description)
 	modelCashLaunchRes.ReferenceDate = modelCashLaunchDefault.ReferenceDate.AddDate(1, 1, 1)
-	modelCashLaunchRes.Value += 98.76
+	modelCashLaunchRes.Value = modelCashLaunchRes.Value.Add(decimal.RequireFromString("98.76"))
 
 	type test struct {
 		name                   string
@@ -282,7 +365,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 			reqBodyModelCashLaunch: &model.CashLaunch{},
 			resBodyModel:           &model.Error{},
 			wantResCode:            http.StatusBadRequest,
-			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
+			wantResBody:            model.BadRequestModelValidate(controllerCashLaunchTitle, "The account_id is empty;The reference_date is empty;The type is empty;The description is empty;The value is less or equal 0"),
 		},
 		{
 			name:                   "NotFoundError",
@@ -313,7 +396,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 				assert.Equal(t, reqBodyModelCashLaunch.ID, resBodyModel.ID)
 				assert.Equal(t, reqBodyModelCashLaunch.ReferenceDate, resBodyModel.ReferenceDate)
 				assert.Equal(t, reqBodyModelCashLaunch.Description, resBodyModel.Description)
-				assert.Equal(t, reqBodyModelCashLaunch.Value, resBodyModel.Value)
+				assert.Equal(t, reqBodyModelCashLaunch.Value.String(), resBodyModel.Value.String())
 				assert.Equal(t, reqBodyModelCashLaunch.CreatedAt, resBodyModel.CreatedAt)
 				assert.NotEqual(t, reqBodyModelCashLaunch.UpdatedAt, resBodyModel.UpdatedAt)
 			},
@@ -325,7 +408,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
 
 			url := fmt.Sprintf("/api/cash/launch/%v", tt.reqParam)
@@ -355,7 +438,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 			if tt.assert != nil {
 				tt.assert(t, tt.reqBodyModelCashLaunch, tt.resBodyModel.(*model.CashLaunch))
 			} else {
-				if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+				if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 					t.Errorf("Update() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 				}
 			}
@@ -408,7 +491,7 @@ func TestCashLaunchDeleteByID(t *testing.T) {
 			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
 			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
 
 			url := fmt.Sprintf("/api/cash/launch/%v", tt.reqParam)
@@ -427,9 +510,547 @@ func TestCashLaunchDeleteByID(t *testing.T) {
 				json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
 			}
 
-			if !reflect.DeepEqual(tt.resBodyModel, tt.wantResBody) {
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
 				t.Errorf("DeleteByID() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
 			}
 		})
 	}
 }
+
+func TestCashLaunchReverse(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		reqBody      string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "DeserializeError",
+			reqParam:     "3",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashLaunchTitle),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashLaunchTitle),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "3",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashLaunchTitle),
+		},
+		{
+			name:         "Success",
+			reqParam:     "3",
+			resBodyModel: &model.CashLaunches{},
+			wantResCode:  http.StatusCreated,
+		},
+		{
+			name:         "ReversedError",
+			reqParam:     "3",
+			reqBody:      `{"description": "estorno"}`,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashLaunchTitle, usecase.CashLaunchReversalMessageReversedError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+			url := fmt.Sprintf("/api/cash/launch/%v/reversal", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(tt.reqBody))
+			handler := http.HandlerFunc(controllerCashLaunch.Reverse)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Reverse() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashLaunches, ok := tt.resBodyModel.(*model.CashLaunches); ok {
+				assert.Len(t, *resultCashLaunches, 1)
+				assert.Equal(t, int64(3), (*resultCashLaunches)[0].ReversalOfID)
+				assert.Equal(t, "C", (*resultCashLaunches)[0].Type)
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Reverse() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashLaunchHistory(t *testing.T) {
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+	controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+	// the launch is inserted through the controller to audit the request headers
+	req, _ := http.NewRequest(http.MethodPost, "/api/cash/launch", bytes.NewBufferString(`{"account_id": 1, "reference_date": "2016-02-10T00:00:00Z", "type": "D", "description": "compra auditada", "value": 10}`))
+	req.Header.Set("X-User-ID", "maria")
+	req.Header.Set("X-Request-ID", "request-history")
+	res := httptest.NewRecorder()
+
+	http.HandlerFunc(controllerCashLaunch.Insert).ServeHTTP(res, req)
+
+	modelCashLaunch := &model.CashLaunch{}
+	json.NewDecoder(res.Body).Decode(modelCashLaunch)
+
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashLaunchTitle),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "1",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashLaunchTitle),
+		},
+		{
+			name:         "Success",
+			reqParam:     fmt.Sprint(modelCashLaunch.ID),
+			resBodyModel: &model.CashLaunchAudits{},
+			wantResCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+			url := fmt.Sprintf("/api/cash/launch/%v/history", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			handler := http.HandlerFunc(controllerCashLaunch.History)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("History() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashLaunchAudits, ok := tt.resBodyModel.(*model.CashLaunchAudits); ok {
+				assert.Len(t, *resultCashLaunchAudits, 1)
+				assert.Equal(t, "insert", (*resultCashLaunchAudits)[0].Action)
+				assert.Equal(t, "maria", (*resultCashLaunchAudits)[0].Actor)
+				assert.Equal(t, "request-history", (*resultCashLaunchAudits)[0].RequestID)
+				assert.Nil(t, (*resultCashLaunchAudits)[0].Before)
+				assert.Equal(t, modelCashLaunch.ID, (*resultCashLaunchAudits)[0].After.ID)
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("History() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashLaunchApproveReject(t *testing.T) {
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+	repository, _ := repository_in_memory.NewInMemory(false)
+	approvalThresholds := usecase.CashLaunchApprovalThresholds{"D": decimal.RequireFromString("1000")}
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, approvalThresholds)
+
+	modelCashLaunchIDs := []int64{}
+
+	for _, description := range []string{"compra aprovada", "compra rejeitada"} {
+		modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+			AccountID:     1,
+			ReferenceDate: time.Date(2007, 9, 10, 0, 0, 0, 0, time.UTC),
+			Type:          "D",
+			Description:   description,
+			Value:         decimal.RequireFromString("5000"),
+		}, modelAuditDefault)
+		assert.Nil(t, err)
+
+		modelCashLaunchIDs = append(modelCashLaunchIDs, modelCashLaunch.ID)
+	}
+
+	type test struct {
+		name         string
+		handler      string
+		reqParam     string
+		reqBody      string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			handler:      "approve",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "DeserializeError",
+			handler:      "approve",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[0]),
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashLaunchTitle),
+		},
+		{
+			name:         "NotFoundError",
+			handler:      "approve",
+			reqParam:     "0",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashLaunchTitle),
+		},
+		{
+			name:         "RepositoryError",
+			handler:      "approve",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[0]),
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashLaunchTitle),
+		},
+		{
+			name:         "ApproveSuccess",
+			handler:      "approve",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[0]),
+			resBodyModel: &model.CashLaunch{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunch{ID: modelCashLaunchIDs[0], Status: "approved"},
+		},
+		{
+			name:         "ApproveNotPendingError",
+			handler:      "approve",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[0]),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashLaunchTitle, usecase.CashLaunchReviewMessageNotPendingError),
+		},
+		{
+			name:         "RejectCommentError",
+			handler:      "reject",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[1]),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashLaunchTitle, usecase.CashLaunchReviewMessageCommentEmptyError),
+		},
+		{
+			name:         "RejectSuccess",
+			handler:      "reject",
+			reqParam:     fmt.Sprint(modelCashLaunchIDs[1]),
+			reqBody:      `{"comment": "sem nota fiscal"}`,
+			resBodyModel: &model.CashLaunch{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunch{ID: modelCashLaunchIDs[1], Status: "rejected", ReviewComment: "sem nota fiscal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, approvalThresholds)
+			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+			url := fmt.Sprintf("/api/cash/launch/%v/%v", tt.reqParam, tt.handler)
+
+			req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(tt.reqBody))
+			req.Header.Set("X-User-ID", "maria")
+
+			handler := http.HandlerFunc(controllerCashLaunch.Approve)
+
+			if tt.handler == "reject" {
+				handler = http.HandlerFunc(controllerCashLaunch.Reject)
+			}
+
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("%v() got res.code = %v, want %v", tt.handler, res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashLaunch, ok := tt.resBodyModel.(*model.CashLaunch); ok {
+				wantCashLaunch := tt.wantResBody.(*model.CashLaunch)
+
+				assert.Equal(t, wantCashLaunch.ID, resultCashLaunch.ID)
+				assert.Equal(t, wantCashLaunch.Status, resultCashLaunch.Status)
+				assert.Equal(t, wantCashLaunch.ReviewComment, resultCashLaunch.ReviewComment)
+				assert.Equal(t, "maria", resultCashLaunch.ReviewedBy)
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("%v() got res.body = %v, want %v", tt.handler, tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err := usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+}
+
+func TestCashLaunchImport(t *testing.T) {
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+	csvValid := "account_id,reference_date,type,description,value\n1,2006-05-10,C,Venda importada,10\n"
+	ofxValid := "<OFX><BANKTRANLIST><STMTTRN><DTPOSTED>20060513<TRNAMT>-15.00<FITID>ofx-1<MEMO>Tarifa importada</STMTTRN><STMTTRN><DTPOSTED>20060513<TRNAMT>-15.00<FITID>ofx-1<MEMO>Tarifa repetida</STMTTRN></BANKTRANLIST></OFX>"
+
+	multipartBody := &bytes.Buffer{}
+	multipartWriter := multipart.NewWriter(multipartBody)
+	multipartFile, _ := multipartWriter.CreateFormFile("file", "lancamentos.csv")
+	multipartFile.Write([]byte(csvValid))
+	multipartWriter.Close()
+
+	multipartBodyNoFile := &bytes.Buffer{}
+	multipartWriterNoFile := multipart.NewWriter(multipartBodyNoFile)
+	multipartWriterNoFile.WriteField("mode", "best_effort")
+	multipartWriterNoFile.Close()
+
+	type test struct {
+		name         string
+		reqParam     string
+		reqQuery     string
+		reqBody      *bytes.Buffer
+		reqType      string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqParam:     "import",
+			reqQuery:     "header=x",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param header is invalid"),
+		},
+		{
+			name:         "ColumnsError",
+			reqParam:     "import",
+			reqQuery:     "columns=value:valor",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The column valor of the value is not in the header;The column of the reference_date is not mapped;The column of the description is not mapped;The param account_id is empty and the column of the account_id is not mapped"),
+		},
+		{
+			name:         "ModeError",
+			reqParam:     "import",
+			reqQuery:     "mode=partial",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashLaunchImportMessageModeInvalidError),
+		},
+		{
+			name:         "DeserializeError",
+			reqParam:     "import",
+			reqBody:      multipartBodyNoFile,
+			reqType:      multipartWriterNoFile.FormDataContentType(),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashLaunchTitle),
+		},
+		{
+			name:         "FormatError",
+			reqParam:     "import",
+			reqQuery:     "format=xls",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param format not in ['csv', 'ofx', 'cnab']"),
+		},
+		{
+			name:         "OFXAccountError",
+			reqParam:     "import",
+			reqQuery:     "format=ofx",
+			reqBody:      bytes.NewBufferString(ofxValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param account_id is empty"),
+		},
+		{
+			name:         "CNABHeaderError",
+			reqParam:     "import",
+			reqQuery:     "format=cnab&account_id=2",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The file is not a CNAB 240 or 400"),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "import",
+			reqBody:      bytes.NewBufferString(csvValid),
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashLaunchTitle),
+		},
+		{
+			name:         "FailedLine",
+			reqParam:     "import",
+			reqQuery:     "header=false&columns=reference_date:1,description:2,value:3&account_id=1",
+			reqBody:      bytes.NewBufferString("2006-05-11,Conta de luz,-20\n2006-05-12,x,30\n"),
+			resBodyModel: &model.CashLaunchImportReport{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunchImportReport{Mode: "all_or_nothing", Total: 2, Failed: 1, Skipped: 1},
+		},
+		{
+			name:         "Success",
+			reqParam:     "import",
+			reqBody:      bytes.NewBufferString(csvValid),
+			resBodyModel: &model.CashLaunchImportReport{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunchImportReport{Mode: "all_or_nothing", Total: 1, Imported: 1},
+		},
+		{
+			name:         "OFXSuccess",
+			reqParam:     "import",
+			reqQuery:     "format=ofx&account_id=2",
+			reqBody:      bytes.NewBufferString(ofxValid),
+			reqType:      "application/x-ofx",
+			resBodyModel: &model.CashLaunchImportReport{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunchImportReport{Mode: "all_or_nothing", Total: 2, Imported: 1, Duplicated: 1},
+		},
+		{
+			name:         "MultipartSuccess",
+			reqParam:     "import",
+			reqQuery:     "mode=best_effort",
+			reqBody:      multipartBody,
+			reqType:      multipartWriter.FormDataContentType(),
+			resBodyModel: &model.CashLaunchImportReport{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunchImportReport{Mode: "best_effort", Total: 1, Imported: 1},
+		},
+	}
+
+	modelCashLaunchIDs := []int64{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+			controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+			url := fmt.Sprintf("/api/cash/launch/%v?%v", tt.reqParam, tt.reqQuery)
+
+			req, _ := http.NewRequest(http.MethodPost, url, tt.reqBody)
+
+			if tt.reqType != "" {
+				req.Header.Set("Content-Type", tt.reqType)
+			}
+
+			res := httptest.NewRecorder()
+
+			handler := http.HandlerFunc(controllerCashLaunch.Import)
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Import() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultReport, ok := tt.resBodyModel.(*model.CashLaunchImportReport); ok {
+				wantReport := tt.wantResBody.(*model.CashLaunchImportReport)
+
+				assert.Equal(t, wantReport.Mode, resultReport.Mode)
+				assert.Equal(t, wantReport.Total, resultReport.Total)
+				assert.Equal(t, wantReport.Imported, resultReport.Imported)
+				assert.Equal(t, wantReport.Failed, resultReport.Failed)
+				assert.Equal(t, wantReport.Skipped, resultReport.Skipped)
+				assert.Equal(t, wantReport.Duplicated, resultReport.Duplicated)
+
+				for _, resultRow := range resultReport.Rows {
+					if resultRow.Status == usecase.CashLaunchImportStatusImported {
+						modelCashLaunchIDs = append(modelCashLaunchIDs, resultRow.LaunchID)
+					}
+				}
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Import() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err := usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashPeriod struct {
+	Title             string
+	Log               hclog.Logger
+	UseCaseCashPeriod usecase.CashPeriod
+}
+
+func NewCashPeriod(log hclog.Logger, useCaseCashPeriod usecase.CashPeriod) *CashPeriod {
+	return &CashPeriod{
+		Title:             "CashPeriod",
+		Log:               log,
+		UseCaseCashPeriod: useCaseCashPeriod,
+	}
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna a Situação de cada mês do intervalo informado, os meses que nunca foram fechados são retornados abertos. Quando não informado retorna os últimos 12 meses até o mês atual. O intervalo não pode ser superior a 120 meses.
+// @Tags         Períodos
+// @Accept       json
+// @Produce      json
+// @Param        from query      string  false  "Período Inicial (AAAA-MM)" example("2020-01")
+// @Param        to   query      string  false  "Período Final (AAAA-MM)" example("2020-12")
+// @Success      200  {object}  model.CashPeriods
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/period [get]
+func (controllerCashPeriod *CashPeriod) List(rw http.ResponseWriter, req *http.Request) {
+	from, to, err := extractURLQueryParamsRangePeriod(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashPeriods, err := controllerCashPeriod.UseCaseCashPeriod.List(from, to)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashPeriod.Title)
+
+			logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashPeriods)
+}
+
+// Close godoc
+// @Summary      Fechar
+// @Description  Fecha um mês encerrado gravando o Saldo Final do seu último dia de todas as Contas. Os Lançamentos com Data de Referencia em um Período fechado não podem ser incluídos, alterados, excluídos ou estornados.
+// @Tags         Períodos
+// @Accept       json
+// @Produce      json
+// @Param        period   path      string  true  "Período (AAAA-MM)" example("2020-05")
+// @Param        X-User-ID   header    string  false  "Responsável pelo fechamento (anonymous quando não informado)"
+// @Success      200  {object}  model.CashPeriod
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/period/{period}/close [post]
+func (controllerCashPeriod *CashPeriod) Close(rw http.ResponseWriter, req *http.Request) {
+	period, err := extractURLPathParamPeriod(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashPeriod, err := controllerCashPeriod.UseCaseCashPeriod.Close(period, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashPeriod.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashPeriod.Title)
+
+			logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashPeriod)
+}
+
+// Reopen godoc
+// @Summary      Reabrir
+// @Description  Reabre um Período fechado liberando a alteração dos seus Lançamentos. Somente os Responsáveis configurados em CASH_PERIOD_REOPEN_ACTORS podem reabrir um Período, o Responsável do header X-User-ID deve ser definido por um proxy autenticado na frente da API.
+// @Tags         Períodos
+// @Accept       json
+// @Produce      json
+// @Param        period   path      string  true  "Período (AAAA-MM)" example("2020-05")
+// @Param        X-User-ID   header    string  true  "Responsável pela reabertura"
+// @Success      200  {object}  model.CashPeriod
+// @Failure      400  {object}  model.Error
+// @Failure      403  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/period/{period}/reopen [post]
+func (controllerCashPeriod *CashPeriod) Reopen(rw http.ResponseWriter, req *http.Request) {
+	period, err := extractURLPathParamPeriod(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashPeriod, err := controllerCashPeriod.UseCaseCashPeriod.Reopen(period, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashPeriod.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrForbidden); ok {
+			responseError = model.ForbiddenPermission(controllerCashPeriod.Title, err.Error())
+
+			logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusForbidden)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashPeriod.Title)
+
+			logger.LogErrorRequest(controllerCashPeriod.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashPeriod)
+}
+
+// extractURLPathParamPeriod returns the first day of the month of the path
+func extractURLPathParamPeriod(req *http.Request) (time.Time, error) {
+	period, err := time.Parse("2006-01", strings.Split(req.URL.Path, "/")[4])
+
+	if err != nil {
+		return period, errors.New("Period invalid")
+	}
+
+	return period, nil
+}
+
+// extractURLQueryParamsRangePeriod returns the months of the query, the month
+// not informed is zero to use the default of the list
+func extractURLQueryParamsRangePeriod(req *http.Request) (time.Time, time.Time, error) {
+	messages := []string{}
+	periods := []time.Time{}
+
+	for _, name := range []string{"from", "to"} {
+		period := time.Time{}
+		param := req.URL.Query().Get(name)
+
+		if param != "" {
+			var err error
+
+			period, err = time.Parse("2006-01", param)
+
+			if err != nil {
+				messages = append(messages, "The param "+name+" is invalid")
+			}
+		}
+
+		periods = append(periods, period)
+	}
+
+	if len(messages) > 0 {
+		return time.Time{}, time.Time{}, errors.New(strings.Join(messages, ";"))
+	}
+
+	return periods[0], periods[1], nil
+}
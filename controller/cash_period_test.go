@@ -0,0 +1,275 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashPeriodTitle = "CashPeriod"
+var cashPeriodReopenActorsDefault = []string{"admin"}
+
+func TestCashPeriodList(t *testing.T) {
+	type test struct {
+		name         string
+		reqQuery     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamError",
+			reqQuery:     "from=2012-13&to=x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param from is invalid;The param to is invalid"),
+		},
+		{
+			name:         "RangeError",
+			reqQuery:     "from=2012-03&to=2012-01",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashPeriodMessageListToSmallerFromError),
+		},
+		{
+			name:         "RepositoryError",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashPeriodTitle),
+		},
+		{
+			name:         "Success",
+			reqQuery:     "from=2011-11&to=2012-02",
+			resBodyModel: &model.CashPeriods{},
+			wantResCode:  http.StatusOK,
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashPeriod := usecase.NewCashPeriod(repository.CashPeriod(), cashPeriodReopenActorsDefault)
+			controllerCashPeriod := controller.NewCashPeriod(log, usecaseCashPeriod)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/cash/period?"+tt.reqQuery, nil)
+			handler := http.HandlerFunc(controllerCashPeriod.List)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("List() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashPeriods, ok := tt.resBodyModel.(*model.CashPeriods); ok {
+				assert.Len(t, *resultCashPeriods, 4)
+				assert.Equal(t, time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), (*resultCashPeriods)[0].Period)
+				assert.Equal(t, time.Date(2012, 2, 1, 0, 0, 0, 0, time.UTC), (*resultCashPeriods)[3].Period)
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("List() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashPeriodCloseReopen(t *testing.T) {
+	type test struct {
+		name         string
+		handler      string
+		reqParam     string
+		reqActor     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "CloseParamError",
+			handler:      "close",
+			reqParam:     "2012-1",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Period invalid"),
+		},
+		{
+			name:         "CloseNotEndedError",
+			handler:      "close",
+			reqParam:     time.Now().UTC().Format("2006-01"),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashPeriodTitle, usecase.CashPeriodMessageNotEndedError),
+		},
+		{
+			name:         "CloseRepositoryError",
+			handler:      "close",
+			reqParam:     "2012-01",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashPeriodTitle),
+		},
+		{
+			name:         "CloseSuccess",
+			handler:      "close",
+			reqParam:     "2012-01",
+			resBodyModel: &model.CashPeriod{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashPeriod{Status: usecase.CashPeriodStatusClosed, ClosedBy: usecase.AuditActorAnonymous},
+		},
+		{
+			name:         "CloseClosedError",
+			handler:      "close",
+			reqParam:     "2012-01",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashPeriodTitle, usecase.CashPeriodMessageClosedError),
+		},
+		{
+			name:         "ReopenParamError",
+			handler:      "reopen",
+			reqParam:     "x",
+			reqActor:     "admin",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Period invalid"),
+		},
+		{
+			name:         "ReopenForbiddenError",
+			handler:      "reopen",
+			reqParam:     "2012-01",
+			reqActor:     "maria",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusForbidden,
+			wantResBody:  model.ForbiddenPermission(controllerCashPeriodTitle, usecase.CashPeriodMessageReopenForbiddenError),
+		},
+		{
+			name:         "ReopenRepositoryError",
+			handler:      "reopen",
+			reqParam:     "2012-01",
+			reqActor:     "admin",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashPeriodTitle),
+		},
+		{
+			name:         "ReopenSuccess",
+			handler:      "reopen",
+			reqParam:     "2012-01",
+			reqActor:     "admin",
+			resBodyModel: &model.CashPeriod{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashPeriod{Status: usecase.CashPeriodStatusOpen, ClosedBy: usecase.AuditActorAnonymous, ReopenedBy: "admin"},
+		},
+		{
+			name:         "ReopenNotClosedError",
+			handler:      "reopen",
+			reqParam:     "2012-01",
+			reqActor:     "admin",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashPeriodTitle, usecase.CashPeriodMessageNotClosedError),
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashPeriod := usecase.NewCashPeriod(repository.CashPeriod(), cashPeriodReopenActorsDefault)
+			controllerCashPeriod := controller.NewCashPeriod(log, usecaseCashPeriod)
+
+			url := fmt.Sprintf("/api/cash/period/%v/%v", tt.reqParam, tt.handler)
+
+			req, _ := http.NewRequest(http.MethodPost, url, nil)
+
+			if tt.reqActor != "" {
+				req.Header.Set("X-User-ID", tt.reqActor)
+			}
+
+			handler := http.HandlerFunc(controllerCashPeriod.Close)
+
+			if tt.handler == "reopen" {
+				handler = http.HandlerFunc(controllerCashPeriod.Reopen)
+			}
+
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("%v() got res.code = %v, want %v", tt.handler, res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if resultCashPeriod, ok := tt.resBodyModel.(*model.CashPeriod); ok {
+				wantCashPeriod := tt.wantResBody.(*model.CashPeriod)
+
+				assert.Equal(t, time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC), resultCashPeriod.Period)
+				assert.Equal(t, wantCashPeriod.Status, resultCashPeriod.Status)
+				assert.Equal(t, wantCashPeriod.ClosedBy, resultCashPeriod.ClosedBy)
+				assert.Equal(t, wantCashPeriod.ReopenedBy, resultCashPeriod.ReopenedBy)
+				return
+			}
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("%v() got res.body = %v, want %v", tt.handler, tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestCashPeriodLaunchClosed(t *testing.T) {
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashPeriod := usecase.NewCashPeriod(repository.CashPeriod(), cashPeriodReopenActorsDefault)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+	controllerCashLaunch := controller.NewCashLaunch(log, usecaseCashLaunch)
+
+	period := time.Date(2012, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := usecaseCashPeriod.Close(period, modelAuditDefault)
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/cash/launch", bytes.NewBufferString(`{"account_id": 1, "reference_date": "2012-05-10T00:00:00Z", "type": "D", "description": "compra fechada", "value": 10}`))
+	res := httptest.NewRecorder()
+
+	http.HandlerFunc(controllerCashLaunch.Insert).ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusConflict, res.Code)
+
+	resultError := &model.Error{}
+	json.NewDecoder(res.Body).Decode(resultError)
+
+	assert.Equal(t, model.ConflictPeriodClosed(controllerCashLaunchTitle, "The reference_date 2012-05-10 is in the closed period 2012-05"), resultError)
+
+	_, err = usecaseCashPeriod.Reopen(period, model.Audit{Actor: "admin"})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,390 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashReconciliation struct {
+	Title                     string
+	Log                       hclog.Logger
+	UseCaseCashReconciliation usecase.CashReconciliation
+}
+
+func NewCashReconciliation(log hclog.Logger, useCaseCashReconciliation usecase.CashReconciliation) *CashReconciliation {
+	return &CashReconciliation{
+		Title:                     "CashReconciliation",
+		Log:                       log,
+		UseCaseCashReconciliation: useCaseCashReconciliation,
+	}
+}
+
+// Report godoc
+// @Summary      Relatório
+// @Description  Retorna a conciliação da Conta no intervalo de Datas de Referencia informado: as Linhas do Extrato conciliadas com os seus Lançamentos, as Linhas do Extrato sem Lançamento e os Lançamentos aprovados sem Linha do Extrato. O intervalo não pode ser superior a 366 dias.
+// @Tags         Conciliação
+// @Accept       json
+// @Produce      json
+// @Param        account_id  query  int     true  "Id da Conta" example(1)
+// @Param        from        query  string  true  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-05-01")
+// @Param        to          query  string  true  "Data de Referencia Final (AAAA-MM-DD)" example("2020-05-31")
+// @Success      200  {object}  model.CashReconciliation
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/reconciliation [get]
+func (controllerCashReconciliation *CashReconciliation) Report(rw http.ResponseWriter, req *http.Request) {
+	accountID, rangeReferenceDate, err := extractURLQueryParamsCashReconciliation(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashReconciliation, err := controllerCashReconciliation.UseCaseCashReconciliation.Report(accountID, rangeReferenceDate.From, rangeReferenceDate.To)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashReconciliation.Title)
+
+			logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashReconciliation)
+}
+
+// Import godoc
+// @Summary      Importar Extrato
+// @Description  Inclui as Linhas de um extrato bancário, separadas dos Lançamentos, de um arquivo CSV, OFX ou CNAB nos mesmos formatos da importação de Lançamentos. Cada linha válida é incluída e o resultado de cada linha é retornado no relatório com o Id da Linha do Extrato incluída. As linhas com o Identificador externo de uma Linha do Extrato da Conta já importada são retornadas como duplicate e não são incluídas novamente.
+// @Tags         Conciliação
+// @Accept       text/csv
+// @Accept       application/x-ofx
+// @Accept       text/plain
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format             query  string  false  "Formato do arquivo" Enums(csv, ofx, cnab) default(csv)
+// @Param        delimiter          query  string  false  "Separador das colunas" default(,)
+// @Param        header             query  bool    false  "A primeira linha contém os nomes das colunas" default(true)
+// @Param        columns            query  string  false  "Mapeamento campo:coluna separado por vírgula, a coluna é o nome no cabeçalho ou a posição a partir de 1 sem cabeçalho (campos account_id, reference_date, type, description, value, external_id)" example(reference_date:data,description:historico,value:valor)
+// @Param        date_format        query  string  false  "Formato das datas" Enums(YYYY-MM-DD, DD/MM/YYYY, MM/DD/YYYY, YYYYMMDD) default(YYYY-MM-DD)
+// @Param        decimal_separator  query  string  false  "Separador decimal dos valores (. ou , quando o . separa os milhares)" default(.)
+// @Param        account_id         query  int     false  "Id da Conta do Extrato (obrigatório no OFX e no CNAB, no CSV somente sem a coluna account_id)" example(1)
+// @Param        file               formData  file  false  "Arquivo CSV, OFX ou CNAB (multipart)"
+// @Success      200  {object}  model.CashLaunchImportReport
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/reconciliation/import [post]
+func (controllerCashReconciliation *CashReconciliation) Import(rw http.ResponseWriter, req *http.Request) {
+	extendImportDeadline(rw)
+
+	file, err := extractRequestImportFile(req)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashReconciliation.Title)
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	launchImportReader, err := newLaunchImportReader(req, file)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashLaunchImportReport, err := controllerCashReconciliation.UseCaseCashReconciliation.Import(launchImportReader)
+
+	if err != nil {
+		responseError := model.InternalServerErrorRepositoryPersist(controllerCashReconciliation.Title)
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashLaunchImportReport)
+}
+
+// AutoMatch godoc
+// @Summary      Conciliar Automaticamente
+// @Description  Concilia as Linhas do Extrato não conciliadas da Conta no intervalo informado com os Lançamentos aprovados não conciliados do mesmo Tipo e Valor, com a Data de Referencia até date_window dias de diferença e a similaridade das Descrições (coeficiente de Dice dos pares de letras, sem acentos e sem pontuação) de pelo menos min_similarity. Os pares mais similares são conciliados primeiro e, na mesma similaridade, as Datas mais próximas. Retorna as conciliações realizadas.
+// @Tags         Conciliação
+// @Accept       json
+// @Produce      json
+// @Param        account_id      query  int     true   "Id da Conta" example(1)
+// @Param        from            query  string  true   "Data de Referencia Inicial das Linhas (AAAA-MM-DD)" example("2020-05-01")
+// @Param        to              query  string  true   "Data de Referencia Final das Linhas (AAAA-MM-DD)" example("2020-05-31")
+// @Param        date_window     query  int     false  "Diferença máxima em dias entre as Datas de Referencia (0 a 31)" default(3)
+// @Param        min_similarity  query  number  false  "Similaridade mínima entre as Descrições (0 a 1)" default(0.3)
+// @Param        X-User-ID   header    string  false  "Responsável pela conciliação (anonymous quando não informado)"
+// @Success      200  {object}  model.CashReconciliationMatches
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/reconciliation/match [post]
+func (controllerCashReconciliation *CashReconciliation) AutoMatch(rw http.ResponseWriter, req *http.Request) {
+	modelCashReconciliationAutoMatch, err := extractURLQueryParamsCashReconciliationAutoMatch(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashReconciliationMatches, err := controllerCashReconciliation.UseCaseCashReconciliation.AutoMatch(modelCashReconciliationAutoMatch, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashReconciliation.Title)
+
+			logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashReconciliationMatches)
+}
+
+// Match godoc
+// @Summary      Conciliar Manualmente
+// @Description  Concilia a Linha do Extrato não conciliada com um Lançamento aprovado da mesma Conta não conciliado com outra Linha. O Tipo, o Valor e a Data de Referencia não são verificados na conciliação manual.
+// @Tags         Conciliação
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Linha do Extrato" example("1")
+// @Param        request   body      model.parametersCashStatementLineMatchWrapper  true  "Conciliação"
+// @Param        X-User-ID   header    string  false  "Responsável pela conciliação (anonymous quando não informado)"
+// @Success      200 {object}  model.CashStatementLine
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/reconciliation/statement/{id}/match [post]
+func (controllerCashReconciliation *CashReconciliation) Match(rw http.ResponseWriter, req *http.Request) {
+	id, err := extractURLPathParamCashStatementLineID(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashStatementLineMatch := &model.CashStatementLineMatch{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashStatementLineMatch)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashReconciliation.Title)
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashStatementLineMatch.ID = id
+
+	modelCashStatementLine, err := controllerCashReconciliation.UseCaseCashReconciliation.Match(modelCashStatementLineMatch, extractRequestAudit(req))
+
+	controllerCashReconciliation.responseCashStatementLine(rw, req, modelCashStatementLine, err)
+}
+
+// Unmatch godoc
+// @Summary      Desfazer Conciliação
+// @Description  Desfaz a conciliação automática ou manual da Linha do Extrato, liberando a Linha e o Lançamento para uma nova conciliação.
+// @Tags         Conciliação
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id da Linha do Extrato" example("1")
+// @Success      200 {object}  model.CashStatementLine
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/reconciliation/statement/{id}/unmatch [post]
+func (controllerCashReconciliation *CashReconciliation) Unmatch(rw http.ResponseWriter, req *http.Request) {
+	id, err := extractURLPathParamCashStatementLineID(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashStatementLine, err := controllerCashReconciliation.UseCaseCashReconciliation.Unmatch(id)
+
+	controllerCashReconciliation.responseCashStatementLine(rw, req, modelCashStatementLine, err)
+}
+
+// responseCashStatementLine writes the statement line matched or unmatched or
+// the error of the change
+func (controllerCashReconciliation *CashReconciliation) responseCashStatementLine(rw http.ResponseWriter, req *http.Request, modelCashStatementLine *model.CashStatementLine, err error) {
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashReconciliation.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashReconciliation.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashReconciliation.Title)
+
+			logger.LogErrorRequest(controllerCashReconciliation.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashStatementLine)
+}
+
+// extractURLPathParamCashStatementLineID returns the id of the statement line
+// of the path /api/cash/reconciliation/statement/{id}
+func extractURLPathParamCashStatementLineID(req *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(strings.Split(req.URL.Path, "/")[5], 10, 64)
+
+	if err != nil {
+		return 0, errors.New("Id invalid")
+	}
+
+	return id, nil
+}
+
+// extractURLQueryParamsCashReconciliation returns the account and the range of
+// the reconciliation, all of them required
+func extractURLQueryParamsCashReconciliation(req *http.Request) (int64, *model.CashBalanceDailyRangeReferenceDate, error) {
+	messages := []string{}
+
+	accountID, err := extractURLQueryParamAccountID(req)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	} else if accountID == 0 {
+		messages = append(messages, usecase.CashReconciliationMessageAccountIDEmptyError)
+	}
+
+	rangeReferenceDate, err := extractURLQueryParamsRangeReferenceDate(req)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	}
+
+	if len(messages) > 0 {
+		return 0, nil, errors.New(strings.Join(messages, ";"))
+	}
+
+	return accountID, rangeReferenceDate, nil
+}
+
+func extractURLQueryParamsCashReconciliationAutoMatch(req *http.Request) (*model.CashReconciliationAutoMatch, error) {
+	query := req.URL.Query()
+	messages := []string{}
+
+	modelCashReconciliationAutoMatch := &model.CashReconciliationAutoMatch{
+		DateWindow:    usecase.CashReconciliationDateWindowDefault,
+		MinSimilarity: usecase.CashReconciliationMinSimilarityDefault,
+	}
+
+	accountID, rangeReferenceDate, err := extractURLQueryParamsCashReconciliation(req)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	} else {
+		modelCashReconciliationAutoMatch.AccountID = accountID
+		modelCashReconciliationAutoMatch.From = rangeReferenceDate.From
+		modelCashReconciliationAutoMatch.To = rangeReferenceDate.To
+	}
+
+	if dateWindowParam := query.Get("date_window"); dateWindowParam != "" {
+		dateWindow, err := strconv.Atoi(dateWindowParam)
+
+		if err != nil {
+			messages = append(messages, "The param date_window is invalid")
+		}
+
+		modelCashReconciliationAutoMatch.DateWindow = dateWindow
+	}
+
+	if minSimilarityParam := query.Get("min_similarity"); minSimilarityParam != "" {
+		minSimilarity, err := strconv.ParseFloat(minSimilarityParam, 64)
+
+		if err != nil {
+			messages = append(messages, "The param min_similarity is invalid")
+		}
+
+		modelCashReconciliationAutoMatch.MinSimilarity = minSimilarity
+	}
+
+	if len(messages) > 0 {
+		return nil, errors.New(strings.Join(messages, ";"))
+	}
+
+	return modelCashReconciliationAutoMatch, nil
+}
@@ -0,0 +1,266 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	launch_import_csv "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/csv"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashReconciliationTitle = "CashReconciliation"
+
+func TestCashReconciliation(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashReconciliation := usecase.NewCashReconciliation(repositoryInMemory.CashStatementLine(), repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount())
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: time.Date(2003, 6, 9, 0, 0, 0, 0, time.UTC),
+		Type:          "D",
+		Description:   "Tarifa bancaria",
+		Value:         decimal.NewFromInt(15),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	launchImportReader, err := launch_import_csv.NewCSV(strings.NewReader("account_id,reference_date,type,description,value,external_id\n1,2003-06-10,D,Tarifa bancária,15,CT-1\n"), &launch_import_csv.CSVOptions{Header: true})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportReport, err := usecaseCashReconciliation.Import(launchImportReader)
+	assert.Nil(t, err)
+
+	statementLineID := modelCashLaunchImportReport.Rows[0].StatementLineID
+	queryRange := "account_id=1&from=2003-06-01&to=2003-06-30"
+
+	type test struct {
+		name         string
+		handler      string
+		reqParam     string
+		reqQuery     string
+		reqBody      string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ReportParamError",
+			handler:      "report",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param account_id is empty;The param from is empty;The param to is empty"),
+		},
+		{
+			name:         "ReportRepositoryError",
+			handler:      "report",
+			reqQuery:     queryRange,
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerCashReconciliationTitle),
+		},
+		{
+			name:         "ImportFormatError",
+			handler:      "import",
+			reqQuery:     "format=xml",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param format not in ['csv', 'ofx', 'cnab']"),
+		},
+		{
+			name:         "ImportSuccess",
+			handler:      "import",
+			reqBody:      "account_id,reference_date,type,description,value,external_id\n1,2003-06-10,D,Tarifa bancária,15,CT-1\n1,2003-06-11,C,Estorno,5,CT-2\n",
+			resBodyModel: &model.CashLaunchImportReport{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashLaunchImportReport{Total: 2, Imported: 1, Duplicated: 1},
+		},
+		{
+			name:         "AutoMatchParamError",
+			handler:      "automatch",
+			reqQuery:     queryRange + "&date_window=x&min_similarity=y",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param date_window is invalid;The param min_similarity is invalid"),
+		},
+		{
+			name:         "AutoMatchRangeError",
+			handler:      "automatch",
+			reqQuery:     queryRange + "&date_window=40",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashReconciliationMessageDateWindowError),
+		},
+		{
+			name:         "AutoMatchSuccess",
+			handler:      "automatch",
+			reqQuery:     queryRange,
+			resBodyModel: &model.CashReconciliationMatches{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashReconciliationMatches{{StatementLine: model.CashStatementLine{ID: statementLineID, LaunchID: modelCashLaunch.ID}}},
+		},
+		{
+			name:         "MatchParamError",
+			handler:      "match",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Id invalid"),
+		},
+		{
+			name:         "MatchDeserializeError",
+			handler:      "match",
+			reqParam:     fmt.Sprint(statementLineID),
+			reqBody:      "{",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashReconciliationTitle),
+		},
+		{
+			name:         "MatchNotFound",
+			handler:      "match",
+			reqParam:     "999999",
+			reqBody:      fmt.Sprintf(`{"launch_id":%d}`, modelCashLaunch.ID),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerCashReconciliationTitle),
+		},
+		{
+			name:         "MatchMatchedError",
+			handler:      "match",
+			reqParam:     fmt.Sprint(statementLineID),
+			reqBody:      fmt.Sprintf(`{"launch_id":%d}`, modelCashLaunch.ID),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashReconciliationTitle, usecase.CashStatementLineMessageMatchedError),
+		},
+		{
+			name:         "UnmatchSuccess",
+			handler:      "unmatch",
+			reqParam:     fmt.Sprint(statementLineID),
+			resBodyModel: &model.CashStatementLine{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashStatementLine{ID: statementLineID},
+		},
+		{
+			name:         "UnmatchNotMatchedError",
+			handler:      "unmatch",
+			reqParam:     fmt.Sprint(statementLineID),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashReconciliationTitle, usecase.CashStatementLineMessageNotMatchedError),
+		},
+		{
+			name:         "MatchSuccess",
+			handler:      "match",
+			reqParam:     fmt.Sprint(statementLineID),
+			reqBody:      fmt.Sprintf(`{"launch_id":%d}`, modelCashLaunch.ID),
+			resBodyModel: &model.CashStatementLine{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.CashStatementLine{ID: statementLineID, LaunchID: modelCashLaunch.ID},
+		},
+		{
+			name:         "ReportSuccess",
+			handler:      "report",
+			reqQuery:     queryRange,
+			resBodyModel: &model.CashReconciliation{},
+			wantResCode:  http.StatusOK,
+		},
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashReconciliation := usecase.NewCashReconciliation(repository.CashStatementLine(), repository.CashLaunch(), repository.CashAccount())
+			controllerCashReconciliation := controller.NewCashReconciliation(log, usecaseCashReconciliation)
+
+			method := http.MethodPost
+			url := "/api/cash/reconciliation"
+			handler := http.HandlerFunc(controllerCashReconciliation.Report)
+
+			switch tt.handler {
+			case "report":
+				method = http.MethodGet
+			case "import":
+				url += "/import"
+				handler = http.HandlerFunc(controllerCashReconciliation.Import)
+			case "automatch":
+				url += "/match"
+				handler = http.HandlerFunc(controllerCashReconciliation.AutoMatch)
+			case "match":
+				url += fmt.Sprintf("/statement/%v/match", tt.reqParam)
+				handler = http.HandlerFunc(controllerCashReconciliation.Match)
+			case "unmatch":
+				url += fmt.Sprintf("/statement/%v/unmatch", tt.reqParam)
+				handler = http.HandlerFunc(controllerCashReconciliation.Unmatch)
+			}
+
+			var reqBody io.Reader
+
+			if tt.reqBody != "" {
+				reqBody = strings.NewReader(tt.reqBody)
+			}
+
+			req, _ := http.NewRequest(method, url+"?"+tt.reqQuery, reqBody)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("%v() got res.code = %v, want %v", tt.handler, res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			switch result := tt.resBodyModel.(type) {
+			case *model.CashLaunchImportReport:
+				want := tt.wantResBody.(*model.CashLaunchImportReport)
+
+				assert.Equal(t, want.Total, result.Total)
+				assert.Equal(t, want.Imported, result.Imported)
+				assert.Equal(t, want.Duplicated, result.Duplicated)
+			case *model.CashReconciliationMatches:
+				want := *tt.wantResBody.(*model.CashReconciliationMatches)
+
+				assert.Len(t, *result, len(want))
+				assert.Equal(t, want[0].StatementLine.ID, (*result)[0].StatementLine.ID)
+				assert.Equal(t, want[0].StatementLine.LaunchID, (*result)[0].Launch.ID)
+				assert.Equal(t, usecase.AuditActorAnonymous, (*result)[0].StatementLine.MatchedBy)
+			case *model.CashStatementLine:
+				want := tt.wantResBody.(*model.CashStatementLine)
+
+				assert.Equal(t, want.ID, result.ID)
+				assert.Equal(t, want.LaunchID, result.LaunchID)
+			case *model.CashReconciliation:
+				assert.Len(t, result.Matched, 1)
+				assert.Len(t, result.UnmatchedBank, 1)
+				assert.Len(t, result.UnmatchedLedger, 0)
+			default:
+				if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+					t.Errorf("%v() got res.body = %v, want %v", tt.handler, tt.resBodyModel, tt.wantResBody)
+				}
+			}
+		})
+	}
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+	assert.Nil(t, err)
+}
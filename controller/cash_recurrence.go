@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashRecurrence struct {
+	Title                 string
+	Log                   hclog.Logger
+	UseCaseCashRecurrence usecase.CashRecurrence
+}
+
+func NewCashRecurrence(log hclog.Logger, useCaseCashRecurrence usecase.CashRecurrence) *CashRecurrence {
+	return &CashRecurrence{
+		Title:                 "CashRecurrence",
+		Log:                   log,
+		UseCaseCashRecurrence: useCaseCashRecurrence,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona Lançamento Recorrente. Os Lançamentos de cada ocorrência até a data atual são gerados por um job executado ao subir a API e depois no intervalo configurado em CASH_RECURRENCE_CRON_JOB_SCHEDULE.
+// @Tags         Lançamentos Recorrentes
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashRecurrenceWrapper  true  "Lançamento Recorrente"
+// @Success      201  {object}  model.CashRecurrence
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/recurrence [post]
+func (controllerCashRecurrence *CashRecurrence) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashRecurrence := &model.CashRecurrence{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashRecurrence)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashRecurrence.Title)
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashRecurrenceInsert, err := controllerCashRecurrence.UseCaseCashRecurrence.Insert(modelCashRecurrence)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashRecurrence.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashRecurrence.Title)
+
+			logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashRecurrenceInsert)
+}
+
+// List godoc
+// @Summary      Listar
+// @Description  Retorna a lista de Lançamentos Recorrentes
+// @Tags         Lançamentos Recorrentes
+// @Accept       json
+// @Produce      json
+// @Success      200 {object}  model.CashRecurrences
+// @Failure      500  {object}  model.Error
+// @Router       /cash/recurrence [get]
+func (controllerCashRecurrence *CashRecurrence) List(rw http.ResponseWriter, req *http.Request) {
+	modelCashRecurrences, err := controllerCashRecurrence.UseCaseCashRecurrence.List()
+
+	if err != nil {
+		responseError := model.InternalServerErrorRepositoryLoad(controllerCashRecurrence.Title)
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashRecurrences)
+}
+
+// GetByID godoc
+// @Summary      Consultar
+// @Description  Retorna um Lançamento Recorrente
+// @Tags         Lançamentos Recorrentes
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento Recorrente" example("1")
+// @Success      200 {object}  model.CashRecurrence
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/recurrence/{id} [get]
+func (controllerCashRecurrence *CashRecurrence) GetByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashRecurrence, err := controllerCashRecurrence.UseCaseCashRecurrence.GetByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashRecurrence.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerCashRecurrence.Title)
+
+			logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	json.NewEncoder(rw).Encode(modelCashRecurrence)
+}
+
+// Update godoc
+// @Summary      Alterar
+// @Description  Altera um Lançamento Recorrente. A alteração vale para as próximas ocorrências, os Lançamentos já gerados não são alterados.
+// @Tags         Lançamentos Recorrentes
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento Recorrente" example("1")
+// @Param        request   body      model.parametersCashRecurrenceWrapper  true  "Lançamento Recorrente"
+// @Success      200 {object}  model.CashRecurrence
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/recurrence/{id} [put]
+func (controllerCashRecurrence *CashRecurrence) Update(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashRecurrence := &model.CashRecurrence{}
+
+	err = json.NewDecoder(req.Body).Decode(modelCashRecurrence)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashRecurrence.Title)
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashRecurrence.ID = id
+
+	modelCashRecurrenceUpdate, err := controllerCashRecurrence.UseCaseCashRecurrence.Update(modelCashRecurrence)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashRecurrence.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashRecurrence.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashRecurrence.Title)
+
+			logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelCashRecurrenceUpdate)
+}
+
+// DeleteByID godoc
+// @Summary      Excluir
+// @Description  Exclui um Lançamento Recorrente. Os Lançamentos já gerados são mantidos.
+// @Tags         Lançamentos Recorrentes
+// @Accept       json
+// @Produce      json
+// @Param        param   path      string  false  "Id do Lançamento Recorrente" example("1")
+// @Success      204
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/recurrence/{id} [delete]
+func (controllerCashRecurrence *CashRecurrence) DeleteByID(rw http.ResponseWriter, req *http.Request) {
+	param := strings.Split(req.URL.Path, "/")[4]
+
+	id, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Id invalid")
+
+		logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	err = controllerCashRecurrence.UseCaseCashRecurrence.DeleteByID(id)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerCashRecurrence.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashRecurrence.Title)
+
+			logger.LogErrorRequest(controllerCashRecurrence.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
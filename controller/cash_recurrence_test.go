@@ -0,0 +1,115 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashRecurrenceTitle = "CashRecurrence"
+
+func TestCashRecurrenceInsert(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      interface{}
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	modelCashRecurrence := &model.CashRecurrence{
+		AccountID:   1,
+		Type:        "D",
+		Description: "ALUGUEL",
+		Value:       decimal.RequireFromString("1000"),
+		Frequency:   "last_business_day",
+		StartDate:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []test{
+		{
+			name:         "DeserializeError",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashRecurrenceTitle),
+		},
+		{
+			name:         "ModelValidateError",
+			reqBody:      &model.CashRecurrence{AccountID: 1, Type: "D", Description: "ALUGUEL", Value: decimal.RequireFromString("1000"), Frequency: "yearly", StartDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashRecurrenceTitle, usecase.CashRecurrenceMessageFrequencyInvalidError),
+		},
+		{
+			name:         "RepositoryError",
+			reqBody:      modelCashRecurrence,
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashRecurrenceTitle),
+		},
+		{
+			name:        "Success",
+			reqBody:     modelCashRecurrence,
+			wantResCode: http.StatusCreated,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashRecurrence := &model.CashRecurrence{}
+				json.NewDecoder(res.Body).Decode(resultCashRecurrence)
+
+				assert.NotEqual(t, int64(0), resultCashRecurrence.ID)
+				assert.Equal(t, "last_business_day", resultCashRecurrence.Frequency)
+				assert.True(t, resultCashRecurrence.LastDate.IsZero())
+
+				repository, _ := repository_in_memory.NewInMemory(false)
+				repository.CashRecurrence().DeleteByID(resultCashRecurrence.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashRecurrence := usecase.NewCashRecurrence(repository.CashRecurrence(), repository.CashAccount(), repository.CashCategory(), repository.CashPeriod(), repository.ExchangeRate(), "BRL", nil)
+			controllerCashRecurrence := controller.NewCashRecurrence(log, usecaseCashRecurrence)
+
+			reqBody, _ := json.Marshal(tt.reqBody)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/recurrence", bytes.NewBuffer(reqBody))
+			handler := http.HandlerFunc(controllerCashRecurrence.Insert)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Insert() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
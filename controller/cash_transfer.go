@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashTransfer struct {
+	Title               string
+	Log                 hclog.Logger
+	UseCaseCashTransfer usecase.CashTransfer
+}
+
+func NewCashTransfer(log hclog.Logger, useCaseCashTransfer usecase.CashTransfer) *CashTransfer {
+	return &CashTransfer{
+		Title:               "CashTransfer",
+		Log:                 log,
+		UseCaseCashTransfer: useCaseCashTransfer,
+	}
+}
+
+// Insert godoc
+// @Summary      Adicionar
+// @Description  Adiciona Transferência entre Contas gerando um Lançamento de Débito na Conta de Origem e um de Crédito na Conta de Destino vinculados pelo Id da Transferência. A alteração ou exclusão de um dos Lançamentos é aplicada também ao outro. O saldo de todas as Contas somadas não considera as Transferências.
+// @Tags         Transferências
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.parametersCashTransferWrapper  true  "Transferência"
+// @Param        X-User-ID   header    string  false  "Responsável pela alteração registrado no histórico do Lançamento (anonymous quando não informado)"
+// @Success      201  {object}  model.CashTransfer
+// @Failure      400  {object}  model.Error
+// @Failure      409  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /cash/transfer [post]
+func (controllerCashTransfer *CashTransfer) Insert(rw http.ResponseWriter, req *http.Request) {
+	modelCashTransfer := &model.CashTransfer{}
+
+	err := json.NewDecoder(req.Body).Decode(modelCashTransfer)
+
+	if err != nil {
+		responseError := model.BadRequestDeserialize(controllerCashTransfer.Title)
+
+		logger.LogErrorRequest(controllerCashTransfer.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelCashTransferInsert, err := controllerCashTransfer.UseCaseCashTransfer.Insert(modelCashTransfer, extractRequestAudit(req))
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrModelValidate); ok {
+			responseError = model.BadRequestModelValidate(controllerCashTransfer.Title, err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(usecase.ErrPeriodClosed); ok {
+			responseError = model.ConflictPeriodClosed(controllerCashTransfer.Title, err.Error())
+
+			rw.WriteHeader(http.StatusConflict)
+		} else {
+			responseError = model.InternalServerErrorRepositoryPersist(controllerCashTransfer.Title)
+
+			logger.LogErrorRequest(controllerCashTransfer.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(modelCashTransferInsert)
+}
@@ -0,0 +1,121 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var controllerCashTransferTitle = "CashTransfer"
+
+func TestCashTransferInsert(t *testing.T) {
+	type test struct {
+		name         string
+		reqBody      interface{}
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+		assert       func(t *testing.T, tt *test, res *httptest.ResponseRecorder)
+	}
+
+	modelCashTransfer := &model.CashTransfer{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		ReferenceDate: time.Date(2010, 01, 05, 00, 00, 00, 000, time.UTC),
+		Description:   "Transfer Test",
+		Value:         decimal.RequireFromString("100"),
+	}
+
+	tests := []test{
+		{
+			name:         "DeserializeError",
+			reqBody:      "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestDeserialize(controllerCashTransferTitle),
+		},
+		{
+			name: "ModelValidateError",
+			reqBody: &model.CashTransfer{
+				FromAccountID: 2,
+				ToAccountID:   2,
+				ReferenceDate: modelCashTransfer.ReferenceDate,
+				Description:   modelCashTransfer.Description,
+				Value:         modelCashTransfer.Value,
+			},
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestModelValidate(controllerCashTransferTitle, usecase.CashTransferMessageAccountIDEqualError),
+		},
+		{
+			name:         "RepositoryError",
+			reqBody:      modelCashTransfer,
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryPersist(controllerCashTransferTitle),
+		},
+		{
+			name:        "Success",
+			reqBody:     modelCashTransfer,
+			wantResCode: http.StatusCreated,
+			assert: func(t *testing.T, tt *test, res *httptest.ResponseRecorder) {
+				resultCashTransfer := &model.CashTransfer{}
+				json.NewDecoder(res.Body).Decode(resultCashTransfer)
+
+				assert.NotEqual(t, int64(0), resultCashTransfer.ID)
+				assert.Equal(t, resultCashTransfer.ID, resultCashTransfer.Debit.TransferID)
+				assert.Equal(t, int64(1), resultCashTransfer.Debit.AccountID)
+				assert.Equal(t, "D", resultCashTransfer.Debit.Type)
+				assert.Equal(t, resultCashTransfer.ID, resultCashTransfer.Credit.TransferID)
+				assert.Equal(t, int64(2), resultCashTransfer.Credit.AccountID)
+				assert.Equal(t, "C", resultCashTransfer.Credit.Type)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseCashTransfer := usecase.NewCashTransfer(repository.CashTransfer(), repository.CashAccount(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault)
+			controllerCashTransfer := controller.NewCashTransfer(log, usecaseCashTransfer)
+
+			reqBody, _ := json.Marshal(tt.reqBody)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/cash/transfer", bytes.NewBuffer(reqBody))
+			handler := http.HandlerFunc(controllerCashTransfer.Insert)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("Insert() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, res)
+				return
+			}
+
+			json.NewDecoder(res.Body).Decode(&tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("Insert() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
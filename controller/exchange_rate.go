@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	logger "github.com/CharlesSchiavinato/minsait-challenge-backend/service/logger"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type ExchangeRate struct {
+	Title               string
+	Log                 hclog.Logger
+	UseCaseExchangeRate usecase.ExchangeRate
+}
+
+func NewExchangeRate(log hclog.Logger, useCaseExchangeRate usecase.ExchangeRate) *ExchangeRate {
+	return &ExchangeRate{
+		Title:               "ExchangeRate",
+		Log:                 log,
+		UseCaseExchangeRate: useCaseExchangeRate,
+	}
+}
+
+// GetByReferenceDate godoc
+// @Summary      Consultar
+// @Description  Retorna as Cotações publicadas pelo Banco Central Europeu na Data Informada. Se não houver publicação na data (fins de semana e feriados) então serão retornadas as Cotações da última publicação anterior.
+// @Tags         Cotações
+// @Accept       json
+// @Produce      json
+// @Param        date   path      string  false  "Data de Referencia (AAAA-MM-DD)" example("2020-05-23")
+// @Success      200  {object}  model.ExchangeRates
+// @Failure      400  {object}  model.Error
+// @Failure      404  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /exchange-rate/{date} [get]
+func (controllerExchangeRate *ExchangeRate) GetByReferenceDate(rw http.ResponseWriter, req *http.Request) {
+	params := strings.Split(req.URL.Path, "/")
+
+	referenceDateParam := ""
+
+	if len(params) > 3 {
+		referenceDateParam = params[3]
+	}
+
+	referenceDate, err := time.Parse("2006-01-02", referenceDateParam)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate("Date invalid")
+
+		logger.LogErrorRequest(controllerExchangeRate.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelExchangeRates, err := controllerExchangeRate.UseCaseExchangeRate.GetByReferenceDate(referenceDate)
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else if _, ok := err.(repository.ErrNotFound); ok {
+			responseError = model.NotFound(controllerExchangeRate.Title)
+
+			rw.WriteHeader(http.StatusNotFound)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerExchangeRate.Title)
+
+			logger.LogErrorRequest(controllerExchangeRate.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelExchangeRates)
+}
+
+// GetByRangeReferenceDate godoc
+// @Summary      Consultar por Período
+// @Description  Retorna as Cotações publicadas pelo Banco Central Europeu no Período informado. O período não pode ser superior a 31 dias.
+// @Tags         Cotações
+// @Accept       json
+// @Produce      json
+// @Param        from query      string  true  "Data de Referencia Inicial (AAAA-MM-DD)" example("2020-05-23")
+// @Param        to   query      string  true  "Data de Referencia Final (AAAA-MM-DD)" example("2020-05-23")
+// @Success      200  {object}  model.ExchangeRates
+// @Failure      400  {object}  model.Error
+// @Failure      500  {object}  model.Error
+// @Router       /exchange-rate [get]
+func (controllerExchangeRate *ExchangeRate) GetByRangeReferenceDate(rw http.ResponseWriter, req *http.Request) {
+	rangeReferenceDate, err := extractURLQueryParamsRangeReferenceDate(req)
+
+	if err != nil {
+		responseError := model.BadRequestParamValidate(err.Error())
+
+		logger.LogErrorRequest(controllerExchangeRate.Log, req, responseError.Message, err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	modelExchangeRates, err := controllerExchangeRate.UseCaseExchangeRate.GetByRangeReferenceDate(&model.ExchangeRateRangeReferenceDate{
+		From: rangeReferenceDate.From,
+		To:   rangeReferenceDate.To,
+	})
+
+	if err != nil {
+		var responseError *model.Error
+
+		if _, ok := err.(usecase.ErrParamValidate); ok {
+			responseError = model.BadRequestParamValidate(err.Error())
+
+			rw.WriteHeader(http.StatusBadRequest)
+		} else {
+			responseError = model.InternalServerErrorRepositoryLoad(controllerExchangeRate.Title)
+
+			logger.LogErrorRequest(controllerExchangeRate.Log, req, responseError.Message, err)
+
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(rw).Encode(responseError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(modelExchangeRates)
+}
@@ -0,0 +1,167 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+var controllerExchangeRateTitle = "ExchangeRate"
+
+func TestExchangeRateGetByReferenceDate(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamInvalidError",
+			reqParam:     "x",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("Date invalid"),
+		},
+		{
+			name:         "ParamBetweenError",
+			reqParam:     usecase.CashLaunchReferenceDateMin.AddDate(0, 0, -1).Format("2006-01-02"),
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate(usecase.CashLaunchMessageReferenceDateBetweenError),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "2000-11-22",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerExchangeRateTitle),
+		},
+		{
+			name:         "NotFoundError",
+			reqParam:     "2000-11-20",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusNotFound,
+			wantResBody:  model.NotFound(controllerExchangeRateTitle),
+		},
+		{
+			name:         "Success",
+			reqParam:     "2000-11-23",
+			resBodyModel: &model.ExchangeRates{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.ExchangeRates{repository_in_memory.InMemoryExchangeRates[1], repository_in_memory.InMemoryExchangeRates[2]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseExchangeRate := usecase.NewExchangeRate(repository.ExchangeRate(), nil)
+
+			controllerExchangeRate := controller.NewExchangeRate(log, usecaseExchangeRate)
+
+			url := fmt.Sprintf("/api/exchange-rate/%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			handler := http.HandlerFunc(controllerExchangeRate.GetByReferenceDate)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("GetByReferenceDate() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("GetByReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
+
+func TestExchangeRateGetByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name         string
+		reqParam     string
+		resBodyModel interface{}
+		repoError    bool
+		wantResCode  int
+		wantResBody  interface{}
+	}
+
+	tests := []test{
+		{
+			name:         "ParamEmptyError",
+			reqParam:     "",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param from is empty;The param to is empty"),
+		},
+		{
+			name:         "ParamInvalidError",
+			reqParam:     "?from=2020-01-02&to=2020-01-01",
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusBadRequest,
+			wantResBody:  model.BadRequestParamValidate("The param to is smaller the param from"),
+		},
+		{
+			name:         "RepositoryError",
+			reqParam:     "?from=2000-11-21&to=2000-11-22",
+			repoError:    true,
+			resBodyModel: &model.Error{},
+			wantResCode:  http.StatusInternalServerError,
+			wantResBody:  model.InternalServerErrorRepositoryLoad(controllerExchangeRateTitle),
+		},
+		{
+			name:         "Success",
+			reqParam:     "?from=2000-11-22&to=2000-11-22",
+			resBodyModel: &model.ExchangeRates{},
+			wantResCode:  http.StatusOK,
+			wantResBody:  &model.ExchangeRates{repository_in_memory.InMemoryExchangeRates[1], repository_in_memory.InMemoryExchangeRates[2]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := hclog.New(&hclog.LoggerOptions{Level: hclog.LevelFromString("OFF")})
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			usecaseExchangeRate := usecase.NewExchangeRate(repository.ExchangeRate(), nil)
+
+			controllerExchangeRate := controller.NewExchangeRate(log, usecaseExchangeRate)
+
+			url := fmt.Sprintf("/api/exchange-rate%v", tt.reqParam)
+
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			handler := http.HandlerFunc(controllerExchangeRate.GetByRangeReferenceDate)
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			if !reflect.DeepEqual(res.Code, tt.wantResCode) {
+				t.Errorf("GetByRangeReferenceDate() got res.code = %v, want %v", res.Code, tt.wantResCode)
+			}
+
+			json.NewDecoder(res.Body).Decode(tt.resBodyModel)
+
+			if !equalJSON(tt.wantResBody, tt.resBodyModel) {
+				t.Errorf("GetByRangeReferenceDate() got res.body = %v, want %v", tt.resBodyModel, tt.wantResBody)
+			}
+		})
+	}
+}
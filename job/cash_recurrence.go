@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashRecurrence struct {
+	Title                 string
+	Log                   hclog.Logger
+	UseCaseCashRecurrence usecase.CashRecurrence
+	Schedule              time.Duration
+}
+
+func NewCashRecurrence(log hclog.Logger, useCaseCashRecurrence usecase.CashRecurrence, schedule time.Duration) *CashRecurrence {
+	return &CashRecurrence{
+		Title:                 "CashRecurrence",
+		Log:                   log,
+		UseCaseCashRecurrence: useCaseCashRecurrence,
+		Schedule:              schedule,
+	}
+}
+
+// Run creates the due launches right away, backfilling the occurrences missed
+// while the server was down, and then on every schedule tick until the
+// context is done
+func (jobCashRecurrence *CashRecurrence) Run(ctx context.Context) {
+	ticker := time.NewTicker(jobCashRecurrence.Schedule)
+	defer ticker.Stop()
+
+	for {
+		jobCashRecurrence.Materialize()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (jobCashRecurrence *CashRecurrence) Materialize() {
+	modelCashLaunches, err := jobCashRecurrence.UseCaseCashRecurrence.Materialize(time.Now())
+
+	if err != nil {
+		jobCashRecurrence.Log.Error("Error creating recurring launches", "job", jobCashRecurrence.Title, "count", len(modelCashLaunches), "error", err)
+		return
+	}
+
+	jobCashRecurrence.Log.Info("Recurring launches created successfuly", "job", jobCashRecurrence.Title, "count", len(modelCashLaunches))
+}
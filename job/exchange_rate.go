@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type ExchangeRate struct {
+	Title               string
+	Log                 hclog.Logger
+	UseCaseExchangeRate usecase.ExchangeRate
+	Schedule            time.Duration
+}
+
+func NewExchangeRate(log hclog.Logger, useCaseExchangeRate usecase.ExchangeRate, schedule time.Duration) *ExchangeRate {
+	return &ExchangeRate{
+		Title:               "ExchangeRate",
+		Log:                 log,
+		UseCaseExchangeRate: useCaseExchangeRate,
+		Schedule:            schedule,
+	}
+}
+
+// Run imports the exchange rates right away and then on every schedule tick
+// until the context is done
+func (jobExchangeRate *ExchangeRate) Run(ctx context.Context) {
+	ticker := time.NewTicker(jobExchangeRate.Schedule)
+	defer ticker.Stop()
+
+	for {
+		jobExchangeRate.Import()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (jobExchangeRate *ExchangeRate) Import() {
+	modelExchangeRates, err := jobExchangeRate.UseCaseExchangeRate.Import()
+
+	if err != nil {
+		jobExchangeRate.Log.Error("Error importing exchange rates", "job", jobExchangeRate.Title, "error", err)
+		return
+	}
+
+	jobExchangeRate.Log.Info("Exchange rates imported successfuly", "job", jobExchangeRate.Title, "count", len(modelExchangeRates))
+}
@@ -11,7 +11,7 @@ type MockCashLaunch struct {
 	mock.Mock
 }
 
-func (mockCashLaunch *MockCashLaunch) Insert(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (mockCashLaunch *MockCashLaunch) Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
 	args := mockCashLaunch.Called()
 
 	modelCashLaunch = args.Get(0).(*model.CashLaunch)
@@ -24,7 +24,7 @@ func (mockCashLaunch *MockCashLaunch) Insert(modelCashLaunch *model.CashLaunch)
 	return &modelCashLaunchInsert, args.Error(1)
 }
 
-func (mockCashLaunch *MockCashLaunch) List() (model.CashLaunches, error) {
+func (mockCashLaunch *MockCashLaunch) List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, error) {
 	args := mockCashLaunch.Called()
 	return args.Get(0).(model.CashLaunches), args.Error(1)
 }
@@ -34,10 +34,26 @@ func (mockCashLaunch *MockCashLaunch) GetByID(id int64) (*model.CashLaunch, erro
 	return args.Get(0).(*model.CashLaunch), args.Error(1)
 }
 
-func (mockCashLaunch *MockCashLaunch) Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (mockCashLaunch *MockCashLaunch) Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
 	return nil, nil
 }
 
-func (mockCashLaunch *MockCashLaunch) DeleteByID(id int64) error {
+func (mockCashLaunch *MockCashLaunch) DeleteByID(id int64, modelAudit model.Audit) error {
 	return nil
 }
+
+func (mockCashLaunch *MockCashLaunch) Reverse(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	return nil, nil
+}
+
+func (mockCashLaunch *MockCashLaunch) ListAuditByID(id int64) (model.CashLaunchAudits, error) {
+	return nil, nil
+}
+
+func (mockCashLaunch *MockCashLaunch) Import(next func() (*model.CashLaunch, error), modelAudit model.Audit) error {
+	return nil
+}
+
+func (mockCashLaunch *MockCashLaunch) Review(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	return nil, nil
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type CashAccount struct {
+	// Identificador da Conta (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Nome da Conta (conta bancária ou caixa)
+	Name string `json:"name" validate:"required" example:"BANCO 001 AG 1234 CC 56789-0"`
+	// Data da Última Alteração da Conta (Atualizado automaticamente na inclusão e alteração)
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão da Conta (Gerado automaticamente na inclusão)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashAccounts []CashAccount
+
+type parametersCashAccountWrapper struct {
+	// Nome da Conta (conta bancária ou caixa)
+	Name string `json:"name" validate:"required" example:"BANCO 001 AG 1234 CC 56789-0"`
+}
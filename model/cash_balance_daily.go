@@ -1,17 +1,128 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type CashBalanceDaily struct {
 	// Data de Referencia
 	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
-	// Saldo
-	Value float64 `json:"value" validate:"required" format:"float" example:"1.23" format:"float"`
+	// Saldo Inicial (acumulado de todos os lançamentos anteriores à Data de Referencia)
+	OpeningBalance decimal.Decimal `json:"opening_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Créditos na Data de Referencia convertidos para a Moeda Base
+	TotalCredit decimal.Decimal `json:"total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos na Data de Referencia convertidos para a Moeda Base
+	TotalDebit decimal.Decimal `json:"total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo do Dia (Créditos - Débitos na Data de Referencia)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo Final (Saldo Inicial + Saldo do Dia)
+	ClosingBalance decimal.Decimal `json:"closing_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Totais do Dia por Moeda original dos Lançamentos (somente quando solicitado)
+	Currencies CashBalanceDailyCurrencies `json:"currencies,omitempty"`
 }
 
 type CashBalanceDailies []CashBalanceDaily
 
+type CashBalanceDailyCurrency struct {
+	// Data de Referencia
+	ReferenceDate time.Time `json:"-"`
+	// Moeda original dos Lançamentos (ISO-4217)
+	Currency string `json:"currency" validate:"required" example:"USD"`
+	// Total de Créditos na Moeda original
+	TotalCredit decimal.Decimal `json:"total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos na Moeda original
+	TotalDebit decimal.Decimal `json:"total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo do Dia na Moeda original (Créditos - Débitos)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo do Dia convertido para a Moeda Base
+	BaseValue decimal.Decimal `json:"base_value" validate:"required" example:"6.09" swaggertype:"number"`
+}
+
+type CashBalanceDailyCurrencies []CashBalanceDailyCurrency
+
 type CashBalanceDailyRangeReferenceDate struct {
 	From time.Time
 	To   time.Time
+	// Identificador da Conta (zero para todas as Contas)
+	AccountID int64
+	// Inclui os Lançamentos excluídos
+	IncludeDeleted bool
+	// Saldo Projetado incluindo os Lançamentos pendentes de aprovação
+	Projected bool
+	// Agrupamento do resumo de saldos (day, week, month, quarter ou year)
+	Granularity string
+	// Retorna todos os dias do Período, inclusive os dias sem Lançamentos
+	Fill bool
 }
+
+type CashBalanceSummary struct {
+	// Início do Período agrupado (dia, segunda-feira da semana ou primeiro dia do mês, trimestre ou ano)
+	Period time.Time `json:"period" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data de Referencia inicial do Período dentro do intervalo consultado
+	From time.Time `json:"from" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data de Referencia final do Período dentro do intervalo consultado
+	To time.Time `json:"to" validate:"required" example:"2019-08-31T00:00:00Z" format:"date-time"`
+	// Saldo Inicial (acumulado de todos os lançamentos anteriores ao Período)
+	OpeningBalance decimal.Decimal `json:"opening_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Créditos do Período convertidos para a Moeda Base
+	TotalCredit decimal.Decimal `json:"total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos do Período convertidos para a Moeda Base
+	TotalDebit decimal.Decimal `json:"total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo do Período (Créditos - Débitos)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo Final (Saldo Inicial + Saldo do Período)
+	ClosingBalance decimal.Decimal `json:"closing_balance" validate:"required" example:"1.23" swaggertype:"number"`
+}
+
+type CashBalanceSummaries []CashBalanceSummary
+
+// CashBalanceSummaryPeriodOf returns the first day of the period of the
+// granularity, the same of the date_trunc of postgres where the week starts
+// on monday
+func CashBalanceSummaryPeriodOf(referenceDate time.Time, granularity string) time.Time {
+	year, month, day := referenceDate.Date()
+
+	switch granularity {
+	case "week":
+		return time.Date(year, month, day-(int(referenceDate.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	case "quarter":
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// CashBalanceSummaryPeriodNext returns the first day of the following period
+func CashBalanceSummaryPeriodNext(period time.Time, granularity string) time.Time {
+	switch granularity {
+	case "week":
+		return period.AddDate(0, 0, 7)
+	case "month":
+		return period.AddDate(0, 1, 0)
+	case "quarter":
+		return period.AddDate(0, 3, 0)
+	case "year":
+		return period.AddDate(1, 0, 0)
+	default:
+		return period.AddDate(0, 0, 1)
+	}
+}
+
+type CashBalanceDailyDrift struct {
+	// Identificador da Conta (zero para o saldo de todas as Contas)
+	AccountID int64 `json:"account_id" format:"int64"`
+	// Data de Referencia
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Saldo armazenado antes da reconstrução
+	Stored CashBalanceDaily `json:"stored" validate:"required"`
+	// Saldo recalculado a partir dos Lançamentos
+	Rebuilt CashBalanceDaily `json:"rebuilt" validate:"required"`
+}
+
+type CashBalanceDailyDrifts []CashBalanceDailyDrift
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashBudget struct {
+	// Identificador do Orçamento (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Categoria orçada, inclui as Subcategorias (0 quando o Orçamento é de um Padrão)
+	CategoryID int64 `json:"category_id" format:"int64" example:"2"`
+	// Padrão procurado na Descrição do Lançamento sem diferenciar maiúsculas e minúsculas (vazio quando o Orçamento é de uma Categoria)
+	Pattern string `json:"pattern" example:""`
+	// Tipo do Padrão (substring=trecho da Descrição regex=expressão regular, vazio quando o Orçamento é de uma Categoria)
+	PatternType string `json:"pattern_type" enums:"substring,regex" example:""`
+	// Tipo dos Lançamentos orçados (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Período orçado (primeiro dia do mês)
+	Period time.Time `json:"period" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Valor orçado na Moeda Base
+	Value decimal.Decimal `json:"value" validate:"required" example:"1500.00" swaggertype:"number"`
+	// Data da Última Alteração do Orçamento (Atualizado automaticamente na inclusão e alteração)
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão do Orçamento (Gerado automaticamente na inclusão)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashBudgets []CashBudget
+
+type parametersCashBudgetWrapper struct {
+	// Identificador da Categoria orçada, inclui as Subcategorias (0 quando o Orçamento é de um Padrão)
+	CategoryID int64 `json:"category_id" format:"int64" example:"2"`
+	// Padrão procurado na Descrição do Lançamento sem diferenciar maiúsculas e minúsculas (vazio quando o Orçamento é de uma Categoria)
+	Pattern string `json:"pattern" example:""`
+	// Tipo do Padrão (substring=trecho da Descrição regex=expressão regular, vazio quando o Orçamento é de uma Categoria)
+	PatternType string `json:"pattern_type" enums:"substring,regex" example:""`
+	// Tipo dos Lançamentos orçados (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Período orçado (o dia é desconsiderado)
+	Period time.Time `json:"period" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Valor orçado na Moeda Base
+	Value decimal.Decimal `json:"value" validate:"required" example:"1500.00" swaggertype:"number"`
+}
+
+// CashBudgetActual is the total of the approved launches of a category or a
+// description and type, the reversed launches and their reversals are left out
+type CashBudgetActual struct {
+	CategoryID  int64
+	Description string
+	Type        string
+	Actual      decimal.Decimal
+}
+
+type CashBudgetActuals []CashBudgetActual
+
+type CashBudgetVarianceItem struct {
+	// Identificador da Categoria orçada (0 quando o Orçamento é de um Padrão)
+	CategoryID int64 `json:"category_id" format:"int64" example:"2"`
+	// Caminho da Categoria no plano de contas (Nomes das Categorias Pai separados por " / ")
+	Path string `json:"path" example:"DESPESAS / ALUGUEL"`
+	// Padrão procurado na Descrição do Lançamento (vazio quando o Orçamento é de uma Categoria)
+	Pattern string `json:"pattern" example:""`
+	// Tipo do Padrão (vazio quando o Orçamento é de uma Categoria)
+	PatternType string `json:"pattern_type" enums:"substring,regex" example:""`
+	// Tipo dos Lançamentos orçados (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Valor orçado nos Períodos do relatório
+	Budgeted decimal.Decimal `json:"budgeted" validate:"required" example:"1500.00" swaggertype:"number"`
+	// Total dos Lançamentos aprovados do Tipo atendidos pelo Orçamento convertidos para a Moeda Base (sem os Lançamentos estornados e os seus Estornos)
+	Actual decimal.Decimal `json:"actual" validate:"required" example:"1650.00" swaggertype:"number"`
+	// Desvio absoluto (Realizado - Orçado)
+	Variance decimal.Decimal `json:"variance" validate:"required" example:"150.00" swaggertype:"number"`
+	// Desvio percentual sobre o Valor orçado
+	VariancePercent decimal.Decimal `json:"variance_percent" validate:"required" example:"10.00" swaggertype:"number"`
+}
+
+type CashBudgetVarianceItems []CashBudgetVarianceItem
+
+type CashBudgetVariance struct {
+	// Período do relatório (primeiro dia do mês)
+	Period time.Time `json:"period" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Visão do relatório (month=somente o Período ytd=de janeiro até o Período)
+	View string `json:"view" validate:"required" enums:"month,ytd" example:"month"`
+	// Data de Referencia inicial dos Lançamentos comparados
+	From time.Time `json:"from" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data de Referencia final dos Lançamentos comparados
+	To time.Time `json:"to" validate:"required" example:"2019-08-31T00:00:00Z" format:"date-time"`
+	// Comparação de cada Orçamento (Categoria ou Padrão e Tipo) com os Lançamentos realizados
+	Items CashBudgetVarianceItems `json:"items"`
+}
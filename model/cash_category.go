@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashCategory struct {
+	// Identificador da Categoria (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Categoria Pai (0 quando é uma Categoria raiz do plano de contas)
+	ParentID int64 `json:"parent_id" format:"int64" example:"0"`
+	// Nome da Categoria (único entre as Categorias de mesmo Pai)
+	Name string `json:"name" validate:"required" example:"DESPESAS ADMINISTRATIVAS"`
+	// Data da Última Alteração da Categoria (Atualizado automaticamente na inclusão e alteração)
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão da Categoria (Gerado automaticamente na inclusão)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashCategories []CashCategory
+
+type parametersCashCategoryWrapper struct {
+	// Identificador da Categoria Pai (0 quando é uma Categoria raiz do plano de contas)
+	ParentID int64 `json:"parent_id" format:"int64" example:"0"`
+	// Nome da Categoria (único entre as Categorias de mesmo Pai)
+	Name string `json:"name" validate:"required" example:"DESPESAS ADMINISTRATIVAS"`
+}
+
+type CashCategoryTotal struct {
+	// Identificador da Categoria (0 para os Lançamentos sem Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"1"`
+	// Identificador da Categoria Pai (0 quando é uma Categoria raiz do plano de contas)
+	ParentID int64 `json:"parent_id" format:"int64" example:"0"`
+	// Caminho da Categoria no plano de contas (Nomes das Categorias Pai separados por " / ")
+	Path string `json:"path" example:"DESPESAS / ALUGUEL"`
+	// Total de Créditos dos Lançamentos da Categoria no Período convertidos para a Moeda Base
+	TotalCredit decimal.Decimal `json:"total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos dos Lançamentos da Categoria no Período convertidos para a Moeda Base
+	TotalDebit decimal.Decimal `json:"total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo dos Lançamentos da Categoria no Período (Créditos - Débitos)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Créditos da Categoria somado ao de todas as suas Subcategorias
+	RollupTotalCredit decimal.Decimal `json:"rollup_total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos da Categoria somado ao de todas as suas Subcategorias
+	RollupTotalDebit decimal.Decimal `json:"rollup_total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo da Categoria somado ao de todas as suas Subcategorias (Créditos - Débitos)
+	RollupValue decimal.Decimal `json:"rollup_value" validate:"required" example:"1.23" swaggertype:"number"`
+}
+
+type CashCategoryTotals []CashCategoryTotal
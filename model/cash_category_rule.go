@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashCategoryRule struct {
+	// Identificador da Regra (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Categoria atribuída aos Lançamentos que atendem a Regra
+	CategoryID int64 `json:"category_id" validate:"required" format:"int64" example:"1"`
+	// Padrão procurado na Descrição do Lançamento (sem diferenciar maiúsculas e minúsculas)
+	Pattern string `json:"pattern" validate:"required" example:"ALUGUEL"`
+	// Tipo do Padrão (substring=trecho da Descrição regex=expressão regular)
+	PatternType string `json:"pattern_type" validate:"required" enums:"substring,regex" example:"substring"`
+	// Tipo do Lançamento (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Valor Mínimo do Lançamento na moeda base (0 quando não há limite)
+	ValueFrom decimal.Decimal `json:"value_from" example:"0" swaggertype:"number"`
+	// Valor Máximo do Lançamento na moeda base (0 quando não há limite)
+	ValueTo decimal.Decimal `json:"value_to" example:"0" swaggertype:"number"`
+	// Prioridade da Regra (quando mais de uma Regra é atendida vale a de menor prioridade e depois a de menor Id)
+	Priority int `json:"priority" example:"10"`
+	// Data da Última Alteração da Regra (Atualizado automaticamente na inclusão e alteração)
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão da Regra (Gerado automaticamente na inclusão)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashCategoryRules []CashCategoryRule
+
+type parametersCashCategoryRuleWrapper struct {
+	// Identificador da Categoria atribuída aos Lançamentos que atendem a Regra
+	CategoryID int64 `json:"category_id" validate:"required" format:"int64" example:"1"`
+	// Padrão procurado na Descrição do Lançamento (sem diferenciar maiúsculas e minúsculas)
+	Pattern string `json:"pattern" validate:"required" example:"ALUGUEL"`
+	// Tipo do Padrão (substring=trecho da Descrição regex=expressão regular)
+	PatternType string `json:"pattern_type" validate:"required" enums:"substring,regex" example:"substring"`
+	// Tipo do Lançamento (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Valor Mínimo do Lançamento na moeda base (0 quando não há limite)
+	ValueFrom decimal.Decimal `json:"value_from" example:"0" swaggertype:"number"`
+	// Valor Máximo do Lançamento na moeda base (0 quando não há limite)
+	ValueTo decimal.Decimal `json:"value_to" example:"0" swaggertype:"number"`
+	// Prioridade da Regra (quando mais de uma Regra é atendida vale a de menor prioridade e depois a de menor Id)
+	Priority int `json:"priority" example:"10"`
+}
+
+type parametersCashCategoryRuleMatchWrapper struct {
+	// Tipo do Lançamento (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D" example:"D"`
+	// Descrição do Lançamento
+	Description string `json:"description" validate:"required" example:"ALUGUEL SALA 12"`
+	// Valor do Lançamento na moeda base
+	Value decimal.Decimal `json:"value" validate:"required" example:"1500.00" swaggertype:"number"`
+}
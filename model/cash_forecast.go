@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashForecastDay struct {
+	// Data de Referencia
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Saldo Inicial projetado (Saldo Final projetado do dia anterior)
+	OpeningBalance decimal.Decimal `json:"opening_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Créditos previstos na Data de Referencia convertidos para a Moeda Base
+	TotalCredit decimal.Decimal `json:"total_credit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Total de Débitos previstos na Data de Referencia convertidos para a Moeda Base
+	TotalDebit decimal.Decimal `json:"total_debit" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo dos Lançamentos aprovados com Data de Referencia futura
+	ScheduledValue decimal.Decimal `json:"scheduled_value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo dos Lançamentos pendentes de aprovação
+	PendingValue decimal.Decimal `json:"pending_value" validate:"required" example:"0" swaggertype:"number"`
+	// Saldo das ocorrências dos Lançamentos Recorrentes ainda não geradas
+	RecurringValue decimal.Decimal `json:"recurring_value" validate:"required" example:"0" swaggertype:"number"`
+	// Saldo do Dia projetado (Créditos - Débitos na Data de Referencia)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo Final projetado (Saldo Inicial + Saldo do Dia)
+	ClosingBalance decimal.Decimal `json:"closing_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo Final projetado negativo
+	Negative bool `json:"negative" example:"false"`
+}
+
+type CashForecastDays []CashForecastDay
+
+type CashForecast struct {
+	// Identificador da Conta (zero para todas as Contas)
+	AccountID int64 `json:"account_id" format:"int64" example:"0"`
+	// Data atual do Saldo Inicial da previsão
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Data final da previsão
+	To time.Time `json:"to" validate:"required" example:"2019-09-30T00:00:00Z" format:"date-time"`
+	// Saldo Final atual (Lançamentos aprovados até a Data atual)
+	OpeningBalance decimal.Decimal `json:"opening_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Saldo Final projetado na Data final
+	ClosingBalance decimal.Decimal `json:"closing_balance" validate:"required" example:"1.23" swaggertype:"number"`
+	// Primeiro dia com o Saldo Final projetado negativo (nulo quando o Saldo projetado não fica negativo)
+	FirstNegativeDate *time.Time `json:"first_negative_date" example:"2019-09-05T00:00:00Z" format:"date-time"`
+	// Saldos projetados de cada dia após a Data atual até a Data final
+	Days CashForecastDays `json:"days"`
+	// Identificadores dos Lançamentos Recorrentes sem cotação para a moeda base, desconsiderados na previsão
+	SkippedRecurrenceIDs []int64 `json:"skipped_recurrence_ids" example:"1"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type CashInstallment struct {
+	// Identificador do Parcelamento
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Conta das Parcelas restantes
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria das Parcelas restantes (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
+	// Descrição das Parcelas restantes
+	Description string `json:"description" validate:"required"`
+	// Valor total das Parcelas restantes (dividido entre elas)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+}
+
+type parametersCashInstallmentWrapper struct {
+	// Identificador da Conta das Parcelas restantes
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria das Parcelas restantes (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
+	// Descrição das Parcelas restantes
+	Description string `json:"description" validate:"required"`
+	// Valor total das Parcelas restantes (dividido entre elas)
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+}
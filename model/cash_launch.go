@@ -1,10 +1,37 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type CashLaunch struct {
 	// Identificador do Lançamento (Gerado automaticamente na inclusão)
 	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Conta do Lançamento
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria do Lançamento (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
+	// Identificador da Transferência do Lançamento (Gerado automaticamente na transferência, 0 quando não é uma transferência)
+	TransferID int64 `json:"transfer_id" format:"int64" example:"0"`
+	// Identificador do Lançamento Recorrente que gerou o Lançamento (Gerado automaticamente, 0 quando não é uma recorrência)
+	RecurrenceID int64 `json:"recurrence_id" format:"int64" example:"0"`
+	// Identificador do Parcelamento do Lançamento (Gerado automaticamente na inclusão parcelada, 0 quando não é parcelado)
+	InstallmentGroupID int64 `json:"installment_group_id" format:"int64" example:"0"`
+	// Número da Parcela do Lançamento (Gerado automaticamente na inclusão parcelada)
+	InstallmentNumber int `json:"installment_number" example:"0"`
+	// Quantidade de Parcelas (informada na inclusão para dividir o Valor em Parcelas mensais, 0 quando não é parcelado)
+	InstallmentCount int `json:"installment_count" example:"0"`
+	// Data de Vencimento da primeira Parcela (somente na inclusão parcelada, quando não informada assume a Data de Referencia)
+	FirstDueDate *time.Time `json:"first_due_date,omitempty" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Identificador do Lançamento estornado por este Lançamento (Gerado automaticamente no estorno, 0 quando não é um estorno)
+	ReversalOfID int64 `json:"reversal_of_id" format:"int64" example:"0"`
+	// Identificador do Lançamento que estornou este Lançamento (Gerado automaticamente no estorno, 0 quando não foi estornado)
+	ReversedByID int64 `json:"reversed_by_id" format:"int64" example:"0"`
+	// Identificador do Lançamento no arquivo importado na sua Conta (Gerado automaticamente na importação, FITID do OFX, vazio quando não foi importado)
+	ExternalID string `json:"external_id" example:""`
 	// Data de Referencia do Lançamento
 	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
 	// Tipo do Lançamento (C=Crédito D=Débito)
@@ -12,16 +39,36 @@ type CashLaunch struct {
 	// Descrição do Lançamento
 	Description string `json:"description" validate:"required"`
 	// Valor do Lançamento
-	Value float64 `json:"value" validate:"required" example:"1.23" format:"float"`
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Moeda do Lançamento (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"USD"`
+	// Cotação utilizada para converter o Valor para a Moeda Base (Calculada automaticamente na inclusão e alteração)
+	ExchangeRate decimal.Decimal `json:"exchange_rate" example:"4.9512" swaggertype:"number"`
+	// Valor do Lançamento convertido para a Moeda Base (Calculado automaticamente na inclusão e alteração)
+	BaseValue decimal.Decimal `json:"base_value" example:"6.09" swaggertype:"number"`
+	// Situação do Lançamento (pending=pendente de aprovação approved=aprovado rejected=rejeitado, Calculada automaticamente na inclusão e alteração pelo limite de aprovação do Tipo, somente os Lançamentos aprovados compõem o saldo)
+	Status string `json:"status" enums:"pending,approved,rejected" example:"approved"`
+	// Comentário da última aprovação ou rejeição do Lançamento
+	ReviewComment string `json:"review_comment" example:""`
+	// Responsável pela última aprovação ou rejeição do Lançamento (header X-User-ID)
+	ReviewedBy string `json:"reviewed_by" example:""`
+	// Data da última aprovação ou rejeição do Lançamento (nulo quando não foi revisado)
+	ReviewedAt *time.Time `json:"reviewed_at" example:"2019-08-24T16:59:59Z" format:"date-time"`
 	// Data da Última Alteração do Lançamento (Atualizado automaticamente na inclusão e alteração)
 	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
 	// Data de Inclusão do Lançamento (Gerado automaticamente na inclusão)
 	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Exclusão do Lançamento (Gerado automaticamente na exclusão, nulo quando não foi excluído)
+	DeletedAt *time.Time `json:"deleted_at" example:"2019-08-24T16:59:59Z" format:"date-time"`
 }
 
 type CashLaunches []CashLaunch
 
 type parametersCashLaunchWrapper struct {
+	// Identificador da Conta do Lançamento
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria do Lançamento (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
 	// Data de Referencia do Lançamento
 	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
 	// Tipo do Lançamento (C=Crédito D=Débito)
@@ -29,5 +76,75 @@ type parametersCashLaunchWrapper struct {
 	// Descrição do Lançamento
 	Description string `json:"description" validate:"required"`
 	// Valor do Lançamento
-	Value float64 `json:"value" validate:"required" example:"1.23" format:"float"`
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Moeda do Lançamento (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"USD"`
+	// Quantidade de Parcelas (opcional, o Valor total é dividido em Parcelas mensais)
+	InstallmentCount int `json:"installment_count" example:"0"`
+	// Data de Vencimento da primeira Parcela (opcional, quando não informada assume a Data de Referencia)
+	FirstDueDate *time.Time `json:"first_due_date,omitempty" example:"2019-08-24T00:00:00Z" format:"date-time"`
+}
+
+type CashLaunchFilter struct {
+	// Identificador da Conta
+	AccountID int64
+	// Identificador da Categoria
+	CategoryID int64
+	// Identificador da Transferência
+	TransferID int64
+	// Data de Referencia inicial (inclusive)
+	ReferenceDateFrom time.Time
+	// Data de Referencia final (inclusive)
+	ReferenceDateTo time.Time
+	// Tipo do Lançamento (C=Crédito D=Débito)
+	Type string
+	// Valor mínimo (inclusive)
+	ValueFrom decimal.NullDecimal
+	// Valor máximo (inclusive)
+	ValueTo decimal.NullDecimal
+	// Trecho da Descrição
+	Description string
+	// Situação do Lançamento (pending, approved ou rejected)
+	Status string
+	// Identificador do Lançamento no arquivo importado
+	ExternalID string
+	// Inclui os Lançamentos excluídos
+	IncludeDeleted bool
+	// Campo de ordenação
+	Sort string
+	// Direção da ordenação (asc ou desc)
+	Order string
+	// Quantidade máxima de Lançamentos retornados
+	Limit int
+	// Token da próxima página retornado pela consulta anterior
+	Next string
+	// Posição da próxima página decodificada do Next
+	Cursor *CashLaunchCursor
+}
+
+type CashLaunchCursor struct {
+	// Campo de ordenação da consulta que gerou o cursor
+	Sort string `json:"sort"`
+	// Direção da ordenação da consulta que gerou o cursor
+	Order string `json:"order"`
+	// Valor do campo de ordenação do último Lançamento retornado
+	Value string `json:"value"`
+	// Identificador do último Lançamento retornado
+	ID int64 `json:"id"`
+}
+
+// CashLaunchSortValue returns the value of the sort field used by the cursor
+func CashLaunchSortValue(modelCashLaunch *CashLaunch, sort string) string {
+	switch sort {
+	case "type":
+		return modelCashLaunch.Type
+	case "description":
+		return modelCashLaunch.Description
+	case "value":
+		return modelCashLaunch.Value.String()
+	case "id":
+		return strconv.FormatInt(modelCashLaunch.ID, 10)
+	default:
+		return modelCashLaunch.ReferenceDate.Format("2006-01-02")
+	}
 }
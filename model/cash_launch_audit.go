@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+// Audit identifies who made a change and in which request, it is recorded
+// with every change of a launch
+type Audit struct {
+	Actor     string
+	RequestID string
+}
+
+type CashLaunchAudit struct {
+	// Identificador do Registro de Auditoria
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador do Lançamento alterado
+	LaunchID int64 `json:"launch_id" validate:"required" minimum:"1" format:"int64"`
+	// Ação realizada no Lançamento
+	Action string `json:"action" validate:"required" enums:"insert,update,delete,reversal"`
+	// Lançamento antes da Ação (nulo na inclusão)
+	Before *CashLaunch `json:"before"`
+	// Lançamento depois da Ação
+	After *CashLaunch `json:"after" validate:"required"`
+	// Responsável pela Ação (header X-User-ID da requisição, anonymous quando não informado e system nas ações automáticas)
+	Actor string `json:"actor" validate:"required" example:"anonymous"`
+	// Identificador da Requisição que realizou a Ação (header X-Request-ID)
+	RequestID string `json:"request_id" example:"4d1f7a52-6c1e-4f7e-9a3b-2b7e1c0f9d11"`
+	// Data da Ação
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashLaunchAudits []CashLaunchAudit
@@ -0,0 +1,47 @@
+package model
+
+// CashLaunchImportRecord stores the launch read from a line of an import file
+// and the errors found reading the line. The line without launch, as an item
+// not settled of a bank return file, is Ignored with the reason in Errors.
+type CashLaunchImportRecord struct {
+	Line       int
+	CashLaunch CashLaunch
+	Errors     []string
+	Ignored    bool
+}
+
+type CashLaunchImportRow struct {
+	// Número da linha no arquivo
+	Line int `json:"line" example:"2"`
+	// Situação da linha (imported=incluída failed=com erros skipped=válida mas não incluída pois outra linha falhou no modo all_or_nothing duplicate=já importada anteriormente ou repetida no arquivo ignored=sem Lançamento, como um título não liquidado do retorno CNAB)
+	Status string `json:"status" enums:"imported,failed,skipped,duplicate,ignored" example:"imported"`
+	// Identificador do Lançamento incluído ou do Lançamento já importado com o mesmo identificador externo (0 quando não foi incluído)
+	LaunchID int64 `json:"launch_id" format:"int64" example:"1"`
+	// Identificador da Linha do Extrato incluída (somente na importação do extrato da conciliação)
+	StatementLineID int64 `json:"statement_line_id,omitempty" format:"int64" example:"0"`
+	// Erros de validação da linha ou o motivo da linha ignorada
+	Errors []string `json:"errors"`
+}
+
+type CashLaunchImportRows []CashLaunchImportRow
+
+type CashLaunchImportReport struct {
+	// Modo da importação (all_or_nothing=nenhuma linha é incluída quando alguma falha best_effort=as linhas válidas são incluídas)
+	Mode string `json:"mode" enums:"all_or_nothing,best_effort" example:"all_or_nothing"`
+	// Quantidade de linhas lidas
+	Total int `json:"total" example:"1"`
+	// Quantidade de linhas incluídas
+	Imported int `json:"imported" example:"1"`
+	// Quantidade de linhas com erros
+	Failed int `json:"failed" example:"0"`
+	// Quantidade de linhas válidas não incluídas
+	Skipped int `json:"skipped" example:"0"`
+	// Quantidade de linhas já importadas
+	Duplicated int `json:"duplicated" example:"0"`
+	// Quantidade de linhas ignoradas
+	Ignored int `json:"ignored" example:"0"`
+	// Resultado de cada linha, limitado às primeiras 10000 linhas
+	Rows CashLaunchImportRows `json:"rows"`
+	// Indica que as linhas após as primeiras 10000 foram somente contadas
+	RowsTruncated bool `json:"rows_truncated" example:"false"`
+}
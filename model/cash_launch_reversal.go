@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+type CashLaunchReversal struct {
+	// Identificador do Lançamento estornado
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Data de Referencia do Estorno (opcional, quando não informada assume a data atual)
+	ReferenceDate time.Time `json:"reference_date" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
+	// Descrição do Estorno (opcional, quando não informada assume ESTORNO seguido da Descrição do Lançamento)
+	Description string `json:"description"`
+}
+
+type parametersCashLaunchReversalWrapper struct {
+	// Data de Referencia do Estorno (opcional, quando não informada assume a data atual)
+	ReferenceDate time.Time `json:"reference_date" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
+	// Descrição do Estorno (opcional, quando não informada assume ESTORNO seguido da Descrição do Lançamento)
+	Description string `json:"description"`
+}
@@ -0,0 +1,15 @@
+package model
+
+type CashLaunchReview struct {
+	// Identificador do Lançamento revisado
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Situação atribuída ao Lançamento (approved ou rejected)
+	Status string `json:"status" validate:"required" enums:"approved,rejected"`
+	// Comentário da revisão (obrigatório na rejeição)
+	Comment string `json:"comment"`
+}
+
+type parametersCashLaunchReviewWrapper struct {
+	// Comentário da revisão (obrigatório na rejeição)
+	Comment string `json:"comment" example:"conferido com a nota fiscal"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashPeriod struct {
+	// Período (primeiro dia do mês)
+	Period time.Time `json:"period" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Situação do Período (open=aberto closed=fechado, os Lançamentos de um Período fechado não podem ser incluídos, alterados, excluídos ou estornados)
+	Status string `json:"status" validate:"required" enums:"open,closed" example:"closed"`
+	// Saldo Final do último dia do Período de todas as Contas na Moeda Base (Gravado automaticamente no fechamento)
+	ClosingBalance decimal.Decimal `json:"closing_balance" example:"1.23" swaggertype:"number"`
+	// Responsável pelo último fechamento (header X-User-ID)
+	ClosedBy string `json:"closed_by" example:"maria"`
+	// Data do último fechamento (nulo quando nunca foi fechado)
+	ClosedAt *time.Time `json:"closed_at" example:"2019-09-02T16:59:59Z" format:"date-time"`
+	// Responsável pela última reabertura (header X-User-ID)
+	ReopenedBy string `json:"reopened_by" example:""`
+	// Data da última reabertura (nulo quando nunca foi reaberto)
+	ReopenedAt *time.Time `json:"reopened_at" example:"2019-09-03T16:59:59Z" format:"date-time"`
+	// Data da Última Alteração do Período (nulo quando nunca foi fechado)
+	UpdatedAt *time.Time `json:"updated_at" example:"2019-09-02T16:59:59Z" format:"date-time"`
+}
+
+type CashPeriods []CashPeriod
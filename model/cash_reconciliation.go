@@ -0,0 +1,100 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashStatementLine struct {
+	// Identificador da Linha do Extrato (Gerado automaticamente na importação)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Conta do Extrato
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Linha no arquivo importado na sua Conta (FITID do OFX, banco e nosso número do CNAB, vazio quando o arquivo não possui)
+	ExternalID string `json:"external_id" example:"2005031001"`
+	// Data de Referencia da Linha no banco
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Tipo da Linha (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D"`
+	// Descrição da Linha no banco
+	Description string `json:"description" validate:"required"`
+	// Valor da Linha
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Identificador do Lançamento conciliado com a Linha (0 quando não está conciliada)
+	LaunchID int64 `json:"launch_id" format:"int64" example:"0"`
+	// Tipo da conciliação (auto=automática manual=manual, vazio quando não está conciliada)
+	MatchType string `json:"match_type" enums:",auto,manual" example:"auto"`
+	// Responsável pela conciliação (header X-User-ID)
+	MatchedBy string `json:"matched_by" example:"maria"`
+	// Data da conciliação (nulo quando não está conciliada)
+	MatchedAt *time.Time `json:"matched_at" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão da Linha (Gerado automaticamente na importação)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashStatementLines []CashStatementLine
+
+type CashStatementLineFilter struct {
+	// Identificador da Conta
+	AccountID int64
+	// Data de Referencia inicial (inclusive)
+	ReferenceDateFrom time.Time
+	// Data de Referencia final (inclusive)
+	ReferenceDateTo time.Time
+	// Somente as Linhas não conciliadas
+	Unmatched bool
+	// Somente as Linhas conciliadas
+	Matched bool
+}
+
+type CashReconciliationMatch struct {
+	// Linha do Extrato
+	StatementLine CashStatementLine `json:"statement_line"`
+	// Lançamento conciliado com a Linha
+	Launch CashLaunch `json:"launch"`
+	// Similaridade entre as Descrições da Linha e do Lançamento (0 a 1, calculada somente na conciliação automática)
+	Similarity float64 `json:"similarity,omitempty" example:"0.85"`
+}
+
+type CashReconciliationMatches []CashReconciliationMatch
+
+type CashReconciliation struct {
+	// Identificador da Conta
+	AccountID int64 `json:"account_id" format:"int64" example:"1"`
+	// Data de Referencia inicial
+	From time.Time `json:"from" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data de Referencia final
+	To time.Time `json:"to" example:"2019-08-31T00:00:00Z" format:"date-time"`
+	// Linhas do Extrato conciliadas com os seus Lançamentos
+	Matched CashReconciliationMatches `json:"matched"`
+	// Linhas do Extrato sem Lançamento
+	UnmatchedBank CashStatementLines `json:"unmatched_bank"`
+	// Lançamentos aprovados sem Linha do Extrato
+	UnmatchedLedger CashLaunches `json:"unmatched_ledger"`
+}
+
+type CashReconciliationAutoMatch struct {
+	// Identificador da Conta
+	AccountID int64
+	// Data de Referencia inicial das Linhas (inclusive)
+	From time.Time
+	// Data de Referencia final das Linhas (inclusive)
+	To time.Time
+	// Diferença máxima em dias entre as Datas de Referencia da Linha e do Lançamento
+	DateWindow int
+	// Similaridade mínima entre as Descrições da Linha e do Lançamento (0 a 1)
+	MinSimilarity float64
+}
+
+type CashStatementLineMatch struct {
+	// Identificador da Linha do Extrato
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador do Lançamento conciliado com a Linha
+	LaunchID int64 `json:"launch_id" validate:"required" minimum:"1" format:"int64"`
+}
+
+type parametersCashStatementLineMatchWrapper struct {
+	// Identificador do Lançamento conciliado com a Linha
+	LaunchID int64 `json:"launch_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+}
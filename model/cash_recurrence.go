@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashRecurrence struct {
+	// Identificador do Lançamento Recorrente (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Conta dos Lançamentos
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria dos Lançamentos (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
+	// Tipo dos Lançamentos (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D"`
+	// Descrição dos Lançamentos
+	Description string `json:"description" validate:"required" example:"ALUGUEL"`
+	// Valor dos Lançamentos
+	Value decimal.Decimal `json:"value" validate:"required" example:"1500.00" swaggertype:"number"`
+	// Moeda dos Lançamentos (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"BRL"`
+	// Frequência (daily=diária weekly=semanal a partir da Data Inicial monthly=mensal no Dia informado last_business_day=último dia útil do mês)
+	Frequency string `json:"frequency" validate:"required" enums:"daily,weekly,monthly,last_business_day" example:"monthly"`
+	// Dia do mês da frequência monthly (1 a 31, nos meses mais curtos é utilizado o último dia do mês)
+	Day int `json:"day" minimum:"0" maximum:"31" example:"5"`
+	// Data Inicial da recorrência
+	StartDate time.Time `json:"start_date" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data Final da recorrência (opcional, sem Data Final os Lançamentos são gerados por tempo indeterminado)
+	EndDate time.Time `json:"end_date" example:"2020-07-31T00:00:00Z" format:"date-time"`
+	// Data de Referencia do último Lançamento gerado (Atualizado automaticamente pela geração dos Lançamentos)
+	LastDate time.Time `json:"last_date" example:"2019-08-05T00:00:00Z" format:"date-time"`
+	// Data da Última Alteração do Lançamento Recorrente (Atualizado automaticamente na inclusão e alteração)
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão do Lançamento Recorrente (Gerado automaticamente na inclusão)
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type CashRecurrences []CashRecurrence
+
+type parametersCashRecurrenceWrapper struct {
+	// Identificador da Conta dos Lançamentos
+	AccountID int64 `json:"account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Categoria dos Lançamentos (opcional, 0 quando não possui Categoria)
+	CategoryID int64 `json:"category_id" format:"int64" example:"0"`
+	// Tipo dos Lançamentos (C=Crédito D=Débito)
+	Type string `json:"type" validate:"required" enums:"C,D"`
+	// Descrição dos Lançamentos
+	Description string `json:"description" validate:"required" example:"ALUGUEL"`
+	// Valor dos Lançamentos
+	Value decimal.Decimal `json:"value" validate:"required" example:"1500.00" swaggertype:"number"`
+	// Moeda dos Lançamentos (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"BRL"`
+	// Frequência (daily=diária weekly=semanal a partir da Data Inicial monthly=mensal no Dia informado last_business_day=último dia útil do mês)
+	Frequency string `json:"frequency" validate:"required" enums:"daily,weekly,monthly,last_business_day" example:"monthly"`
+	// Dia do mês da frequência monthly (1 a 31, nos meses mais curtos é utilizado o último dia do mês)
+	Day int `json:"day" minimum:"0" maximum:"31" example:"5"`
+	// Data Inicial da recorrência
+	StartDate time.Time `json:"start_date" validate:"required" example:"2019-08-01T00:00:00Z" format:"date-time"`
+	// Data Final da recorrência (opcional, sem Data Final os Lançamentos são gerados por tempo indeterminado)
+	EndDate time.Time `json:"end_date" example:"2020-07-31T00:00:00Z" format:"date-time"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CashTransfer struct {
+	// Identificador da Transferência (Gerado automaticamente na inclusão)
+	ID int64 `json:"id" validate:"required" minimum:"1" format:"int64"`
+	// Identificador da Conta de Origem
+	FromAccountID int64 `json:"from_account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Conta de Destino
+	ToAccountID int64 `json:"to_account_id" validate:"required" minimum:"1" format:"int64" example:"2"`
+	// Data de Referencia da Transferência
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
+	// Descrição da Transferência
+	Description string `json:"description" validate:"required"`
+	// Valor da Transferência
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Moeda da Transferência (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"USD"`
+	// Lançamento de Débito na Conta de Origem (Gerado automaticamente na inclusão)
+	Debit CashLaunch `json:"debit"`
+	// Lançamento de Crédito na Conta de Destino (Gerado automaticamente na inclusão)
+	Credit CashLaunch `json:"credit"`
+}
+
+type parametersCashTransferWrapper struct {
+	// Identificador da Conta de Origem
+	FromAccountID int64 `json:"from_account_id" validate:"required" minimum:"1" format:"int64" example:"1"`
+	// Identificador da Conta de Destino
+	ToAccountID int64 `json:"to_account_id" validate:"required" minimum:"1" format:"int64" example:"2"`
+	// Data de Referencia da Transferência
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time" minimum:"1900-01-01T00:00:00Z"`
+	// Descrição da Transferência
+	Description string `json:"description" validate:"required"`
+	// Valor da Transferência
+	Value decimal.Decimal `json:"value" validate:"required" example:"1.23" swaggertype:"number"`
+	// Moeda da Transferência (ISO-4217, quando não informada assume a Moeda Base)
+	Currency string `json:"currency" example:"USD"`
+}
@@ -0,0 +1,9 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+func init() {
+	// keep the money values as JSON numbers, the clients already send and
+	// read them that way. Numbers and strings are both accepted on input.
+	decimal.MarshalJSONWithoutQuotes = true
+}
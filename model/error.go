@@ -37,6 +37,13 @@ func BadRequestRepositoryPersist(controllerTitle, message string) *Error {
 	}
 }
 
+func ForbiddenPermission(controllerTitle, message string) *Error {
+	return &Error{
+		Code:    403.1,
+		Message: fmt.Sprintf("Error %s is forbidden: %s", controllerTitle, message),
+	}
+}
+
 func NotFound(controllerTitle string) *Error {
 	return &Error{
 		Code:    404.1,
@@ -44,6 +51,20 @@ func NotFound(controllerTitle string) *Error {
 	}
 }
 
+func ConflictRepositoryReferenced(controllerTitle, message string) *Error {
+	return &Error{
+		Code:    409.1,
+		Message: fmt.Sprintf("Error %s is referenced in repository: %s", controllerTitle, message),
+	}
+}
+
+func ConflictPeriodClosed(controllerTitle, message string) *Error {
+	return &Error{
+		Code:    409.2,
+		Message: fmt.Sprintf("Error %s is in a closed period: %s", controllerTitle, message),
+	}
+}
+
 func InternalServerErrorGeneral(message string) *Error {
 	return &Error{
 		Code:    500.1,
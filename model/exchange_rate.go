@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ExchangeRate struct {
+	// Data de Referencia da Cotação
+	ReferenceDate time.Time `json:"reference_date" validate:"required" example:"2019-08-24T00:00:00Z" format:"date-time"`
+	// Moeda Base da Cotação (ISO-4217)
+	BaseCurrency string `json:"base_currency" validate:"required" example:"EUR"`
+	// Moeda Cotada (ISO-4217)
+	Currency string `json:"currency" validate:"required" example:"USD"`
+	// Quantidade da Moeda Cotada equivalente a uma unidade da Moeda Base
+	Rate decimal.Decimal `json:"rate" validate:"required" example:"1.1011" swaggertype:"number"`
+	// Data da Última Alteração da Cotação
+	UpdatedAt time.Time `json:"updated_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+	// Data de Inclusão da Cotação
+	CreatedAt time.Time `json:"created_at" validate:"required" example:"2019-08-24T16:59:59Z" format:"date-time"`
+}
+
+type ExchangeRates []ExchangeRate
+
+type ExchangeRateRangeReferenceDate struct {
+	From time.Time
+	To   time.Time
+}
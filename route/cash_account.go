@@ -0,0 +1,33 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashAccountRouteParameters struct {
+	AppRouter             router.Router
+	Log                   hclog.Logger
+	RepositoryCashAccount repository.CashAccount
+}
+
+func CashAccountRoute(params *CashAccountRouteParameters) {
+	usecaseCashAccount := usecase.NewCashAccount(params.RepositoryCashAccount)
+
+	controllerCashAccount := controller.NewCashAccount(params.Log, usecaseCashAccount)
+
+	pathApiCashAccount := "/api/cash/account"
+	pathApiCashAccountParam := params.AppRouter.PathFormat("/api/cash/account/%s", "param")
+
+	params.AppRouter.Get(pathApiCashAccount, controllerCashAccount.List)
+	params.AppRouter.Get(pathApiCashAccountParam, controllerCashAccount.GetByID)
+
+	params.AppRouter.Post(pathApiCashAccount, controllerCashAccount.Insert)
+
+	params.AppRouter.Put(pathApiCashAccountParam, controllerCashAccount.Update)
+
+	params.AppRouter.Delete(pathApiCashAccountParam, controllerCashAccount.DeleteByID)
+}
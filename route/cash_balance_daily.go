@@ -3,6 +3,7 @@ package route
 import (
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/hashicorp/go-hclog"
@@ -12,15 +13,24 @@ type CashBalanceDailyRouteParameters struct {
 	AppRouter                  router.Router
 	Log                        hclog.Logger
 	RepositoryCashBalanceDaily repository.CashBalanceDaily
+	Cache                      cache.Cache
 }
 
 func CashBalanceDailyRoute(params *CashBalanceDailyRouteParameters) {
 	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(params.RepositoryCashBalanceDaily)
+
+	if params.Cache != nil {
+		usecaseCashBalanceDaily = usecase.NewCashBalanceDailyCache(usecaseCashBalanceDaily, params.Cache)
+	}
+
 	controllerCashBalanceDaily := controller.NewCashBalanceDaily(params.Log, usecaseCashBalanceDaily)
 
 	pathApiCashBalanceDaily := "/api/cash/balance/daily"
 	pathApiCashBalanceDailyParam := params.AppRouter.PathFormat("/api/cash/balance/daily/%s", "param")
+	pathApiCashBalanceSummary := "/api/cash/balance/summary"
 
 	params.AppRouter.Get(pathApiCashBalanceDaily, controllerCashBalanceDaily.GetByRangeReferenceDate)
 	params.AppRouter.Get(pathApiCashBalanceDailyParam, controllerCashBalanceDaily.GetByReferenceDate)
+
+	params.AppRouter.Get(pathApiCashBalanceSummary, controllerCashBalanceDaily.GetSummaryByRangeReferenceDate)
 }
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashBudgetRouteParameters struct {
+	AppRouter              router.Router
+	Log                    hclog.Logger
+	RepositoryCashBudget   repository.CashBudget
+	RepositoryCashCategory repository.CashCategory
+}
+
+func CashBudgetRoute(params *CashBudgetRouteParameters) {
+	usecaseCashBudget := usecase.NewCashBudget(params.RepositoryCashBudget, params.RepositoryCashCategory)
+
+	controllerCashBudget := controller.NewCashBudget(params.Log, usecaseCashBudget)
+
+	pathApiCashBudget := "/api/cash/budget"
+	pathApiCashBudgetParam := params.AppRouter.PathFormat("/api/cash/budget/%s", "param")
+
+	params.AppRouter.Get(pathApiCashBudget, controllerCashBudget.List)
+	// the router does not accept /api/cash/budget/variance beside the wildcard
+	// of the budget id, so the variance report answers the GET of the id route
+	params.AppRouter.Get(pathApiCashBudgetParam, controllerCashBudget.GetByID)
+
+	params.AppRouter.Post(pathApiCashBudget, controllerCashBudget.Insert)
+
+	params.AppRouter.Put(pathApiCashBudgetParam, controllerCashBudget.Update)
+
+	params.AppRouter.Delete(pathApiCashBudgetParam, controllerCashBudget.DeleteByID)
+}
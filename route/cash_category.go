@@ -0,0 +1,35 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashCategoryRouteParameters struct {
+	AppRouter              router.Router
+	Log                    hclog.Logger
+	RepositoryCashCategory repository.CashCategory
+}
+
+func CashCategoryRoute(params *CashCategoryRouteParameters) {
+	usecaseCashCategory := usecase.NewCashCategory(params.RepositoryCashCategory)
+
+	controllerCashCategory := controller.NewCashCategory(params.Log, usecaseCashCategory)
+
+	pathApiCashCategory := "/api/cash/category"
+	pathApiCashCategoryParam := params.AppRouter.PathFormat("/api/cash/category/%s", "param")
+	pathApiCashBalanceCategory := "/api/cash/balance/category"
+
+	params.AppRouter.Get(pathApiCashCategory, controllerCashCategory.List)
+	params.AppRouter.Get(pathApiCashCategoryParam, controllerCashCategory.GetByID)
+	params.AppRouter.Get(pathApiCashBalanceCategory, controllerCashCategory.GetTotalsByRangeReferenceDate)
+
+	params.AppRouter.Post(pathApiCashCategory, controllerCashCategory.Insert)
+
+	params.AppRouter.Put(pathApiCashCategoryParam, controllerCashCategory.Update)
+
+	params.AppRouter.Delete(pathApiCashCategoryParam, controllerCashCategory.DeleteByID)
+}
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashCategoryRuleRouteParameters struct {
+	AppRouter                  router.Router
+	Log                        hclog.Logger
+	RepositoryCashCategoryRule repository.CashCategoryRule
+	RepositoryCashCategory     repository.CashCategory
+}
+
+func CashCategoryRuleRoute(params *CashCategoryRuleRouteParameters) {
+	usecaseCashCategoryRule := usecase.NewCashCategoryRule(params.RepositoryCashCategoryRule, params.RepositoryCashCategory)
+
+	controllerCashCategoryRule := controller.NewCashCategoryRule(params.Log, usecaseCashCategoryRule)
+
+	pathApiCashCategoryRule := "/api/cash/category-rule"
+	pathApiCashCategoryRuleParam := params.AppRouter.PathFormat("/api/cash/category-rule/%s", "param")
+	pathApiCashCategoryRuleMatch := "/api/cash/category-rule/match"
+
+	params.AppRouter.Get(pathApiCashCategoryRule, controllerCashCategoryRule.List)
+	params.AppRouter.Get(pathApiCashCategoryRuleParam, controllerCashCategoryRule.GetByID)
+
+	params.AppRouter.Post(pathApiCashCategoryRule, controllerCashCategoryRule.Insert)
+	params.AppRouter.Post(pathApiCashCategoryRuleMatch, controllerCashCategoryRule.Match)
+
+	params.AppRouter.Put(pathApiCashCategoryRuleParam, controllerCashCategoryRule.Update)
+
+	params.AppRouter.Delete(pathApiCashCategoryRuleParam, controllerCashCategoryRule.DeleteByID)
+}
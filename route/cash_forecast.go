@@ -0,0 +1,30 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashForecastRouteParameters struct {
+	AppRouter                  router.Router
+	Log                        hclog.Logger
+	RepositoryCashBalanceDaily repository.CashBalanceDaily
+	RepositoryCashLaunch       repository.CashLaunch
+	RepositoryCashRecurrence   repository.CashRecurrence
+	RepositoryCashAccount      repository.CashAccount
+	RepositoryExchangeRate     repository.ExchangeRate
+	BaseCurrency               string
+}
+
+func CashForecastRoute(params *CashForecastRouteParameters) {
+	usecaseCashForecast := usecase.NewCashForecast(params.RepositoryCashBalanceDaily, params.RepositoryCashLaunch, params.RepositoryCashRecurrence, params.RepositoryCashAccount, params.RepositoryExchangeRate, params.BaseCurrency)
+
+	controllerCashForecast := controller.NewCashForecast(params.Log, usecaseCashForecast)
+
+	pathApiCashForecast := "/api/cash/forecast"
+
+	params.AppRouter.Get(pathApiCashForecast, controllerCashForecast.Forecast)
+}
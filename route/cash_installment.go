@@ -0,0 +1,40 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashInstallmentRouteParameters struct {
+	AppRouter                 router.Router
+	Log                       hclog.Logger
+	RepositoryCashInstallment repository.CashInstallment
+	RepositoryCashAccount     repository.CashAccount
+	RepositoryCashCategory    repository.CashCategory
+	RepositoryExchangeRate    repository.ExchangeRate
+	BaseCurrency              string
+	ApprovalThresholds        usecase.CashLaunchApprovalThresholds
+	Cache                     cache.Cache
+}
+
+func CashInstallmentRoute(params *CashInstallmentRouteParameters) {
+	usecaseCashInstallment := usecase.NewCashInstallment(params.RepositoryCashInstallment, params.RepositoryCashAccount, params.RepositoryCashCategory, params.RepositoryExchangeRate, params.BaseCurrency, params.ApprovalThresholds)
+
+	if params.Cache != nil {
+		usecaseCashInstallment = usecase.NewCashInstallmentCache(usecaseCashInstallment, params.Cache)
+	}
+
+	controllerCashInstallment := controller.NewCashInstallment(params.Log, usecaseCashInstallment)
+
+	pathApiCashInstallmentParam := params.AppRouter.PathFormat("/api/cash/installment/%s", "param")
+
+	params.AppRouter.Get(pathApiCashInstallmentParam, controllerCashInstallment.ListByGroupID)
+
+	params.AppRouter.Put(pathApiCashInstallmentParam, controllerCashInstallment.Update)
+
+	params.AppRouter.Delete(pathApiCashInstallmentParam, controllerCashInstallment.DeleteByGroupID)
+}
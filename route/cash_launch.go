@@ -3,28 +3,53 @@ package route
 import (
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/hashicorp/go-hclog"
 )
 
 type CashLaunchRouteParameters struct {
-	AppRouter            router.Router
-	Log                  hclog.Logger
-	RepositoryCashLaunch repository.CashLaunch
+	AppRouter                  router.Router
+	Log                        hclog.Logger
+	RepositoryCashLaunch       repository.CashLaunch
+	RepositoryCashAccount      repository.CashAccount
+	RepositoryCashCategory     repository.CashCategory
+	RepositoryCashCategoryRule repository.CashCategoryRule
+	RepositoryCashInstallment  repository.CashInstallment
+	RepositoryCashPeriod       repository.CashPeriod
+	RepositoryExchangeRate     repository.ExchangeRate
+	BaseCurrency               string
+	ApprovalThresholds         usecase.CashLaunchApprovalThresholds
+	Cache                      cache.Cache
 }
 
 func CashLaunchRoute(params *CashLaunchRouteParameters) {
-	usecaseCashLaunch := usecase.NewCashLaunch(params.RepositoryCashLaunch)
+	usecaseCashLaunch := usecase.NewCashLaunch(params.RepositoryCashLaunch, params.RepositoryCashAccount, params.RepositoryCashCategory, params.RepositoryCashCategoryRule, params.RepositoryCashInstallment, params.RepositoryCashPeriod, params.RepositoryExchangeRate, params.BaseCurrency, params.ApprovalThresholds)
+
+	if params.Cache != nil {
+		usecaseCashLaunch = usecase.NewCashLaunchCache(usecaseCashLaunch, params.Cache)
+	}
+
 	controllerCashLaunch := controller.NewCashLaunch(params.Log, usecaseCashLaunch)
 
 	pathApiCashLaunch := "/api/cash/launch"
+	pathApiCashLaunchImport := "/api/cash/launch/import"
 	pathApiCashLaunchParam := params.AppRouter.PathFormat("/api/cash/launch/%s", "param")
+	pathApiCashLaunchParamReversal := params.AppRouter.PathFormat("/api/cash/launch/%s/reversal", "param")
+	pathApiCashLaunchParamHistory := params.AppRouter.PathFormat("/api/cash/launch/%s/history", "param")
+	pathApiCashLaunchParamApprove := params.AppRouter.PathFormat("/api/cash/launch/%s/approve", "param")
+	pathApiCashLaunchParamReject := params.AppRouter.PathFormat("/api/cash/launch/%s/reject", "param")
 
 	params.AppRouter.Get(pathApiCashLaunch, controllerCashLaunch.List)
 	params.AppRouter.Get(pathApiCashLaunchParam, controllerCashLaunch.GetByID)
+	params.AppRouter.Get(pathApiCashLaunchParamHistory, controllerCashLaunch.History)
 
 	params.AppRouter.Post(pathApiCashLaunch, controllerCashLaunch.Insert)
+	params.AppRouter.Post(pathApiCashLaunchImport, controllerCashLaunch.Import)
+	params.AppRouter.Post(pathApiCashLaunchParamReversal, controllerCashLaunch.Reverse)
+	params.AppRouter.Post(pathApiCashLaunchParamApprove, controllerCashLaunch.Approve)
+	params.AppRouter.Post(pathApiCashLaunchParamReject, controllerCashLaunch.Reject)
 
 	params.AppRouter.Put(pathApiCashLaunchParam, controllerCashLaunch.Update)
 
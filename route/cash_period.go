@@ -0,0 +1,30 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashPeriodRouteParameters struct {
+	AppRouter            router.Router
+	Log                  hclog.Logger
+	RepositoryCashPeriod repository.CashPeriod
+	ReopenActors         []string
+}
+
+func CashPeriodRoute(params *CashPeriodRouteParameters) {
+	usecaseCashPeriod := usecase.NewCashPeriod(params.RepositoryCashPeriod, params.ReopenActors)
+
+	controllerCashPeriod := controller.NewCashPeriod(params.Log, usecaseCashPeriod)
+
+	pathApiCashPeriod := "/api/cash/period"
+	pathApiCashPeriodParamClose := params.AppRouter.PathFormat("/api/cash/period/%s/close", "param")
+	pathApiCashPeriodParamReopen := params.AppRouter.PathFormat("/api/cash/period/%s/reopen", "param")
+
+	params.AppRouter.Get(pathApiCashPeriod, controllerCashPeriod.List)
+	params.AppRouter.Post(pathApiCashPeriodParamClose, controllerCashPeriod.Close)
+	params.AppRouter.Post(pathApiCashPeriodParamReopen, controllerCashPeriod.Reopen)
+}
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashReconciliationRouteParameters struct {
+	AppRouter                   router.Router
+	Log                         hclog.Logger
+	RepositoryCashStatementLine repository.CashStatementLine
+	RepositoryCashLaunch        repository.CashLaunch
+	RepositoryCashAccount       repository.CashAccount
+}
+
+func CashReconciliationRoute(params *CashReconciliationRouteParameters) {
+	usecaseCashReconciliation := usecase.NewCashReconciliation(params.RepositoryCashStatementLine, params.RepositoryCashLaunch, params.RepositoryCashAccount)
+
+	controllerCashReconciliation := controller.NewCashReconciliation(params.Log, usecaseCashReconciliation)
+
+	pathApiCashReconciliation := "/api/cash/reconciliation"
+	pathApiCashReconciliationImport := "/api/cash/reconciliation/import"
+	pathApiCashReconciliationMatch := "/api/cash/reconciliation/match"
+	pathApiCashReconciliationStatementParamMatch := params.AppRouter.PathFormat("/api/cash/reconciliation/statement/%s/match", "param")
+	pathApiCashReconciliationStatementParamUnmatch := params.AppRouter.PathFormat("/api/cash/reconciliation/statement/%s/unmatch", "param")
+
+	params.AppRouter.Get(pathApiCashReconciliation, controllerCashReconciliation.Report)
+
+	params.AppRouter.Post(pathApiCashReconciliationImport, controllerCashReconciliation.Import)
+	params.AppRouter.Post(pathApiCashReconciliationMatch, controllerCashReconciliation.AutoMatch)
+	params.AppRouter.Post(pathApiCashReconciliationStatementParamMatch, controllerCashReconciliation.Match)
+	params.AppRouter.Post(pathApiCashReconciliationStatementParamUnmatch, controllerCashReconciliation.Unmatch)
+}
@@ -0,0 +1,45 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashRecurrenceRouteParameters struct {
+	AppRouter                router.Router
+	Log                      hclog.Logger
+	RepositoryCashRecurrence repository.CashRecurrence
+	RepositoryCashAccount    repository.CashAccount
+	RepositoryCashCategory   repository.CashCategory
+	RepositoryCashPeriod     repository.CashPeriod
+	RepositoryExchangeRate   repository.ExchangeRate
+	BaseCurrency             string
+	ApprovalThresholds       usecase.CashLaunchApprovalThresholds
+	Cache                    cache.Cache
+}
+
+func CashRecurrenceRoute(params *CashRecurrenceRouteParameters) {
+	usecaseCashRecurrence := usecase.NewCashRecurrence(params.RepositoryCashRecurrence, params.RepositoryCashAccount, params.RepositoryCashCategory, params.RepositoryCashPeriod, params.RepositoryExchangeRate, params.BaseCurrency, params.ApprovalThresholds)
+
+	if params.Cache != nil {
+		usecaseCashRecurrence = usecase.NewCashRecurrenceCache(usecaseCashRecurrence, params.Cache)
+	}
+
+	controllerCashRecurrence := controller.NewCashRecurrence(params.Log, usecaseCashRecurrence)
+
+	pathApiCashRecurrence := "/api/cash/recurrence"
+	pathApiCashRecurrenceParam := params.AppRouter.PathFormat("/api/cash/recurrence/%s", "param")
+
+	params.AppRouter.Get(pathApiCashRecurrence, controllerCashRecurrence.List)
+	params.AppRouter.Get(pathApiCashRecurrenceParam, controllerCashRecurrence.GetByID)
+
+	params.AppRouter.Post(pathApiCashRecurrence, controllerCashRecurrence.Insert)
+
+	params.AppRouter.Put(pathApiCashRecurrenceParam, controllerCashRecurrence.Update)
+
+	params.AppRouter.Delete(pathApiCashRecurrenceParam, controllerCashRecurrence.DeleteByID)
+}
@@ -0,0 +1,35 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type CashTransferRouteParameters struct {
+	AppRouter              router.Router
+	Log                    hclog.Logger
+	RepositoryCashTransfer repository.CashTransfer
+	RepositoryCashAccount  repository.CashAccount
+	RepositoryCashPeriod   repository.CashPeriod
+	RepositoryExchangeRate repository.ExchangeRate
+	BaseCurrency           string
+	Cache                  cache.Cache
+}
+
+func CashTransferRoute(params *CashTransferRouteParameters) {
+	usecaseCashTransfer := usecase.NewCashTransfer(params.RepositoryCashTransfer, params.RepositoryCashAccount, params.RepositoryCashPeriod, params.RepositoryExchangeRate, params.BaseCurrency)
+
+	if params.Cache != nil {
+		usecaseCashTransfer = usecase.NewCashTransferCache(usecaseCashTransfer, params.Cache)
+	}
+
+	controllerCashTransfer := controller.NewCashTransfer(params.Log, usecaseCashTransfer)
+
+	pathApiCashTransfer := "/api/cash/transfer"
+
+	params.AppRouter.Post(pathApiCashTransfer, controllerCashTransfer.Insert)
+}
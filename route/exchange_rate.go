@@ -0,0 +1,29 @@
+package route
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	exchange_rate "github.com/CharlesSchiavinato/minsait-challenge-backend/service/exchange_rate"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/hashicorp/go-hclog"
+)
+
+type ExchangeRateRouteParameters struct {
+	AppRouter              router.Router
+	Log                    hclog.Logger
+	RepositoryExchangeRate repository.ExchangeRate
+	ServiceExchangeRate    exchange_rate.ExchangeRate
+}
+
+func ExchangeRateRoute(params *ExchangeRateRouteParameters) {
+	usecaseExchangeRate := usecase.NewExchangeRate(params.RepositoryExchangeRate, params.ServiceExchangeRate)
+
+	controllerExchangeRate := controller.NewExchangeRate(params.Log, usecaseExchangeRate)
+
+	pathApiExchangeRate := "/api/exchange-rate"
+	pathApiExchangeRateParam := params.AppRouter.PathFormat("/api/exchange-rate/%s", "param")
+
+	params.AppRouter.Get(pathApiExchangeRate, controllerExchangeRate.GetByRangeReferenceDate)
+	params.AppRouter.Get(pathApiExchangeRateParam, controllerExchangeRate.GetByReferenceDate)
+}
@@ -3,18 +3,26 @@ package router
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/controller"
 	"github.com/julienschmidt/httprouter"
 )
 
+// HttpRouter serves the paths without parameters from its own table ahead of
+// the tree of httprouter, which does not accept a fixed segment beside a
+// parameter, as /api/cash/launch/import beside /api/cash/launch/:param
 type HttpRouter struct {
 	*httprouter.Router
+	// static are the handlers of the paths without parameters by method and
+	// path
+	static map[string]http.Handler
 }
 
 func NewHttpRouter() Router {
 	router := &HttpRouter{
 		httprouter.New(),
+		map[string]http.Handler{},
 	}
 
 	return router
@@ -23,23 +31,30 @@ func NewHttpRouter() Router {
 func (hr *HttpRouter) Serve() http.Handler {
 	hr.NotFound = http.HandlerFunc(controller.NewNotFound().NotFound)
 
-	return hr
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if handler, ok := hr.static[req.Method+" "+req.URL.Path]; ok {
+			handler.ServeHTTP(rw, req)
+			return
+		}
+
+		hr.Router.ServeHTTP(rw, req)
+	})
 }
 
 func (hr *HttpRouter) Get(path string, handle Handle) {
-	hr.GET(path, handleAdapter(http.HandlerFunc(handle)))
+	hr.handle(http.MethodGet, path, handle)
 }
 
 func (hr *HttpRouter) Post(path string, handle Handle) {
-	hr.POST(path, handleAdapter(http.HandlerFunc(handle)))
+	hr.handle(http.MethodPost, path, handle)
 }
 
 func (hr *HttpRouter) Put(path string, handle Handle) {
-	hr.PUT(path, handleAdapter(http.HandlerFunc(handle)))
+	hr.handle(http.MethodPut, path, handle)
 }
 
 func (hr *HttpRouter) Delete(path string, handle Handle) {
-	hr.DELETE(path, handleAdapter(http.HandlerFunc(handle)))
+	hr.handle(http.MethodDelete, path, handle)
 }
 
 func (hr *HttpRouter) PathFormat(format string, a ...any) string {
@@ -52,6 +67,18 @@ func (hr *HttpRouter) PathFormat(format string, a ...any) string {
 	return fmt.Sprintf(format, argsPath...)
 }
 
+// handle registers the paths with parameters in the tree and the others in
+// the table served first, the other methods of a path of the table fall back
+// to the tree
+func (hr *HttpRouter) handle(method string, path string, handle Handle) {
+	if strings.ContainsAny(path, ":*") {
+		hr.Handle(method, path, handleAdapter(http.HandlerFunc(handle)))
+		return
+	}
+
+	hr.static[method+" "+path] = http.HandlerFunc(handle)
+}
+
 func handleAdapter(h http.Handler) httprouter.Handle {
 	return func(rw http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		// // Take the context out from the request
@@ -22,11 +22,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/job"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/route"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/router"
 	cache "github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache/redis"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/migration"
 	repository "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/postgres"
+	exchange_rate "github.com/CharlesSchiavinato/minsait-challenge-backend/service/exchange_rate/ecb"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
 	gohandlers "github.com/gorilla/handlers"
 	"github.com/hashicorp/go-hclog"
@@ -91,6 +94,53 @@ func main() {
 
 	log.Info("Connected cache successfuly")
 
+	// create a new exchange rate service
+	serviceExchangeRate := exchange_rate.NewECB(config)
+
+	// start the exchange rate import job
+	exchangeRateSchedule, err := time.ParseDuration(config.ExchangeRateCronJobSchedule)
+
+	if err != nil {
+		log.Error("Cannot parse the exchange rate job schedule", "error", err)
+		os.Exit(0)
+	}
+
+	ctxJob, cancelJob := context.WithCancel(context.Background())
+	defer cancelJob()
+
+	jobExchangeRate := job.NewExchangeRate(log, usecase.NewExchangeRate(repository.ExchangeRate(), serviceExchangeRate), exchangeRateSchedule)
+
+	go jobExchangeRate.Run(ctxJob)
+
+	log.Info("Exchange rate job started successfuly", "schedule", exchangeRateSchedule)
+
+	// the launches above the threshold of their type wait for approval
+	cashLaunchApprovalThresholds, err := usecase.CashLaunchApprovalThresholdsParse(config.CashLaunchApprovalThresholds)
+
+	if err != nil {
+		log.Error("Cannot parse the launch approval thresholds", "error", err)
+		os.Exit(0)
+	}
+
+	// start the recurring launches job
+	cashRecurrenceSchedule, err := time.ParseDuration(config.CashRecurrenceCronJobSchedule)
+
+	if err != nil {
+		log.Error("Cannot parse the recurring launches job schedule", "error", err)
+		os.Exit(0)
+	}
+
+	usecaseCashRecurrence := usecase.NewCashRecurrenceCache(
+		usecase.NewCashRecurrence(repository.CashRecurrence(), repository.CashAccount(), repository.CashCategory(), repository.CashPeriod(), repository.ExchangeRate(), config.BaseCurrency, cashLaunchApprovalThresholds),
+		cache,
+	)
+
+	jobCashRecurrence := job.NewCashRecurrence(log, usecaseCashRecurrence, cashRecurrenceSchedule)
+
+	go jobCashRecurrence.Run(ctxJob)
+
+	log.Info("Recurring launches job started successfuly", "schedule", cashRecurrenceSchedule)
+
 	// set server address
 	serverAddr := config.ServerAddress
 
@@ -98,16 +148,121 @@ func main() {
 	appRouter := router.NewHttpRouter()
 
 	// include the routes
+	route.CashAccountRoute(&route.CashAccountRouteParameters{
+		AppRouter:             appRouter,
+		Log:                   log,
+		RepositoryCashAccount: repository.CashAccount(),
+	})
+
+	route.CashCategoryRoute(&route.CashCategoryRouteParameters{
+		AppRouter:              appRouter,
+		Log:                    log,
+		RepositoryCashCategory: repository.CashCategory(),
+	})
+
+	route.CashCategoryRuleRoute(&route.CashCategoryRuleRouteParameters{
+		AppRouter:                  appRouter,
+		Log:                        log,
+		RepositoryCashCategoryRule: repository.CashCategoryRule(),
+		RepositoryCashCategory:     repository.CashCategory(),
+	})
+
 	route.CashLaunchRoute(&route.CashLaunchRouteParameters{
+		AppRouter:                  appRouter,
+		Log:                        log,
+		RepositoryCashLaunch:       repository.CashLaunch(),
+		RepositoryCashAccount:      repository.CashAccount(),
+		RepositoryCashCategory:     repository.CashCategory(),
+		RepositoryCashCategoryRule: repository.CashCategoryRule(),
+		RepositoryCashInstallment:  repository.CashInstallment(),
+		RepositoryCashPeriod:       repository.CashPeriod(),
+		RepositoryExchangeRate:     repository.ExchangeRate(),
+		BaseCurrency:               config.BaseCurrency,
+		ApprovalThresholds:         cashLaunchApprovalThresholds,
+		Cache:                      cache,
+	})
+
+	route.CashInstallmentRoute(&route.CashInstallmentRouteParameters{
+		AppRouter:                 appRouter,
+		Log:                       log,
+		RepositoryCashInstallment: repository.CashInstallment(),
+		RepositoryCashAccount:     repository.CashAccount(),
+		RepositoryCashCategory:    repository.CashCategory(),
+		RepositoryExchangeRate:    repository.ExchangeRate(),
+		BaseCurrency:              config.BaseCurrency,
+		ApprovalThresholds:        cashLaunchApprovalThresholds,
+		Cache:                     cache,
+	})
+
+	route.CashRecurrenceRoute(&route.CashRecurrenceRouteParameters{
+		AppRouter:                appRouter,
+		Log:                      log,
+		RepositoryCashRecurrence: repository.CashRecurrence(),
+		RepositoryCashAccount:    repository.CashAccount(),
+		RepositoryCashCategory:   repository.CashCategory(),
+		RepositoryCashPeriod:     repository.CashPeriod(),
+		RepositoryExchangeRate:   repository.ExchangeRate(),
+		BaseCurrency:             config.BaseCurrency,
+		ApprovalThresholds:       cashLaunchApprovalThresholds,
+		Cache:                    cache,
+	})
+
+	route.CashTransferRoute(&route.CashTransferRouteParameters{
+		AppRouter:              appRouter,
+		Log:                    log,
+		RepositoryCashTransfer: repository.CashTransfer(),
+		RepositoryCashAccount:  repository.CashAccount(),
+		RepositoryCashPeriod:   repository.CashPeriod(),
+		RepositoryExchangeRate: repository.ExchangeRate(),
+		BaseCurrency:           config.BaseCurrency,
+		Cache:                  cache,
+	})
+
+	route.CashPeriodRoute(&route.CashPeriodRouteParameters{
 		AppRouter:            appRouter,
 		Log:                  log,
-		RepositoryCashLaunch: repository.CashLaunch(),
+		RepositoryCashPeriod: repository.CashPeriod(),
+		ReopenActors:         strings.Split(config.CashPeriodReopenActors, ";"),
+	})
+
+	route.CashReconciliationRoute(&route.CashReconciliationRouteParameters{
+		AppRouter:                   appRouter,
+		Log:                         log,
+		RepositoryCashStatementLine: repository.CashStatementLine(),
+		RepositoryCashLaunch:        repository.CashLaunch(),
+		RepositoryCashAccount:       repository.CashAccount(),
+	})
+
+	route.CashBudgetRoute(&route.CashBudgetRouteParameters{
+		AppRouter:              appRouter,
+		Log:                    log,
+		RepositoryCashBudget:   repository.CashBudget(),
+		RepositoryCashCategory: repository.CashCategory(),
 	})
 
 	route.CashBalanceDailyRoute(&route.CashBalanceDailyRouteParameters{
 		AppRouter:                  appRouter,
 		Log:                        log,
 		RepositoryCashBalanceDaily: repository.CashBalanceDaily(),
+		Cache:                      cache,
+	})
+
+	route.CashForecastRoute(&route.CashForecastRouteParameters{
+		AppRouter:                  appRouter,
+		Log:                        log,
+		RepositoryCashBalanceDaily: repository.CashBalanceDaily(),
+		RepositoryCashLaunch:       repository.CashLaunch(),
+		RepositoryCashRecurrence:   repository.CashRecurrence(),
+		RepositoryCashAccount:      repository.CashAccount(),
+		RepositoryExchangeRate:     repository.ExchangeRate(),
+		BaseCurrency:               config.BaseCurrency,
+	})
+
+	route.ExchangeRateRoute(&route.ExchangeRateRouteParameters{
+		AppRouter:              appRouter,
+		Log:                    log,
+		RepositoryExchangeRate: repository.ExchangeRate(),
+		ServiceExchangeRate:    serviceExchangeRate,
 	})
 
 	route.SwaggerRoute(appRouter)
@@ -124,7 +279,8 @@ func main() {
 
 	// include the middleware handler CORS
 	corsOption := gohandlers.AllowedOrigins(strings.Split(config.ServerCORSAllowedOrigins, ";"))
-	corsHandler := gohandlers.CORS(corsOption)
+	corsExposedHeaders := gohandlers.ExposedHeaders([]string{"X-Next"})
+	corsHandler := gohandlers.CORS(corsOption, corsExposedHeaders)
 	httpHandler = corsHandler(httpHandler)
 
 	// include the middleware handler logger
@@ -160,6 +316,9 @@ func main() {
 	sig := <-chanSignal
 	log.Info(fmt.Sprintf("HTTP server terminate signal %v", sig))
 
+	// stop the background jobs
+	cancelJob()
+
 	// gracefully shutdown the server, waiting max 30 seconds for current operations to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -1,6 +1,24 @@
 package cache
 
 type Cache interface {
+	// Get decodes the value stored in the key into value
+	Get(key string, value interface{}) error
+	// Set stores the value in the key until the cache expiration
+	Set(key string, value interface{}) error
+	Delete(keys ...string) error
+	// Increment adds one to the counter stored in the key, without expiration,
+	// and returns the new value
+	Increment(key string) (int64, error)
 	Check() error
 	Close() error
 }
+
+// ErrNotFound denotes failing cache key not found.
+type ErrNotFound struct {
+	Message string
+}
+
+// ErrNotFound returns the cache error not found.
+func (enf ErrNotFound) Error() string {
+	return enf.Message
+}
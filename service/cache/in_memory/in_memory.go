@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+)
+
+// InMemory is an in-process cache used by the tests in place of redis
+type InMemory struct {
+	Error bool
+	items map[string][]byte
+	mutex sync.RWMutex
+}
+
+func NewInMemory(error bool) (cache.Cache, error) {
+	return &InMemory{Error: error, items: map[string][]byte{}}, nil
+}
+
+func (inMemory *InMemory) Check() error {
+	return nil
+}
+
+func (inMemory *InMemory) Close() error {
+	return nil
+}
+
+func (inMemory *InMemory) Get(key string, value interface{}) error {
+	if inMemory.Error == true {
+		return errors.New("Error load from cache")
+	}
+
+	inMemory.mutex.RLock()
+	data, ok := inMemory.items[key]
+	inMemory.mutex.RUnlock()
+
+	if !ok {
+		return cache.ErrNotFound{Message: "not found"}
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func (inMemory *InMemory) Set(key string, value interface{}) error {
+	if inMemory.Error == true {
+		return errors.New("Error persist in cache")
+	}
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	inMemory.mutex.Lock()
+	inMemory.items[key] = data
+	inMemory.mutex.Unlock()
+
+	return nil
+}
+
+func (inMemory *InMemory) Delete(keys ...string) error {
+	if inMemory.Error == true {
+		return errors.New("Error persist in cache")
+	}
+
+	inMemory.mutex.Lock()
+	defer inMemory.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(inMemory.items, key)
+	}
+
+	return nil
+}
+
+func (inMemory *InMemory) Increment(key string) (int64, error) {
+	if inMemory.Error == true {
+		return 0, errors.New("Error persist in cache")
+	}
+
+	inMemory.mutex.Lock()
+	defer inMemory.mutex.Unlock()
+
+	var value int64
+
+	if data, ok := inMemory.items[key]; ok {
+		err := json.Unmarshal(data, &value)
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	value++
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return 0, err
+	}
+
+	inMemory.items[key] = data
+
+	return value, nil
+}
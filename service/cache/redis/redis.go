@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
@@ -37,10 +38,47 @@ func NewRedis(config *util.Config) (cache.Cache, error) {
 	return cacheRedis, err
 }
 
-func (redis *Redis) Close() error {
-	return redis.Client.Close()
+func (cacheRedis *Redis) Close() error {
+	return cacheRedis.Client.Close()
 }
 
-func (redis *Redis) Check() error {
-	return redis.Client.Ping(context.Background()).Err()
+func (cacheRedis *Redis) Check() error {
+	return cacheRedis.Client.Ping(context.Background()).Err()
+}
+
+func (cacheRedis *Redis) Get(key string, value interface{}) error {
+	data, err := cacheRedis.Client.Get(context.Background(), key).Bytes()
+
+	// cache error not found
+	if err == redis.Nil {
+		return cache.ErrNotFound{Message: err.Error()}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func (cacheRedis *Redis) Set(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	return cacheRedis.Client.Set(context.Background(), key, data, cacheRedis.Expiration).Err()
+}
+
+func (cacheRedis *Redis) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return cacheRedis.Client.Del(context.Background(), keys...).Err()
+}
+
+func (cacheRedis *Redis) Increment(key string) (int64, error) {
+	return cacheRedis.Client.Incr(context.Background(), key).Result()
 }
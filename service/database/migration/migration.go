@@ -1,6 +1,8 @@
 package migration
 
 import (
+	"database/sql"
+
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -16,9 +18,26 @@ func Run(config *util.Config) error {
 
 	err = migration.Up()
 
-	if err != migrate.ErrNoChange {
+	if err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+
+	return baseCurrencyBackfill(config)
+}
+
+// baseCurrencyBackfill sets the configured base currency on the launches
+// recorded before the multi-currency support, left without currency by the
+// migration 000004
+func baseCurrencyBackfill(config *util.Config) error {
+	db, err := sql.Open(config.DBDriver, config.DBURL)
+
+	if err != nil {
 		return err
 	}
 
-	return nil
+	defer db.Close()
+
+	_, err = db.Exec(`UPDATE "cash_launch" SET "currency" = $1 WHERE "currency" IS NULL`, config.BaseCurrency)
+
+	return err
 }
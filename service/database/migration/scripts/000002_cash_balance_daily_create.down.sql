@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "cash_balance_daily";
\ No newline at end of file
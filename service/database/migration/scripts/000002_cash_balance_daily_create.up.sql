@@ -0,0 +1,28 @@
+CREATE TABLE "cash_balance_daily" (
+    "reference_date" date PRIMARY KEY,
+    "total_credit" numeric(18,2) NOT NULL DEFAULT 0,
+    "total_debit" numeric(18,2) NOT NULL DEFAULT 0,
+    "closing_balance" numeric(18,2) NOT NULL DEFAULT 0,
+    "launch_count" integer NOT NULL DEFAULT 0,
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "cash_balance_daily"
+    ("reference_date", "total_credit", "total_debit", "closing_balance", "launch_count")
+SELECT
+    reference_date,
+    total_credit,
+    total_debit,
+    SUM(total_credit - total_debit) OVER (ORDER BY reference_date) AS closing_balance,
+    launch_count
+FROM (
+    SELECT
+        reference_date,
+        SUM(CASE WHEN type = 'C' THEN value::numeric(18,2) ELSE 0 END) AS total_credit,
+        SUM(CASE WHEN type = 'D' THEN value::numeric(18,2) ELSE 0 END) AS total_debit,
+        COUNT(*) AS launch_count
+    FROM
+        "cash_launch"
+    GROUP BY
+        reference_date
+) AS cash_launch_daily;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "exchange_rate";
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE "exchange_rate" (
+    "reference_date" date NOT NULL,
+    "base_currency" varchar(3) NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "rate" numeric(18,6) NOT NULL CHECK ("rate" > 0),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("reference_date", "base_currency", "currency")
+);
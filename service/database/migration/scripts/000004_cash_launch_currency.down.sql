@@ -0,0 +1,4 @@
+ALTER TABLE "cash_launch"
+    DROP COLUMN IF EXISTS "base_value",
+    DROP COLUMN IF EXISTS "exchange_rate",
+    DROP COLUMN IF EXISTS "currency";
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- the launches before the multi-currency support were all recorded in the base
+-- currency, which is only known by the application, so their currency is left
+-- null here and filled by the application with the configured base currency
+-- after migrating
+ALTER TABLE "cash_launch"
+    ADD COLUMN "currency" varchar(3),
+    ADD COLUMN "exchange_rate" numeric(18,6) NOT NULL DEFAULT 1 CHECK ("exchange_rate" > 0),
+    ADD COLUMN "base_value" real;
+
+UPDATE "cash_launch" SET "base_value" = "value";
+
+ALTER TABLE "cash_launch"
+    ALTER COLUMN "exchange_rate" DROP DEFAULT,
+    ALTER COLUMN "base_value" SET NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE "cash_launch"
+    ALTER COLUMN "value" TYPE real,
+    ALTER COLUMN "base_value" TYPE real;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "cash_launch"
+    ALTER COLUMN "value" TYPE numeric(18,2) USING "value"::numeric(18,2),
+    ALTER COLUMN "base_value" TYPE numeric(18,2) USING "base_value"::numeric(18,2);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "cash_launch_reference_date_id_idx";
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX "cash_launch_reference_date_id_idx" ON "cash_launch" ("reference_date", "id");
\ No newline at end of file
@@ -0,0 +1,13 @@
+DELETE FROM "cash_balance_daily" WHERE "account_id" <> 0;
+
+ALTER TABLE "cash_balance_daily"
+    DROP CONSTRAINT "cash_balance_daily_pkey",
+    DROP COLUMN "account_id";
+
+ALTER TABLE "cash_balance_daily"
+    ADD PRIMARY KEY ("reference_date");
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "account_id";
+
+DROP TABLE IF EXISTS "cash_account";
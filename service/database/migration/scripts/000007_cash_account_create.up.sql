@@ -0,0 +1,43 @@
+CREATE TABLE "cash_account" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(100) NOT NULL UNIQUE,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- the launches before the accounts support are moved to a default cash box
+INSERT INTO "cash_account" ("name") VALUES ('CAIXA');
+
+ALTER TABLE "cash_launch"
+    ADD COLUMN "account_id" bigint REFERENCES "cash_account" ("id");
+
+UPDATE "cash_launch" SET "account_id" = (SELECT "id" FROM "cash_account" WHERE "name" = 'CAIXA');
+
+ALTER TABLE "cash_launch"
+    ALTER COLUMN "account_id" SET NOT NULL;
+
+CREATE INDEX "cash_launch_account_id_idx" ON "cash_launch" ("account_id");
+
+-- the balance of each account plus the combined balance of all accounts on account_id 0
+ALTER TABLE "cash_balance_daily"
+    ADD COLUMN "account_id" bigint NOT NULL DEFAULT 0,
+    DROP CONSTRAINT "cash_balance_daily_pkey";
+
+ALTER TABLE "cash_balance_daily"
+    ADD PRIMARY KEY ("account_id", "reference_date"),
+    ALTER COLUMN "account_id" DROP DEFAULT;
+
+INSERT INTO "cash_balance_daily"
+    ("account_id", "reference_date", "total_credit", "total_debit", "closing_balance", "launch_count", "updated_at")
+SELECT
+    (SELECT "id" FROM "cash_account" WHERE "name" = 'CAIXA'),
+    "reference_date",
+    "total_credit",
+    "total_debit",
+    "closing_balance",
+    "launch_count",
+    "updated_at"
+FROM
+    "cash_balance_daily"
+WHERE
+    "account_id" = 0;
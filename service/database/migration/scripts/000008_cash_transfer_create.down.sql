@@ -0,0 +1,8 @@
+-- the transfers become regular launches, run make cash-balance-daily-rebuild
+-- to add them back to the combined balance of account_id 0
+DROP INDEX IF EXISTS "cash_launch_transfer_id_idx";
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "transfer_id";
+
+DROP SEQUENCE IF EXISTS "cash_transfer_id_seq";
@@ -0,0 +1,7 @@
+-- both launches of a transfer share the same transfer_id
+CREATE SEQUENCE "cash_transfer_id_seq";
+
+ALTER TABLE "cash_launch"
+    ADD COLUMN "transfer_id" bigint;
+
+CREATE INDEX "cash_launch_transfer_id_idx" ON "cash_launch" ("transfer_id");
@@ -0,0 +1,4 @@
+ALTER TABLE "cash_launch"
+    DROP COLUMN "category_id";
+
+DROP TABLE IF EXISTS "cash_category";
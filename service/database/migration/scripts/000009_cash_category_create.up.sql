@@ -0,0 +1,16 @@
+CREATE TABLE "cash_category" (
+    "id" bigserial PRIMARY KEY,
+    "parent_id" bigint REFERENCES "cash_category" ("id"),
+    "name" varchar(100) NOT NULL,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- the name is unique among the categories of the same parent, the root
+-- categories included
+CREATE UNIQUE INDEX "cash_category_parent_id_name_idx" ON "cash_category" (COALESCE("parent_id", 0), "name");
+
+ALTER TABLE "cash_launch"
+    ADD COLUMN "category_id" bigint REFERENCES "cash_category" ("id");
+
+CREATE INDEX "cash_launch_category_id_idx" ON "cash_launch" ("category_id");
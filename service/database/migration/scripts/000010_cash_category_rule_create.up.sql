@@ -0,0 +1,16 @@
+CREATE TABLE "cash_category_rule" (
+    "id" bigserial PRIMARY KEY,
+    "category_id" bigint NOT NULL REFERENCES "cash_category" ("id"),
+    "pattern" varchar(100) NOT NULL,
+    "pattern_type" varchar(9) NOT NULL,
+    "type" char(1) NOT NULL,
+    "value_from" numeric(18,2) NOT NULL DEFAULT 0,
+    "value_to" numeric(18,2) NOT NULL DEFAULT 0,
+    "priority" integer NOT NULL DEFAULT 0,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "cash_category_rule_priority_idx" ON "cash_category_rule" ("priority", "id");
+
+CREATE INDEX "cash_category_rule_category_id_idx" ON "cash_category_rule" ("category_id");
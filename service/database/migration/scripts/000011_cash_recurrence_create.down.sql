@@ -0,0 +1,4 @@
+ALTER TABLE "cash_launch"
+    DROP COLUMN "recurrence_id";
+
+DROP TABLE IF EXISTS "cash_recurrence";
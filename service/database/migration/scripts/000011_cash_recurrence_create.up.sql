@@ -0,0 +1,23 @@
+CREATE TABLE "cash_recurrence" (
+    "id" bigserial PRIMARY KEY,
+    "account_id" bigint NOT NULL REFERENCES "cash_account" ("id"),
+    "category_id" bigint REFERENCES "cash_category" ("id"),
+    "type" varchar(1) NOT NULL CHECK ("type" in ('C', 'D')),
+    "description" varchar(100) NOT NULL,
+    "value" numeric(18,2) NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "frequency" varchar(17) NOT NULL,
+    "day" smallint NOT NULL DEFAULT 0,
+    "start_date" date NOT NULL,
+    "end_date" date,
+    "last_date" date,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- the launches created by a template keep existing when the template is deleted
+ALTER TABLE "cash_launch"
+    ADD COLUMN "recurrence_id" bigint REFERENCES "cash_recurrence" ("id") ON DELETE SET NULL;
+
+-- an occurrence of a template is created only once, even after a restart
+CREATE UNIQUE INDEX "cash_launch_recurrence_id_reference_date_idx" ON "cash_launch" ("recurrence_id", "reference_date");
@@ -0,0 +1,9 @@
+-- the installments become regular launches
+DROP INDEX IF EXISTS "cash_launch_installment_group_id_idx";
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "installment_group_id",
+    DROP COLUMN "installment_number",
+    DROP COLUMN "installment_count";
+
+DROP SEQUENCE IF EXISTS "cash_installment_id_seq";
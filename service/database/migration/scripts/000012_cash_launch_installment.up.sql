@@ -0,0 +1,9 @@
+-- the installments of a purchase share the same installment_group_id
+CREATE SEQUENCE "cash_installment_id_seq";
+
+ALTER TABLE "cash_launch"
+    ADD COLUMN "installment_group_id" bigint,
+    ADD COLUMN "installment_number" smallint,
+    ADD COLUMN "installment_count" smallint;
+
+CREATE INDEX "cash_launch_installment_group_id_idx" ON "cash_launch" ("installment_group_id");
@@ -0,0 +1,12 @@
+-- the reversals become regular launches and the deleted launches, already out
+-- of the cash_balance_daily, are removed for good
+DROP INDEX IF EXISTS "cash_launch_reversal_of_id_idx";
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "reversal_of_id",
+    DROP COLUMN "reversed_by_id";
+
+DELETE FROM "cash_launch" WHERE "deleted_at" IS NOT NULL;
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "deleted_at";
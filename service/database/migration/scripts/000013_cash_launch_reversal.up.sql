@@ -0,0 +1,8 @@
+-- a reversal is an offsetting launch linked to the launch it reverses, which
+-- is marked with the reversal, so a launch is reversed only once
+ALTER TABLE "cash_launch"
+    ADD COLUMN "reversal_of_id" bigint REFERENCES "cash_launch" ("id"),
+    ADD COLUMN "reversed_by_id" bigint REFERENCES "cash_launch" ("id"),
+    ADD COLUMN "deleted_at" timestamptz;
+
+CREATE UNIQUE INDEX "cash_launch_reversal_of_id_idx" ON "cash_launch" ("reversal_of_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "cash_launch_audit";
+
+DROP FUNCTION IF EXISTS "cash_launch_audit_append_only"();
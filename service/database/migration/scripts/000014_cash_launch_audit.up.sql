@@ -0,0 +1,24 @@
+-- the history of the launches, each change is appended in the transaction of
+-- the change with the launch before and after it and is never changed
+CREATE TABLE "cash_launch_audit" (
+    "id" bigserial PRIMARY KEY,
+    "launch_id" bigint NOT NULL REFERENCES "cash_launch" ("id"),
+    "action" varchar(10) NOT NULL CHECK ("action" IN ('insert', 'update', 'delete', 'reversal')),
+    "before" jsonb,
+    "after" jsonb NOT NULL,
+    "actor" varchar(100) NOT NULL,
+    "request_id" varchar(100) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "cash_launch_audit_launch_id_idx" ON "cash_launch_audit" ("launch_id", "id");
+
+CREATE OR REPLACE FUNCTION "cash_launch_audit_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'cash_launch_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "cash_launch_audit_append_only"
+    BEFORE UPDATE OR DELETE ON "cash_launch_audit"
+    FOR EACH ROW EXECUTE FUNCTION "cash_launch_audit_append_only"();
@@ -0,0 +1,12 @@
+-- a period is a month identified by its first day, the months without a row
+-- are open and a closed month keeps the closing balance of its last day
+CREATE TABLE "cash_period" (
+    "period" date PRIMARY KEY CHECK (EXTRACT(DAY FROM "period") = 1),
+    "status" varchar(6) NOT NULL CHECK ("status" IN ('open', 'closed')),
+    "closing_balance" numeric(18,2) NOT NULL DEFAULT 0,
+    "closed_by" varchar(100) NOT NULL DEFAULT '',
+    "closed_at" timestamptz,
+    "reopened_by" varchar(100) NOT NULL DEFAULT '',
+    "reopened_at" timestamptz,
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
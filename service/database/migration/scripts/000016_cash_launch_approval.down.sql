@@ -0,0 +1,24 @@
+-- the pending and rejected launches, already out of the cash_balance_daily,
+-- are removed for good with their history and the approvals and rejections
+-- are left out of the history
+ALTER TABLE "cash_launch_audit" DISABLE TRIGGER "cash_launch_audit_append_only";
+
+DELETE FROM "cash_launch_audit" WHERE "action" IN ('approval', 'rejection');
+
+DELETE FROM "cash_launch_audit" WHERE "launch_id" IN (SELECT "id" FROM "cash_launch" WHERE "status" <> 'approved');
+
+ALTER TABLE "cash_launch_audit" ENABLE TRIGGER "cash_launch_audit_append_only";
+
+ALTER TABLE "cash_launch_audit"
+    DROP CONSTRAINT "cash_launch_audit_action_check",
+    ADD CONSTRAINT "cash_launch_audit_action_check" CHECK ("action" IN ('insert', 'update', 'delete', 'reversal'));
+
+DROP INDEX IF EXISTS "cash_launch_status_pending_idx";
+
+DELETE FROM "cash_launch" WHERE "status" <> 'approved';
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "status",
+    DROP COLUMN "review_comment",
+    DROP COLUMN "reviewed_by",
+    DROP COLUMN "reviewed_at";
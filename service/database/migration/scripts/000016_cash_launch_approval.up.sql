@@ -0,0 +1,14 @@
+-- the launches above the approval threshold of their type wait as pending and
+-- only the approved ones are kept in the cash_balance_daily, the existing
+-- launches are approved
+ALTER TABLE "cash_launch"
+    ADD COLUMN "status" varchar(8) NOT NULL DEFAULT 'approved' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN "review_comment" varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN "reviewed_by" varchar(100) NOT NULL DEFAULT '',
+    ADD COLUMN "reviewed_at" timestamptz;
+
+CREATE INDEX "cash_launch_status_pending_idx" ON "cash_launch" ("reference_date") WHERE "status" = 'pending';
+
+ALTER TABLE "cash_launch_audit"
+    DROP CONSTRAINT "cash_launch_audit_action_check",
+    ADD CONSTRAINT "cash_launch_audit_action_check" CHECK ("action" IN ('insert', 'update', 'delete', 'reversal', 'approval', 'rejection'));
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "cash_launch_account_id_external_id_idx";
+
+ALTER TABLE "cash_launch"
+    DROP COLUMN "external_id";
@@ -0,0 +1,7 @@
+-- the external_id identifies an imported launch in the file of its account,
+-- as the FITID of an OFX statement, so the same file is only imported once.
+-- The deleted launches keep their external_id and are not imported again.
+ALTER TABLE "cash_launch"
+    ADD COLUMN "external_id" varchar(255);
+
+CREATE UNIQUE INDEX "cash_launch_account_id_external_id_idx" ON "cash_launch" ("account_id", "external_id") WHERE "external_id" IS NOT NULL;
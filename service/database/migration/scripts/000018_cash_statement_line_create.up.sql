@@ -0,0 +1,23 @@
+-- the statement lines are imported from the files of the bank of an account
+-- and kept apart from the launches. The reconciliation matches each line to
+-- at most one launch of its account and each launch to at most one line.
+CREATE TABLE "cash_statement_line" (
+    "id" bigserial PRIMARY KEY,
+    "account_id" bigint NOT NULL REFERENCES "cash_account" ("id"),
+    "external_id" varchar(255),
+    "reference_date" date NOT NULL,
+    "type" varchar(1) NOT NULL CHECK ("type" in ('C', 'D')),
+    "description" varchar(100) NOT NULL,
+    "value" numeric(18,2) NOT NULL,
+    "launch_id" bigint REFERENCES "cash_launch" ("id"),
+    "match_type" varchar(6) NOT NULL DEFAULT '' CHECK ("match_type" IN ('', 'auto', 'manual')),
+    "matched_by" varchar(100) NOT NULL DEFAULT '',
+    "matched_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "cash_statement_line_account_id_reference_date_idx" ON "cash_statement_line" ("account_id", "reference_date");
+
+CREATE UNIQUE INDEX "cash_statement_line_account_id_external_id_idx" ON "cash_statement_line" ("account_id", "external_id") WHERE "external_id" IS NOT NULL;
+
+CREATE UNIQUE INDEX "cash_statement_line_launch_id_idx" ON "cash_statement_line" ("launch_id") WHERE "launch_id" IS NOT NULL;
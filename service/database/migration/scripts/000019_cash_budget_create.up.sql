@@ -0,0 +1,19 @@
+-- a budget is the planned value of a month for the launches of a category
+-- (and its subcategories) or for the launches whose description matches a
+-- pattern, the budgets without category keep the category_id null
+CREATE TABLE "cash_budget" (
+    "id" bigserial PRIMARY KEY,
+    "category_id" bigint REFERENCES "cash_category" ("id"),
+    "pattern" varchar(100) NOT NULL DEFAULT '',
+    "pattern_type" varchar(9) NOT NULL DEFAULT '',
+    "type" char(1) NOT NULL,
+    "period" date NOT NULL CHECK (EXTRACT(DAY FROM "period") = 1),
+    "value" numeric(18,2) NOT NULL,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK (("category_id" IS NULL) <> ("pattern" = ''))
+);
+
+CREATE UNIQUE INDEX "cash_budget_key_idx" ON "cash_budget" ("period", "type", COALESCE("category_id", 0), "pattern_type", "pattern");
+
+CREATE INDEX "cash_budget_category_id_idx" ON "cash_budget" ("category_id");
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "cash_launch_period_open" ON "cash_launch";
+
+DROP FUNCTION IF EXISTS "cash_launch_period_open"();
@@ -0,0 +1,41 @@
+-- the launches of a closed period can not be inserted, changed or deleted.
+-- The check runs in the transaction of the change and shares the lock of the
+-- closed period, so a period closed meanwhile is never bypassed. The
+-- recurrence_id set to null by the deletion of a recurrence is not checked.
+CREATE OR REPLACE FUNCTION "cash_launch_period_open"() RETURNS trigger AS $$
+DECLARE
+    launch_reference_date date;
+    closed_period date;
+BEGIN
+    FOREACH launch_reference_date IN ARRAY CASE TG_OP
+        WHEN 'INSERT' THEN ARRAY[NEW."reference_date"]
+        WHEN 'DELETE' THEN ARRAY[OLD."reference_date"]
+        ELSE ARRAY[OLD."reference_date", NEW."reference_date"]
+    END LOOP
+        SELECT
+            "cash_period"."period" INTO closed_period
+        FROM
+            "cash_period"
+        WHERE
+            "cash_period"."period" = date_trunc('month', launch_reference_date)::date AND
+            "cash_period"."status" = 'closed'
+        FOR SHARE;
+
+        IF FOUND THEN
+            RAISE EXCEPTION 'The reference_date % is in the closed period %', to_char(launch_reference_date, 'YYYY-MM-DD'), to_char(closed_period, 'YYYY-MM')
+                USING ERRCODE = 'CP001';
+        END IF;
+    END LOOP;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "cash_launch_period_open"
+    BEFORE INSERT OR DELETE OR UPDATE OF "account_id", "category_id", "transfer_id", "installment_group_id", "installment_number", "installment_count", "reversal_of_id", "reversed_by_id", "external_id", "reference_date", "type", "description", "value", "currency", "exchange_rate", "base_value", "status", "review_comment", "reviewed_by", "reviewed_at", "deleted_at"
+    ON "cash_launch"
+    FOR EACH ROW EXECUTE FUNCTION "cash_launch_period_open"();
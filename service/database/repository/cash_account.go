@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashAccount interface {
+	Insert(modelCashAccount *model.CashAccount) (*model.CashAccount, error)
+	List() (model.CashAccounts, error)
+	GetByID(id int64) (*model.CashAccount, error)
+	Update(modelCashAccount *model.CashAccount) (*model.CashAccount, error)
+	DeleteByID(id int64) error
+}
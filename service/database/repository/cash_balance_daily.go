@@ -7,6 +7,11 @@ import (
 )
 
 type CashBalanceDaily interface {
-	GetByReferenceDate(referenceDate time.Time) (*model.CashBalanceDaily, error)
+	GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error)
 	GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error)
+	GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error)
+	// GetSummaryByRangeReferenceDate returns the totals and the closing balance
+	// of each period of the granularity with launches, in ascending order
+	GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error)
+	Rebuild() (model.CashBalanceDailyDrifts, error)
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashBudget interface {
+	Insert(modelCashBudget *model.CashBudget) (*model.CashBudget, error)
+	// List returns the budgets of the periods between from and to ordered by
+	// period, type, category_id, pattern_type, pattern and id
+	List(from time.Time, to time.Time) (model.CashBudgets, error)
+	GetByID(id int64) (*model.CashBudget, error)
+	Update(modelCashBudget *model.CashBudget) (*model.CashBudget, error)
+	DeleteByID(id int64) error
+	// ListActualByCategory sums the base value of the approved launches with
+	// reference date between from and to by category_id and type, leaving out
+	// both launches of a reversal
+	ListActualByCategory(from time.Time, to time.Time) (model.CashBudgetActuals, error)
+	// ListActualByDescription sums the same launches by description and type,
+	// the patterns are matched by the use case with the category rule matcher
+	ListActualByDescription(from time.Time, to time.Time) (model.CashBudgetActuals, error)
+}
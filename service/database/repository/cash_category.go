@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashCategory interface {
+	Insert(modelCashCategory *model.CashCategory) (*model.CashCategory, error)
+	List() (model.CashCategories, error)
+	GetByID(id int64) (*model.CashCategory, error)
+	Update(modelCashCategory *model.CashCategory) (*model.CashCategory, error)
+	DeleteByID(id int64) error
+	// GetTotalsByRangeReferenceDate returns the totals of the launches of each
+	// category without the subcategories, the launches without category on
+	// category_id 0
+	GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) (model.CashCategoryTotals, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashCategoryRule interface {
+	Insert(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error)
+	// List returns the rules ordered by priority and id, the order in which
+	// they are applied
+	List() (model.CashCategoryRules, error)
+	GetByID(id int64) (*model.CashCategoryRule, error)
+	Update(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error)
+	DeleteByID(id int64) error
+}
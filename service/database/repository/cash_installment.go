@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashInstallment interface {
+	// Insert persists the installments in the same transaction linked by a
+	// new installment_group_id
+	Insert(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error)
+	// ListByGroupID returns the installments of the group ordered by number
+	ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error)
+	// Update persists the changes of the installments in the same transaction
+	Update(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error)
+	// DeleteByGroupID removes the installments of the group due on or after
+	// the date and returns them, ErrNotFound when there is none
+	DeleteByGroupID(groupID int64, referenceDateFrom time.Time, modelAudit model.Audit) (model.CashLaunches, error)
+}
@@ -4,10 +4,40 @@ import (
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 )
 
+// the actions recorded in the history of a launch
+const (
+	CashLaunchAuditActionInsert    = "insert"
+	CashLaunchAuditActionUpdate    = "update"
+	CashLaunchAuditActionDelete    = "delete"
+	CashLaunchAuditActionReversal  = "reversal"
+	CashLaunchAuditActionApproval  = "approval"
+	CashLaunchAuditActionRejection = "rejection"
+)
+
+// the status of a launch, only the approved launches compose the balance
+const (
+	CashLaunchStatusPending  = "pending"
+	CashLaunchStatusApproved = "approved"
+	CashLaunchStatusRejected = "rejected"
+)
+
+// CashLaunch records every change of a launch in its history, with the actor
+// and request of the audit, in the same transaction of the change
 type CashLaunch interface {
-	Insert(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error)
-	List() (model.CashLaunches, error)
+	Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+	List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, error)
 	GetByID(id int64) (*model.CashLaunch, error)
-	Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error)
-	DeleteByID(id int64) error
+	Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+	DeleteByID(id int64, modelAudit model.Audit) error
+	Reverse(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error)
+	// Import persists in a single transaction the launches returned by next
+	// until it returns io.EOF, storing the persisted launch in the pointer
+	// returned. A launch with the external_id of a launch of its account is
+	// left out with a zero id. Any other error of next discards every launch.
+	Import(next func() (*model.CashLaunch, error), modelAudit model.Audit) error
+	// Review approves or rejects the pending launch with the status and review
+	// fields of the model, the approved launch is applied to the balance
+	Review(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+	// ListAuditByID returns the history of the launch in the order of the changes
+	ListAuditByID(id int64) (model.CashLaunchAudits, error)
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashPeriod interface {
+	// List returns the periods stored between the months ordered by period,
+	// the months without a stored period are open
+	List(from time.Time, to time.Time) (model.CashPeriods, error)
+	// GetByPeriod returns ErrNotFound for a month without a stored period
+	GetByPeriod(period time.Time) (*model.CashPeriod, error)
+	// Close stores the period as closed with the closing balance of all
+	// accounts on its last day read along with the close, a period already
+	// closed returns ErrDuplicateKey
+	Close(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error)
+	// Reopen stores the closed period as open, a period not closed returns
+	// ErrNotFound
+	Reopen(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashRecurrence interface {
+	Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error)
+	List() (model.CashRecurrences, error)
+	GetByID(id int64) (*model.CashRecurrence, error)
+	// Update changes the template keeping the last_date of the launches
+	// already created
+	Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error)
+	DeleteByID(id int64) error
+	// LaunchInsert persists the launch of an occurrence and moves the
+	// last_date of its template in the same transaction, an occurrence
+	// already created returns ErrDuplicateKey
+	LaunchInsert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+// the types of the match of a statement line with a launch
+const (
+	CashStatementLineMatchTypeAuto   = "auto"
+	CashStatementLineMatchTypeManual = "manual"
+)
+
+type CashStatementLine interface {
+	// Insert returns ErrDuplicateKey for the external_id of a line of the
+	// account already imported
+	Insert(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error)
+	// List returns the lines of the filter ordered by reference_date and id
+	List(modelCashStatementLineFilter *model.CashStatementLineFilter) (model.CashStatementLines, error)
+	GetByID(id int64) (*model.CashStatementLine, error)
+	// Match stores the launch and the match fields of the model in the line
+	// not matched, a line already matched returns ErrNotFound and a launch
+	// matched to another line returns ErrDuplicateKey
+	Match(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error)
+	// Unmatch clears the launch of the line matched, a line not matched
+	// returns ErrNotFound
+	Unmatch(id int64) (*model.CashStatementLine, error)
+}
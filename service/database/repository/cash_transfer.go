@@ -0,0 +1,9 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type CashTransfer interface {
+	Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+type ExchangeRate interface {
+	Upsert(modelExchangeRates model.ExchangeRates) error
+	GetByReferenceDate(referenceDate time.Time) (model.ExchangeRates, error)
+	GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams *model.ExchangeRateRangeReferenceDate) (model.ExchangeRates, error)
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var cashAccountIDLast int64 = 2
+
+var InMemoryCashAccounts = model.CashAccounts{
+	{
+		ID:        1,
+		Name:      "CAIXA",
+		UpdatedAt: time.Now().UTC(),
+		CreatedAt: time.Now().UTC(),
+	},
+	{
+		ID:        2,
+		Name:      "BANCO",
+		UpdatedAt: time.Now().UTC(),
+		CreatedAt: time.Now().UTC(),
+	},
+}
+
+type InMemoryCashAccount struct {
+	InMemory *InMemory
+}
+
+func NewCashAccount(inMemory *InMemory) repository.CashAccount {
+	return &InMemoryCashAccount{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashAccount *InMemoryCashAccount) Insert(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	if repositoryInMemoryCashAccount.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	if cashAccountNameExists(modelCashAccount.ID, modelCashAccount.Name) {
+		return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (name)=(%s) already exists.", modelCashAccount.Name)}
+	}
+
+	modelCashAccountInsert := *modelCashAccount
+	cashAccountIDLast += 1
+	modelCashAccountInsert.ID = cashAccountIDLast
+	InMemoryCashAccounts = append(InMemoryCashAccounts, modelCashAccountInsert)
+
+	return &modelCashAccountInsert, nil
+}
+
+func (repositoryInMemoryCashAccount *InMemoryCashAccount) List() (model.CashAccounts, error) {
+	if repositoryInMemoryCashAccount.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashAccounts := append(model.CashAccounts{}, InMemoryCashAccounts...)
+
+	sort.Slice(modelCashAccounts, func(i, j int) bool {
+		return modelCashAccounts[i].Name < modelCashAccounts[j].Name
+	})
+
+	return modelCashAccounts, nil
+}
+
+func (repositoryInMemoryCashAccount *InMemoryCashAccount) GetByID(id int64) (*model.CashAccount, error) {
+	if repositoryInMemoryCashAccount.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashAccountByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashAccount := InMemoryCashAccounts[idx]
+
+	return &modelCashAccount, nil
+}
+
+func (repositoryInMemoryCashAccount *InMemoryCashAccount) Update(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	if repositoryInMemoryCashAccount.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashAccountByID(modelCashAccount.ID)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	if cashAccountNameExists(modelCashAccount.ID, modelCashAccount.Name) {
+		return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (name)=(%s) already exists.", modelCashAccount.Name)}
+	}
+
+	modelCashAccount.CreatedAt = InMemoryCashAccounts[idx].CreatedAt
+	InMemoryCashAccounts[idx] = *modelCashAccount
+
+	return &InMemoryCashAccounts[idx], nil
+}
+
+func (repositoryInMemoryCashAccount *InMemoryCashAccount) DeleteByID(id int64) error {
+	if repositoryInMemoryCashAccount.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	idx := getCashAccountByID(id)
+
+	if idx < 0 {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	// mirror the foreign key of the cash_launch table
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if cashLaunch.AccountID == id {
+			return repository.ErrReferenced{Message: fmt.Sprintf("Key (id)=(%d) is still referenced from table \"cash_launch\".", id), Table: "cash_launch"}
+		}
+	}
+
+	InMemoryCashAccounts = append(InMemoryCashAccounts[:idx], InMemoryCashAccounts[idx+1:]...)
+
+	return nil
+}
+
+func getCashAccountByID(id int64) int {
+	for idx, cashAccount := range InMemoryCashAccounts {
+		if cashAccount.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// cashAccountNameExists mirrors the unique name of the cash_account table
+func cashAccountNameExists(id int64, name string) bool {
+	for _, cashAccount := range InMemoryCashAccounts {
+		if cashAccount.ID != id && cashAccount.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,12 +2,24 @@ package repository
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 )
 
+// InMemoryCashBalanceDailyStored is a daily balance stored apart from the
+// launches, like a row of the cash_balance_daily table that drifted
+type InMemoryCashBalanceDailyStored struct {
+	AccountID        int64
+	CashBalanceDaily model.CashBalanceDaily
+}
+
+// InMemoryCashBalanceDailiesStored holds the drifted balances, the balances not
+// held here are always accumulated straight from the launches
+var InMemoryCashBalanceDailiesStored = []InMemoryCashBalanceDailyStored{}
+
 type InMemoryCashBalanceDaily struct {
 	InMemory *InMemory
 }
@@ -18,27 +30,14 @@ func NewCashBalanceDaily(inMemory *InMemory) repository.CashBalanceDaily {
 	}
 }
 
-func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetByReferenceDate(referenceDate time.Time) (*model.CashBalanceDaily, error) {
+func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error) {
 	if repositoryInMemoryCashBalanceDaily.InMemory.Error == true {
 		return nil, errors.New("Error load from database")
 	}
 
-	cashBalanceDaily := &model.CashBalanceDaily{
-		ReferenceDate: referenceDate,
-		Value:         0,
-	}
+	cashBalanceDaily := getCashBalanceDaily(referenceDate, accountID, includeDeleted, projected)
 
-	for _, cashLaunch := range InMemoryCashLaunches {
-		if cashLaunch.ReferenceDate == referenceDate {
-			if cashLaunch.Type == "C" {
-				cashBalanceDaily.Value += cashLaunch.Value
-			} else {
-				cashBalanceDaily.Value -= cashLaunch.Value
-			}
-		}
-	}
-
-	return cashBalanceDaily, nil
+	return &cashBalanceDaily, nil
 }
 
 func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error) {
@@ -49,24 +48,237 @@ func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetByRangeRe
 	cashBalanceDailies := model.CashBalanceDailies{}
 
 	for _, cashLaunch := range InMemoryCashLaunches {
+		if !cashLaunchAccountMatch(cashLaunch, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected) {
+			continue
+		}
+
 		if cashLaunch.ReferenceDate.Sub(cashBalanceGetByRangeReferenceDateParams.From).Hours()/24 >= 0 &&
 			cashBalanceGetByRangeReferenceDateParams.To.Sub(cashLaunch.ReferenceDate).Hours()/24 >= 0 {
 			idx := getCashBalanceDailyByReferenceDate(cashBalanceDailies, cashLaunch.ReferenceDate)
 
 			if idx < 0 {
-				cashBalanceDailies = append(cashBalanceDailies, model.CashBalanceDaily{ReferenceDate: cashLaunch.ReferenceDate})
-				idx = len(cashBalanceDailies) - 1
+				cashBalanceDailies = append(cashBalanceDailies, getCashBalanceDaily(cashLaunch.ReferenceDate, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected))
 			}
+		}
+	}
+
+	sort.Slice(cashBalanceDailies, func(i, j int) bool {
+		return cashBalanceDailies[i].ReferenceDate.Before(cashBalanceDailies[j].ReferenceDate)
+	})
+
+	return cashBalanceDailies, nil
+}
+
+func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error) {
+	if repositoryInMemoryCashBalanceDaily.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	cashBalanceDailyCurrencies := model.CashBalanceDailyCurrencies{}
+
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if !cashLaunchAccountMatch(cashLaunch, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected) ||
+			cashLaunch.ReferenceDate.Before(cashBalanceGetByRangeReferenceDateParams.From) ||
+			cashLaunch.ReferenceDate.After(cashBalanceGetByRangeReferenceDateParams.To) {
+			continue
+		}
+
+		idx := getCashBalanceDailyCurrency(cashBalanceDailyCurrencies, cashLaunch.ReferenceDate, cashLaunch.Currency)
+
+		if idx < 0 {
+			cashBalanceDailyCurrencies = append(cashBalanceDailyCurrencies, model.CashBalanceDailyCurrency{
+				ReferenceDate: cashLaunch.ReferenceDate,
+				Currency:      cashLaunch.Currency,
+			})
+
+			idx = len(cashBalanceDailyCurrencies) - 1
+		}
+
+		if cashLaunch.Type == "C" {
+			cashBalanceDailyCurrencies[idx].TotalCredit = cashBalanceDailyCurrencies[idx].TotalCredit.Add(cashLaunch.Value)
+			cashBalanceDailyCurrencies[idx].BaseValue = cashBalanceDailyCurrencies[idx].BaseValue.Add(cashLaunch.BaseValue)
+		} else {
+			cashBalanceDailyCurrencies[idx].TotalDebit = cashBalanceDailyCurrencies[idx].TotalDebit.Add(cashLaunch.Value)
+			cashBalanceDailyCurrencies[idx].BaseValue = cashBalanceDailyCurrencies[idx].BaseValue.Sub(cashLaunch.BaseValue)
+		}
+
+		cashBalanceDailyCurrencies[idx].Value = cashBalanceDailyCurrencies[idx].TotalCredit.Sub(cashBalanceDailyCurrencies[idx].TotalDebit)
+	}
+
+	sort.Slice(cashBalanceDailyCurrencies, func(i, j int) bool {
+		if cashBalanceDailyCurrencies[i].ReferenceDate.Equal(cashBalanceDailyCurrencies[j].ReferenceDate) {
+			return cashBalanceDailyCurrencies[i].Currency < cashBalanceDailyCurrencies[j].Currency
+		}
+
+		return cashBalanceDailyCurrencies[i].ReferenceDate.Before(cashBalanceDailyCurrencies[j].ReferenceDate)
+	})
+
+	return cashBalanceDailyCurrencies, nil
+}
+
+func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error) {
+	if repositoryInMemoryCashBalanceDaily.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	cashBalanceSummaries := model.CashBalanceSummaries{}
+	// the last day with launches of each period
+	referenceDatesLast := []time.Time{}
+
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if !cashLaunchAccountMatch(cashLaunch, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected) ||
+			cashLaunch.ReferenceDate.Before(cashBalanceGetByRangeReferenceDateParams.From) ||
+			cashLaunch.ReferenceDate.After(cashBalanceGetByRangeReferenceDateParams.To) {
+			continue
+		}
+
+		period := model.CashBalanceSummaryPeriodOf(cashLaunch.ReferenceDate, cashBalanceGetByRangeReferenceDateParams.Granularity)
+		idx := -1
+
+		for idxSummary, cashBalanceSummary := range cashBalanceSummaries {
+			if cashBalanceSummary.Period.Equal(period) {
+				idx = idxSummary
+			}
+		}
+
+		if idx < 0 {
+			cashBalanceSummaries = append(cashBalanceSummaries, model.CashBalanceSummary{Period: period})
+			referenceDatesLast = append(referenceDatesLast, cashLaunch.ReferenceDate)
+			idx = len(cashBalanceSummaries) - 1
+		}
+
+		if cashLaunch.ReferenceDate.After(referenceDatesLast[idx]) {
+			referenceDatesLast[idx] = cashLaunch.ReferenceDate
+		}
 
+		if cashLaunch.Type == "C" {
+			cashBalanceSummaries[idx].TotalCredit = cashBalanceSummaries[idx].TotalCredit.Add(cashLaunch.BaseValue)
+		} else {
+			cashBalanceSummaries[idx].TotalDebit = cashBalanceSummaries[idx].TotalDebit.Add(cashLaunch.BaseValue)
+		}
+	}
+
+	for idx := range cashBalanceSummaries {
+		cashBalanceDaily := getCashBalanceDaily(referenceDatesLast[idx], cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected)
+
+		cashBalanceSummaries[idx].Value = cashBalanceSummaries[idx].TotalCredit.Sub(cashBalanceSummaries[idx].TotalDebit)
+		cashBalanceSummaries[idx].ClosingBalance = cashBalanceDaily.ClosingBalance
+		cashBalanceSummaries[idx].OpeningBalance = cashBalanceDaily.ClosingBalance.Sub(cashBalanceSummaries[idx].Value)
+	}
+
+	sort.Slice(cashBalanceSummaries, func(i, j int) bool {
+		return cashBalanceSummaries[i].Period.Before(cashBalanceSummaries[j].Period)
+	})
+
+	return cashBalanceSummaries, nil
+}
+
+// Rebuild compares the stored balances with the ones accumulated from the
+// launches, returns the differences and drops the stored balances
+func (repositoryInMemoryCashBalanceDaily *InMemoryCashBalanceDaily) Rebuild() (model.CashBalanceDailyDrifts, error) {
+	if repositoryInMemoryCashBalanceDaily.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	cashBalanceDailyDrifts := model.CashBalanceDailyDrifts{}
+
+	for _, cashBalanceDailyStored := range InMemoryCashBalanceDailiesStored {
+		cashBalanceDailyRebuilt := getCashBalanceDailyRebuilt(cashBalanceDailyStored.CashBalanceDaily.ReferenceDate, cashBalanceDailyStored.AccountID, false, false)
+
+		if cashBalanceDailyStored.CashBalanceDaily.TotalCredit.Equal(cashBalanceDailyRebuilt.TotalCredit) &&
+			cashBalanceDailyStored.CashBalanceDaily.TotalDebit.Equal(cashBalanceDailyRebuilt.TotalDebit) &&
+			cashBalanceDailyStored.CashBalanceDaily.ClosingBalance.Equal(cashBalanceDailyRebuilt.ClosingBalance) {
+			continue
+		}
+
+		cashBalanceDailyDrifts = append(cashBalanceDailyDrifts, model.CashBalanceDailyDrift{
+			AccountID:     cashBalanceDailyStored.AccountID,
+			ReferenceDate: cashBalanceDailyStored.CashBalanceDaily.ReferenceDate,
+			Stored:        cashBalanceDailyStored.CashBalanceDaily,
+			Rebuilt:       cashBalanceDailyRebuilt,
+		})
+	}
+
+	sort.Slice(cashBalanceDailyDrifts, func(i, j int) bool {
+		if cashBalanceDailyDrifts[i].AccountID == cashBalanceDailyDrifts[j].AccountID {
+			return cashBalanceDailyDrifts[i].ReferenceDate.Before(cashBalanceDailyDrifts[j].ReferenceDate)
+		}
+
+		return cashBalanceDailyDrifts[i].AccountID < cashBalanceDailyDrifts[j].AccountID
+	})
+
+	InMemoryCashBalanceDailiesStored = []InMemoryCashBalanceDailyStored{}
+
+	return cashBalanceDailyDrifts, nil
+}
+
+// getCashBalanceDaily returns the stored balance of the account on the
+// reference date when there is one, as the postgres repository reads the
+// cash_balance_daily table, otherwise the balance accumulated from the launches
+func getCashBalanceDaily(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) model.CashBalanceDaily {
+	if !includeDeleted && !projected {
+		for _, cashBalanceDailyStored := range InMemoryCashBalanceDailiesStored {
+			if cashBalanceDailyStored.AccountID == accountID && cashBalanceDailyStored.CashBalanceDaily.ReferenceDate.Equal(referenceDate) {
+				return cashBalanceDailyStored.CashBalanceDaily
+			}
+		}
+	}
+
+	return getCashBalanceDailyRebuilt(referenceDate, accountID, includeDeleted, projected)
+}
+
+// getCashBalanceDailyRebuilt accumulates every launch of the account (or of all
+// accounts when zero) up to the reference date mirroring the
+// cash_balance_daily table kept by the postgres repository
+func getCashBalanceDailyRebuilt(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) model.CashBalanceDaily {
+	cashBalanceDaily := model.CashBalanceDaily{
+		ReferenceDate: referenceDate,
+	}
+
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if !cashLaunchAccountMatch(cashLaunch, accountID, includeDeleted, projected) {
+			continue
+		}
+
+		if cashLaunch.ReferenceDate.Before(referenceDate) {
+			if cashLaunch.Type == "C" {
+				cashBalanceDaily.OpeningBalance = cashBalanceDaily.OpeningBalance.Add(cashLaunch.BaseValue)
+			} else {
+				cashBalanceDaily.OpeningBalance = cashBalanceDaily.OpeningBalance.Sub(cashLaunch.BaseValue)
+			}
+		} else if cashLaunch.ReferenceDate.Equal(referenceDate) {
 			if cashLaunch.Type == "C" {
-				cashBalanceDailies[idx].Value += cashLaunch.Value
+				cashBalanceDaily.TotalCredit = cashBalanceDaily.TotalCredit.Add(cashLaunch.BaseValue)
 			} else {
-				cashBalanceDailies[idx].Value -= cashLaunch.Value
+				cashBalanceDaily.TotalDebit = cashBalanceDaily.TotalDebit.Add(cashLaunch.BaseValue)
 			}
 		}
 	}
 
-	return cashBalanceDailies, nil
+	cashBalanceDaily.Value = cashBalanceDaily.TotalCredit.Sub(cashBalanceDaily.TotalDebit)
+	cashBalanceDaily.ClosingBalance = cashBalanceDaily.OpeningBalance.Add(cashBalanceDaily.Value)
+
+	return cashBalanceDaily
+}
+
+// cashLaunchAccountMatch mirrors the account_id 0 of the cash_balance_daily
+// table, which combines all accounts leaving the transfers out, and keeps only
+// the approved launches leaving the deleted launches out unless includeDeleted
+// and the pending launches out unless projected
+func cashLaunchAccountMatch(cashLaunch model.CashLaunch, accountID int64, includeDeleted bool, projected bool) bool {
+	if !includeDeleted && cashLaunch.DeletedAt != nil {
+		return false
+	}
+
+	if cashLaunch.Status != repository.CashLaunchStatusApproved && (!projected || cashLaunch.Status != repository.CashLaunchStatusPending) {
+		return false
+	}
+
+	if accountID == 0 {
+		return cashLaunch.TransferID == 0
+	}
+
+	return cashLaunch.AccountID == accountID
 }
 
 func getCashBalanceDailyByReferenceDate(cashBalanceDailies model.CashBalanceDailies, referenceDate time.Time) int {
@@ -78,3 +290,13 @@ func getCashBalanceDailyByReferenceDate(cashBalanceDailies model.CashBalanceDail
 
 	return -1
 }
+
+func getCashBalanceDailyCurrency(cashBalanceDailyCurrencies model.CashBalanceDailyCurrencies, referenceDate time.Time, currency string) int {
+	for index, cashBalanceDailyCurrency := range cashBalanceDailyCurrencies {
+		if cashBalanceDailyCurrency.ReferenceDate.Equal(referenceDate) && cashBalanceDailyCurrency.Currency == currency {
+			return index
+		}
+	}
+
+	return -1
+}
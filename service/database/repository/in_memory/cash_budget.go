@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var cashBudgetIDLast int64 = 1
+
+var InMemoryCashBudgets = model.CashBudgets{
+	{
+		ID:         1,
+		CategoryID: 2,
+		Type:       "D",
+		Period:     time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC),
+		Value:      decimal.NewFromInt(1500),
+		UpdatedAt:  time.Now().UTC(),
+		CreatedAt:  time.Now().UTC(),
+	},
+}
+
+type InMemoryCashBudget struct {
+	InMemory *InMemory
+}
+
+func NewCashBudget(inMemory *InMemory) repository.CashBudget {
+	return &InMemoryCashBudget{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) Insert(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	if cashBudgetKeyExists(modelCashBudget) {
+		return nil, cashBudgetErrDuplicateKey(modelCashBudget)
+	}
+
+	modelCashBudgetInsert := *modelCashBudget
+	cashBudgetIDLast += 1
+	modelCashBudgetInsert.ID = cashBudgetIDLast
+	InMemoryCashBudgets = append(InMemoryCashBudgets, modelCashBudgetInsert)
+
+	return &modelCashBudgetInsert, nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) List(from time.Time, to time.Time) (model.CashBudgets, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashBudgets := model.CashBudgets{}
+
+	for _, cashBudget := range InMemoryCashBudgets {
+		if !cashBudget.Period.Before(from) && !cashBudget.Period.After(to) {
+			modelCashBudgets = append(modelCashBudgets, cashBudget)
+		}
+	}
+
+	sort.Slice(modelCashBudgets, func(i, j int) bool {
+		if !modelCashBudgets[i].Period.Equal(modelCashBudgets[j].Period) {
+			return modelCashBudgets[i].Period.Before(modelCashBudgets[j].Period)
+		}
+
+		if modelCashBudgets[i].Type != modelCashBudgets[j].Type {
+			return modelCashBudgets[i].Type < modelCashBudgets[j].Type
+		}
+
+		if modelCashBudgets[i].CategoryID != modelCashBudgets[j].CategoryID {
+			return modelCashBudgets[i].CategoryID < modelCashBudgets[j].CategoryID
+		}
+
+		if modelCashBudgets[i].PatternType != modelCashBudgets[j].PatternType {
+			return modelCashBudgets[i].PatternType < modelCashBudgets[j].PatternType
+		}
+
+		if modelCashBudgets[i].Pattern != modelCashBudgets[j].Pattern {
+			return modelCashBudgets[i].Pattern < modelCashBudgets[j].Pattern
+		}
+
+		return modelCashBudgets[i].ID < modelCashBudgets[j].ID
+	})
+
+	return modelCashBudgets, nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) GetByID(id int64) (*model.CashBudget, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashBudgetByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashBudget := InMemoryCashBudgets[idx]
+
+	return &modelCashBudget, nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) Update(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashBudgetByID(modelCashBudget.ID)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	if cashBudgetKeyExists(modelCashBudget) {
+		return nil, cashBudgetErrDuplicateKey(modelCashBudget)
+	}
+
+	modelCashBudget.CreatedAt = InMemoryCashBudgets[idx].CreatedAt
+	InMemoryCashBudgets[idx] = *modelCashBudget
+
+	return &InMemoryCashBudgets[idx], nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) DeleteByID(id int64) error {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	idx := getCashBudgetByID(id)
+
+	if idx < 0 {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	InMemoryCashBudgets = append(InMemoryCashBudgets[:idx], InMemoryCashBudgets[idx+1:]...)
+
+	return nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) ListActualByCategory(from time.Time, to time.Time) (model.CashBudgetActuals, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashBudgetActuals := model.CashBudgetActuals{}
+
+	for _, modelCashLaunch := range InMemoryCashLaunches {
+		if modelCashLaunch.CategoryID == 0 || !cashBudgetActualMatch(&modelCashLaunch, from, to) {
+			continue
+		}
+
+		idx := -1
+
+		for idxActual, modelCashBudgetActual := range modelCashBudgetActuals {
+			if modelCashBudgetActual.CategoryID == modelCashLaunch.CategoryID && modelCashBudgetActual.Type == modelCashLaunch.Type {
+				idx = idxActual
+			}
+		}
+
+		if idx < 0 {
+			modelCashBudgetActuals = append(modelCashBudgetActuals, model.CashBudgetActual{CategoryID: modelCashLaunch.CategoryID, Type: modelCashLaunch.Type})
+			idx = len(modelCashBudgetActuals) - 1
+		}
+
+		modelCashBudgetActuals[idx].Actual = modelCashBudgetActuals[idx].Actual.Add(modelCashLaunch.BaseValue)
+	}
+
+	sort.Slice(modelCashBudgetActuals, func(i, j int) bool {
+		if modelCashBudgetActuals[i].CategoryID != modelCashBudgetActuals[j].CategoryID {
+			return modelCashBudgetActuals[i].CategoryID < modelCashBudgetActuals[j].CategoryID
+		}
+
+		return modelCashBudgetActuals[i].Type < modelCashBudgetActuals[j].Type
+	})
+
+	return modelCashBudgetActuals, nil
+}
+
+func (repositoryInMemoryCashBudget *InMemoryCashBudget) ListActualByDescription(from time.Time, to time.Time) (model.CashBudgetActuals, error) {
+	if repositoryInMemoryCashBudget.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashBudgetActuals := model.CashBudgetActuals{}
+
+	for _, modelCashLaunch := range InMemoryCashLaunches {
+		if !cashBudgetActualMatch(&modelCashLaunch, from, to) {
+			continue
+		}
+
+		idx := -1
+
+		for idxActual, modelCashBudgetActual := range modelCashBudgetActuals {
+			if modelCashBudgetActual.Description == modelCashLaunch.Description && modelCashBudgetActual.Type == modelCashLaunch.Type {
+				idx = idxActual
+			}
+		}
+
+		if idx < 0 {
+			modelCashBudgetActuals = append(modelCashBudgetActuals, model.CashBudgetActual{Description: modelCashLaunch.Description, Type: modelCashLaunch.Type})
+			idx = len(modelCashBudgetActuals) - 1
+		}
+
+		modelCashBudgetActuals[idx].Actual = modelCashBudgetActuals[idx].Actual.Add(modelCashLaunch.BaseValue)
+	}
+
+	sort.Slice(modelCashBudgetActuals, func(i, j int) bool {
+		if modelCashBudgetActuals[i].Description != modelCashBudgetActuals[j].Description {
+			return modelCashBudgetActuals[i].Description < modelCashBudgetActuals[j].Description
+		}
+
+		return modelCashBudgetActuals[i].Type < modelCashBudgetActuals[j].Type
+	})
+
+	return modelCashBudgetActuals, nil
+}
+
+// cashBudgetActualMatch mirrors the cashBudgetActualCondition of postgres
+func cashBudgetActualMatch(modelCashLaunch *model.CashLaunch, from time.Time, to time.Time) bool {
+	return !modelCashLaunch.ReferenceDate.Before(from) &&
+		!modelCashLaunch.ReferenceDate.After(to) &&
+		modelCashLaunch.DeletedAt == nil &&
+		modelCashLaunch.Status == repository.CashLaunchStatusApproved &&
+		modelCashLaunch.ReversalOfID == 0 &&
+		modelCashLaunch.ReversedByID == 0
+}
+
+func getCashBudgetByID(id int64) int {
+	for idx, cashBudget := range InMemoryCashBudgets {
+		if cashBudget.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// cashBudgetKeyExists mirrors the unique index of the period, type, category
+// and pattern of the cash_budget table
+func cashBudgetKeyExists(modelCashBudget *model.CashBudget) bool {
+	for _, cashBudget := range InMemoryCashBudgets {
+		if cashBudget.ID != modelCashBudget.ID &&
+			cashBudget.Period.Equal(modelCashBudget.Period) &&
+			cashBudget.Type == modelCashBudget.Type &&
+			cashBudget.CategoryID == modelCashBudget.CategoryID &&
+			cashBudget.PatternType == modelCashBudget.PatternType &&
+			cashBudget.Pattern == modelCashBudget.Pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cashBudgetErrDuplicateKey(modelCashBudget *model.CashBudget) error {
+	return repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (period, type, category_id, pattern_type, pattern)=(%s, %s, %d, %s, %s) already exists.",
+		modelCashBudget.Period.Format("2006-01-02"), modelCashBudget.Type, modelCashBudget.CategoryID, modelCashBudget.PatternType, modelCashBudget.Pattern)}
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var cashCategoryIDLast int64 = 3
+
+var InMemoryCashCategories = model.CashCategories{
+	{
+		ID:        1,
+		Name:      "DESPESAS",
+		UpdatedAt: time.Now().UTC(),
+		CreatedAt: time.Now().UTC(),
+	},
+	{
+		ID:        2,
+		ParentID:  1,
+		Name:      "ALUGUEL",
+		UpdatedAt: time.Now().UTC(),
+		CreatedAt: time.Now().UTC(),
+	},
+	{
+		ID:        3,
+		Name:      "RECEITAS",
+		UpdatedAt: time.Now().UTC(),
+		CreatedAt: time.Now().UTC(),
+	},
+}
+
+type InMemoryCashCategory struct {
+	InMemory *InMemory
+}
+
+func NewCashCategory(inMemory *InMemory) repository.CashCategory {
+	return &InMemoryCashCategory{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) Insert(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	if cashCategoryNameExists(modelCashCategory) {
+		return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (parent_id, name)=(%d, %s) already exists.", modelCashCategory.ParentID, modelCashCategory.Name)}
+	}
+
+	modelCashCategoryInsert := *modelCashCategory
+	cashCategoryIDLast += 1
+	modelCashCategoryInsert.ID = cashCategoryIDLast
+	InMemoryCashCategories = append(InMemoryCashCategories, modelCashCategoryInsert)
+
+	return &modelCashCategoryInsert, nil
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) List() (model.CashCategories, error) {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashCategories := append(model.CashCategories{}, InMemoryCashCategories...)
+
+	sort.Slice(modelCashCategories, func(i, j int) bool {
+		return modelCashCategories[i].Name < modelCashCategories[j].Name
+	})
+
+	return modelCashCategories, nil
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) GetByID(id int64) (*model.CashCategory, error) {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashCategoryByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashCategory := InMemoryCashCategories[idx]
+
+	return &modelCashCategory, nil
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) Update(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashCategoryByID(modelCashCategory.ID)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	if cashCategoryNameExists(modelCashCategory) {
+		return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (parent_id, name)=(%d, %s) already exists.", modelCashCategory.ParentID, modelCashCategory.Name)}
+	}
+
+	modelCashCategory.CreatedAt = InMemoryCashCategories[idx].CreatedAt
+	InMemoryCashCategories[idx] = *modelCashCategory
+
+	return &InMemoryCashCategories[idx], nil
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) DeleteByID(id int64) error {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	idx := getCashCategoryByID(id)
+
+	if idx < 0 {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	// mirror the foreign keys of the cash_launch, cash_category,
+	// cash_category_rule and cash_budget tables
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if cashLaunch.CategoryID == id {
+			return repository.ErrReferenced{Message: fmt.Sprintf("Key (id)=(%d) is still referenced from table \"cash_launch\".", id), Table: "cash_launch"}
+		}
+	}
+
+	for _, cashCategory := range InMemoryCashCategories {
+		if cashCategory.ParentID == id {
+			return repository.ErrReferenced{Message: fmt.Sprintf("Key (id)=(%d) is still referenced from table \"cash_category\".", id), Table: "cash_category"}
+		}
+	}
+
+	for _, cashCategoryRule := range InMemoryCashCategoryRules {
+		if cashCategoryRule.CategoryID == id {
+			return repository.ErrReferenced{Message: fmt.Sprintf("Key (id)=(%d) is still referenced from table \"cash_category_rule\".", id), Table: "cash_category_rule"}
+		}
+	}
+
+	for _, cashBudget := range InMemoryCashBudgets {
+		if cashBudget.CategoryID == id {
+			return repository.ErrReferenced{Message: fmt.Sprintf("Key (id)=(%d) is still referenced from table \"cash_budget\".", id), Table: "cash_budget"}
+		}
+	}
+
+	InMemoryCashCategories = append(InMemoryCashCategories[:idx], InMemoryCashCategories[idx+1:]...)
+
+	return nil
+}
+
+func (repositoryInMemoryCashCategory *InMemoryCashCategory) GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) (model.CashCategoryTotals, error) {
+	if repositoryInMemoryCashCategory.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashCategoryTotals := model.CashCategoryTotals{}
+
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if !cashLaunchAccountMatch(cashLaunch, cashCategoryRangeReferenceDate.AccountID, cashCategoryRangeReferenceDate.IncludeDeleted, cashCategoryRangeReferenceDate.Projected) ||
+			cashLaunch.ReferenceDate.Before(cashCategoryRangeReferenceDate.From) ||
+			cashLaunch.ReferenceDate.After(cashCategoryRangeReferenceDate.To) {
+			continue
+		}
+
+		idx := -1
+
+		for idxTotal, modelCashCategoryTotal := range modelCashCategoryTotals {
+			if modelCashCategoryTotal.CategoryID == cashLaunch.CategoryID {
+				idx = idxTotal
+			}
+		}
+
+		if idx < 0 {
+			modelCashCategoryTotals = append(modelCashCategoryTotals, model.CashCategoryTotal{
+				CategoryID:  cashLaunch.CategoryID,
+				TotalCredit: decimal.Zero,
+				TotalDebit:  decimal.Zero,
+			})
+			idx = len(modelCashCategoryTotals) - 1
+		}
+
+		if cashLaunch.Type == "C" {
+			modelCashCategoryTotals[idx].TotalCredit = modelCashCategoryTotals[idx].TotalCredit.Add(cashLaunch.BaseValue)
+		} else {
+			modelCashCategoryTotals[idx].TotalDebit = modelCashCategoryTotals[idx].TotalDebit.Add(cashLaunch.BaseValue)
+		}
+
+		modelCashCategoryTotals[idx].Value = modelCashCategoryTotals[idx].TotalCredit.Sub(modelCashCategoryTotals[idx].TotalDebit)
+	}
+
+	sort.Slice(modelCashCategoryTotals, func(i, j int) bool {
+		return modelCashCategoryTotals[i].CategoryID < modelCashCategoryTotals[j].CategoryID
+	})
+
+	return modelCashCategoryTotals, nil
+}
+
+func getCashCategoryByID(id int64) int {
+	for idx, cashCategory := range InMemoryCashCategories {
+		if cashCategory.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// cashCategoryNameExists mirrors the unique parent_id and name of the
+// cash_category table
+func cashCategoryNameExists(modelCashCategory *model.CashCategory) bool {
+	for _, cashCategory := range InMemoryCashCategories {
+		if cashCategory.ID != modelCashCategory.ID &&
+			cashCategory.ParentID == modelCashCategory.ParentID &&
+			cashCategory.Name == modelCashCategory.Name {
+			return true
+		}
+	}
+
+	return false
+}
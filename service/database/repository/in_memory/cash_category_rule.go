@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var cashCategoryRuleIDLast int64 = 1
+
+var InMemoryCashCategoryRules = model.CashCategoryRules{
+	{
+		ID:          1,
+		CategoryID:  2,
+		Pattern:     "ALUGUEL",
+		PatternType: "substring",
+		Type:        "D",
+		ValueFrom:   decimal.Zero,
+		ValueTo:     decimal.Zero,
+		Priority:    10,
+		UpdatedAt:   time.Now().UTC(),
+		CreatedAt:   time.Now().UTC(),
+	},
+}
+
+type InMemoryCashCategoryRule struct {
+	InMemory *InMemory
+}
+
+func NewCashCategoryRule(inMemory *InMemory) repository.CashCategoryRule {
+	return &InMemoryCashCategoryRule{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashCategoryRule *InMemoryCashCategoryRule) Insert(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	if repositoryInMemoryCashCategoryRule.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	modelCashCategoryRuleInsert := *modelCashCategoryRule
+	cashCategoryRuleIDLast += 1
+	modelCashCategoryRuleInsert.ID = cashCategoryRuleIDLast
+	InMemoryCashCategoryRules = append(InMemoryCashCategoryRules, modelCashCategoryRuleInsert)
+
+	return &modelCashCategoryRuleInsert, nil
+}
+
+func (repositoryInMemoryCashCategoryRule *InMemoryCashCategoryRule) List() (model.CashCategoryRules, error) {
+	if repositoryInMemoryCashCategoryRule.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashCategoryRules := append(model.CashCategoryRules{}, InMemoryCashCategoryRules...)
+
+	sort.Slice(modelCashCategoryRules, func(i, j int) bool {
+		if modelCashCategoryRules[i].Priority != modelCashCategoryRules[j].Priority {
+			return modelCashCategoryRules[i].Priority < modelCashCategoryRules[j].Priority
+		}
+
+		return modelCashCategoryRules[i].ID < modelCashCategoryRules[j].ID
+	})
+
+	return modelCashCategoryRules, nil
+}
+
+func (repositoryInMemoryCashCategoryRule *InMemoryCashCategoryRule) GetByID(id int64) (*model.CashCategoryRule, error) {
+	if repositoryInMemoryCashCategoryRule.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashCategoryRuleByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashCategoryRule := InMemoryCashCategoryRules[idx]
+
+	return &modelCashCategoryRule, nil
+}
+
+func (repositoryInMemoryCashCategoryRule *InMemoryCashCategoryRule) Update(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	if repositoryInMemoryCashCategoryRule.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashCategoryRuleByID(modelCashCategoryRule.ID)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashCategoryRule.CreatedAt = InMemoryCashCategoryRules[idx].CreatedAt
+	InMemoryCashCategoryRules[idx] = *modelCashCategoryRule
+
+	return &InMemoryCashCategoryRules[idx], nil
+}
+
+func (repositoryInMemoryCashCategoryRule *InMemoryCashCategoryRule) DeleteByID(id int64) error {
+	if repositoryInMemoryCashCategoryRule.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	idx := getCashCategoryRuleByID(id)
+
+	if idx < 0 {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	InMemoryCashCategoryRules = append(InMemoryCashCategoryRules[:idx], InMemoryCashCategoryRules[idx+1:]...)
+
+	return nil
+}
+
+func getCashCategoryRuleByID(id int64) int {
+	for idx, cashCategoryRule := range InMemoryCashCategoryRules {
+		if cashCategoryRule.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
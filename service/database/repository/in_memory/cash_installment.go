@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var cashInstallmentIDLast int64 = 0
+
+type InMemoryCashInstallment struct {
+	InMemory *InMemory
+}
+
+func NewCashInstallment(inMemory *InMemory) repository.CashInstallment {
+	return &InMemoryCashInstallment{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashInstallment *InMemoryCashInstallment) Insert(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	if repositoryInMemoryCashInstallment.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	cashInstallmentIDLast += 1
+	modelCashLaunchesInsert := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		cashLaunchIDLast += 1
+		modelCashLaunch.ID = cashLaunchIDLast
+		modelCashLaunch.InstallmentGroupID = cashInstallmentIDLast
+		InMemoryCashLaunches = append(InMemoryCashLaunches, modelCashLaunch)
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, modelCashLaunch, modelAudit)
+		modelCashLaunchesInsert = append(modelCashLaunchesInsert, modelCashLaunch)
+	}
+
+	return modelCashLaunchesInsert, nil
+}
+
+func (repositoryInMemoryCashInstallment *InMemoryCashInstallment) ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error) {
+	if repositoryInMemoryCashInstallment.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashLaunches := model.CashLaunches{}
+
+	for _, modelCashLaunch := range InMemoryCashLaunches {
+		// mirror the null installment_group_id of the launches without installments
+		if modelCashLaunch.InstallmentGroupID != 0 && modelCashLaunch.InstallmentGroupID == groupID &&
+			(includeDeleted || modelCashLaunch.DeletedAt == nil) {
+			modelCashLaunches = append(modelCashLaunches, modelCashLaunch)
+		}
+	}
+
+	sort.SliceStable(modelCashLaunches, func(i, j int) bool {
+		return modelCashLaunches[i].InstallmentNumber < modelCashLaunches[j].InstallmentNumber
+	})
+
+	return modelCashLaunches, nil
+}
+
+func (repositoryInMemoryCashInstallment *InMemoryCashInstallment) Update(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	if repositoryInMemoryCashInstallment.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	modelCashLaunchesUpdate := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		idx, modelCashLaunchCurrent := GetByID(modelCashLaunch.ID)
+
+		if idx < 0 {
+			return nil, repository.ErrNotFound{Message: "not found"}
+		}
+
+		modelCashLaunch.InstallmentGroupID = modelCashLaunchCurrent.InstallmentGroupID
+		modelCashLaunch.InstallmentNumber = modelCashLaunchCurrent.InstallmentNumber
+		modelCashLaunch.InstallmentCount = modelCashLaunchCurrent.InstallmentCount
+		modelCashLaunch.ReversalOfID = modelCashLaunchCurrent.ReversalOfID
+		modelCashLaunch.ReversedByID = modelCashLaunchCurrent.ReversedByID
+		modelCashLaunch.DeletedAt = modelCashLaunchCurrent.DeletedAt
+		modelCashLaunch.CreatedAt = modelCashLaunchCurrent.CreatedAt
+		InMemoryCashLaunches[idx] = modelCashLaunch
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionUpdate, modelCashLaunchCurrent, modelCashLaunch, modelAudit)
+		modelCashLaunchesUpdate = append(modelCashLaunchesUpdate, modelCashLaunch)
+	}
+
+	return modelCashLaunchesUpdate, nil
+}
+
+func (repositoryInMemoryCashInstallment *InMemoryCashInstallment) DeleteByGroupID(groupID int64, referenceDateFrom time.Time, modelAudit model.Audit) (model.CashLaunches, error) {
+	if repositoryInMemoryCashInstallment.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	modelCashLaunches := model.CashLaunches{}
+	deletedAt := time.Now().UTC()
+
+	for idx := range InMemoryCashLaunches {
+		modelCashLaunch := &InMemoryCashLaunches[idx]
+
+		if modelCashLaunch.InstallmentGroupID != 0 && modelCashLaunch.InstallmentGroupID == groupID && !modelCashLaunch.ReferenceDate.Before(referenceDateFrom) &&
+			modelCashLaunch.ReversedByID == 0 && modelCashLaunch.DeletedAt == nil {
+			modelCashLaunch.DeletedAt = &deletedAt
+			cashLaunchAuditDelete(*modelCashLaunch, modelAudit)
+			modelCashLaunches = append(modelCashLaunches, *modelCashLaunch)
+		}
+	}
+
+	if len(modelCashLaunches) == 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	return modelCashLaunches, nil
+}
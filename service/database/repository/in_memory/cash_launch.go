@@ -2,39 +2,60 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
 )
 
-var cashLaunchIDLast int64 = 2
+var cashLaunchIDLast int64 = 3
 
 var InMemoryCashLaunches = model.CashLaunches{
 	{
 		ID:            1,
+		AccountID:     1,
+		CategoryID:    2,
 		ReferenceDate: time.Date(2001, 11, 22, 00, 00, 00, 000, time.UTC),
 		Type:          "D",
 		Description:   "Description InMemory 1",
-		Value:         12.34,
+		Value:         decimal.RequireFromString("12.34"),
+		Currency:      "BRL",
+		ExchangeRate:  decimal.RequireFromString("1"),
+		BaseValue:     decimal.RequireFromString("12.34"),
+		Status:        repository.CashLaunchStatusApproved,
 		UpdatedAt:     time.Now().UTC(),
 		CreatedAt:     time.Now().UTC(),
 	},
 	{
 		ID:            2,
+		AccountID:     2,
 		ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
 		Type:          "C",
 		Description:   "Description InMemory 2",
-		Value:         987.65,
+		Value:         decimal.RequireFromString("987.65"),
+		Currency:      "BRL",
+		ExchangeRate:  decimal.RequireFromString("1"),
+		BaseValue:     decimal.RequireFromString("987.65"),
+		Status:        repository.CashLaunchStatusApproved,
 		UpdatedAt:     time.Now().UTC(),
 		CreatedAt:     time.Now().UTC(),
 	},
 	{
 		ID:            3,
+		AccountID:     1,
 		ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
 		Type:          "D",
 		Description:   "Description InMemory 1",
-		Value:         12.34,
+		Value:         decimal.RequireFromString("12.34"),
+		Currency:      "BRL",
+		ExchangeRate:  decimal.RequireFromString("1"),
+		BaseValue:     decimal.RequireFromString("12.34"),
+		Status:        repository.CashLaunchStatusApproved,
 		UpdatedAt:     time.Now().UTC(),
 		CreatedAt:     time.Now().UTC(),
 	},
@@ -50,25 +71,53 @@ func NewCashLaunch(inMemory *InMemory) repository.CashLaunch {
 	}
 }
 
-func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Insert(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
 	if repositoryInMemoryCashLaunch.InMemory.Error == true {
 		return nil, errors.New("Error persist in database")
 	}
 
+	if cashLaunchExternalIDExists(modelCashLaunch) {
+		return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (account_id, external_id)=(%d, %s) already exists.", modelCashLaunch.AccountID, modelCashLaunch.ExternalID)}
+	}
+
 	modelCashLaunchInsert := *modelCashLaunch
 	cashLaunchIDLast += 1
 	modelCashLaunchInsert.ID = cashLaunchIDLast
 	InMemoryCashLaunches = append(InMemoryCashLaunches, modelCashLaunchInsert)
+	cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, modelCashLaunchInsert, modelAudit)
 
 	return &modelCashLaunchInsert, nil
 }
 
-func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) List() (model.CashLaunches, error) {
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, error) {
 	if repositoryInMemoryCashLaunch.InMemory.Error == true {
 		return nil, errors.New("Error load from database")
 	}
 
-	return InMemoryCashLaunches, nil
+	modelCashLaunches := model.CashLaunches{}
+
+	for _, modelCashLaunch := range InMemoryCashLaunches {
+		if cashLaunchFilterMatch(&modelCashLaunch, modelCashLaunchFilter) {
+			modelCashLaunches = append(modelCashLaunches, modelCashLaunch)
+		}
+	}
+
+	sort.Slice(modelCashLaunches, func(i, j int) bool {
+		compare := cashLaunchSortCompare(&modelCashLaunches[i], modelCashLaunchFilter.Sort,
+			model.CashLaunchSortValue(&modelCashLaunches[j], modelCashLaunchFilter.Sort), modelCashLaunches[j].ID)
+
+		if modelCashLaunchFilter.Order == "desc" {
+			return compare > 0
+		}
+
+		return compare < 0
+	})
+
+	if modelCashLaunchFilter.Limit > 0 && len(modelCashLaunches) > modelCashLaunchFilter.Limit {
+		modelCashLaunches = modelCashLaunches[:modelCashLaunchFilter.Limit]
+	}
+
+	return modelCashLaunches, nil
 }
 
 func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) GetByID(id int64) (*model.CashLaunch, error) {
@@ -85,36 +134,182 @@ func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) GetByID(id int64) (*mode
 	return modelCashLaunch, nil
 }
 
-func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
 	if repositoryInMemoryCashLaunch.InMemory.Error == true {
 		return nil, errors.New("Error persist in database")
 	}
 
-	idx, _ := GetByID(modelCashLaunch.ID)
+	idx, modelCashLaunchCurrent := GetByID(modelCashLaunch.ID)
 
-	if idx < 0 {
+	if idx < 0 || modelCashLaunchCurrent.DeletedAt != nil {
 		return nil, repository.ErrNotFound{Message: "not found"}
 	}
 
+	modelCashLaunch.TransferID = InMemoryCashLaunches[idx].TransferID
+	modelCashLaunch.RecurrenceID = InMemoryCashLaunches[idx].RecurrenceID
+	modelCashLaunch.InstallmentGroupID = InMemoryCashLaunches[idx].InstallmentGroupID
+	modelCashLaunch.InstallmentNumber = InMemoryCashLaunches[idx].InstallmentNumber
+	modelCashLaunch.InstallmentCount = InMemoryCashLaunches[idx].InstallmentCount
+	modelCashLaunch.ReversalOfID = InMemoryCashLaunches[idx].ReversalOfID
+	modelCashLaunch.ReversedByID = InMemoryCashLaunches[idx].ReversedByID
+	modelCashLaunch.ExternalID = InMemoryCashLaunches[idx].ExternalID
+	modelCashLaunch.DeletedAt = InMemoryCashLaunches[idx].DeletedAt
 	InMemoryCashLaunches[idx] = *modelCashLaunch
+	cashLaunchAuditAppend(repository.CashLaunchAuditActionUpdate, modelCashLaunchCurrent, *modelCashLaunch, modelAudit)
+
+	// the other side of a transfer follows the date, description and values
+	if modelCashLaunch.TransferID != 0 {
+		for idxPair := range InMemoryCashLaunches {
+			modelCashLaunchPair := &InMemoryCashLaunches[idxPair]
+
+			if idxPair != idx && modelCashLaunchPair.TransferID == modelCashLaunch.TransferID {
+				modelCashLaunchPairCurrent := *modelCashLaunchPair
+				modelCashLaunchPair.ReferenceDate = modelCashLaunch.ReferenceDate
+				modelCashLaunchPair.Description = modelCashLaunch.Description
+				modelCashLaunchPair.Value = modelCashLaunch.Value
+				modelCashLaunchPair.Currency = modelCashLaunch.Currency
+				modelCashLaunchPair.ExchangeRate = modelCashLaunch.ExchangeRate
+				modelCashLaunchPair.BaseValue = modelCashLaunch.BaseValue
+				modelCashLaunchPair.UpdatedAt = modelCashLaunch.UpdatedAt
+				cashLaunchAuditAppend(repository.CashLaunchAuditActionUpdate, &modelCashLaunchPairCurrent, *modelCashLaunchPair, modelAudit)
+			}
+		}
+	}
 
 	return &InMemoryCashLaunches[idx], nil
 }
 
-func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) DeleteByID(id int64) error {
+// DeleteByID marks the launch as deleted, or both launches when it is one side
+// of a transfer, keeping it in memory
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) DeleteByID(id int64, modelAudit model.Audit) error {
 	if repositoryInMemoryCashLaunch.InMemory.Error == true {
 		return errors.New("Error persist in database")
 	}
 
-	idx, _ := GetByID(id)
+	idx, modelCashLaunch := GetByID(id)
 
-	if idx < 0 {
+	if idx < 0 || modelCashLaunch.DeletedAt != nil {
 		return repository.ErrNotFound{Message: "not found"}
 	}
 
+	deletedAt := time.Now().UTC()
+
+	for idxDelete := range InMemoryCashLaunches {
+		modelCashLaunchDelete := &InMemoryCashLaunches[idxDelete]
+
+		if modelCashLaunchDelete.DeletedAt == nil &&
+			(modelCashLaunchDelete.ID == id || (modelCashLaunch.TransferID != 0 && modelCashLaunchDelete.TransferID == modelCashLaunch.TransferID)) {
+			modelCashLaunchDelete.DeletedAt = &deletedAt
+			cashLaunchAuditDelete(*modelCashLaunchDelete, modelAudit)
+		}
+	}
+
 	return nil
 }
 
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Reverse(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	if repositoryInMemoryCashLaunch.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	var transferID int64
+
+	if modelCashLaunches[0].TransferID != 0 {
+		cashTransferIDLast += 1
+		transferID = cashTransferIDLast
+	}
+
+	modelCashLaunchesInsert := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		idx, modelCashLaunchReversed := GetByID(modelCashLaunch.ReversalOfID)
+
+		if idx < 0 || modelCashLaunchReversed.ReversedByID != 0 || modelCashLaunchReversed.DeletedAt != nil {
+			return nil, repository.ErrNotFound{Message: "not found"}
+		}
+
+		cashLaunchIDLast += 1
+		modelCashLaunch.ID = cashLaunchIDLast
+		modelCashLaunch.TransferID = transferID
+		InMemoryCashLaunches = append(InMemoryCashLaunches, modelCashLaunch)
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, modelCashLaunch, modelAudit)
+
+		InMemoryCashLaunches[idx].ReversedByID = modelCashLaunch.ID
+		InMemoryCashLaunches[idx].UpdatedAt = modelCashLaunch.CreatedAt
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionReversal, modelCashLaunchReversed, InMemoryCashLaunches[idx], modelAudit)
+
+		modelCashLaunchesInsert = append(modelCashLaunchesInsert, modelCashLaunch)
+	}
+
+	return modelCashLaunchesInsert, nil
+}
+
+// Import persists each launch when it is returned and removes them all on an
+// error, as the rollback of a transaction
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Import(next func() (*model.CashLaunch, error), modelAudit model.Audit) error {
+	if repositoryInMemoryCashLaunch.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	cashLaunchesLen, cashLaunchAuditsLen := len(InMemoryCashLaunches), len(InMemoryCashLaunchAudits)
+
+	for {
+		modelCashLaunch, err := next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			InMemoryCashLaunches = InMemoryCashLaunches[:cashLaunchesLen]
+			InMemoryCashLaunchAudits = InMemoryCashLaunchAudits[:cashLaunchAuditsLen]
+
+			return err
+		}
+
+		if cashLaunchExternalIDExists(modelCashLaunch) {
+			modelCashLaunch.ID = 0
+			continue
+		}
+
+		cashLaunchIDLast += 1
+		modelCashLaunch.ID = cashLaunchIDLast
+		InMemoryCashLaunches = append(InMemoryCashLaunches, *modelCashLaunch)
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, *modelCashLaunch, modelAudit)
+	}
+}
+
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) Review(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	if repositoryInMemoryCashLaunch.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx, modelCashLaunchCurrent := GetByID(modelCashLaunch.ID)
+
+	if idx < 0 || modelCashLaunchCurrent.Status != repository.CashLaunchStatusPending || modelCashLaunchCurrent.DeletedAt != nil {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashLaunchReview := &InMemoryCashLaunches[idx]
+	modelCashLaunchReview.Status = modelCashLaunch.Status
+	modelCashLaunchReview.ReviewComment = modelCashLaunch.ReviewComment
+	modelCashLaunchReview.ReviewedBy = modelCashLaunch.ReviewedBy
+	modelCashLaunchReview.ReviewedAt = modelCashLaunch.ReviewedAt
+	modelCashLaunchReview.UpdatedAt = *modelCashLaunch.ReviewedAt
+
+	action := repository.CashLaunchAuditActionApproval
+
+	if modelCashLaunchReview.Status == repository.CashLaunchStatusRejected {
+		action = repository.CashLaunchAuditActionRejection
+	}
+
+	cashLaunchAuditAppend(action, modelCashLaunchCurrent, *modelCashLaunchReview, modelAudit)
+
+	modelCashLaunchCopy := *modelCashLaunchReview
+
+	return &modelCashLaunchCopy, nil
+}
+
 func GetByID(id int64) (int, *model.CashLaunch) {
 	for idx, cashLaunch := range InMemoryCashLaunches {
 		if cashLaunch.ID == id {
@@ -124,3 +319,108 @@ func GetByID(id int64) (int, *model.CashLaunch) {
 
 	return -1, nil
 }
+
+// cashLaunchExternalIDExists checks the unique external_id of the account
+func cashLaunchExternalIDExists(modelCashLaunch *model.CashLaunch) bool {
+	if modelCashLaunch.ExternalID == "" {
+		return false
+	}
+
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if cashLaunch.AccountID == modelCashLaunch.AccountID && cashLaunch.ExternalID == modelCashLaunch.ExternalID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cashLaunchFilterMatch(modelCashLaunch *model.CashLaunch, modelCashLaunchFilter *model.CashLaunchFilter) bool {
+	if modelCashLaunchFilter.AccountID != 0 && modelCashLaunch.AccountID != modelCashLaunchFilter.AccountID {
+		return false
+	}
+
+	if modelCashLaunchFilter.CategoryID != 0 && modelCashLaunch.CategoryID != modelCashLaunchFilter.CategoryID {
+		return false
+	}
+
+	if modelCashLaunchFilter.TransferID != 0 && modelCashLaunch.TransferID != modelCashLaunchFilter.TransferID {
+		return false
+	}
+
+	if !modelCashLaunchFilter.ReferenceDateFrom.IsZero() && modelCashLaunch.ReferenceDate.Before(modelCashLaunchFilter.ReferenceDateFrom) {
+		return false
+	}
+
+	if !modelCashLaunchFilter.ReferenceDateTo.IsZero() && modelCashLaunch.ReferenceDate.After(modelCashLaunchFilter.ReferenceDateTo) {
+		return false
+	}
+
+	if modelCashLaunchFilter.Type != "" && modelCashLaunch.Type != modelCashLaunchFilter.Type {
+		return false
+	}
+
+	if modelCashLaunchFilter.ValueFrom.Valid && modelCashLaunch.Value.LessThan(modelCashLaunchFilter.ValueFrom.Decimal) {
+		return false
+	}
+
+	if modelCashLaunchFilter.ValueTo.Valid && modelCashLaunch.Value.GreaterThan(modelCashLaunchFilter.ValueTo.Decimal) {
+		return false
+	}
+
+	if modelCashLaunchFilter.Description != "" &&
+		!strings.Contains(strings.ToUpper(modelCashLaunch.Description), strings.ToUpper(modelCashLaunchFilter.Description)) {
+		return false
+	}
+
+	if modelCashLaunchFilter.Status != "" && modelCashLaunch.Status != modelCashLaunchFilter.Status {
+		return false
+	}
+
+	if modelCashLaunchFilter.ExternalID != "" && modelCashLaunch.ExternalID != modelCashLaunchFilter.ExternalID {
+		return false
+	}
+
+	if !modelCashLaunchFilter.IncludeDeleted && modelCashLaunch.DeletedAt != nil {
+		return false
+	}
+
+	// keyset pagination: only the launches after the last one of the previous page
+	if modelCashLaunchFilter.Cursor != nil {
+		compare := cashLaunchSortCompare(modelCashLaunch, modelCashLaunchFilter.Sort, modelCashLaunchFilter.Cursor.Value, modelCashLaunchFilter.Cursor.ID)
+
+		if modelCashLaunchFilter.Order == "desc" {
+			return compare < 0
+		}
+
+		return compare > 0
+	}
+
+	return true
+}
+
+// cashLaunchSortCompare compares the launch with the sort value and id of
+// another launch, returning -1, 0 or 1 in ascending order
+func cashLaunchSortCompare(modelCashLaunch *model.CashLaunch, sort string, value string, id int64) int {
+	compare := 0
+
+	switch sort {
+	case "value":
+		compare = modelCashLaunch.Value.Cmp(decimal.RequireFromString(value))
+	case "id":
+	default:
+		compare = strings.Compare(model.CashLaunchSortValue(modelCashLaunch, sort), value)
+	}
+
+	if compare != 0 {
+		return compare
+	}
+
+	if modelCashLaunch.ID < id {
+		return -1
+	} else if modelCashLaunch.ID > id {
+		return 1
+	}
+
+	return 0
+}
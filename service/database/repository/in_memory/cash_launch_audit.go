@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var cashLaunchAuditIDLast int64 = 0
+
+var InMemoryCashLaunchAudits = model.CashLaunchAudits{}
+
+func (repositoryInMemoryCashLaunch *InMemoryCashLaunch) ListAuditByID(id int64) (model.CashLaunchAudits, error) {
+	if repositoryInMemoryCashLaunch.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashLaunchAudits := model.CashLaunchAudits{}
+
+	for _, modelCashLaunchAudit := range InMemoryCashLaunchAudits {
+		if modelCashLaunchAudit.LaunchID == id {
+			modelCashLaunchAudits = append(modelCashLaunchAudits, modelCashLaunchAudit)
+		}
+	}
+
+	return modelCashLaunchAudits, nil
+}
+
+// cashLaunchAuditAppend appends the change of the launch to its history keeping
+// copies of the launch before and after it
+func cashLaunchAuditAppend(action string, modelCashLaunchBefore *model.CashLaunch, modelCashLaunchAfter model.CashLaunch, modelAudit model.Audit) {
+	var before *model.CashLaunch
+
+	if modelCashLaunchBefore != nil {
+		modelCashLaunchBeforeCopy := *modelCashLaunchBefore
+		before = &modelCashLaunchBeforeCopy
+	}
+
+	cashLaunchAuditIDLast += 1
+
+	InMemoryCashLaunchAudits = append(InMemoryCashLaunchAudits, model.CashLaunchAudit{
+		ID:        cashLaunchAuditIDLast,
+		LaunchID:  modelCashLaunchAfter.ID,
+		Action:    action,
+		Before:    before,
+		After:     &modelCashLaunchAfter,
+		Actor:     modelAudit.Actor,
+		RequestID: modelAudit.RequestID,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// cashLaunchAuditDelete appends the deletion of the launch to its history, the
+// launch before it is the deleted one without the deleted_at
+func cashLaunchAuditDelete(modelCashLaunch model.CashLaunch, modelAudit model.Audit) {
+	modelCashLaunchBefore := modelCashLaunch
+	modelCashLaunchBefore.DeletedAt = nil
+
+	cashLaunchAuditAppend(repository.CashLaunchAuditActionDelete, &modelCashLaunchBefore, modelCashLaunch, modelAudit)
+}
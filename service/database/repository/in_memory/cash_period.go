@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var InMemoryCashPeriods = model.CashPeriods{}
+
+type InMemoryCashPeriod struct {
+	InMemory *InMemory
+}
+
+func NewCashPeriod(inMemory *InMemory) repository.CashPeriod {
+	return &InMemoryCashPeriod{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashPeriod *InMemoryCashPeriod) List(from time.Time, to time.Time) (model.CashPeriods, error) {
+	if repositoryInMemoryCashPeriod.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashPeriods := model.CashPeriods{}
+
+	for _, modelCashPeriod := range InMemoryCashPeriods {
+		if !modelCashPeriod.Period.Before(from) && !modelCashPeriod.Period.After(to) {
+			modelCashPeriods = append(modelCashPeriods, modelCashPeriod)
+		}
+	}
+
+	sort.Slice(modelCashPeriods, func(i, j int) bool {
+		return modelCashPeriods[i].Period.Before(modelCashPeriods[j].Period)
+	})
+
+	return modelCashPeriods, nil
+}
+
+func (repositoryInMemoryCashPeriod *InMemoryCashPeriod) GetByPeriod(period time.Time) (*model.CashPeriod, error) {
+	if repositoryInMemoryCashPeriod.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashPeriodByPeriod(period)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashPeriod := InMemoryCashPeriods[idx]
+
+	return &modelCashPeriod, nil
+}
+
+func (repositoryInMemoryCashPeriod *InMemoryCashPeriod) Close(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error) {
+	if repositoryInMemoryCashPeriod.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashPeriodByPeriod(modelCashPeriod.Period)
+
+	if idx < 0 {
+		InMemoryCashPeriods = append(InMemoryCashPeriods, model.CashPeriod{Period: modelCashPeriod.Period})
+		idx = len(InMemoryCashPeriods) - 1
+	} else if InMemoryCashPeriods[idx].Status == "closed" {
+		return nil, repository.ErrDuplicateKey{Message: "period already closed"}
+	}
+
+	modelCashPeriodClose := &InMemoryCashPeriods[idx]
+	modelCashPeriodClose.Status = "closed"
+	modelCashPeriodClose.ClosingBalance = getCashBalanceDaily(modelCashPeriod.Period.AddDate(0, 1, -1), 0, false, false).ClosingBalance
+	modelCashPeriodClose.ClosedBy = modelCashPeriod.ClosedBy
+	modelCashPeriodClose.ClosedAt = modelCashPeriod.ClosedAt
+	modelCashPeriodClose.UpdatedAt = modelCashPeriod.ClosedAt
+
+	modelCashPeriodCopy := *modelCashPeriodClose
+
+	return &modelCashPeriodCopy, nil
+}
+
+func (repositoryInMemoryCashPeriod *InMemoryCashPeriod) Reopen(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error) {
+	if repositoryInMemoryCashPeriod.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashPeriodByPeriod(modelCashPeriod.Period)
+
+	if idx < 0 || InMemoryCashPeriods[idx].Status != "closed" {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashPeriodReopen := &InMemoryCashPeriods[idx]
+	modelCashPeriodReopen.Status = "open"
+	modelCashPeriodReopen.ReopenedBy = modelCashPeriod.ReopenedBy
+	modelCashPeriodReopen.ReopenedAt = modelCashPeriod.ReopenedAt
+	modelCashPeriodReopen.UpdatedAt = modelCashPeriod.ReopenedAt
+
+	modelCashPeriodCopy := *modelCashPeriodReopen
+
+	return &modelCashPeriodCopy, nil
+}
+
+func getCashPeriodByPeriod(period time.Time) int {
+	for idx, modelCashPeriod := range InMemoryCashPeriods {
+		if modelCashPeriod.Period.Equal(period) {
+			return idx
+		}
+	}
+
+	return -1
+}
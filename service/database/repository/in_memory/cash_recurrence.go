@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var cashRecurrenceIDLast int64 = 0
+
+var InMemoryCashRecurrences = model.CashRecurrences{}
+
+type InMemoryCashRecurrence struct {
+	InMemory *InMemory
+}
+
+func NewCashRecurrence(inMemory *InMemory) repository.CashRecurrence {
+	return &InMemoryCashRecurrence{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	modelCashRecurrenceInsert := *modelCashRecurrence
+	cashRecurrenceIDLast += 1
+	modelCashRecurrenceInsert.ID = cashRecurrenceIDLast
+	InMemoryCashRecurrences = append(InMemoryCashRecurrences, modelCashRecurrenceInsert)
+
+	return &modelCashRecurrenceInsert, nil
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) List() (model.CashRecurrences, error) {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	return append(model.CashRecurrences{}, InMemoryCashRecurrences...), nil
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) GetByID(id int64) (*model.CashRecurrence, error) {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashRecurrenceByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashRecurrence := InMemoryCashRecurrences[idx]
+
+	return &modelCashRecurrence, nil
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashRecurrenceByID(modelCashRecurrence.ID)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashRecurrence.LastDate = InMemoryCashRecurrences[idx].LastDate
+	modelCashRecurrence.CreatedAt = InMemoryCashRecurrences[idx].CreatedAt
+	InMemoryCashRecurrences[idx] = *modelCashRecurrence
+
+	return &InMemoryCashRecurrences[idx], nil
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) DeleteByID(id int64) error {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	idx := getCashRecurrenceByID(id)
+
+	if idx < 0 {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	// mirror the on delete set null of the cash_launch foreign key
+	for idxLaunch := range InMemoryCashLaunches {
+		if InMemoryCashLaunches[idxLaunch].RecurrenceID == id {
+			InMemoryCashLaunches[idxLaunch].RecurrenceID = 0
+		}
+	}
+
+	InMemoryCashRecurrences = append(InMemoryCashRecurrences[:idx], InMemoryCashRecurrences[idx+1:]...)
+
+	return nil
+}
+
+func (repositoryInMemoryCashRecurrence *InMemoryCashRecurrence) LaunchInsert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	if repositoryInMemoryCashRecurrence.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	// mirror the unique recurrence_id and reference_date of the cash_launch table
+	for _, cashLaunch := range InMemoryCashLaunches {
+		if cashLaunch.RecurrenceID == modelCashLaunch.RecurrenceID && cashLaunch.ReferenceDate.Equal(modelCashLaunch.ReferenceDate) {
+			return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (recurrence_id, reference_date)=(%d, %s) already exists.", modelCashLaunch.RecurrenceID, modelCashLaunch.ReferenceDate.Format("2006-01-02"))}
+		}
+	}
+
+	modelCashLaunchInsert := *modelCashLaunch
+	cashLaunchIDLast += 1
+	modelCashLaunchInsert.ID = cashLaunchIDLast
+	InMemoryCashLaunches = append(InMemoryCashLaunches, modelCashLaunchInsert)
+	cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, modelCashLaunchInsert, modelAudit)
+
+	if idx := getCashRecurrenceByID(modelCashLaunch.RecurrenceID); idx >= 0 && InMemoryCashRecurrences[idx].LastDate.Before(modelCashLaunch.ReferenceDate) {
+		InMemoryCashRecurrences[idx].LastDate = modelCashLaunch.ReferenceDate
+	}
+
+	return &modelCashLaunchInsert, nil
+}
+
+func getCashRecurrenceByID(id int64) int {
+	for idx, cashRecurrence := range InMemoryCashRecurrences {
+		if cashRecurrence.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
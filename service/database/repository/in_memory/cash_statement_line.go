@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var InMemoryCashStatementLines = model.CashStatementLines{}
+
+type InMemoryCashStatementLine struct {
+	InMemory *InMemory
+}
+
+func NewCashStatementLine(inMemory *InMemory) repository.CashStatementLine {
+	return &InMemoryCashStatementLine{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashStatementLine *InMemoryCashStatementLine) Insert(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error) {
+	if repositoryInMemoryCashStatementLine.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	if modelCashStatementLine.ExternalID != "" {
+		for _, modelCashStatementLineStored := range InMemoryCashStatementLines {
+			if modelCashStatementLineStored.AccountID == modelCashStatementLine.AccountID && modelCashStatementLineStored.ExternalID == modelCashStatementLine.ExternalID {
+				return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (account_id, external_id)=(%d, %s) already exists.", modelCashStatementLine.AccountID, modelCashStatementLine.ExternalID)}
+			}
+		}
+	}
+
+	modelCashStatementLineInsert := *modelCashStatementLine
+	modelCashStatementLineInsert.ID = int64(len(InMemoryCashStatementLines) + 1)
+	modelCashStatementLineInsert.LaunchID = 0
+	modelCashStatementLineInsert.MatchType = ""
+	modelCashStatementLineInsert.MatchedBy = ""
+	modelCashStatementLineInsert.MatchedAt = nil
+	modelCashStatementLineInsert.CreatedAt = time.Now().UTC()
+
+	InMemoryCashStatementLines = append(InMemoryCashStatementLines, modelCashStatementLineInsert)
+
+	return &modelCashStatementLineInsert, nil
+}
+
+func (repositoryInMemoryCashStatementLine *InMemoryCashStatementLine) List(modelCashStatementLineFilter *model.CashStatementLineFilter) (model.CashStatementLines, error) {
+	if repositoryInMemoryCashStatementLine.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	modelCashStatementLines := model.CashStatementLines{}
+
+	for _, modelCashStatementLine := range InMemoryCashStatementLines {
+		if modelCashStatementLineFilter.AccountID != 0 && modelCashStatementLine.AccountID != modelCashStatementLineFilter.AccountID {
+			continue
+		}
+
+		if !modelCashStatementLineFilter.ReferenceDateFrom.IsZero() && modelCashStatementLine.ReferenceDate.Before(modelCashStatementLineFilter.ReferenceDateFrom) {
+			continue
+		}
+
+		if !modelCashStatementLineFilter.ReferenceDateTo.IsZero() && modelCashStatementLine.ReferenceDate.After(modelCashStatementLineFilter.ReferenceDateTo) {
+			continue
+		}
+
+		if modelCashStatementLineFilter.Unmatched && modelCashStatementLine.LaunchID != 0 {
+			continue
+		}
+
+		if modelCashStatementLineFilter.Matched && modelCashStatementLine.LaunchID == 0 {
+			continue
+		}
+
+		modelCashStatementLines = append(modelCashStatementLines, modelCashStatementLine)
+	}
+
+	sort.SliceStable(modelCashStatementLines, func(i, j int) bool {
+		if !modelCashStatementLines[i].ReferenceDate.Equal(modelCashStatementLines[j].ReferenceDate) {
+			return modelCashStatementLines[i].ReferenceDate.Before(modelCashStatementLines[j].ReferenceDate)
+		}
+
+		return modelCashStatementLines[i].ID < modelCashStatementLines[j].ID
+	})
+
+	return modelCashStatementLines, nil
+}
+
+func (repositoryInMemoryCashStatementLine *InMemoryCashStatementLine) GetByID(id int64) (*model.CashStatementLine, error) {
+	if repositoryInMemoryCashStatementLine.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	idx := getCashStatementLineByID(id)
+
+	if idx < 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashStatementLine := InMemoryCashStatementLines[idx]
+
+	return &modelCashStatementLine, nil
+}
+
+func (repositoryInMemoryCashStatementLine *InMemoryCashStatementLine) Match(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error) {
+	if repositoryInMemoryCashStatementLine.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashStatementLineByID(modelCashStatementLine.ID)
+
+	if idx < 0 || InMemoryCashStatementLines[idx].LaunchID != 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	for _, modelCashStatementLineStored := range InMemoryCashStatementLines {
+		if modelCashStatementLineStored.LaunchID == modelCashStatementLine.LaunchID {
+			return nil, repository.ErrDuplicateKey{Message: fmt.Sprintf("Key (launch_id)=(%d) already exists.", modelCashStatementLine.LaunchID)}
+		}
+	}
+
+	modelCashStatementLineMatch := &InMemoryCashStatementLines[idx]
+	modelCashStatementLineMatch.LaunchID = modelCashStatementLine.LaunchID
+	modelCashStatementLineMatch.MatchType = modelCashStatementLine.MatchType
+	modelCashStatementLineMatch.MatchedBy = modelCashStatementLine.MatchedBy
+	modelCashStatementLineMatch.MatchedAt = modelCashStatementLine.MatchedAt
+
+	modelCashStatementLineCopy := *modelCashStatementLineMatch
+
+	return &modelCashStatementLineCopy, nil
+}
+
+func (repositoryInMemoryCashStatementLine *InMemoryCashStatementLine) Unmatch(id int64) (*model.CashStatementLine, error) {
+	if repositoryInMemoryCashStatementLine.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	idx := getCashStatementLineByID(id)
+
+	if idx < 0 || InMemoryCashStatementLines[idx].LaunchID == 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	modelCashStatementLineUnmatch := &InMemoryCashStatementLines[idx]
+	modelCashStatementLineUnmatch.LaunchID = 0
+	modelCashStatementLineUnmatch.MatchType = ""
+	modelCashStatementLineUnmatch.MatchedBy = ""
+	modelCashStatementLineUnmatch.MatchedAt = nil
+
+	modelCashStatementLineCopy := *modelCashStatementLineUnmatch
+
+	return &modelCashStatementLineCopy, nil
+}
+
+func getCashStatementLineByID(id int64) int {
+	for idx, modelCashStatementLine := range InMemoryCashStatementLines {
+		if modelCashStatementLine.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
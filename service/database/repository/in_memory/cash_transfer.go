@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var cashTransferIDLast int64 = 0
+
+type InMemoryCashTransfer struct {
+	InMemory *InMemory
+}
+
+func NewCashTransfer(inMemory *InMemory) repository.CashTransfer {
+	return &InMemoryCashTransfer{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryCashTransfer *InMemoryCashTransfer) Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error) {
+	if repositoryInMemoryCashTransfer.InMemory.Error == true {
+		return nil, errors.New("Error persist in database")
+	}
+
+	modelCashTransferInsert := *modelCashTransfer
+	cashTransferIDLast += 1
+	modelCashTransferInsert.ID = cashTransferIDLast
+
+	for _, modelCashLaunch := range []*model.CashLaunch{&modelCashTransferInsert.Debit, &modelCashTransferInsert.Credit} {
+		cashLaunchIDLast += 1
+		modelCashLaunch.ID = cashLaunchIDLast
+		modelCashLaunch.TransferID = modelCashTransferInsert.ID
+		InMemoryCashLaunches = append(InMemoryCashLaunches, *modelCashLaunch)
+		cashLaunchAuditAppend(repository.CashLaunchAuditActionInsert, nil, *modelCashLaunch, modelAudit)
+	}
+
+	return &modelCashTransferInsert, nil
+}
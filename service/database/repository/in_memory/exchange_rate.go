@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var InMemoryExchangeRates = model.ExchangeRates{
+	{
+		ReferenceDate: time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+		BaseCurrency:  "EUR",
+		Currency:      "USD",
+		Rate:          decimal.RequireFromString("0.8489"),
+		UpdatedAt:     time.Now().UTC(),
+		CreatedAt:     time.Now().UTC(),
+	},
+	{
+		ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+		BaseCurrency:  "EUR",
+		Currency:      "BRL",
+		Rate:          decimal.RequireFromString("1.6810"),
+		UpdatedAt:     time.Now().UTC(),
+		CreatedAt:     time.Now().UTC(),
+	},
+	{
+		ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+		BaseCurrency:  "EUR",
+		Currency:      "USD",
+		Rate:          decimal.RequireFromString("0.8500"),
+		UpdatedAt:     time.Now().UTC(),
+		CreatedAt:     time.Now().UTC(),
+	},
+}
+
+type InMemoryExchangeRate struct {
+	InMemory *InMemory
+}
+
+func NewExchangeRate(inMemory *InMemory) repository.ExchangeRate {
+	return &InMemoryExchangeRate{
+		InMemory: inMemory,
+	}
+}
+
+func (repositoryInMemoryExchangeRate *InMemoryExchangeRate) Upsert(modelExchangeRates model.ExchangeRates) error {
+	if repositoryInMemoryExchangeRate.InMemory.Error == true {
+		return errors.New("Error persist in database")
+	}
+
+	for _, modelExchangeRate := range modelExchangeRates {
+		idx := getExchangeRateByKey(modelExchangeRate.ReferenceDate, modelExchangeRate.BaseCurrency, modelExchangeRate.Currency)
+
+		if idx < 0 {
+			InMemoryExchangeRates = append(InMemoryExchangeRates, modelExchangeRate)
+		} else {
+			InMemoryExchangeRates[idx].Rate = modelExchangeRate.Rate
+			InMemoryExchangeRates[idx].UpdatedAt = modelExchangeRate.UpdatedAt
+		}
+	}
+
+	return nil
+}
+
+func (repositoryInMemoryExchangeRate *InMemoryExchangeRate) GetByReferenceDate(referenceDate time.Time) (model.ExchangeRates, error) {
+	if repositoryInMemoryExchangeRate.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	// last published date on or before the reference date
+	referenceDateLast := time.Time{}
+
+	for _, exchangeRate := range InMemoryExchangeRates {
+		if !exchangeRate.ReferenceDate.After(referenceDate) && exchangeRate.ReferenceDate.After(referenceDateLast) {
+			referenceDateLast = exchangeRate.ReferenceDate
+		}
+	}
+
+	exchangeRates := model.ExchangeRates{}
+
+	for _, exchangeRate := range InMemoryExchangeRates {
+		if exchangeRate.ReferenceDate.Equal(referenceDateLast) {
+			exchangeRates = append(exchangeRates, exchangeRate)
+		}
+	}
+
+	if len(exchangeRates) == 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	return exchangeRates, nil
+}
+
+func (repositoryInMemoryExchangeRate *InMemoryExchangeRate) GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams *model.ExchangeRateRangeReferenceDate) (model.ExchangeRates, error) {
+	if repositoryInMemoryExchangeRate.InMemory.Error == true {
+		return nil, errors.New("Error load from database")
+	}
+
+	exchangeRates := model.ExchangeRates{}
+
+	for _, exchangeRate := range InMemoryExchangeRates {
+		if !exchangeRate.ReferenceDate.Before(exchangeRateRangeReferenceDateParams.From) &&
+			!exchangeRate.ReferenceDate.After(exchangeRateRangeReferenceDateParams.To) {
+			exchangeRates = append(exchangeRates, exchangeRate)
+		}
+	}
+
+	return exchangeRates, nil
+}
+
+func getExchangeRateByKey(referenceDate time.Time, baseCurrency, currency string) int {
+	for idx, exchangeRate := range InMemoryExchangeRates {
+		if exchangeRate.ReferenceDate.Equal(referenceDate) &&
+			exchangeRate.BaseCurrency == baseCurrency &&
+			exchangeRate.Currency == currency {
+			return idx
+		}
+	}
+
+	return -1
+}
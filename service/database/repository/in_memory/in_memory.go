@@ -20,10 +20,50 @@ func (inMemory *InMemory) Close() error {
 	return nil
 }
 
+func (inMemory *InMemory) CashAccount() repository.CashAccount {
+	return NewCashAccount(inMemory)
+}
+
+func (inMemory *InMemory) CashCategory() repository.CashCategory {
+	return NewCashCategory(inMemory)
+}
+
+func (inMemory *InMemory) CashCategoryRule() repository.CashCategoryRule {
+	return NewCashCategoryRule(inMemory)
+}
+
+func (inMemory *InMemory) CashBudget() repository.CashBudget {
+	return NewCashBudget(inMemory)
+}
+
 func (inMemory *InMemory) CashLaunch() repository.CashLaunch {
 	return NewCashLaunch(inMemory)
 }
 
+func (inMemory *InMemory) CashTransfer() repository.CashTransfer {
+	return NewCashTransfer(inMemory)
+}
+
+func (inMemory *InMemory) CashInstallment() repository.CashInstallment {
+	return NewCashInstallment(inMemory)
+}
+
+func (inMemory *InMemory) CashRecurrence() repository.CashRecurrence {
+	return NewCashRecurrence(inMemory)
+}
+
 func (inMemory *InMemory) CashBalanceDaily() repository.CashBalanceDaily {
 	return NewCashBalanceDaily(inMemory)
 }
+
+func (inMemory *InMemory) CashPeriod() repository.CashPeriod {
+	return NewCashPeriod(inMemory)
+}
+
+func (inMemory *InMemory) CashStatementLine() repository.CashStatementLine {
+	return NewCashStatementLine(inMemory)
+}
+
+func (inMemory *InMemory) ExchangeRate() repository.ExchangeRate {
+	return NewExchangeRate(inMemory)
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresCashAccount struct {
+	Postgres *Postgres
+}
+
+func NewCashAccount(postgres *Postgres) repository.CashAccount {
+	return &PostgresCashAccount{Postgres: postgres}
+}
+
+func (postgresCashAccount *PostgresCashAccount) Insert(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	query :=
+		`INSERT INTO 
+			cash_account
+			(name, updated_at, created_at)
+		VALUES
+			($1, $2, $3)
+		RETURNING
+			id, name, updated_at, created_at;`
+
+	row := postgresCashAccount.Postgres.Conn.QueryRow(
+		query,
+		modelCashAccount.Name,
+		modelCashAccount.UpdatedAt,
+		modelCashAccount.CreatedAt,
+	)
+
+	modelCashAccountInsert := &model.CashAccount{}
+
+	err := row.Scan(
+		&modelCashAccountInsert.ID,
+		&modelCashAccountInsert.Name,
+		&modelCashAccountInsert.UpdatedAt,
+		&modelCashAccountInsert.CreatedAt,
+	)
+
+	return modelCashAccountInsert, postgresError(err)
+}
+
+func (postgresCashAccount *PostgresCashAccount) List() (model.CashAccounts, error) {
+	query :=
+		`SELECT
+			id, name, updated_at, created_at
+		FROM
+			cash_account
+		ORDER BY
+			name, id`
+
+	rows, err := postgresCashAccount.Postgres.Conn.Query(query)
+
+	modelCashAccounts := model.CashAccounts{}
+
+	if err != nil {
+		return modelCashAccounts, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashAccount := model.CashAccount{}
+
+		err = rows.Scan(
+			&modelCashAccount.ID,
+			&modelCashAccount.Name,
+			&modelCashAccount.UpdatedAt,
+			&modelCashAccount.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashAccounts = append(modelCashAccounts, modelCashAccount)
+	}
+
+	return modelCashAccounts, err
+}
+
+func (postgresCashAccount *PostgresCashAccount) GetByID(id int64) (*model.CashAccount, error) {
+	query :=
+		`SELECT
+			id, name, updated_at, created_at
+		FROM
+			cash_account
+		WHERE
+			id = $1`
+
+	row := postgresCashAccount.Postgres.Conn.QueryRow(query, id)
+
+	modelCashAccount := model.CashAccount{}
+
+	err := row.Scan(
+		&modelCashAccount.ID,
+		&modelCashAccount.Name,
+		&modelCashAccount.UpdatedAt,
+		&modelCashAccount.CreatedAt,
+	)
+
+	return &modelCashAccount, postgresError(err)
+}
+
+func (postgresCashAccount *PostgresCashAccount) Update(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	query :=
+		`UPDATE
+		cash_account
+	SET
+		name = $2,
+		updated_at = $3
+	WHERE
+		id = $1
+	RETURNING
+		id, name, updated_at, created_at;`
+
+	row := postgresCashAccount.Postgres.Conn.QueryRow(
+		query,
+		modelCashAccount.ID,
+		modelCashAccount.Name,
+		modelCashAccount.UpdatedAt,
+	)
+
+	modelCashAccountUpdate := &model.CashAccount{}
+
+	err := row.Scan(
+		&modelCashAccountUpdate.ID,
+		&modelCashAccountUpdate.Name,
+		&modelCashAccountUpdate.UpdatedAt,
+		&modelCashAccountUpdate.CreatedAt,
+	)
+
+	return modelCashAccountUpdate, postgresError(err)
+}
+
+func (postgresCashAccount *PostgresCashAccount) DeleteByID(id int64) error {
+	query :=
+		`DELETE FROM
+		cash_account
+	WHERE
+		id = $1
+	RETURNING id`
+
+	err := postgresCashAccount.Postgres.Conn.QueryRow(query, id).Scan(&id)
+
+	return postgresError(err)
+}
@@ -1,13 +1,57 @@
 package repository
 
 import (
+	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
-	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
 )
 
+// cashBalanceDailyRebuiltQuery recomputes the daily totals and the running
+// closing balance of every day in the base currency straight from the
+// cash_launch table, for each account and for all accounts combined on
+// account_id 0, where the transfers between accounts are left out. The
+// launches are filtered by the condition formatted by cashBalanceDailyRebuilt.
+const cashBalanceDailyRebuiltQuery = `
+	SELECT
+		account_id,
+		reference_date,
+		total_credit,
+		total_debit,
+		SUM(total_credit - total_debit) OVER (PARTITION BY account_id ORDER BY reference_date) AS closing_balance,
+		launch_count
+	FROM (
+		SELECT
+			account_id,
+			reference_date,
+			SUM(CASE WHEN type = 'C' THEN base_value ELSE 0 END) AS total_credit,
+			SUM(CASE WHEN type = 'D' THEN base_value ELSE 0 END) AS total_debit,
+			COUNT(*) AS launch_count
+		FROM
+			cash_launch
+		WHERE
+			%[1]s
+		GROUP BY
+			account_id, reference_date
+		UNION ALL
+		SELECT
+			0 AS account_id,
+			reference_date,
+			SUM(CASE WHEN type = 'C' THEN base_value ELSE 0 END) AS total_credit,
+			SUM(CASE WHEN type = 'D' THEN base_value ELSE 0 END) AS total_debit,
+			COUNT(*) AS launch_count
+		FROM
+			cash_launch
+		WHERE
+			transfer_id IS NULL AND
+			%[1]s
+		GROUP BY
+			reference_date
+	) AS cash_launch_daily `
+
 type PostgresCashBalanceDaily struct {
 	Postgres *Postgres
 }
@@ -16,25 +60,63 @@ func NewCashBalanceDaily(postgres *Postgres) repository.CashBalanceDaily {
 	return &PostgresCashBalanceDaily{Postgres: postgres}
 }
 
-func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetByReferenceDate(referenceDate time.Time) (*model.CashBalanceDaily, error) {
+// cashBalanceDailyRebuilt returns the cashBalanceDailyRebuiltQuery of the
+// approved launches not deleted, the deleted launches are included when
+// includeDeleted and the pending launches when projected
+func cashBalanceDailyRebuilt(includeDeleted bool, projected bool) string {
+	condition := "deleted_at IS NULL"
+
+	if includeDeleted {
+		condition = "TRUE"
+	}
+
+	if projected {
+		condition += " AND status IN ('approved', 'pending')"
+	} else {
+		condition += " AND status = 'approved'"
+	}
+
+	return fmt.Sprintf(cashBalanceDailyRebuiltQuery, condition)
+}
+
+// cashBalanceDailyWith returns the query reading the cash_balance_daily table,
+// which only keeps the approved launches not deleted. With includeDeleted or
+// projected the table is shadowed by the balances recomputed from the launches.
+func cashBalanceDailyWith(query string, includeDeleted bool, projected bool) string {
+	if !includeDeleted && !projected {
+		return query
+	}
+
+	return `WITH cash_balance_daily AS (` + cashBalanceDailyRebuilt(includeDeleted, projected) + `) ` + query
+}
+
+func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error) {
 	query :=
-		`SELECT 
-			reference_date, 
-			SUM(CASE WHEN type = 'C' THEN value ELSE (value * -1) END) AS value
-		FROM 
-			cash_launch
-		WHERE
-			reference_date = $1
-		GROUP BY 
-			reference_date `
+		`SELECT
+			$1::date AS reference_date,
+			opening_balance,
+			total_credit,
+			total_debit,
+			total_credit - total_debit AS value,
+			opening_balance + total_credit - total_debit AS closing_balance
+		FROM (
+			SELECT
+				COALESCE((SELECT closing_balance FROM cash_balance_daily WHERE account_id = $2 AND reference_date < $1 ORDER BY reference_date DESC LIMIT 1), 0) AS opening_balance,
+				COALESCE((SELECT total_credit FROM cash_balance_daily WHERE account_id = $2 AND reference_date = $1), 0) AS total_credit,
+				COALESCE((SELECT total_debit FROM cash_balance_daily WHERE account_id = $2 AND reference_date = $1), 0) AS total_debit
+		) AS cash_balance `
 
-	row := postgresCashBalanceDaily.Postgres.Conn.QueryRow(query, referenceDate)
+	row := postgresCashBalanceDaily.Postgres.Conn.QueryRow(cashBalanceDailyWith(query, includeDeleted, projected), referenceDate, accountID)
 
 	modelCashBalance := model.CashBalanceDaily{}
 
 	err := row.Scan(
 		&modelCashBalance.ReferenceDate,
+		&modelCashBalance.OpeningBalance,
+		&modelCashBalance.TotalCredit,
+		&modelCashBalance.TotalDebit,
 		&modelCashBalance.Value,
+		&modelCashBalance.ClosingBalance,
 	)
 
 	// repository error not found
@@ -47,17 +129,22 @@ func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetByReferenceDate(ref
 
 func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error) {
 	query :=
-		`SELECT 
-			reference_date, 
-			SUM(CASE WHEN type = 'C' THEN value ELSE (value * -1) END) AS value
-		FROM 
-			cash_launch
+		`SELECT
+			reference_date,
+			closing_balance - (total_credit - total_debit) AS opening_balance,
+			total_credit,
+			total_debit,
+			total_credit - total_debit AS value,
+			closing_balance
+		FROM
+			cash_balance_daily
 		WHERE
+			account_id = $3 AND
 			reference_date BETWEEN $1 AND $2
-		GROUP BY 
+		ORDER BY
 			reference_date `
 
-	rows, err := postgresCashBalanceDaily.Postgres.Conn.Query(query, cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.To)
+	rows, err := postgresCashBalanceDaily.Postgres.Conn.Query(cashBalanceDailyWith(query, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected), cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.To, cashBalanceGetByRangeReferenceDateParams.AccountID)
 
 	modelCashBalances := model.CashBalanceDailies{}
 
@@ -72,17 +159,382 @@ func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetByRangeReferenceDat
 
 		err = rows.Scan(
 			&modelCashBalance.ReferenceDate,
+			&modelCashBalance.OpeningBalance,
+			&modelCashBalance.TotalCredit,
+			&modelCashBalance.TotalDebit,
 			&modelCashBalance.Value,
+			&modelCashBalance.ClosingBalance,
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
-		modelCashBalance.Value = util.MathRoundPrecision(modelCashBalance.Value, 2)
-
 		modelCashBalances = append(modelCashBalances, modelCashBalance)
 	}
 
 	return modelCashBalances, err
 }
+
+func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error) {
+	query :=
+		`SELECT
+			reference_date,
+			currency,
+			SUM(CASE WHEN type = 'C' THEN value ELSE 0 END) AS total_credit,
+			SUM(CASE WHEN type = 'D' THEN value ELSE 0 END) AS total_debit,
+			SUM(CASE WHEN type = 'C' THEN base_value ELSE -base_value END) AS base_value
+		FROM
+			cash_launch
+		WHERE
+			reference_date BETWEEN $1 AND $2 AND
+			(($3 = 0 AND transfer_id IS NULL) OR account_id = $3) AND
+			($4 OR deleted_at IS NULL) AND
+			(status = 'approved' OR ($5 AND status = 'pending'))
+		GROUP BY
+			reference_date, currency
+		ORDER BY
+			reference_date, currency `
+
+	rows, err := postgresCashBalanceDaily.Postgres.Conn.Query(query, cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.To, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected)
+
+	modelCashBalanceDailyCurrencies := model.CashBalanceDailyCurrencies{}
+
+	if err != nil {
+		return modelCashBalanceDailyCurrencies, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashBalanceDailyCurrency := model.CashBalanceDailyCurrency{}
+
+		err = rows.Scan(
+			&modelCashBalanceDailyCurrency.ReferenceDate,
+			&modelCashBalanceDailyCurrency.Currency,
+			&modelCashBalanceDailyCurrency.TotalCredit,
+			&modelCashBalanceDailyCurrency.TotalDebit,
+			&modelCashBalanceDailyCurrency.BaseValue,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashBalanceDailyCurrency.Value = modelCashBalanceDailyCurrency.TotalCredit.Sub(modelCashBalanceDailyCurrency.TotalDebit)
+
+		modelCashBalanceDailyCurrencies = append(modelCashBalanceDailyCurrencies, modelCashBalanceDailyCurrency)
+	}
+
+	return modelCashBalanceDailyCurrencies, err
+}
+
+// GetSummaryByRangeReferenceDate groups the daily balances by the start of the
+// period truncated by the granularity, the monday for week, keeping the
+// closing balance of the last day with launches of each period
+func (postgresCashBalanceDaily *PostgresCashBalanceDaily) GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error) {
+	query :=
+		`SELECT
+			period,
+			closing_balance - (total_credit - total_debit) AS opening_balance,
+			total_credit,
+			total_debit,
+			total_credit - total_debit AS value,
+			closing_balance
+		FROM (
+			SELECT
+				date_trunc($4, reference_date::timestamp)::date AS period,
+				SUM(total_credit) AS total_credit,
+				SUM(total_debit) AS total_debit,
+				(array_agg(closing_balance ORDER BY reference_date DESC))[1] AS closing_balance
+			FROM
+				cash_balance_daily
+			WHERE
+				account_id = $3 AND
+				reference_date BETWEEN $1 AND $2
+			GROUP BY
+				1
+		) AS cash_balance_summary
+		ORDER BY
+			period `
+
+	rows, err := postgresCashBalanceDaily.Postgres.Conn.Query(cashBalanceDailyWith(query, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected), cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.To, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.Granularity)
+
+	modelCashBalanceSummaries := model.CashBalanceSummaries{}
+
+	if err != nil {
+		return modelCashBalanceSummaries, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashBalanceSummary := model.CashBalanceSummary{}
+
+		err = rows.Scan(
+			&modelCashBalanceSummary.Period,
+			&modelCashBalanceSummary.OpeningBalance,
+			&modelCashBalanceSummary.TotalCredit,
+			&modelCashBalanceSummary.TotalDebit,
+			&modelCashBalanceSummary.Value,
+			&modelCashBalanceSummary.ClosingBalance,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashBalanceSummaries = append(modelCashBalanceSummaries, modelCashBalanceSummary)
+	}
+
+	return modelCashBalanceSummaries, err
+}
+
+func (postgresCashBalanceDaily *PostgresCashBalanceDaily) Rebuild() (model.CashBalanceDailyDrifts, error) {
+	tx, err := postgresCashBalanceDaily.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	// block the launch writes until the table is rebuilt
+	_, err = tx.Exec(`LOCK TABLE cash_balance_daily IN EXCLUSIVE MODE`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query :=
+		`SELECT
+			COALESCE(stored.account_id, rebuilt.account_id) AS account_id,
+			COALESCE(stored.reference_date, rebuilt.reference_date) AS reference_date,
+			COALESCE(stored.total_credit, 0),
+			COALESCE(stored.total_debit, 0),
+			COALESCE(stored.closing_balance, 0),
+			COALESCE(rebuilt.total_credit, 0),
+			COALESCE(rebuilt.total_debit, 0),
+			COALESCE(rebuilt.closing_balance, 0)
+		FROM
+			cash_balance_daily AS stored
+		FULL OUTER JOIN (` + cashBalanceDailyRebuilt(false, false) + `) AS rebuilt
+			ON rebuilt.account_id = stored.account_id AND rebuilt.reference_date = stored.reference_date
+		WHERE
+			stored.reference_date IS NULL OR
+			rebuilt.reference_date IS NULL OR
+			stored.total_credit <> rebuilt.total_credit OR
+			stored.total_debit <> rebuilt.total_debit OR
+			stored.closing_balance <> rebuilt.closing_balance
+		ORDER BY
+			1, 2 `
+
+	rows, err := tx.Query(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashBalanceDailyDrifts := model.CashBalanceDailyDrifts{}
+
+	for rows.Next() {
+		modelCashBalanceDailyDrift := model.CashBalanceDailyDrift{}
+
+		err = rows.Scan(
+			&modelCashBalanceDailyDrift.AccountID,
+			&modelCashBalanceDailyDrift.ReferenceDate,
+			&modelCashBalanceDailyDrift.Stored.TotalCredit,
+			&modelCashBalanceDailyDrift.Stored.TotalDebit,
+			&modelCashBalanceDailyDrift.Stored.ClosingBalance,
+			&modelCashBalanceDailyDrift.Rebuilt.TotalCredit,
+			&modelCashBalanceDailyDrift.Rebuilt.TotalDebit,
+			&modelCashBalanceDailyDrift.Rebuilt.ClosingBalance,
+		)
+
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		for _, modelCashBalance := range []*model.CashBalanceDaily{&modelCashBalanceDailyDrift.Stored, &modelCashBalanceDailyDrift.Rebuilt} {
+			modelCashBalance.ReferenceDate = modelCashBalanceDailyDrift.ReferenceDate
+			modelCashBalance.Value = modelCashBalance.TotalCredit.Sub(modelCashBalance.TotalDebit)
+			modelCashBalance.OpeningBalance = modelCashBalance.ClosingBalance.Sub(modelCashBalance.Value)
+		}
+
+		modelCashBalanceDailyDrifts = append(modelCashBalanceDailyDrifts, modelCashBalanceDailyDrift)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM cash_balance_daily`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO
+			cash_balance_daily
+			(account_id, reference_date, total_credit, total_debit, closing_balance, launch_count)
+		` + cashBalanceDailyRebuilt(false, false))
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashBalanceDailyDrifts, nil
+}
+
+// cashLaunchBalanceApply adds the approved launch to the daily balance, or
+// removes it with a negative launch count, the pending and rejected launches
+// are kept out of the balance
+func cashLaunchBalanceApply(tx *sql.Tx, modelCashLaunch *model.CashLaunch, launchCount int) error {
+	if modelCashLaunch.Status != repository.CashLaunchStatusApproved {
+		return nil
+	}
+
+	value := modelCashLaunch.BaseValue
+
+	if launchCount < 0 {
+		value = value.Neg()
+	}
+
+	return cashBalanceDailyApply(tx, modelCashLaunch.AccountID, modelCashLaunch.TransferID, modelCashLaunch.ReferenceDate, modelCashLaunch.Type, value, launchCount)
+}
+
+// cashBalanceDailyApply adds a launch to (or removes it from, with a negative
+// value and launch count) the materialized daily balance of its account and of
+// all accounts combined inside the launch transaction. The closing balance of
+// every following day is moved as well. A transfer only moves money between
+// accounts so it is kept out of the combined balance.
+func cashBalanceDailyApply(tx *sql.Tx, accountID int64, transferID int64, referenceDate time.Time, launchType string, value decimal.Decimal, launchCount int) error {
+	credit, debit := decimal.Zero, decimal.Zero
+
+	if launchType == "C" {
+		credit = value
+	} else {
+		debit = value
+	}
+
+	balanceAccountIDs := []int64{accountID}
+
+	if transferID == 0 {
+		balanceAccountIDs = append(balanceAccountIDs, 0)
+	}
+
+	for _, balanceAccountID := range balanceAccountIDs {
+		err := cashBalanceDailyLock(tx, balanceAccountID, referenceDate)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO
+				cash_balance_daily
+				(account_id, reference_date, closing_balance)
+			VALUES
+				($2, $1, COALESCE((SELECT closing_balance FROM cash_balance_daily WHERE account_id = $2 AND reference_date < $1 ORDER BY reference_date DESC LIMIT 1), 0))
+			ON CONFLICT (account_id, reference_date) DO NOTHING`,
+			referenceDate, balanceAccountID,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			`UPDATE
+				cash_balance_daily
+			SET
+				total_credit = total_credit + $3,
+				total_debit = total_debit + $4,
+				launch_count = launch_count + $5,
+				updated_at = now()
+			WHERE
+				account_id = $2 AND reference_date = $1`,
+			referenceDate, balanceAccountID, credit, debit, launchCount,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			`UPDATE
+				cash_balance_daily
+			SET
+				closing_balance = closing_balance + $3 - $4
+			WHERE
+				account_id = $2 AND reference_date >= $1`,
+			referenceDate, balanceAccountID, credit, debit,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			`DELETE FROM
+				cash_balance_daily
+			WHERE
+				account_id = $2 AND reference_date = $1 AND launch_count <= 0`,
+			referenceDate, balanceAccountID,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cashBalanceDailyLock locks the balances of the account from the last day
+// before the reference date on, the day whose closing balance is carried and
+// the days moved by the launch, so only the writers of the same account and
+// overlapping days wait for each other. An account without balances has no row
+// to lock yet, so its first writers wait for each other on a transaction
+// advisory lock and lock the rows again after it.
+func cashBalanceDailyLock(tx *sql.Tx, accountID int64, referenceDate time.Time) error {
+	query :=
+		`SELECT
+			count(*)
+		FROM (
+			SELECT
+				1
+			FROM
+				cash_balance_daily
+			WHERE
+				account_id = $2 AND
+				reference_date >= COALESCE((SELECT max(reference_date) FROM cash_balance_daily WHERE account_id = $2 AND reference_date < $1), $1)
+			FOR UPDATE
+		) AS locked`
+
+	var lockedCount int64
+
+	err := tx.QueryRow(query, referenceDate, accountID).Scan(&lockedCount)
+
+	if err != nil || lockedCount > 0 {
+		return err
+	}
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('cash_balance_daily'), $1::integer)`, accountID)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(query, referenceDate, accountID).Scan(&lockedCount)
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+// the budgets of a pattern keep the category_id null to not reference a
+// category, the model uses zero
+const cashBudgetColumns = `id, COALESCE(category_id, 0), pattern, pattern_type, type, period, value, updated_at, created_at`
+
+// cashBudgetActualCondition keeps the approved launches not deleted of the
+// range, a reversed launch and its reversal cancel each other so both are
+// left out
+const cashBudgetActualCondition = `
+			reference_date BETWEEN $1 AND $2 AND
+			deleted_at IS NULL AND
+			status = 'approved' AND
+			reversal_of_id IS NULL AND
+			reversed_by_id IS NULL `
+
+type PostgresCashBudget struct {
+	Postgres *Postgres
+}
+
+func NewCashBudget(postgres *Postgres) repository.CashBudget {
+	return &PostgresCashBudget{Postgres: postgres}
+}
+
+func (postgresCashBudget *PostgresCashBudget) Insert(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	query :=
+		`INSERT INTO
+			cash_budget
+			(category_id, pattern, pattern_type, type, period, value, updated_at, created_at)
+		VALUES
+			(NULLIF($1, 0), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING
+			` + cashBudgetColumns + `;`
+
+	row := postgresCashBudget.Postgres.Conn.QueryRow(
+		query,
+		modelCashBudget.CategoryID,
+		modelCashBudget.Pattern,
+		modelCashBudget.PatternType,
+		modelCashBudget.Type,
+		modelCashBudget.Period,
+		modelCashBudget.Value,
+		modelCashBudget.UpdatedAt,
+		modelCashBudget.CreatedAt,
+	)
+
+	modelCashBudgetInsert := &model.CashBudget{}
+
+	err := cashBudgetScan(row, modelCashBudgetInsert)
+
+	return modelCashBudgetInsert, postgresError(err)
+}
+
+func (postgresCashBudget *PostgresCashBudget) List(from time.Time, to time.Time) (model.CashBudgets, error) {
+	query :=
+		`SELECT
+			` + cashBudgetColumns + `
+		FROM
+			cash_budget
+		WHERE
+			period BETWEEN $1 AND $2
+		ORDER BY
+			period, type, COALESCE(category_id, 0), pattern_type, pattern, id`
+
+	rows, err := postgresCashBudget.Postgres.Conn.Query(query, from, to)
+
+	modelCashBudgets := model.CashBudgets{}
+
+	if err != nil {
+		return modelCashBudgets, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashBudget := model.CashBudget{}
+
+		err = cashBudgetScan(rows, &modelCashBudget)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashBudgets = append(modelCashBudgets, modelCashBudget)
+	}
+
+	return modelCashBudgets, err
+}
+
+func (postgresCashBudget *PostgresCashBudget) GetByID(id int64) (*model.CashBudget, error) {
+	query :=
+		`SELECT
+			` + cashBudgetColumns + `
+		FROM
+			cash_budget
+		WHERE
+			id = $1`
+
+	row := postgresCashBudget.Postgres.Conn.QueryRow(query, id)
+
+	modelCashBudget := model.CashBudget{}
+
+	err := cashBudgetScan(row, &modelCashBudget)
+
+	return &modelCashBudget, postgresError(err)
+}
+
+func (postgresCashBudget *PostgresCashBudget) Update(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	query :=
+		`UPDATE
+		cash_budget
+	SET
+		category_id = NULLIF($2, 0),
+		pattern = $3,
+		pattern_type = $4,
+		type = $5,
+		period = $6,
+		value = $7,
+		updated_at = $8
+	WHERE
+		id = $1
+	RETURNING
+		` + cashBudgetColumns + `;`
+
+	row := postgresCashBudget.Postgres.Conn.QueryRow(
+		query,
+		modelCashBudget.ID,
+		modelCashBudget.CategoryID,
+		modelCashBudget.Pattern,
+		modelCashBudget.PatternType,
+		modelCashBudget.Type,
+		modelCashBudget.Period,
+		modelCashBudget.Value,
+		modelCashBudget.UpdatedAt,
+	)
+
+	modelCashBudgetUpdate := &model.CashBudget{}
+
+	err := cashBudgetScan(row, modelCashBudgetUpdate)
+
+	return modelCashBudgetUpdate, postgresError(err)
+}
+
+func (postgresCashBudget *PostgresCashBudget) DeleteByID(id int64) error {
+	query :=
+		`DELETE FROM
+		cash_budget
+	WHERE
+		id = $1
+	RETURNING id`
+
+	err := postgresCashBudget.Postgres.Conn.QueryRow(query, id).Scan(&id)
+
+	return postgresError(err)
+}
+
+func (postgresCashBudget *PostgresCashBudget) ListActualByCategory(from time.Time, to time.Time) (model.CashBudgetActuals, error) {
+	query :=
+		`SELECT
+			category_id,
+			type,
+			SUM(base_value)
+		FROM
+			cash_launch
+		WHERE
+			category_id IS NOT NULL AND` + cashBudgetActualCondition + `
+		GROUP BY
+			category_id, type
+		ORDER BY
+			category_id, type`
+
+	rows, err := postgresCashBudget.Postgres.Conn.Query(query, from, to)
+
+	modelCashBudgetActuals := model.CashBudgetActuals{}
+
+	if err != nil {
+		return modelCashBudgetActuals, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashBudgetActual := model.CashBudgetActual{}
+
+		err = rows.Scan(
+			&modelCashBudgetActual.CategoryID,
+			&modelCashBudgetActual.Type,
+			&modelCashBudgetActual.Actual,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashBudgetActuals = append(modelCashBudgetActuals, modelCashBudgetActual)
+	}
+
+	return modelCashBudgetActuals, err
+}
+
+func (postgresCashBudget *PostgresCashBudget) ListActualByDescription(from time.Time, to time.Time) (model.CashBudgetActuals, error) {
+	query :=
+		`SELECT
+			description,
+			type,
+			SUM(base_value)
+		FROM
+			cash_launch
+		WHERE` + cashBudgetActualCondition + `
+		GROUP BY
+			description, type
+		ORDER BY
+			description, type`
+
+	rows, err := postgresCashBudget.Postgres.Conn.Query(query, from, to)
+
+	modelCashBudgetActuals := model.CashBudgetActuals{}
+
+	if err != nil {
+		return modelCashBudgetActuals, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashBudgetActual := model.CashBudgetActual{}
+
+		err = rows.Scan(
+			&modelCashBudgetActual.Description,
+			&modelCashBudgetActual.Type,
+			&modelCashBudgetActual.Actual,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashBudgetActuals = append(modelCashBudgetActuals, modelCashBudgetActual)
+	}
+
+	return modelCashBudgetActuals, err
+}
+
+func cashBudgetScan(row interface{ Scan(dest ...any) error }, modelCashBudget *model.CashBudget) error {
+	return row.Scan(
+		&modelCashBudget.ID,
+		&modelCashBudget.CategoryID,
+		&modelCashBudget.Pattern,
+		&modelCashBudget.PatternType,
+		&modelCashBudget.Type,
+		&modelCashBudget.Period,
+		&modelCashBudget.Value,
+		&modelCashBudget.UpdatedAt,
+		&modelCashBudget.CreatedAt,
+	)
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresCashCategory struct {
+	Postgres *Postgres
+}
+
+func NewCashCategory(postgres *Postgres) repository.CashCategory {
+	return &PostgresCashCategory{Postgres: postgres}
+}
+
+func (postgresCashCategory *PostgresCashCategory) Insert(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	query :=
+		`INSERT INTO 
+			cash_category
+			(parent_id, name, updated_at, created_at)
+		VALUES
+			(NULLIF($1, 0), $2, $3, $4)
+		RETURNING
+			id, COALESCE(parent_id, 0), name, updated_at, created_at;`
+
+	row := postgresCashCategory.Postgres.Conn.QueryRow(
+		query,
+		modelCashCategory.ParentID,
+		modelCashCategory.Name,
+		modelCashCategory.UpdatedAt,
+		modelCashCategory.CreatedAt,
+	)
+
+	modelCashCategoryInsert := &model.CashCategory{}
+
+	err := row.Scan(
+		&modelCashCategoryInsert.ID,
+		&modelCashCategoryInsert.ParentID,
+		&modelCashCategoryInsert.Name,
+		&modelCashCategoryInsert.UpdatedAt,
+		&modelCashCategoryInsert.CreatedAt,
+	)
+
+	return modelCashCategoryInsert, postgresError(err)
+}
+
+func (postgresCashCategory *PostgresCashCategory) List() (model.CashCategories, error) {
+	query :=
+		`SELECT
+			id, COALESCE(parent_id, 0), name, updated_at, created_at
+		FROM
+			cash_category
+		ORDER BY
+			name, id`
+
+	rows, err := postgresCashCategory.Postgres.Conn.Query(query)
+
+	modelCashCategories := model.CashCategories{}
+
+	if err != nil {
+		return modelCashCategories, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashCategory := model.CashCategory{}
+
+		err = rows.Scan(
+			&modelCashCategory.ID,
+			&modelCashCategory.ParentID,
+			&modelCashCategory.Name,
+			&modelCashCategory.UpdatedAt,
+			&modelCashCategory.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashCategories = append(modelCashCategories, modelCashCategory)
+	}
+
+	return modelCashCategories, err
+}
+
+func (postgresCashCategory *PostgresCashCategory) GetByID(id int64) (*model.CashCategory, error) {
+	query :=
+		`SELECT
+			id, COALESCE(parent_id, 0), name, updated_at, created_at
+		FROM
+			cash_category
+		WHERE
+			id = $1`
+
+	row := postgresCashCategory.Postgres.Conn.QueryRow(query, id)
+
+	modelCashCategory := model.CashCategory{}
+
+	err := row.Scan(
+		&modelCashCategory.ID,
+		&modelCashCategory.ParentID,
+		&modelCashCategory.Name,
+		&modelCashCategory.UpdatedAt,
+		&modelCashCategory.CreatedAt,
+	)
+
+	return &modelCashCategory, postgresError(err)
+}
+
+func (postgresCashCategory *PostgresCashCategory) Update(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	query :=
+		`UPDATE
+		cash_category
+	SET
+		parent_id = NULLIF($2, 0),
+		name = $3,
+		updated_at = $4
+	WHERE
+		id = $1
+	RETURNING
+		id, COALESCE(parent_id, 0), name, updated_at, created_at;`
+
+	row := postgresCashCategory.Postgres.Conn.QueryRow(
+		query,
+		modelCashCategory.ID,
+		modelCashCategory.ParentID,
+		modelCashCategory.Name,
+		modelCashCategory.UpdatedAt,
+	)
+
+	modelCashCategoryUpdate := &model.CashCategory{}
+
+	err := row.Scan(
+		&modelCashCategoryUpdate.ID,
+		&modelCashCategoryUpdate.ParentID,
+		&modelCashCategoryUpdate.Name,
+		&modelCashCategoryUpdate.UpdatedAt,
+		&modelCashCategoryUpdate.CreatedAt,
+	)
+
+	return modelCashCategoryUpdate, postgresError(err)
+}
+
+func (postgresCashCategory *PostgresCashCategory) DeleteByID(id int64) error {
+	query :=
+		`DELETE FROM
+		cash_category
+	WHERE
+		id = $1
+	RETURNING id`
+
+	err := postgresCashCategory.Postgres.Conn.QueryRow(query, id).Scan(&id)
+
+	return postgresError(err)
+}
+
+func (postgresCashCategory *PostgresCashCategory) GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) (model.CashCategoryTotals, error) {
+	query :=
+		`SELECT
+			COALESCE(category_id, 0),
+			SUM(CASE WHEN type = 'C' THEN base_value ELSE 0 END) AS total_credit,
+			SUM(CASE WHEN type = 'D' THEN base_value ELSE 0 END) AS total_debit
+		FROM
+			cash_launch
+		WHERE
+			reference_date BETWEEN $1 AND $2 AND
+			(($3 = 0 AND transfer_id IS NULL) OR account_id = $3) AND
+			($4 OR deleted_at IS NULL) AND
+			(status = 'approved' OR ($5 AND status = 'pending'))
+		GROUP BY
+			1
+		ORDER BY
+			1 `
+
+	rows, err := postgresCashCategory.Postgres.Conn.Query(query, cashCategoryRangeReferenceDate.From, cashCategoryRangeReferenceDate.To, cashCategoryRangeReferenceDate.AccountID, cashCategoryRangeReferenceDate.IncludeDeleted, cashCategoryRangeReferenceDate.Projected)
+
+	modelCashCategoryTotals := model.CashCategoryTotals{}
+
+	if err != nil {
+		return modelCashCategoryTotals, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashCategoryTotal := model.CashCategoryTotal{}
+
+		err = rows.Scan(
+			&modelCashCategoryTotal.CategoryID,
+			&modelCashCategoryTotal.TotalCredit,
+			&modelCashCategoryTotal.TotalDebit,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashCategoryTotal.Value = modelCashCategoryTotal.TotalCredit.Sub(modelCashCategoryTotal.TotalDebit)
+
+		modelCashCategoryTotals = append(modelCashCategoryTotals, modelCashCategoryTotal)
+	}
+
+	return modelCashCategoryTotals, err
+}
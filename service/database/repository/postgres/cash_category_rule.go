@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresCashCategoryRule struct {
+	Postgres *Postgres
+}
+
+func NewCashCategoryRule(postgres *Postgres) repository.CashCategoryRule {
+	return &PostgresCashCategoryRule{Postgres: postgres}
+}
+
+func (postgresCashCategoryRule *PostgresCashCategoryRule) Insert(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	query :=
+		`INSERT INTO 
+			cash_category_rule
+			(category_id, pattern, pattern_type, type, value_from, value_to, priority, updated_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING
+			id, category_id, pattern, pattern_type, type, value_from, value_to, priority, updated_at, created_at;`
+
+	row := postgresCashCategoryRule.Postgres.Conn.QueryRow(
+		query,
+		modelCashCategoryRule.CategoryID,
+		modelCashCategoryRule.Pattern,
+		modelCashCategoryRule.PatternType,
+		modelCashCategoryRule.Type,
+		modelCashCategoryRule.ValueFrom,
+		modelCashCategoryRule.ValueTo,
+		modelCashCategoryRule.Priority,
+		modelCashCategoryRule.UpdatedAt,
+		modelCashCategoryRule.CreatedAt,
+	)
+
+	modelCashCategoryRuleInsert := &model.CashCategoryRule{}
+
+	err := row.Scan(
+		&modelCashCategoryRuleInsert.ID,
+		&modelCashCategoryRuleInsert.CategoryID,
+		&modelCashCategoryRuleInsert.Pattern,
+		&modelCashCategoryRuleInsert.PatternType,
+		&modelCashCategoryRuleInsert.Type,
+		&modelCashCategoryRuleInsert.ValueFrom,
+		&modelCashCategoryRuleInsert.ValueTo,
+		&modelCashCategoryRuleInsert.Priority,
+		&modelCashCategoryRuleInsert.UpdatedAt,
+		&modelCashCategoryRuleInsert.CreatedAt,
+	)
+
+	return modelCashCategoryRuleInsert, postgresError(err)
+}
+
+func (postgresCashCategoryRule *PostgresCashCategoryRule) List() (model.CashCategoryRules, error) {
+	query :=
+		`SELECT
+			id, category_id, pattern, pattern_type, type, value_from, value_to, priority, updated_at, created_at
+		FROM
+			cash_category_rule
+		ORDER BY
+			priority, id`
+
+	rows, err := postgresCashCategoryRule.Postgres.Conn.Query(query)
+
+	modelCashCategoryRules := model.CashCategoryRules{}
+
+	if err != nil {
+		return modelCashCategoryRules, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashCategoryRule := model.CashCategoryRule{}
+
+		err = rows.Scan(
+			&modelCashCategoryRule.ID,
+			&modelCashCategoryRule.CategoryID,
+			&modelCashCategoryRule.Pattern,
+			&modelCashCategoryRule.PatternType,
+			&modelCashCategoryRule.Type,
+			&modelCashCategoryRule.ValueFrom,
+			&modelCashCategoryRule.ValueTo,
+			&modelCashCategoryRule.Priority,
+			&modelCashCategoryRule.UpdatedAt,
+			&modelCashCategoryRule.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashCategoryRules = append(modelCashCategoryRules, modelCashCategoryRule)
+	}
+
+	return modelCashCategoryRules, err
+}
+
+func (postgresCashCategoryRule *PostgresCashCategoryRule) GetByID(id int64) (*model.CashCategoryRule, error) {
+	query :=
+		`SELECT
+			id, category_id, pattern, pattern_type, type, value_from, value_to, priority, updated_at, created_at
+		FROM
+			cash_category_rule
+		WHERE
+			id = $1`
+
+	row := postgresCashCategoryRule.Postgres.Conn.QueryRow(query, id)
+
+	modelCashCategoryRule := model.CashCategoryRule{}
+
+	err := row.Scan(
+		&modelCashCategoryRule.ID,
+		&modelCashCategoryRule.CategoryID,
+		&modelCashCategoryRule.Pattern,
+		&modelCashCategoryRule.PatternType,
+		&modelCashCategoryRule.Type,
+		&modelCashCategoryRule.ValueFrom,
+		&modelCashCategoryRule.ValueTo,
+		&modelCashCategoryRule.Priority,
+		&modelCashCategoryRule.UpdatedAt,
+		&modelCashCategoryRule.CreatedAt,
+	)
+
+	return &modelCashCategoryRule, postgresError(err)
+}
+
+func (postgresCashCategoryRule *PostgresCashCategoryRule) Update(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	query :=
+		`UPDATE
+		cash_category_rule
+	SET
+		category_id = $2,
+		pattern = $3,
+		pattern_type = $4,
+		type = $5,
+		value_from = $6,
+		value_to = $7,
+		priority = $8,
+		updated_at = $9
+	WHERE
+		id = $1
+	RETURNING
+		id, category_id, pattern, pattern_type, type, value_from, value_to, priority, updated_at, created_at;`
+
+	row := postgresCashCategoryRule.Postgres.Conn.QueryRow(
+		query,
+		modelCashCategoryRule.ID,
+		modelCashCategoryRule.CategoryID,
+		modelCashCategoryRule.Pattern,
+		modelCashCategoryRule.PatternType,
+		modelCashCategoryRule.Type,
+		modelCashCategoryRule.ValueFrom,
+		modelCashCategoryRule.ValueTo,
+		modelCashCategoryRule.Priority,
+		modelCashCategoryRule.UpdatedAt,
+	)
+
+	modelCashCategoryRuleUpdate := &model.CashCategoryRule{}
+
+	err := row.Scan(
+		&modelCashCategoryRuleUpdate.ID,
+		&modelCashCategoryRuleUpdate.CategoryID,
+		&modelCashCategoryRuleUpdate.Pattern,
+		&modelCashCategoryRuleUpdate.PatternType,
+		&modelCashCategoryRuleUpdate.Type,
+		&modelCashCategoryRuleUpdate.ValueFrom,
+		&modelCashCategoryRuleUpdate.ValueTo,
+		&modelCashCategoryRuleUpdate.Priority,
+		&modelCashCategoryRuleUpdate.UpdatedAt,
+		&modelCashCategoryRuleUpdate.CreatedAt,
+	)
+
+	return modelCashCategoryRuleUpdate, postgresError(err)
+}
+
+func (postgresCashCategoryRule *PostgresCashCategoryRule) DeleteByID(id int64) error {
+	query :=
+		`DELETE FROM
+		cash_category_rule
+	WHERE
+		id = $1
+	RETURNING id`
+
+	err := postgresCashCategoryRule.Postgres.Conn.QueryRow(query, id).Scan(&id)
+
+	return postgresError(err)
+}
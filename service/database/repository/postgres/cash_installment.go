@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresCashInstallment struct {
+	Postgres *Postgres
+}
+
+func NewCashInstallment(postgres *Postgres) repository.CashInstallment {
+	return &PostgresCashInstallment{Postgres: postgres}
+}
+
+// Insert persists the installments in the same transaction linked by a new
+// installment_group_id
+func (postgresCashInstallment *PostgresCashInstallment) Insert(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	tx, err := postgresCashInstallment.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var groupID int64
+
+	err = tx.QueryRow(`SELECT nextval('cash_installment_id_seq')`).Scan(&groupID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchesInsert := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		modelCashLaunch.InstallmentGroupID = groupID
+
+		modelCashLaunchInsert, err := cashLaunchInsert(tx, &modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunchesInsert = append(modelCashLaunchesInsert, *modelCashLaunchInsert)
+	}
+
+	return modelCashLaunchesInsert, tx.Commit()
+}
+
+func (postgresCashInstallment *PostgresCashInstallment) ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error) {
+	query :=
+		`SELECT
+			` + cashLaunchColumns + `
+		FROM
+			cash_launch
+		WHERE
+			installment_group_id = $1 AND
+			($2 OR deleted_at IS NULL)
+		ORDER BY
+			installment_number, id`
+
+	rows, err := postgresCashInstallment.Postgres.Conn.Query(query, groupID, includeDeleted)
+
+	modelCashLaunches := model.CashLaunches{}
+
+	if err != nil {
+		return modelCashLaunches, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashLaunch := model.CashLaunch{}
+
+		err = cashLaunchScan(rows, &modelCashLaunch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunches = append(modelCashLaunches, modelCashLaunch)
+	}
+
+	return modelCashLaunches, rows.Err()
+}
+
+// Update persists the changes of the installments in the same transaction
+func (postgresCashInstallment *PostgresCashInstallment) Update(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	tx, err := postgresCashInstallment.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	modelCashLaunchesUpdate := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		modelCashLaunchUpdate, err := cashLaunchUpdate(tx, &modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunchesUpdate = append(modelCashLaunchesUpdate, *modelCashLaunchUpdate)
+	}
+
+	return modelCashLaunchesUpdate, tx.Commit()
+}
+
+// DeleteByGroupID marks as deleted the installments of the group due on or
+// after the date, except the reversed ones, and takes them out of the daily
+// balance in the same transaction
+func (postgresCashInstallment *PostgresCashInstallment) DeleteByGroupID(groupID int64, referenceDateFrom time.Time, modelAudit model.Audit) (model.CashLaunches, error) {
+	query :=
+		`UPDATE
+			cash_launch
+		SET
+			deleted_at = now()
+		WHERE
+			installment_group_id = $1 AND
+			reference_date >= $2 AND
+			reversed_by_id IS NULL AND
+			deleted_at IS NULL
+		RETURNING
+			` + cashLaunchColumns
+
+	tx, err := postgresCashInstallment.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(query, groupID, referenceDateFrom)
+
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	modelCashLaunches := model.CashLaunches{}
+
+	for rows.Next() {
+		modelCashLaunch := model.CashLaunch{}
+
+		err = cashLaunchScan(rows, &modelCashLaunch)
+
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		modelCashLaunches = append(modelCashLaunches, modelCashLaunch)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, postgresError(err)
+	}
+
+	if len(modelCashLaunches) == 0 {
+		// repository error not found
+		return nil, repository.ErrNotFound{}
+	}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		err = cashLaunchBalanceApply(tx, &modelCashLaunch, -1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = cashLaunchAuditDelete(tx, &modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modelCashLaunches, tx.Commit()
+}
@@ -1,11 +1,36 @@
 package repository
 
 import (
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
-	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
+// cashLaunchSortColumns maps the sort fields to the column and the type used
+// to cast the cursor value
+var cashLaunchSortColumns = map[string]struct {
+	name string
+	cast string
+}{
+	"reference_date": {name: "reference_date", cast: "date"},
+	"type":           {name: "type", cast: "text"},
+	"description":    {name: "description", cast: "text"},
+	"value":          {name: "value", cast: "numeric"},
+	"id":             {name: "id", cast: "bigint"},
+}
+
+// cashLaunchColumns are the columns read into a launch by cashLaunchScan
+const cashLaunchColumns = `id, account_id, COALESCE(category_id, 0), COALESCE(transfer_id, 0), COALESCE(recurrence_id, 0), COALESCE(installment_group_id, 0), COALESCE(installment_number, 0), COALESCE(installment_count, 0), COALESCE(reversal_of_id, 0), COALESCE(reversed_by_id, 0), COALESCE(external_id, ''), reference_date, type, description, value, currency, exchange_rate, base_value, status, review_comment, reviewed_by, reviewed_at, updated_at, created_at, deleted_at`
+
+var cashLaunchLikeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type PostgresCashLaunch struct {
 	Postgres *Postgres
 }
@@ -14,57 +39,125 @@ func NewCashLaunch(postgres *Postgres) repository.CashLaunch {
 	return &PostgresCashLaunch{Postgres: postgres}
 }
 
-func (postgresCashLaunch *PostgresCashLaunch) Insert(modelCurrency *model.CashLaunch) (*model.CashLaunch, error) {
-	query :=
-		`INSERT INTO 
-			cash_launch
-			(reference_date, type, description, value, updated_at, created_at)
-		VALUES
-			($1, $2, $3, $4, $5, $6)
-		RETURNING *;`
+func (postgresCashLaunch *PostgresCashLaunch) Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
 
-	row := postgresCashLaunch.Postgres.Conn.QueryRow(
-		query,
-		modelCurrency.ReferenceDate,
-		modelCurrency.Type,
-		modelCurrency.Description,
-		modelCurrency.Value,
-		modelCurrency.UpdatedAt,
-		modelCurrency.CreatedAt,
-	)
+	if err != nil {
+		return nil, err
+	}
 
-	modelCashLaunchInsert := &model.CashLaunch{}
+	defer tx.Rollback()
 
-	err := row.Scan(
-		&modelCashLaunchInsert.ID,
-		&modelCashLaunchInsert.ReferenceDate,
-		&modelCashLaunchInsert.Type,
-		&modelCashLaunchInsert.Description,
-		&modelCashLaunchInsert.Value,
-		&modelCashLaunchInsert.UpdatedAt,
-		&modelCashLaunchInsert.CreatedAt,
-	)
+	modelCashLaunchInsert, err := cashLaunchInsert(tx, modelCashLaunch, modelAudit)
 
-	// repository error duplicate key
-	if errPQ, ok := err.(*pq.Error); ok {
-		if errPQ.Code == "23505" {
-			err = repository.ErrDuplicateKey{Message: errPQ.Detail}
-		}
+	if err != nil {
+		return modelCashLaunchInsert, err
 	}
 
-	return modelCashLaunchInsert, err
+	return modelCashLaunchInsert, tx.Commit()
 }
 
-func (postgresCashLaunch *PostgresCashLaunch) List() (model.CashLaunches, error) {
-	query :=
+func (postgresCashLaunch *PostgresCashLaunch) List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	conditionAppend := func(condition string, values ...interface{}) {
+		placeholders := []interface{}{}
+
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, len(args))
+		}
+
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if modelCashLaunchFilter.AccountID != 0 {
+		conditionAppend("account_id = $%d", modelCashLaunchFilter.AccountID)
+	}
+
+	if modelCashLaunchFilter.CategoryID != 0 {
+		conditionAppend("category_id = $%d", modelCashLaunchFilter.CategoryID)
+	}
+
+	if modelCashLaunchFilter.TransferID != 0 {
+		conditionAppend("transfer_id = $%d", modelCashLaunchFilter.TransferID)
+	}
+
+	if !modelCashLaunchFilter.ReferenceDateFrom.IsZero() {
+		conditionAppend("reference_date >= $%d", modelCashLaunchFilter.ReferenceDateFrom)
+	}
+
+	if !modelCashLaunchFilter.ReferenceDateTo.IsZero() {
+		conditionAppend("reference_date <= $%d", modelCashLaunchFilter.ReferenceDateTo)
+	}
+
+	if modelCashLaunchFilter.Type != "" {
+		conditionAppend("type = $%d", modelCashLaunchFilter.Type)
+	}
+
+	if modelCashLaunchFilter.ValueFrom.Valid {
+		conditionAppend("value >= $%d", modelCashLaunchFilter.ValueFrom.Decimal)
+	}
+
+	if modelCashLaunchFilter.ValueTo.Valid {
+		conditionAppend("value <= $%d", modelCashLaunchFilter.ValueTo.Decimal)
+	}
+
+	if modelCashLaunchFilter.Description != "" {
+		conditionAppend(`description ILIKE $%d ESCAPE '\'`, "%"+cashLaunchLikeEscaper.Replace(modelCashLaunchFilter.Description)+"%")
+	}
+
+	if modelCashLaunchFilter.Status != "" {
+		conditionAppend("status = $%d", modelCashLaunchFilter.Status)
+	}
+
+	if modelCashLaunchFilter.ExternalID != "" {
+		conditionAppend("external_id = $%d", modelCashLaunchFilter.ExternalID)
+	}
+
+	if !modelCashLaunchFilter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	sortColumn := cashLaunchSortColumns[modelCashLaunchFilter.Sort]
+	order := "ASC"
+	operator := ">"
+
+	if modelCashLaunchFilter.Order == "desc" {
+		order = "DESC"
+		operator = "<"
+	}
+
+	// keyset pagination: the rows after the last one of the previous page
+	if modelCashLaunchFilter.Cursor != nil {
+		conditionAppend(
+			fmt.Sprintf("(%s, id) %s ($%%d::%s, $%%d)", sortColumn.name, operator, sortColumn.cast),
+			modelCashLaunchFilter.Cursor.Value,
+			modelCashLaunchFilter.Cursor.ID,
+		)
+	}
+
+	where := ""
+
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, modelCashLaunchFilter.Limit)
+
+	query := fmt.Sprintf(
 		`SELECT
-			id, reference_date, type, description, value, updated_at, created_at
+			`+cashLaunchColumns+`
 		FROM
 			cash_launch
+		%s
 		ORDER BY
-			reference_date, type, value`
+			%s %s, id %s
+		LIMIT $%d`,
+		where, sortColumn.name, order, order, len(args))
 
-	rows, err := postgresCashLaunch.Postgres.Conn.Query(query)
+	rows, err := postgresCashLaunch.Postgres.Conn.Query(query, args...)
 
 	modelCashLaunches := model.CashLaunches{}
 
@@ -77,15 +170,7 @@ func (postgresCashLaunch *PostgresCashLaunch) List() (model.CashLaunches, error)
 	for rows.Next() {
 		modelCashLaunch := model.CashLaunch{}
 
-		err = rows.Scan(
-			&modelCashLaunch.ID,
-			&modelCashLaunch.ReferenceDate,
-			&modelCashLaunch.Type,
-			&modelCashLaunch.Description,
-			&modelCashLaunch.Value,
-			&modelCashLaunch.UpdatedAt,
-			&modelCashLaunch.CreatedAt,
-		)
+		err = cashLaunchScan(rows, &modelCashLaunch)
 
 		if err != nil {
 			return nil, err
@@ -100,7 +185,7 @@ func (postgresCashLaunch *PostgresCashLaunch) List() (model.CashLaunches, error)
 func (postgresCashLaunch *PostgresCashLaunch) GetByID(id int64) (*model.CashLaunch, error) {
 	query :=
 		`SELECT
-			id, reference_date, type, description, value, updated_at, created_at
+			` + cashLaunchColumns + `
 		FROM
 			cash_launch
 		WHERE
@@ -110,15 +195,7 @@ func (postgresCashLaunch *PostgresCashLaunch) GetByID(id int64) (*model.CashLaun
 
 	modelCashLaunch := model.CashLaunch{}
 
-	err := row.Scan(
-		&modelCashLaunch.ID,
-		&modelCashLaunch.ReferenceDate,
-		&modelCashLaunch.Type,
-		&modelCashLaunch.Description,
-		&modelCashLaunch.Value,
-		&modelCashLaunch.UpdatedAt,
-		&modelCashLaunch.CreatedAt,
-	)
+	err := cashLaunchScan(row, &modelCashLaunch)
 
 	// repository error not found
 	if err != nil && err.Error() == "sql: no rows in result set" {
@@ -128,75 +205,625 @@ func (postgresCashLaunch *PostgresCashLaunch) GetByID(id int64) (*model.CashLaun
 	return &modelCashLaunch, err
 }
 
-func (postgresCashLaunch *PostgresCashLaunch) Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (postgresCashLaunch *PostgresCashLaunch) Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	modelCashLaunchUpdate, err := cashLaunchUpdate(tx, modelCashLaunch, modelAudit)
+
+	if err != nil {
+		return modelCashLaunchUpdate, err
+	}
+
+	return modelCashLaunchUpdate, tx.Commit()
+}
+
+// DeleteByID marks the launch as deleted, or both launches when it is one side
+// of a transfer, and takes it out of the daily balance keeping the row
+func (postgresCashLaunch *PostgresCashLaunch) DeleteByID(id int64, modelAudit model.Audit) error {
 	query :=
 		`UPDATE
 		cash_launch
 	SET
-		reference_date = $2,
-		type = $3,
-		description = $4,
-		value = $5,
-		updated_at = $6
+		deleted_at = now()
+	WHERE
+		(id = $1 OR transfer_id = (SELECT transfer_id FROM cash_launch WHERE id = $1)) AND
+		deleted_at IS NULL
+	RETURNING
+		` + cashLaunchColumns
+
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(query, id)
+
+	if err != nil {
+		return postgresError(err)
+	}
+
+	modelCashLaunches := model.CashLaunches{}
+
+	for rows.Next() {
+		modelCashLaunch := model.CashLaunch{}
+
+		err = cashLaunchScan(rows, &modelCashLaunch)
+
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		modelCashLaunches = append(modelCashLaunches, modelCashLaunch)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return postgresError(err)
+	}
+
+	if len(modelCashLaunches) == 0 {
+		// repository error not found
+		return repository.ErrNotFound{}
+	}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		err = cashLaunchBalanceApply(tx, &modelCashLaunch, -1)
+
+		if err != nil {
+			return err
+		}
+
+		err = cashLaunchAuditDelete(tx, &modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Reverse persists the offsetting launches in the same transaction marking
+// each reversed launch with its reversal, the reversals of the sides of a
+// transfer are linked by a new transfer_id
+func (postgresCashLaunch *PostgresCashLaunch) Reverse(modelCashLaunches model.CashLaunches, modelAudit model.Audit) (model.CashLaunches, error) {
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var transferID int64
+
+	if modelCashLaunches[0].TransferID != 0 {
+		err = tx.QueryRow(`SELECT nextval('cash_transfer_id_seq')`).Scan(&transferID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modelCashLaunchesInsert := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		modelCashLaunch.TransferID = transferID
+
+		modelCashLaunchInsert, err := cashLaunchInsert(tx, &modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the launch reversed or deleted meanwhile is left untouched
+		modelCashLaunchReversed := model.CashLaunch{}
+
+		err = cashLaunchScan(tx.QueryRow(
+			`SELECT `+cashLaunchColumns+` FROM cash_launch WHERE id = $1 AND reversed_by_id IS NULL AND deleted_at IS NULL FOR UPDATE`,
+			modelCashLaunch.ReversalOfID,
+		), &modelCashLaunchReversed)
+
+		if err != nil {
+			if err.Error() == "sql: no rows in result set" {
+				// repository error not found
+				err = repository.ErrNotFound{Message: err.Error()}
+			}
+
+			return nil, err
+		}
+
+		modelCashLaunchReversedUpdate := model.CashLaunch{}
+
+		err = cashLaunchScan(tx.QueryRow(
+			`UPDATE
+				cash_launch
+			SET
+				reversed_by_id = $2,
+				updated_at = $3
+			WHERE
+				id = $1
+			RETURNING
+				`+cashLaunchColumns,
+			modelCashLaunch.ReversalOfID,
+			modelCashLaunchInsert.ID,
+			modelCashLaunchInsert.CreatedAt,
+		), &modelCashLaunchReversedUpdate)
+
+		if err != nil {
+			return nil, postgresError(err)
+		}
+
+		err = cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionReversal, &modelCashLaunchReversed, &modelCashLaunchReversedUpdate, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunchesInsert = append(modelCashLaunchesInsert, *modelCashLaunchInsert)
+	}
+
+	return modelCashLaunchesInsert, tx.Commit()
+}
+
+// cashLaunchImportBalance is the account, date and type of the launches of an
+// import applied together to the daily balance
+type cashLaunchImportBalance struct {
+	accountID     int64
+	referenceDate time.Time
+	launchType    string
+}
+
+// cashLaunchImportBalanceValue is the total and count of the launches of a
+// cashLaunchImportBalance
+type cashLaunchImportBalanceValue struct {
+	value       decimal.Decimal
+	launchCount int
+}
+
+// Import persists the launches streamed by next in the same transaction, so
+// the file is never held in memory. The daily balance is only applied after
+// the last launch, once for each account, date and type, so the balance is
+// not locked while the file is read.
+func (postgresCashLaunch *PostgresCashLaunch) Import(next func() (*model.CashLaunch, error), modelAudit model.Audit) error {
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	cashLaunchImportBalances := map[cashLaunchImportBalance]cashLaunchImportBalanceValue{}
+
+	for {
+		modelCashLaunch, err := next()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// the external_id repeated in the file is seen only inside the
+		// transaction
+		if modelCashLaunch.ExternalID != "" {
+			exists := false
+
+			err = tx.QueryRow(
+				`SELECT EXISTS (SELECT 1 FROM cash_launch WHERE account_id = $1 AND external_id = $2)`,
+				modelCashLaunch.AccountID, modelCashLaunch.ExternalID,
+			).Scan(&exists)
+
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				modelCashLaunch.ID = 0
+				continue
+			}
+		}
+
+		modelCashLaunchInsert, err := cashLaunchInsertRow(tx, modelCashLaunch)
+
+		if err != nil {
+			return err
+		}
+
+		err = cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionInsert, nil, modelCashLaunchInsert, modelAudit)
+
+		if err != nil {
+			return err
+		}
+
+		*modelCashLaunch = *modelCashLaunchInsert
+
+		if modelCashLaunch.Status != repository.CashLaunchStatusApproved {
+			continue
+		}
+
+		key := cashLaunchImportBalance{accountID: modelCashLaunch.AccountID, referenceDate: modelCashLaunch.ReferenceDate, launchType: modelCashLaunch.Type}
+		cashLaunchImportBalanceValue := cashLaunchImportBalances[key]
+		cashLaunchImportBalanceValue.value = cashLaunchImportBalanceValue.value.Add(modelCashLaunch.BaseValue)
+		cashLaunchImportBalanceValue.launchCount++
+		cashLaunchImportBalances[key] = cashLaunchImportBalanceValue
+	}
+
+	keys := make([]cashLaunchImportBalance, 0, len(cashLaunchImportBalances))
+
+	for key := range cashLaunchImportBalances {
+		keys = append(keys, key)
+	}
+
+	// the same order of the rows for every import
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].accountID != keys[j].accountID {
+			return keys[i].accountID < keys[j].accountID
+		}
+
+		if !keys[i].referenceDate.Equal(keys[j].referenceDate) {
+			return keys[i].referenceDate.Before(keys[j].referenceDate)
+		}
+
+		return keys[i].launchType < keys[j].launchType
+	})
+
+	for _, key := range keys {
+		err = cashBalanceDailyApply(tx, key.accountID, 0, key.referenceDate, key.launchType, cashLaunchImportBalances[key].value, cashLaunchImportBalances[key].launchCount)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Review stores the approval or rejection of the pending launch and applies
+// the approved launch to the daily balance in the same transaction
+func (postgresCashLaunch *PostgresCashLaunch) Review(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	tx, err := postgresCashLaunch.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	// the launch reviewed or deleted meanwhile is left untouched
+	modelCashLaunchCurrent := model.CashLaunch{}
+
+	err = cashLaunchScan(tx.QueryRow(
+		`SELECT `+cashLaunchColumns+` FROM cash_launch WHERE id = $1 AND status = 'pending' AND deleted_at IS NULL FOR UPDATE`,
+		modelCashLaunch.ID,
+	), &modelCashLaunchCurrent)
+
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	modelCashLaunchReview := &model.CashLaunch{}
+
+	err = cashLaunchScan(tx.QueryRow(
+		`UPDATE
+			cash_launch
+		SET
+			status = $2,
+			review_comment = $3,
+			reviewed_by = $4,
+			reviewed_at = $5,
+			updated_at = $5
+		WHERE
+			id = $1
+		RETURNING
+			`+cashLaunchColumns,
+		modelCashLaunch.ID,
+		modelCashLaunch.Status,
+		modelCashLaunch.ReviewComment,
+		modelCashLaunch.ReviewedBy,
+		modelCashLaunch.ReviewedAt,
+	), modelCashLaunchReview)
+
+	if err != nil {
+		return nil, postgresError(err)
+	}
+
+	err = cashLaunchBalanceApply(tx, modelCashLaunchReview, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	action := repository.CashLaunchAuditActionApproval
+
+	if modelCashLaunchReview.Status == repository.CashLaunchStatusRejected {
+		action = repository.CashLaunchAuditActionRejection
+	}
+
+	err = cashLaunchAuditInsert(tx, action, &modelCashLaunchCurrent, modelCashLaunchReview, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchReview, tx.Commit()
+}
+
+// cashLaunchInsert persists the launch and applies it to the daily balance
+// inside the transaction, a category_id, transfer_id, recurrence_id,
+// installment_group_id or reversal_of_id 0 and an empty external_id are
+// stored as null
+func cashLaunchInsert(tx *sql.Tx, modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	modelCashLaunchInsert, err := cashLaunchInsertRow(tx, modelCashLaunch)
+
+	if err != nil {
+		return modelCashLaunchInsert, err
+	}
+
+	err = cashLaunchBalanceApply(tx, modelCashLaunchInsert, 1)
+
+	if err != nil {
+		return modelCashLaunchInsert, err
+	}
+
+	err = cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionInsert, nil, modelCashLaunchInsert, modelAudit)
+
+	return modelCashLaunchInsert, err
+}
+
+// cashLaunchInsertRow only inserts the row of the launch inside the
+// transaction
+func cashLaunchInsertRow(tx *sql.Tx, modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+	query :=
+		`INSERT INTO 
+			cash_launch
+			(account_id, category_id, transfer_id, recurrence_id, installment_group_id, installment_number, installment_count, reversal_of_id, external_id, reference_date, type, description, value, currency, exchange_rate, base_value, status, updated_at, created_at)
+		VALUES
+			($1, NULLIF($2, 0), NULLIF($3, 0), NULLIF($4, 0), NULLIF($5, 0), NULLIF($6, 0), NULLIF($7, 0), NULLIF($8, 0), NULLIF($9, ''), $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING
+			` + cashLaunchColumns + `;`
+
+	row := tx.QueryRow(
+		query,
+		modelCashLaunch.AccountID,
+		modelCashLaunch.CategoryID,
+		modelCashLaunch.TransferID,
+		modelCashLaunch.RecurrenceID,
+		modelCashLaunch.InstallmentGroupID,
+		modelCashLaunch.InstallmentNumber,
+		modelCashLaunch.InstallmentCount,
+		modelCashLaunch.ReversalOfID,
+		modelCashLaunch.ExternalID,
+		modelCashLaunch.ReferenceDate,
+		modelCashLaunch.Type,
+		modelCashLaunch.Description,
+		modelCashLaunch.Value,
+		modelCashLaunch.Currency,
+		modelCashLaunch.ExchangeRate,
+		modelCashLaunch.BaseValue,
+		modelCashLaunch.Status,
+		modelCashLaunch.UpdatedAt,
+		modelCashLaunch.CreatedAt,
+	)
+
+	modelCashLaunchInsert := &model.CashLaunch{}
+
+	err := cashLaunchScan(row, modelCashLaunchInsert)
+
+	// repository error duplicate key or closed period
+	return modelCashLaunchInsert, postgresError(err)
+}
+
+// cashLaunchUpdate persists the changes of the launch inside the transaction
+// moving its value out of the previous date of the daily balance, the other
+// side of a transfer follows the changes
+func cashLaunchUpdate(tx *sql.Tx, modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	query :=
+		`UPDATE
+		cash_launch
+	SET
+		account_id = $2,
+		category_id = NULLIF($3, 0),
+		reference_date = $4,
+		type = $5,
+		description = $6,
+		value = $7,
+		currency = $8,
+		exchange_rate = $9,
+		base_value = $10,
+		status = $11,
+		review_comment = $12,
+		reviewed_by = $13,
+		reviewed_at = $14,
+		updated_at = $15
 	WHERE
 		id = $1
-	RETURNING *;`
+	RETURNING
+		` + cashLaunchColumns + `;`
 
-	row := postgresCashLaunch.Postgres.Conn.QueryRow(
+	// lock the current launch to move its value out of the previous date
+	modelCashLaunchCurrent := model.CashLaunch{}
+
+	err := cashLaunchScan(tx.QueryRow(
+		`SELECT `+cashLaunchColumns+` FROM cash_launch WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`,
+		modelCashLaunch.ID,
+	), &modelCashLaunchCurrent)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			// repository error not found
+			err = repository.ErrNotFound{Message: err.Error()}
+		}
+
+		return &model.CashLaunch{}, err
+	}
+
+	row := tx.QueryRow(
 		query,
 		modelCashLaunch.ID,
+		modelCashLaunch.AccountID,
+		modelCashLaunch.CategoryID,
 		modelCashLaunch.ReferenceDate,
 		modelCashLaunch.Type,
 		modelCashLaunch.Description,
 		modelCashLaunch.Value,
+		modelCashLaunch.Currency,
+		modelCashLaunch.ExchangeRate,
+		modelCashLaunch.BaseValue,
+		modelCashLaunch.Status,
+		modelCashLaunch.ReviewComment,
+		modelCashLaunch.ReviewedBy,
+		modelCashLaunch.ReviewedAt,
 		modelCashLaunch.UpdatedAt,
 	)
 
 	modelCashLaunchUpdate := &model.CashLaunch{}
 
-	err := row.Scan(
-		&modelCashLaunchUpdate.ID,
-		&modelCashLaunchUpdate.ReferenceDate,
-		&modelCashLaunchUpdate.Type,
-		&modelCashLaunchUpdate.Description,
-		&modelCashLaunchUpdate.Value,
-		&modelCashLaunchUpdate.UpdatedAt,
-		&modelCashLaunchUpdate.CreatedAt,
-	)
+	err = cashLaunchScan(row, modelCashLaunchUpdate)
 
 	if err != nil {
 		if err.Error() == "sql: no rows in result set" {
 			// repository error not found
 			err = repository.ErrNotFound{Message: err.Error()}
-		} else if errPQ, ok := err.(*pq.Error); ok {
-			// repository error duplicate key
-			if errPQ.Code == "23505" {
-				err = repository.ErrDuplicateKey{Message: errPQ.Detail}
-			}
+		} else {
+			// repository error duplicate key or closed period
+			err = postgresError(err)
 		}
+
+		return modelCashLaunchUpdate, err
 	}
 
-	return modelCashLaunchUpdate, err
-}
+	err = cashLaunchBalanceApply(tx, &modelCashLaunchCurrent, -1)
 
-func (postgresCashLaunch *PostgresCashLaunch) DeleteByID(id int64) error {
-	query :=
-		`DELETE FROM
-		cash_launch
-	WHERE
-		id = $1`
+	if err != nil {
+		return modelCashLaunchUpdate, err
+	}
+
+	err = cashLaunchBalanceApply(tx, modelCashLaunchUpdate, 1)
 
-	sqlResult, err := postgresCashLaunch.Postgres.Conn.Exec(query, id)
+	if err != nil {
+		return modelCashLaunchUpdate, err
+	}
 
-	if err == nil {
-		rowsAffected, errRA := sqlResult.RowsAffected()
+	err = cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionUpdate, &modelCashLaunchCurrent, modelCashLaunchUpdate, modelAudit)
 
-		if errRA != nil {
-			err = errRA
-		} else if rowsAffected == 0 {
-			err = repository.ErrNotFound{}
+	if err != nil {
+		return modelCashLaunchUpdate, err
+	}
+
+	if modelCashLaunchUpdate.TransferID != 0 {
+		err = cashLaunchTransferPairUpdate(tx, modelCashLaunchUpdate, modelAudit)
+
+		if err != nil {
+			return modelCashLaunchUpdate, err
 		}
 	}
 
-	return err
+	return modelCashLaunchUpdate, nil
+}
+
+// cashLaunchTransferPairUpdate copies the date, description and values of an
+// updated transfer launch to the other side of the transfer, which keeps its
+// own account and type
+func cashLaunchTransferPairUpdate(tx *sql.Tx, modelCashLaunch *model.CashLaunch, modelAudit model.Audit) error {
+	modelCashLaunchPair := model.CashLaunch{}
+
+	err := cashLaunchScan(tx.QueryRow(
+		`SELECT `+cashLaunchColumns+` FROM cash_launch WHERE transfer_id = $1 AND id <> $2 FOR UPDATE`,
+		modelCashLaunch.TransferID, modelCashLaunch.ID,
+	), &modelCashLaunchPair)
+
+	if err != nil {
+		return err
+	}
+
+	modelCashLaunchPairUpdate := model.CashLaunch{}
+
+	err = cashLaunchScan(tx.QueryRow(
+		`UPDATE
+			cash_launch
+		SET
+			reference_date = $2,
+			description = $3,
+			value = $4,
+			currency = $5,
+			exchange_rate = $6,
+			base_value = $7,
+			updated_at = $8
+		WHERE
+			id = $1
+		RETURNING
+			`+cashLaunchColumns,
+		modelCashLaunchPair.ID,
+		modelCashLaunch.ReferenceDate,
+		modelCashLaunch.Description,
+		modelCashLaunch.Value,
+		modelCashLaunch.Currency,
+		modelCashLaunch.ExchangeRate,
+		modelCashLaunch.BaseValue,
+		modelCashLaunch.UpdatedAt,
+	), &modelCashLaunchPairUpdate)
+
+	if err != nil {
+		return postgresError(err)
+	}
+
+	err = cashLaunchBalanceApply(tx, &modelCashLaunchPair, -1)
+
+	if err != nil {
+		return err
+	}
+
+	err = cashLaunchBalanceApply(tx, &modelCashLaunchPairUpdate, 1)
+
+	if err != nil {
+		return err
+	}
+
+	return cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionUpdate, &modelCashLaunchPair, &modelCashLaunchPairUpdate, modelAudit)
+}
+
+// cashLaunchScan reads the cashLaunchColumns of the row into the launch
+func cashLaunchScan(row interface{ Scan(dest ...any) error }, modelCashLaunch *model.CashLaunch) error {
+	return row.Scan(
+		&modelCashLaunch.ID,
+		&modelCashLaunch.AccountID,
+		&modelCashLaunch.CategoryID,
+		&modelCashLaunch.TransferID,
+		&modelCashLaunch.RecurrenceID,
+		&modelCashLaunch.InstallmentGroupID,
+		&modelCashLaunch.InstallmentNumber,
+		&modelCashLaunch.InstallmentCount,
+		&modelCashLaunch.ReversalOfID,
+		&modelCashLaunch.ReversedByID,
+		&modelCashLaunch.ExternalID,
+		&modelCashLaunch.ReferenceDate,
+		&modelCashLaunch.Type,
+		&modelCashLaunch.Description,
+		&modelCashLaunch.Value,
+		&modelCashLaunch.Currency,
+		&modelCashLaunch.ExchangeRate,
+		&modelCashLaunch.BaseValue,
+		&modelCashLaunch.Status,
+		&modelCashLaunch.ReviewComment,
+		&modelCashLaunch.ReviewedBy,
+		&modelCashLaunch.ReviewedAt,
+		&modelCashLaunch.UpdatedAt,
+		&modelCashLaunch.CreatedAt,
+		&modelCashLaunch.DeletedAt,
+	)
 }
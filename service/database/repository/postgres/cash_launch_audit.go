@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+// ListAuditByID returns the history of the launch in the order of the changes
+func (postgresCashLaunch *PostgresCashLaunch) ListAuditByID(id int64) (model.CashLaunchAudits, error) {
+	query :=
+		`SELECT
+			id, launch_id, action, before, after, actor, request_id, created_at
+		FROM
+			cash_launch_audit
+		WHERE
+			launch_id = $1
+		ORDER BY
+			id`
+
+	rows, err := postgresCashLaunch.Postgres.Conn.Query(query, id)
+
+	modelCashLaunchAudits := model.CashLaunchAudits{}
+
+	if err != nil {
+		return modelCashLaunchAudits, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashLaunchAudit := model.CashLaunchAudit{}
+		before := []byte{}
+		after := []byte{}
+
+		err = rows.Scan(
+			&modelCashLaunchAudit.ID,
+			&modelCashLaunchAudit.LaunchID,
+			&modelCashLaunchAudit.Action,
+			&before,
+			&after,
+			&modelCashLaunchAudit.Actor,
+			&modelCashLaunchAudit.RequestID,
+			&modelCashLaunchAudit.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the null before of an insert is kept as nil
+		if len(before) > 0 {
+			err = json.Unmarshal(before, &modelCashLaunchAudit.Before)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = json.Unmarshal(after, &modelCashLaunchAudit.After)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunchAudits = append(modelCashLaunchAudits, modelCashLaunchAudit)
+	}
+
+	return modelCashLaunchAudits, rows.Err()
+}
+
+// cashLaunchAuditInsert appends the change of the launch to its history inside
+// the transaction of the change, the before of an insert is stored as null
+func cashLaunchAuditInsert(tx *sql.Tx, action string, modelCashLaunchBefore *model.CashLaunch, modelCashLaunchAfter *model.CashLaunch, modelAudit model.Audit) error {
+	var before interface{}
+
+	if modelCashLaunchBefore != nil {
+		beforeJSON, err := json.Marshal(modelCashLaunchBefore)
+
+		if err != nil {
+			return err
+		}
+
+		before = string(beforeJSON)
+	}
+
+	after, err := json.Marshal(modelCashLaunchAfter)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO
+			cash_launch_audit
+			(launch_id, action, before, after, actor, request_id)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`,
+		modelCashLaunchAfter.ID,
+		action,
+		before,
+		string(after),
+		modelAudit.Actor,
+		modelAudit.RequestID,
+	)
+
+	return err
+}
+
+// cashLaunchAuditDelete appends the deletion of the launch to its history, the
+// launch before it is the deleted one without the deleted_at
+func cashLaunchAuditDelete(tx *sql.Tx, modelCashLaunch *model.CashLaunch, modelAudit model.Audit) error {
+	modelCashLaunchBefore := *modelCashLaunch
+	modelCashLaunchBefore.DeletedAt = nil
+
+	return cashLaunchAuditInsert(tx, repository.CashLaunchAuditActionDelete, &modelCashLaunchBefore, modelCashLaunch, modelAudit)
+}
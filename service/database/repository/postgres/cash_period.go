@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+const cashPeriodColumns = `period, status, closing_balance, closed_by, closed_at, reopened_by, reopened_at, updated_at`
+
+type PostgresCashPeriod struct {
+	Postgres *Postgres
+}
+
+func NewCashPeriod(postgres *Postgres) repository.CashPeriod {
+	return &PostgresCashPeriod{Postgres: postgres}
+}
+
+func (postgresCashPeriod *PostgresCashPeriod) List(from time.Time, to time.Time) (model.CashPeriods, error) {
+	query :=
+		`SELECT
+			` + cashPeriodColumns + `
+		FROM
+			cash_period
+		WHERE
+			period BETWEEN $1 AND $2
+		ORDER BY
+			period`
+
+	rows, err := postgresCashPeriod.Postgres.Conn.Query(query, from, to)
+
+	modelCashPeriods := model.CashPeriods{}
+
+	if err != nil {
+		return modelCashPeriods, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashPeriod := model.CashPeriod{}
+
+		err = cashPeriodScan(rows, &modelCashPeriod)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashPeriods = append(modelCashPeriods, modelCashPeriod)
+	}
+
+	return modelCashPeriods, rows.Err()
+}
+
+func (postgresCashPeriod *PostgresCashPeriod) GetByPeriod(period time.Time) (*model.CashPeriod, error) {
+	query :=
+		`SELECT
+			` + cashPeriodColumns + `
+		FROM
+			cash_period
+		WHERE
+			period = $1`
+
+	row := postgresCashPeriod.Postgres.Conn.QueryRow(query, period)
+
+	modelCashPeriod := model.CashPeriod{}
+
+	err := cashPeriodScan(row, &modelCashPeriod)
+
+	return &modelCashPeriod, postgresError(err)
+}
+
+// Close stores the period as closed, the period reopened is closed again with
+// a new closing balance. The cash_launch table is locked in share mode,
+// waiting for the changes of launches in progress and holding the new ones
+// until the period is closed, so the closing balance read in the same
+// statement has every launch of the month and the trigger
+// cash_launch_period_open of a change never misses the close.
+func (postgresCashPeriod *PostgresCashPeriod) Close(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error) {
+	query :=
+		`INSERT INTO
+			cash_period
+			(period, status, closing_balance, closed_by, closed_at, updated_at)
+		VALUES
+			(
+				$1,
+				'closed',
+				COALESCE((SELECT closing_balance FROM cash_balance_daily WHERE account_id = 0 AND reference_date < $1::date + interval '1 month' ORDER BY reference_date DESC LIMIT 1), 0),
+				$2,
+				$3,
+				$3
+			)
+		ON CONFLICT (period) DO UPDATE SET
+			status = EXCLUDED.status,
+			closing_balance = EXCLUDED.closing_balance,
+			closed_by = EXCLUDED.closed_by,
+			closed_at = EXCLUDED.closed_at,
+			updated_at = EXCLUDED.updated_at
+		WHERE
+			cash_period.status = 'open'
+		RETURNING
+			` + cashPeriodColumns
+
+	modelCashPeriodClose := &model.CashPeriod{}
+
+	tx, err := postgresCashPeriod.Postgres.Conn.Begin()
+
+	if err != nil {
+		return modelCashPeriodClose, err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`LOCK TABLE cash_launch IN SHARE MODE`)
+
+	if err != nil {
+		return modelCashPeriodClose, err
+	}
+
+	row := tx.QueryRow(
+		query,
+		modelCashPeriod.Period,
+		modelCashPeriod.ClosedBy,
+		modelCashPeriod.ClosedAt,
+	)
+
+	err = cashPeriodScan(row, modelCashPeriodClose)
+
+	// the period closed meanwhile is left untouched
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return modelCashPeriodClose, repository.ErrDuplicateKey{Message: "period already closed"}
+	}
+
+	if err != nil {
+		return modelCashPeriodClose, postgresError(err)
+	}
+
+	return modelCashPeriodClose, tx.Commit()
+}
+
+func (postgresCashPeriod *PostgresCashPeriod) Reopen(modelCashPeriod *model.CashPeriod) (*model.CashPeriod, error) {
+	query :=
+		`UPDATE
+			cash_period
+		SET
+			status = 'open',
+			reopened_by = $2,
+			reopened_at = $3,
+			updated_at = $3
+		WHERE
+			period = $1 AND
+			status = 'closed'
+		RETURNING
+			` + cashPeriodColumns
+
+	row := postgresCashPeriod.Postgres.Conn.QueryRow(
+		query,
+		modelCashPeriod.Period,
+		modelCashPeriod.ReopenedBy,
+		modelCashPeriod.ReopenedAt,
+	)
+
+	modelCashPeriodReopen := &model.CashPeriod{}
+
+	err := cashPeriodScan(row, modelCashPeriodReopen)
+
+	return modelCashPeriodReopen, postgresError(err)
+}
+
+// cashPeriodScan reads the cashPeriodColumns of the row into the period
+func cashPeriodScan(row interface{ Scan(dest ...any) error }, modelCashPeriod *model.CashPeriod) error {
+	return row.Scan(
+		&modelCashPeriod.Period,
+		&modelCashPeriod.Status,
+		&modelCashPeriod.ClosingBalance,
+		&modelCashPeriod.ClosedBy,
+		&modelCashPeriod.ClosedAt,
+		&modelCashPeriod.ReopenedBy,
+		&modelCashPeriod.ReopenedAt,
+		&modelCashPeriod.UpdatedAt,
+	)
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+const cashRecurrenceColumns = `id, account_id, COALESCE(category_id, 0), type, description, value, currency, frequency, day, start_date, end_date, last_date, updated_at, created_at`
+
+type PostgresCashRecurrence struct {
+	Postgres *Postgres
+}
+
+func NewCashRecurrence(postgres *Postgres) repository.CashRecurrence {
+	return &PostgresCashRecurrence{Postgres: postgres}
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	query :=
+		`INSERT INTO 
+			cash_recurrence
+			(account_id, category_id, type, description, value, currency, frequency, day, start_date, end_date, updated_at, created_at)
+		VALUES
+			($1, NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING
+			` + cashRecurrenceColumns + `;`
+
+	row := postgresCashRecurrence.Postgres.Conn.QueryRow(
+		query,
+		modelCashRecurrence.AccountID,
+		modelCashRecurrence.CategoryID,
+		modelCashRecurrence.Type,
+		modelCashRecurrence.Description,
+		modelCashRecurrence.Value,
+		modelCashRecurrence.Currency,
+		modelCashRecurrence.Frequency,
+		modelCashRecurrence.Day,
+		modelCashRecurrence.StartDate,
+		nullTime(modelCashRecurrence.EndDate),
+		modelCashRecurrence.UpdatedAt,
+		modelCashRecurrence.CreatedAt,
+	)
+
+	modelCashRecurrenceInsert := &model.CashRecurrence{}
+
+	err := cashRecurrenceScan(row, modelCashRecurrenceInsert)
+
+	return modelCashRecurrenceInsert, postgresError(err)
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) List() (model.CashRecurrences, error) {
+	query :=
+		`SELECT
+			` + cashRecurrenceColumns + `
+		FROM
+			cash_recurrence
+		ORDER BY
+			id`
+
+	rows, err := postgresCashRecurrence.Postgres.Conn.Query(query)
+
+	modelCashRecurrences := model.CashRecurrences{}
+
+	if err != nil {
+		return modelCashRecurrences, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashRecurrence := model.CashRecurrence{}
+
+		err = cashRecurrenceScan(rows, &modelCashRecurrence)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashRecurrences = append(modelCashRecurrences, modelCashRecurrence)
+	}
+
+	return modelCashRecurrences, err
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) GetByID(id int64) (*model.CashRecurrence, error) {
+	query :=
+		`SELECT
+			` + cashRecurrenceColumns + `
+		FROM
+			cash_recurrence
+		WHERE
+			id = $1`
+
+	row := postgresCashRecurrence.Postgres.Conn.QueryRow(query, id)
+
+	modelCashRecurrence := model.CashRecurrence{}
+
+	err := cashRecurrenceScan(row, &modelCashRecurrence)
+
+	return &modelCashRecurrence, postgresError(err)
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	query :=
+		`UPDATE
+		cash_recurrence
+	SET
+		account_id = $2,
+		category_id = NULLIF($3, 0),
+		type = $4,
+		description = $5,
+		value = $6,
+		currency = $7,
+		frequency = $8,
+		day = $9,
+		start_date = $10,
+		end_date = $11,
+		updated_at = $12
+	WHERE
+		id = $1
+	RETURNING
+		` + cashRecurrenceColumns + `;`
+
+	row := postgresCashRecurrence.Postgres.Conn.QueryRow(
+		query,
+		modelCashRecurrence.ID,
+		modelCashRecurrence.AccountID,
+		modelCashRecurrence.CategoryID,
+		modelCashRecurrence.Type,
+		modelCashRecurrence.Description,
+		modelCashRecurrence.Value,
+		modelCashRecurrence.Currency,
+		modelCashRecurrence.Frequency,
+		modelCashRecurrence.Day,
+		modelCashRecurrence.StartDate,
+		nullTime(modelCashRecurrence.EndDate),
+		modelCashRecurrence.UpdatedAt,
+	)
+
+	modelCashRecurrenceUpdate := &model.CashRecurrence{}
+
+	err := cashRecurrenceScan(row, modelCashRecurrenceUpdate)
+
+	return modelCashRecurrenceUpdate, postgresError(err)
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) DeleteByID(id int64) error {
+	query :=
+		`DELETE FROM
+		cash_recurrence
+	WHERE
+		id = $1
+	RETURNING id`
+
+	err := postgresCashRecurrence.Postgres.Conn.QueryRow(query, id).Scan(&id)
+
+	return postgresError(err)
+}
+
+func (postgresCashRecurrence *PostgresCashRecurrence) LaunchInsert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	tx, err := postgresCashRecurrence.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	modelCashLaunchInsert, err := cashLaunchInsert(tx, modelCashLaunch, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE cash_recurrence SET last_date = $2 WHERE id = $1 AND (last_date IS NULL OR last_date < $2)`,
+		modelCashLaunchInsert.RecurrenceID, modelCashLaunchInsert.ReferenceDate,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchInsert, tx.Commit()
+}
+
+// cashRecurrenceScan reads the cashRecurrenceColumns, the null end_date and
+// last_date are read as the zero time
+func cashRecurrenceScan(row interface{ Scan(dest ...any) error }, modelCashRecurrence *model.CashRecurrence) error {
+	endDate := sql.NullTime{}
+	lastDate := sql.NullTime{}
+
+	err := row.Scan(
+		&modelCashRecurrence.ID,
+		&modelCashRecurrence.AccountID,
+		&modelCashRecurrence.CategoryID,
+		&modelCashRecurrence.Type,
+		&modelCashRecurrence.Description,
+		&modelCashRecurrence.Value,
+		&modelCashRecurrence.Currency,
+		&modelCashRecurrence.Frequency,
+		&modelCashRecurrence.Day,
+		&modelCashRecurrence.StartDate,
+		&endDate,
+		&lastDate,
+		&modelCashRecurrence.UpdatedAt,
+		&modelCashRecurrence.CreatedAt,
+	)
+
+	modelCashRecurrence.EndDate = endDate.Time
+	modelCashRecurrence.LastDate = lastDate.Time
+
+	return err
+}
+
+// nullTime stores the zero time as null
+func nullTime(value time.Time) sql.NullTime {
+	return sql.NullTime{Time: value, Valid: !value.IsZero()}
+}
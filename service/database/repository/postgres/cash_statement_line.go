@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+const cashStatementLineColumns = `id, account_id, COALESCE(external_id, ''), reference_date, type, description, value, COALESCE(launch_id, 0), match_type, matched_by, matched_at, created_at`
+
+type PostgresCashStatementLine struct {
+	Postgres *Postgres
+}
+
+func NewCashStatementLine(postgres *Postgres) repository.CashStatementLine {
+	return &PostgresCashStatementLine{Postgres: postgres}
+}
+
+func (postgresCashStatementLine *PostgresCashStatementLine) Insert(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error) {
+	query :=
+		`INSERT INTO
+			cash_statement_line
+			(account_id, external_id, reference_date, type, description, value)
+		VALUES
+			($1, NULLIF($2, ''), $3, $4, $5, $6)
+		RETURNING
+			` + cashStatementLineColumns
+
+	row := postgresCashStatementLine.Postgres.Conn.QueryRow(
+		query,
+		modelCashStatementLine.AccountID,
+		modelCashStatementLine.ExternalID,
+		modelCashStatementLine.ReferenceDate,
+		modelCashStatementLine.Type,
+		modelCashStatementLine.Description,
+		modelCashStatementLine.Value,
+	)
+
+	modelCashStatementLineInsert := &model.CashStatementLine{}
+
+	err := cashStatementLineScan(row, modelCashStatementLineInsert)
+
+	return modelCashStatementLineInsert, postgresError(err)
+}
+
+func (postgresCashStatementLine *PostgresCashStatementLine) List(modelCashStatementLineFilter *model.CashStatementLineFilter) (model.CashStatementLines, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	conditionAppend := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if modelCashStatementLineFilter.AccountID != 0 {
+		conditionAppend("account_id = $%d", modelCashStatementLineFilter.AccountID)
+	}
+
+	if !modelCashStatementLineFilter.ReferenceDateFrom.IsZero() {
+		conditionAppend("reference_date >= $%d", modelCashStatementLineFilter.ReferenceDateFrom)
+	}
+
+	if !modelCashStatementLineFilter.ReferenceDateTo.IsZero() {
+		conditionAppend("reference_date <= $%d", modelCashStatementLineFilter.ReferenceDateTo)
+	}
+
+	if modelCashStatementLineFilter.Unmatched {
+		conditions = append(conditions, "launch_id IS NULL")
+	}
+
+	if modelCashStatementLineFilter.Matched {
+		conditions = append(conditions, "launch_id IS NOT NULL")
+	}
+
+	where := ""
+
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(
+		`SELECT
+			`+cashStatementLineColumns+`
+		FROM
+			cash_statement_line
+		%s
+		ORDER BY
+			reference_date, id`,
+		where)
+
+	rows, err := postgresCashStatementLine.Postgres.Conn.Query(query, args...)
+
+	modelCashStatementLines := model.CashStatementLines{}
+
+	if err != nil {
+		return modelCashStatementLines, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		modelCashStatementLine := model.CashStatementLine{}
+
+		err = cashStatementLineScan(rows, &modelCashStatementLine)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashStatementLines = append(modelCashStatementLines, modelCashStatementLine)
+	}
+
+	return modelCashStatementLines, rows.Err()
+}
+
+func (postgresCashStatementLine *PostgresCashStatementLine) GetByID(id int64) (*model.CashStatementLine, error) {
+	query :=
+		`SELECT
+			` + cashStatementLineColumns + `
+		FROM
+			cash_statement_line
+		WHERE
+			id = $1`
+
+	row := postgresCashStatementLine.Postgres.Conn.QueryRow(query, id)
+
+	modelCashStatementLine := model.CashStatementLine{}
+
+	err := cashStatementLineScan(row, &modelCashStatementLine)
+
+	return &modelCashStatementLine, postgresError(err)
+}
+
+func (postgresCashStatementLine *PostgresCashStatementLine) Match(modelCashStatementLine *model.CashStatementLine) (*model.CashStatementLine, error) {
+	query :=
+		`UPDATE
+			cash_statement_line
+		SET
+			launch_id = $2,
+			match_type = $3,
+			matched_by = $4,
+			matched_at = $5
+		WHERE
+			id = $1 AND
+			launch_id IS NULL
+		RETURNING
+			` + cashStatementLineColumns
+
+	row := postgresCashStatementLine.Postgres.Conn.QueryRow(
+		query,
+		modelCashStatementLine.ID,
+		modelCashStatementLine.LaunchID,
+		modelCashStatementLine.MatchType,
+		modelCashStatementLine.MatchedBy,
+		modelCashStatementLine.MatchedAt,
+	)
+
+	modelCashStatementLineMatch := &model.CashStatementLine{}
+
+	err := cashStatementLineScan(row, modelCashStatementLineMatch)
+
+	return modelCashStatementLineMatch, postgresError(err)
+}
+
+func (postgresCashStatementLine *PostgresCashStatementLine) Unmatch(id int64) (*model.CashStatementLine, error) {
+	query :=
+		`UPDATE
+			cash_statement_line
+		SET
+			launch_id = NULL,
+			match_type = '',
+			matched_by = '',
+			matched_at = NULL
+		WHERE
+			id = $1 AND
+			launch_id IS NOT NULL
+		RETURNING
+			` + cashStatementLineColumns
+
+	row := postgresCashStatementLine.Postgres.Conn.QueryRow(query, id)
+
+	modelCashStatementLineUnmatch := &model.CashStatementLine{}
+
+	err := cashStatementLineScan(row, modelCashStatementLineUnmatch)
+
+	return modelCashStatementLineUnmatch, postgresError(err)
+}
+
+// cashStatementLineScan reads the cashStatementLineColumns of the row into the
+// line
+func cashStatementLineScan(row interface{ Scan(dest ...any) error }, modelCashStatementLine *model.CashStatementLine) error {
+	return row.Scan(
+		&modelCashStatementLine.ID,
+		&modelCashStatementLine.AccountID,
+		&modelCashStatementLine.ExternalID,
+		&modelCashStatementLine.ReferenceDate,
+		&modelCashStatementLine.Type,
+		&modelCashStatementLine.Description,
+		&modelCashStatementLine.Value,
+		&modelCashStatementLine.LaunchID,
+		&modelCashStatementLine.MatchType,
+		&modelCashStatementLine.MatchedBy,
+		&modelCashStatementLine.MatchedAt,
+		&modelCashStatementLine.CreatedAt,
+	)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresCashTransfer struct {
+	Postgres *Postgres
+}
+
+func NewCashTransfer(postgres *Postgres) repository.CashTransfer {
+	return &PostgresCashTransfer{Postgres: postgres}
+}
+
+// Insert persists the debit and the credit of the transfer in the same
+// transaction linked by a new transfer_id
+func (postgresCashTransfer *PostgresCashTransfer) Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error) {
+	tx, err := postgresCashTransfer.Postgres.Conn.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	modelCashTransferInsert := *modelCashTransfer
+
+	err = tx.QueryRow(`SELECT nextval('cash_transfer_id_seq')`).Scan(&modelCashTransferInsert.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelCashLaunch := range []*model.CashLaunch{&modelCashTransferInsert.Debit, &modelCashTransferInsert.Credit} {
+		modelCashLaunch.TransferID = modelCashTransferInsert.ID
+
+		modelCashLaunchInsert, err := cashLaunchInsert(tx, modelCashLaunch, modelAudit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*modelCashLaunch = *modelCashLaunchInsert
+	}
+
+	return &modelCashTransferInsert, tx.Commit()
+}
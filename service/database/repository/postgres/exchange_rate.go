@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+type PostgresExchangeRate struct {
+	Postgres *Postgres
+}
+
+func NewExchangeRate(postgres *Postgres) repository.ExchangeRate {
+	return &PostgresExchangeRate{Postgres: postgres}
+}
+
+func (postgresExchangeRate *PostgresExchangeRate) Upsert(modelExchangeRates model.ExchangeRates) error {
+	query :=
+		`INSERT INTO
+			exchange_rate
+			(reference_date, base_currency, currency, rate, updated_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (reference_date, base_currency, currency) DO UPDATE SET
+			rate = EXCLUDED.rate,
+			updated_at = EXCLUDED.updated_at`
+
+	tx, err := postgresExchangeRate.Postgres.Conn.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for _, modelExchangeRate := range modelExchangeRates {
+		_, err = tx.Exec(
+			query,
+			modelExchangeRate.ReferenceDate,
+			modelExchangeRate.BaseCurrency,
+			modelExchangeRate.Currency,
+			modelExchangeRate.Rate,
+			modelExchangeRate.UpdatedAt,
+			modelExchangeRate.CreatedAt,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (postgresExchangeRate *PostgresExchangeRate) GetByReferenceDate(referenceDate time.Time) (model.ExchangeRates, error) {
+	// the rates are not published on weekends and holidays so the last
+	// published rates on or before the reference date are returned
+	query :=
+		`SELECT
+			reference_date, base_currency, currency, rate, updated_at, created_at
+		FROM
+			exchange_rate
+		WHERE
+			reference_date = (SELECT MAX(reference_date) FROM exchange_rate WHERE reference_date <= $1)
+		ORDER BY
+			base_currency, currency`
+
+	rows, err := postgresExchangeRate.Postgres.Conn.Query(query, referenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelExchangeRates, err := exchangeRateRowsScan(rows)
+
+	// repository error not found
+	if err == nil && len(modelExchangeRates) == 0 {
+		err = repository.ErrNotFound{Message: "not found"}
+	}
+
+	return modelExchangeRates, err
+}
+
+func (postgresExchangeRate *PostgresExchangeRate) GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams *model.ExchangeRateRangeReferenceDate) (model.ExchangeRates, error) {
+	query :=
+		`SELECT
+			reference_date, base_currency, currency, rate, updated_at, created_at
+		FROM
+			exchange_rate
+		WHERE
+			reference_date BETWEEN $1 AND $2
+		ORDER BY
+			reference_date, base_currency, currency`
+
+	rows, err := postgresExchangeRate.Postgres.Conn.Query(query, exchangeRateRangeReferenceDateParams.From, exchangeRateRangeReferenceDateParams.To)
+
+	if err != nil {
+		return model.ExchangeRates{}, err
+	}
+
+	return exchangeRateRowsScan(rows)
+}
+
+func exchangeRateRowsScan(rows *sql.Rows) (model.ExchangeRates, error) {
+	defer rows.Close()
+
+	modelExchangeRates := model.ExchangeRates{}
+
+	for rows.Next() {
+		modelExchangeRate := model.ExchangeRate{}
+
+		err := rows.Scan(
+			&modelExchangeRate.ReferenceDate,
+			&modelExchangeRate.BaseCurrency,
+			&modelExchangeRate.Currency,
+			&modelExchangeRate.Rate,
+			&modelExchangeRate.UpdatedAt,
+			&modelExchangeRate.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelExchangeRates = append(modelExchangeRates, modelExchangeRate)
+	}
+
+	return modelExchangeRates, rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/lib/pq"
 )
 
 type Postgres struct {
@@ -41,10 +42,79 @@ func (postgres *Postgres) Close() error {
 	return postgres.Conn.Close()
 }
 
+func (postgres *Postgres) CashAccount() repository.CashAccount {
+	return NewCashAccount(postgres)
+}
+
+func (postgres *Postgres) CashCategory() repository.CashCategory {
+	return NewCashCategory(postgres)
+}
+
+func (postgres *Postgres) CashCategoryRule() repository.CashCategoryRule {
+	return NewCashCategoryRule(postgres)
+}
+
+func (postgres *Postgres) CashBudget() repository.CashBudget {
+	return NewCashBudget(postgres)
+}
+
 func (postgres *Postgres) CashLaunch() repository.CashLaunch {
 	return NewCashLaunch(postgres)
 }
 
+func (postgres *Postgres) CashTransfer() repository.CashTransfer {
+	return NewCashTransfer(postgres)
+}
+
+func (postgres *Postgres) CashInstallment() repository.CashInstallment {
+	return NewCashInstallment(postgres)
+}
+
+func (postgres *Postgres) CashRecurrence() repository.CashRecurrence {
+	return NewCashRecurrence(postgres)
+}
+
 func (postgres *Postgres) CashBalanceDaily() repository.CashBalanceDaily {
 	return NewCashBalanceDaily(postgres)
 }
+
+func (postgres *Postgres) CashPeriod() repository.CashPeriod {
+	return NewCashPeriod(postgres)
+}
+
+func (postgres *Postgres) CashStatementLine() repository.CashStatementLine {
+	return NewCashStatementLine(postgres)
+}
+
+func (postgres *Postgres) ExchangeRate() repository.ExchangeRate {
+	return NewExchangeRate(postgres)
+}
+
+// postgresError translates the postgres errors into the repository errors
+func postgresError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if err.Error() == "sql: no rows in result set" {
+		// repository error not found
+		return repository.ErrNotFound{Message: err.Error()}
+	}
+
+	if errPQ, ok := err.(*pq.Error); ok {
+		switch errPQ.Code {
+		case "23505":
+			// repository error duplicate key
+			return repository.ErrDuplicateKey{Message: errPQ.Detail}
+		case "23503":
+			// repository error record still referenced by another table
+			return repository.ErrReferenced{Message: errPQ.Detail, Table: errPQ.Table}
+		case "CP001":
+			// repository error launch in a closed period raised by the
+			// trigger cash_launch_period_open
+			return repository.ErrPeriodClosed{Message: errPQ.Message}
+		}
+	}
+
+	return err
+}
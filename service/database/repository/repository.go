@@ -1,8 +1,18 @@
 package repository
 
 type Repository interface {
+	CashAccount() CashAccount
+	CashCategory() CashCategory
+	CashCategoryRule() CashCategoryRule
+	CashBudget() CashBudget
+	CashRecurrence() CashRecurrence
 	CashLaunch() CashLaunch
+	CashTransfer() CashTransfer
+	CashInstallment() CashInstallment
 	CashBalanceDaily() CashBalanceDaily
+	CashPeriod() CashPeriod
+	CashStatementLine() CashStatementLine
+	ExchangeRate() ExchangeRate
 	Check() error
 	Close() error
 }
@@ -26,3 +36,25 @@ type ErrNotFound struct {
 func (enf ErrNotFound) Error() string {
 	return enf.Message
 }
+
+// ErrPeriodClosed denotes failing change of a launch in a closed period.
+type ErrPeriodClosed struct {
+	Message string
+}
+
+// ErrPeriodClosed returns the repository error closed period message.
+func (epc ErrPeriodClosed) Error() string {
+	return epc.Message
+}
+
+// ErrReferenced denotes failing repository record still referenced by others.
+type ErrReferenced struct {
+	Message string
+	// Table is the table of the records still referencing it, empty when unknown
+	Table string
+}
+
+// ErrReferenced returns the repository error referenced message.
+func (er ErrReferenced) Error() string {
+	return er.Message
+}
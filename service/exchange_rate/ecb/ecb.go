@@ -0,0 +1,95 @@
+package exchange_rate
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	exchange_rate "github.com/CharlesSchiavinato/minsait-challenge-backend/service/exchange_rate"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+)
+
+// ECBBaseCurrency is the currency all the ECB reference rates are quoted against
+const ECBBaseCurrency = "EUR"
+
+type ECB struct {
+	URL    string
+	Client *http.Client
+}
+
+// ecbEnvelope maps the gesmes envelope of the ECB eurofxref feed
+//
+//	<gesmes:Envelope>
+//		<Cube>
+//			<Cube time="2023-05-05">
+//				<Cube currency="USD" rate="1.1011"/>
+type ecbEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Cube    struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string          `xml:"currency,attr"`
+				Rate     decimal.Decimal `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func NewECB(config *util.Config) exchange_rate.ExchangeRate {
+	return &ECB{
+		URL:    config.ExchangeRateURL,
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (ecb *ECB) Fetch() (model.ExchangeRates, error) {
+	res, err := ecb.Client.Get(ecb.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error fetching exchange rate: status code %d", res.StatusCode)
+	}
+
+	envelope := ecbEnvelope{}
+
+	err = xml.NewDecoder(res.Body).Decode(&envelope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelExchangeRates := model.ExchangeRates{}
+
+	for _, day := range envelope.Cube.Days {
+		referenceDate, err := time.Parse("2006-01-02", day.Time)
+
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing exchange rate time %q: %w", day.Time, err)
+		}
+
+		for _, rate := range day.Rates {
+			modelExchangeRates = append(modelExchangeRates, model.ExchangeRate{
+				ReferenceDate: referenceDate,
+				BaseCurrency:  ECBBaseCurrency,
+				Currency:      rate.Currency,
+				Rate:          rate.Rate,
+			})
+		}
+	}
+
+	if len(modelExchangeRates) == 0 {
+		return nil, errors.New("Error fetching exchange rate: envelope without rates")
+	}
+
+	return modelExchangeRates, nil
+}
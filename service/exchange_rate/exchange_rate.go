@@ -0,0 +1,8 @@
+package exchange_rate
+
+import "github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+
+type ExchangeRate interface {
+	// Fetch returns the latest rates published by the provider
+	Fetch() (model.ExchangeRates, error)
+}
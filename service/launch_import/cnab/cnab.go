@@ -0,0 +1,586 @@
+package launch_import
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	"github.com/shopspring/decimal"
+)
+
+// CNAB240SettlementMovements are the movements of the segment T of the titles
+// settled (06=liquidação, 17=liquidação após baixa)
+var CNAB240SettlementMovements = []string{"06", "17"}
+
+// CNAB240PaymentOccurrence is the first occurrence of the segments A and J of
+// the payments made
+var CNAB240PaymentOccurrence = "00"
+
+// CNAB400SettlementOccurrences are the occurrences of the detail of the titles
+// settled (06=liquidação, 15=liquidação em cartório, 17=liquidação após baixa)
+var CNAB400SettlementOccurrences = []string{"06", "15", "17"}
+
+// CNAB400OurNumberPositions maps the bank of the header to the positions of
+// the nosso número in the detail, as the CNAB 400 varies among the banks.
+// The banks not mapped use the positions of the empty bank.
+var CNAB400OurNumberPositions = map[string][2]int{
+	"":    {71, 82},
+	"341": {63, 70},
+}
+
+// CNABDescriptionMaxLen is the size of the description of the launches, the
+// names of the payers and payees are cut to fit
+var CNABDescriptionMaxLen = 100
+
+type CNABOptions struct {
+	// AccountID is the account of the launches, the account of the file is not
+	// read
+	AccountID int64
+}
+
+// CNAB reads the return files of the CNAB 240 (FEBRABAN), the titles settled
+// of the segments T and U and the payments made of the segments A and J, and
+// of the CNAB 400, the titles settled of the details. The records are fixed
+// width and the layout is detected by the size of the file header.
+type CNAB struct {
+	Scanner *bufio.Scanner
+	Options *CNABOptions
+	// Layout is the size of the records, 240 or 400
+	Layout int
+	// Bank is the code of the bank of the file header, the prefix of the
+	// external_id of the launches
+	Bank string
+	// line is the line of the file being read
+	line int
+	// records are the records of the file read, checked by the trailers
+	records int
+	// batchRecords are the records of the batch of the CNAB 240 being read,
+	// -1 out of a batch
+	batchRecords int
+	// segmentT is the segment T waiting for its segment U
+	segmentT     []rune
+	segmentTLine int
+	// unread is the record read after a segment T without its segment U
+	unread     []rune
+	unreadLine int
+	trailer    bool
+}
+
+// NewCNAB checks the file header is of a return file, the records are only
+// read one at a time by Read
+func NewCNAB(reader io.Reader, options *CNABOptions) (launch_import.Reader, error) {
+	if options.AccountID <= 0 {
+		return nil, errors.New("The param account_id is empty")
+	}
+
+	launchImportCNAB := &CNAB{
+		Scanner:      bufio.NewScanner(reader),
+		Options:      options,
+		batchRecords: -1,
+	}
+
+	header, _, err := launchImportCNAB.next()
+
+	if err == io.EOF {
+		return nil, errors.New("The file is empty")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	launchImportCNAB.Layout = len(header)
+	launchImportCNAB.records = 1
+
+	switch launchImportCNAB.Layout {
+	case 240:
+		if cnabField(header, 8, 8) != "0" {
+			return nil, errors.New("The file header of the CNAB 240 is invalid")
+		}
+
+		if cnabField(header, 143, 143) != "2" {
+			return nil, errors.New("The file is not a CNAB 240 return file")
+		}
+
+		launchImportCNAB.Bank = cnabField(header, 1, 3)
+	case 400:
+		if cnabField(header, 1, 1) != "0" || cnabField(header, 395, 400) != "000001" {
+			return nil, errors.New("The file header of the CNAB 400 is invalid")
+		}
+
+		if cnabField(header, 2, 9) != "2RETORNO" {
+			return nil, errors.New("The file is not a CNAB 400 return file")
+		}
+
+		launchImportCNAB.Bank = cnabField(header, 77, 79)
+	default:
+		return nil, errors.New("The file is not a CNAB 240 or 400")
+	}
+
+	return launchImportCNAB, nil
+}
+
+func (launchImportCNAB *CNAB) Read() (*model.CashLaunchImportRecord, error) {
+	for {
+		record, line, err := launchImportCNAB.next()
+
+		if err == io.EOF {
+			return launchImportCNAB.end()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the record after a segment T is read again after reporting it
+		if launchImportCNAB.segmentT != nil && !launchImportCNAB.segmentU(record) {
+			launchImportCNAB.unread, launchImportCNAB.unreadLine = record, line
+
+			return launchImportCNAB.segmentTError(), nil
+		}
+
+		launchImportCNAB.records++
+
+		if len(record) != launchImportCNAB.Layout {
+			return cnabRecordError(line, "The record size %d is not %d", len(record), launchImportCNAB.Layout), nil
+		}
+
+		if launchImportCNAB.trailer {
+			return cnabRecordError(line, "The record is after the file trailer"), nil
+		}
+
+		var modelCashLaunchImportRecord *model.CashLaunchImportRecord
+
+		if launchImportCNAB.Layout == 240 {
+			modelCashLaunchImportRecord = launchImportCNAB.read240(record, line)
+		} else {
+			modelCashLaunchImportRecord = launchImportCNAB.read400(record, line)
+		}
+
+		if modelCashLaunchImportRecord != nil {
+			return modelCashLaunchImportRecord, nil
+		}
+	}
+}
+
+// end reports the segment T without its segment U and the file without
+// trailer before the end of the file
+func (launchImportCNAB *CNAB) end() (*model.CashLaunchImportRecord, error) {
+	if launchImportCNAB.segmentT != nil {
+		return launchImportCNAB.segmentTError(), nil
+	}
+
+	if !launchImportCNAB.trailer {
+		launchImportCNAB.trailer = true
+
+		return cnabRecordError(launchImportCNAB.line+1, "The file trailer is missing"), nil
+	}
+
+	return nil, io.EOF
+}
+
+// read240 validates the batches and the trailer and maps the details of the
+// CNAB 240, the records without launch return nil
+func (launchImportCNAB *CNAB) read240(record []rune, line int) *model.CashLaunchImportRecord {
+	recordType := cnabField(record, 8, 8)
+
+	if recordType != "0" && recordType != "1" && recordType != "9" && launchImportCNAB.batchRecords >= 0 {
+		launchImportCNAB.batchRecords++
+	}
+
+	switch recordType {
+	case "0":
+		return cnabRecordError(line, "The file header is repeated")
+	case "1":
+		batchRecords := launchImportCNAB.batchRecords
+		launchImportCNAB.batchRecords = 1
+
+		if batchRecords >= 0 {
+			return cnabRecordError(line, "The batch trailer is missing")
+		}
+	case "2", "4":
+		// the optional records of the batch have no launch
+	case "3":
+		if launchImportCNAB.batchRecords < 0 {
+			return cnabRecordError(line, "The detail is out of a batch")
+		}
+
+		return launchImportCNAB.detail240(record, line)
+	case "5":
+		batchRecords := launchImportCNAB.batchRecords
+		launchImportCNAB.batchRecords = -1
+
+		if batchRecords < 0 {
+			return cnabRecordError(line, "The batch trailer has no batch header")
+		}
+
+		if count := cnabField(record, 18, 23); count != fmt.Sprintf("%06d", batchRecords) {
+			return cnabRecordError(line, "The batch trailer has %v records and the batch has %d", count, batchRecords)
+		}
+	case "9":
+		launchImportCNAB.trailer = true
+
+		if launchImportCNAB.batchRecords >= 0 {
+			return cnabRecordError(line, "The batch trailer is missing")
+		}
+
+		if count := cnabField(record, 24, 29); count != fmt.Sprintf("%06d", launchImportCNAB.records) {
+			return cnabRecordError(line, "The file trailer has %v records and the file has %d", count, launchImportCNAB.records)
+		}
+	default:
+		return cnabRecordError(line, "The record type %v is not mapped", recordType)
+	}
+
+	return nil
+}
+
+func (launchImportCNAB *CNAB) detail240(record []rune, line int) *model.CashLaunchImportRecord {
+	segment := cnabField(record, 14, 14)
+
+	switch segment {
+	case "T":
+		launchImportCNAB.segmentT, launchImportCNAB.segmentTLine = record, line
+	case "U":
+		if launchImportCNAB.segmentT == nil {
+			return cnabRecordError(line, "The segment U has no segment T")
+		}
+
+		segmentT, segmentTLine := launchImportCNAB.segmentT, launchImportCNAB.segmentTLine
+		launchImportCNAB.segmentT = nil
+
+		return launchImportCNAB.recordLoad240Settlement(segmentT, record, segmentTLine)
+	case "A":
+		return launchImportCNAB.recordLoad240Payment(record, line, cnabPaymentPositions{
+			occurrences: [2]int{231, 240},
+			payee:       [2]int{44, 73},
+			yourNumber:  [2]int{74, 93},
+			ourNumber:   [2]int{135, 154},
+			date:        [2]int{155, 162},
+			dateDue:     [2]int{94, 101},
+			value:       [2]int{163, 177},
+			valueDue:    [2]int{120, 134},
+		})
+	case "J":
+		// the segment J-52 completes the segment J with the payer and payee
+		if cnabField(record, 18, 19) == "52" {
+			return nil
+		}
+
+		return launchImportCNAB.recordLoad240Payment(record, line, cnabPaymentPositions{
+			occurrences: [2]int{231, 240},
+			payee:       [2]int{62, 91},
+			yourNumber:  [2]int{183, 202},
+			ourNumber:   [2]int{203, 222},
+			date:        [2]int{145, 152},
+			dateDue:     [2]int{92, 99},
+			value:       [2]int{153, 167},
+			valueDue:    [2]int{100, 114},
+		})
+	case "B", "C", "Z":
+		// the segments that complete the segment A have no launch
+	default:
+		return cnabRecordError(line, "The segment %v is not mapped", segment)
+	}
+
+	return nil
+}
+
+// recordLoad240Settlement maps the title of the segment T settled with the
+// value paid and the credit date of the segment U to a credit
+func (launchImportCNAB *CNAB) recordLoad240Settlement(segmentT []rune, segmentU []rune, line int) *model.CashLaunchImportRecord {
+	ourNumber := cnabField(segmentT, 38, 57)
+	yourNumber := cnabField(segmentT, 59, 73)
+
+	if yourNumber == "" {
+		yourNumber = ourNumber
+	}
+
+	if movement := cnabField(segmentT, 16, 17); !cnabCodeContains(CNAB240SettlementMovements, movement) {
+		return cnabRecordIgnored(line, "The movement %v of the title %v is not a settlement", movement, yourNumber)
+	}
+
+	modelCashLaunchImportRecord := launchImportCNAB.recordNew(line, "C", ourNumber, "Liquidação título "+yourNumber+" "+cnabField(segmentT, 149, 188))
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+
+	modelCashLaunch.Value = cnabValue(modelCashLaunchImportRecord, segmentU, "value paid", 78, 92)
+	modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, segmentU, "credit date", 146, 153, "02012006")
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, segmentU, "occurrence date", 138, 145, "02012006")
+	}
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The credit date (positions 146-153) is empty")
+	}
+
+	return modelCashLaunchImportRecord
+}
+
+// cnabPaymentPositions are the positions of the fields of the segments of the
+// payments of the CNAB 240, the date and value made fall back to the due ones
+type cnabPaymentPositions struct {
+	occurrences [2]int
+	payee       [2]int
+	yourNumber  [2]int
+	ourNumber   [2]int
+	date        [2]int
+	dateDue     [2]int
+	value       [2]int
+	valueDue    [2]int
+}
+
+// recordLoad240Payment maps the payment made of the segment A or J to a debit
+func (launchImportCNAB *CNAB) recordLoad240Payment(record []rune, line int, positions cnabPaymentPositions) *model.CashLaunchImportRecord {
+	yourNumber := cnabField(record, positions.yourNumber[0], positions.yourNumber[1])
+	ourNumber := cnabField(record, positions.ourNumber[0], positions.ourNumber[1])
+
+	// the payments not yet processed by the bank have only the number of the company
+	if ourNumber == "" {
+		ourNumber = yourNumber
+	}
+
+	if occurrences := cnabField(record, positions.occurrences[0], positions.occurrences[1]); !strings.HasPrefix(occurrences, CNAB240PaymentOccurrence) {
+		return cnabRecordIgnored(line, "The occurrences %v of the payment %v are not of a payment made", occurrences, ourNumber)
+	}
+
+	modelCashLaunchImportRecord := launchImportCNAB.recordNew(line, "D", ourNumber, "Pagamento "+yourNumber+" "+cnabField(record, positions.payee[0], positions.payee[1]))
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+
+	modelCashLaunch.Value = cnabValue(modelCashLaunchImportRecord, record, "value paid", positions.value[0], positions.value[1])
+
+	if modelCashLaunch.Value.IsZero() {
+		modelCashLaunch.Value = cnabValue(modelCashLaunchImportRecord, record, "value", positions.valueDue[0], positions.valueDue[1])
+	}
+
+	modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, record, "payment date", positions.date[0], positions.date[1], "02012006")
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, record, "due date", positions.dateDue[0], positions.dateDue[1], "02012006")
+	}
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, fmt.Sprintf("The payment date (positions %d-%d) is empty", positions.date[0], positions.date[1]))
+	}
+
+	return modelCashLaunchImportRecord
+}
+
+// read400 validates the sequence and maps the details of the CNAB 400, the
+// records without launch return nil
+func (launchImportCNAB *CNAB) read400(record []rune, line int) *model.CashLaunchImportRecord {
+	recordType := cnabField(record, 1, 1)
+
+	if recordType == "9" {
+		launchImportCNAB.trailer = true
+	}
+
+	if sequence := cnabField(record, 395, 400); sequence != fmt.Sprintf("%06d", launchImportCNAB.records) {
+		return cnabRecordError(line, "The sequential number %v is not %d", sequence, launchImportCNAB.records)
+	}
+
+	switch recordType {
+	case "0":
+		return cnabRecordError(line, "The file header is repeated")
+	case "1":
+		return launchImportCNAB.recordLoad400(record, line)
+	case "9":
+		// the totals of the trailer vary among the banks, only the sequence is checked
+	default:
+		return cnabRecordError(line, "The record type %v is not mapped", recordType)
+	}
+
+	return nil
+}
+
+// recordLoad400 maps the title of the detail settled with the value paid and
+// the credit date to a credit
+func (launchImportCNAB *CNAB) recordLoad400(record []rune, line int) *model.CashLaunchImportRecord {
+	positions, ok := CNAB400OurNumberPositions[launchImportCNAB.Bank]
+
+	if !ok {
+		positions = CNAB400OurNumberPositions[""]
+	}
+
+	ourNumber := cnabField(record, positions[0], positions[1])
+	yourNumber := cnabField(record, 117, 126)
+
+	if yourNumber == "" {
+		yourNumber = ourNumber
+	}
+
+	if occurrence := cnabField(record, 109, 110); !cnabCodeContains(CNAB400SettlementOccurrences, occurrence) {
+		return cnabRecordIgnored(line, "The occurrence %v of the title %v is not a settlement", occurrence, yourNumber)
+	}
+
+	modelCashLaunchImportRecord := launchImportCNAB.recordNew(line, "C", ourNumber, "Liquidação título "+yourNumber)
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+
+	modelCashLaunch.Value = cnabValue(modelCashLaunchImportRecord, record, "value paid", 254, 266)
+	modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, record, "credit date", 296, 301, "020106")
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunch.ReferenceDate = cnabDate(modelCashLaunchImportRecord, record, "occurrence date", 111, 116, "020106")
+	}
+
+	if modelCashLaunch.ReferenceDate.IsZero() {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The credit date (positions 296-301) is empty")
+	}
+
+	return modelCashLaunchImportRecord
+}
+
+// recordNew returns the record of a launch identified by the bank and the
+// nosso número, the reference of the bank kept by the re-imports
+func (launchImportCNAB *CNAB) recordNew(line int, launchType string, ourNumber string, description string) *model.CashLaunchImportRecord {
+	modelCashLaunchImportRecord := &model.CashLaunchImportRecord{
+		Line: line,
+		CashLaunch: model.CashLaunch{
+			AccountID:   launchImportCNAB.Options.AccountID,
+			Type:        launchType,
+			Description: cnabDescription(description),
+		},
+		Errors: []string{},
+	}
+
+	if ourNumber == "" {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The nosso número is empty")
+	} else {
+		modelCashLaunchImportRecord.CashLaunch.ExternalID = launchImportCNAB.Bank + "-" + ourNumber
+	}
+
+	return modelCashLaunchImportRecord
+}
+
+// segmentU tells the record is the segment U of the segment T read
+func (launchImportCNAB *CNAB) segmentU(record []rune) bool {
+	return len(record) == 240 && cnabField(record, 8, 8) == "3" && cnabField(record, 14, 14) == "U"
+}
+
+func (launchImportCNAB *CNAB) segmentTError() *model.CashLaunchImportRecord {
+	line := launchImportCNAB.segmentTLine
+	launchImportCNAB.segmentT = nil
+
+	return cnabRecordError(line, "The segment T has no segment U")
+}
+
+// next returns the next record not blank, skipping the end of file mark of
+// some banks
+func (launchImportCNAB *CNAB) next() ([]rune, int, error) {
+	if launchImportCNAB.unread != nil {
+		record, line := launchImportCNAB.unread, launchImportCNAB.unreadLine
+		launchImportCNAB.unread = nil
+
+		return record, line, nil
+	}
+
+	for launchImportCNAB.Scanner.Scan() {
+		launchImportCNAB.line++
+
+		text := strings.TrimSuffix(launchImportCNAB.Scanner.Text(), "\r")
+
+		if strings.TrimSpace(strings.Trim(text, "\x1a")) == "" {
+			continue
+		}
+
+		return cnabRecordDecode(text), launchImportCNAB.line, nil
+	}
+
+	if err := launchImportCNAB.Scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return nil, 0, io.EOF
+}
+
+// cnabField returns the field between the positions from 1 of the layout
+// without the spaces around
+func cnabField(record []rune, start int, end int) string {
+	return strings.TrimSpace(string(record[start-1 : end]))
+}
+
+// cnabValue returns the value of the field with 2 implied decimals
+func cnabValue(modelCashLaunchImportRecord *model.CashLaunchImportRecord, record []rune, name string, start int, end int) decimal.Decimal {
+	field := cnabField(record, start, end)
+
+	if _, err := strconv.ParseUint(field, 10, 64); err != nil {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, fmt.Sprintf("The %v (positions %d-%d) is invalid", name, start, end))
+		return decimal.Zero
+	}
+
+	value, _ := decimal.NewFromString(field)
+
+	return value.Shift(-2)
+}
+
+// cnabDate returns the date of the field, zero when the field is empty or
+// filled with zeros
+func cnabDate(modelCashLaunchImportRecord *model.CashLaunchImportRecord, record []rune, name string, start int, end int, layout string) time.Time {
+	field := cnabField(record, start, end)
+
+	if strings.Trim(field, "0") == "" {
+		return time.Time{}
+	}
+
+	date, err := time.Parse(layout, field)
+
+	if err != nil {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, fmt.Sprintf("The %v (positions %d-%d) is invalid", name, start, end))
+	}
+
+	return date
+}
+
+// cnabDescription joins the fields of the description cutting it to
+// CNABDescriptionMaxLen bytes without breaking a character
+func cnabDescription(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+
+	for len(description) > CNABDescriptionMaxLen {
+		_, size := utf8.DecodeLastRuneInString(description)
+		description = description[:len(description)-size]
+	}
+
+	return strings.TrimSpace(description)
+}
+
+func cnabCodeContains(codes []string, code string) bool {
+	for _, item := range codes {
+		if item == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cnabRecordError(line int, format string, args ...interface{}) *model.CashLaunchImportRecord {
+	return &model.CashLaunchImportRecord{Line: line, Errors: []string{fmt.Sprintf(format, args...)}}
+}
+
+func cnabRecordIgnored(line int, format string, args ...interface{}) *model.CashLaunchImportRecord {
+	return &model.CashLaunchImportRecord{Line: line, Errors: []string{fmt.Sprintf(format, args...)}, Ignored: true}
+}
+
+// cnabRecordDecode returns the characters of the record, the files of the
+// banks are usually in ISO-8859-1, which is not valid UTF-8, and each byte is
+// read as a Latin-1 rune
+func cnabRecordDecode(text string) []rune {
+	if utf8.ValidString(text) {
+		return []rune(text)
+	}
+
+	record := make([]rune, 0, len(text))
+
+	for idx := 0; idx < len(text); idx++ {
+		record = append(record, rune(text[idx]))
+	}
+
+	return record
+}
@@ -0,0 +1,328 @@
+package launch_import
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	"github.com/shopspring/decimal"
+)
+
+// CSVFields are the launch fields that can be mapped to a column
+var CSVFields = []string{"account_id", "category_id", "reference_date", "type", "description", "value", "currency", "external_id"}
+
+// CSVFieldsRequired are the fields every file must map
+var CSVFieldsRequired = []string{"reference_date", "description", "value"}
+
+// CSVDateFormats maps the accepted date formats to their layout
+var CSVDateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"YYYYMMDD":   "20060102",
+}
+
+type CSVOptions struct {
+	// Delimiter separates the columns, comma when zero
+	Delimiter rune
+	// Header tells the first line names the columns
+	Header bool
+	// Columns maps each field to the name of its column in the header, or to
+	// its position from 1 when the file has no header. With header and no
+	// columns the columns are named as the fields.
+	Columns map[string]string
+	// DateFormat is one of CSVDateFormats, YYYY-MM-DD when empty
+	DateFormat string
+	// DecimalSeparator is . or , (the . is then the thousands separator), .
+	// when empty
+	DecimalSeparator string
+	// AccountID is the account of the launches when the account_id is not mapped
+	AccountID int64
+}
+
+type CSV struct {
+	Reader  *csv.Reader
+	Options *CSVOptions
+	// positions maps each field mapped to the index of its column
+	positions map[string]int
+	layout    string
+}
+
+// NewCSV reads the header and checks the options, the lines are only read
+// one at a time by Read
+func NewCSV(reader io.Reader, options *CSVOptions) (launch_import.Reader, error) {
+	launchImportCSV := &CSV{
+		Reader:    csv.NewReader(reader),
+		Options:   options,
+		positions: map[string]int{},
+	}
+
+	if options.Delimiter != 0 {
+		launchImportCSV.Reader.Comma = options.Delimiter
+	}
+
+	// the lines may have less columns than the header, the missing ones are
+	// reported by line
+	launchImportCSV.Reader.FieldsPerRecord = -1
+	launchImportCSV.Reader.ReuseRecord = true
+
+	err := launchImportCSV.optionsValidate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = launchImportCSV.positionsLoad()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return launchImportCSV, nil
+}
+
+func (launchImportCSV *CSV) Read() (*model.CashLaunchImportRecord, error) {
+	fields, err := launchImportCSV.Reader.Read()
+
+	// a malformed line is reported and the reading goes on from the next one
+	if errParse, ok := err.(*csv.ParseError); ok && errParse.Err != csv.ErrFieldCount {
+		return &model.CashLaunchImportRecord{Line: errParse.StartLine, Errors: []string{errParse.Err.Error()}}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := launchImportCSV.Reader.FieldPos(0)
+
+	modelCashLaunchImportRecord := &model.CashLaunchImportRecord{
+		Line:       line,
+		CashLaunch: model.CashLaunch{AccountID: launchImportCSV.Options.AccountID},
+		Errors:     []string{},
+	}
+
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+	value := ""
+
+	for _, field := range CSVFields {
+		position, ok := launchImportCSV.positions[field]
+
+		if !ok {
+			continue
+		}
+
+		if position >= len(fields) {
+			modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, fmt.Sprintf("The column of the %v is missing", field))
+			continue
+		}
+
+		fieldValue := strings.TrimSpace(strings.TrimPrefix(fields[position], "\uFEFF"))
+
+		switch field {
+		case "account_id", "category_id":
+			id, err := strconv.ParseInt(fieldValue, 10, 64)
+
+			if fieldValue != "" && err != nil {
+				modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, fmt.Sprintf("The %v is invalid", field))
+			}
+
+			if field == "account_id" {
+				modelCashLaunch.AccountID = id
+			} else {
+				modelCashLaunch.CategoryID = id
+			}
+		case "reference_date":
+			if fieldValue != "" {
+				modelCashLaunch.ReferenceDate, err = time.Parse(launchImportCSV.layout, fieldValue)
+
+				if err != nil {
+					modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The reference_date is invalid")
+				}
+			}
+		case "type":
+			modelCashLaunch.Type = strings.ToUpper(fieldValue)
+		case "description":
+			modelCashLaunch.Description = fieldValue
+		case "value":
+			value = fieldValue
+		case "currency":
+			modelCashLaunch.Currency = strings.ToUpper(fieldValue)
+		case "external_id":
+			modelCashLaunch.ExternalID = fieldValue
+		}
+	}
+
+	if value != "" {
+		modelCashLaunch.Value, err = decimal.NewFromString(launchImportCSV.decimalFormat(value))
+
+		if err != nil {
+			modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The value is invalid")
+		}
+	}
+
+	// without the type the sign of the value tells the credits from the debits
+	if modelCashLaunch.Type == "" && !modelCashLaunch.Value.IsZero() {
+		modelCashLaunch.Type = "C"
+
+		if modelCashLaunch.Value.IsNegative() {
+			modelCashLaunch.Type = "D"
+			modelCashLaunch.Value = modelCashLaunch.Value.Neg()
+		}
+	}
+
+	return modelCashLaunchImportRecord, nil
+}
+
+func (launchImportCSV *CSV) optionsValidate() error {
+	messages := []string{}
+
+	if launchImportCSV.Options.DateFormat == "" {
+		launchImportCSV.Options.DateFormat = "YYYY-MM-DD"
+	}
+
+	layout, ok := CSVDateFormats[launchImportCSV.Options.DateFormat]
+
+	if !ok {
+		messages = append(messages, "The param date_format not in ['YYYY-MM-DD', 'DD/MM/YYYY', 'MM/DD/YYYY', 'YYYYMMDD']")
+	}
+
+	launchImportCSV.layout = layout
+
+	if launchImportCSV.Options.DecimalSeparator == "" {
+		launchImportCSV.Options.DecimalSeparator = "."
+	} else if launchImportCSV.Options.DecimalSeparator != "." && launchImportCSV.Options.DecimalSeparator != "," {
+		messages = append(messages, "The param decimal_separator not in ['.', ',']")
+	}
+
+	fields := []string{}
+
+	for field := range launchImportCSV.Options.Columns {
+		if !csvFieldValidate(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("The param columns field %v not in ['%v']", field, strings.Join(CSVFields, "', '")))
+	}
+
+	if !launchImportCSV.Options.Header && len(launchImportCSV.Options.Columns) == 0 {
+		messages = append(messages, "The param columns is empty and the file has no header")
+	}
+
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, ";"))
+	}
+
+	return nil
+}
+
+// positionsLoad finds the column of each field mapped, by its name in the
+// header or by its position
+func (launchImportCSV *CSV) positionsLoad() error {
+	columns := launchImportCSV.Options.Columns
+	names := map[string]int{}
+
+	if launchImportCSV.Options.Header {
+		header, err := launchImportCSV.Reader.Read()
+
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("The header is invalid: %w", err)
+		}
+
+		for idx, name := range header {
+			names[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))] = idx
+		}
+
+		if len(columns) == 0 {
+			columns = map[string]string{}
+
+			for _, field := range CSVFields {
+				if _, ok := names[field]; ok {
+					columns[field] = field
+				}
+			}
+		}
+	}
+
+	messages := []string{}
+
+	for _, field := range CSVFields {
+		column, ok := columns[field]
+
+		if !ok {
+			continue
+		}
+
+		column = strings.ToLower(strings.TrimSpace(column))
+
+		if launchImportCSV.Options.Header {
+			position, ok := names[column]
+
+			if !ok {
+				messages = append(messages, fmt.Sprintf("The column %v of the %v is not in the header", column, field))
+			}
+
+			launchImportCSV.positions[field] = position
+			continue
+		}
+
+		position, err := strconv.Atoi(column)
+
+		if err != nil || position < 1 {
+			messages = append(messages, fmt.Sprintf("The column %v of the %v is not a position from 1", column, field))
+		}
+
+		launchImportCSV.positions[field] = position - 1
+	}
+
+	for _, field := range CSVFieldsRequired {
+		if _, ok := launchImportCSV.positions[field]; !ok {
+			messages = append(messages, fmt.Sprintf("The column of the %v is not mapped", field))
+		}
+	}
+
+	if _, ok := launchImportCSV.positions["account_id"]; !ok && launchImportCSV.Options.AccountID <= 0 {
+		messages = append(messages, "The param account_id is empty and the column of the account_id is not mapped")
+	}
+
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, ";"))
+	}
+
+	return nil
+}
+
+// decimalFormat removes the thousands separator and the spaces of the value
+// leaving the . as the decimal separator
+func (launchImportCSV *CSV) decimalFormat(value string) string {
+	value = strings.ReplaceAll(value, " ", "")
+
+	if launchImportCSV.Options.DecimalSeparator == "," {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	return value
+}
+
+func csvFieldValidate(field string) bool {
+	for _, csvField := range CSVFields {
+		if field == csvField {
+			return true
+		}
+	}
+
+	return false
+}
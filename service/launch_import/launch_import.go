@@ -0,0 +1,9 @@
+package launch_import
+
+import "github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+
+type Reader interface {
+	// Read returns the next record of the file and io.EOF after the last one,
+	// the errors of a single line are returned in the record
+	Read() (*model.CashLaunchImportRecord, error)
+}
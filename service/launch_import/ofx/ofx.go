@@ -0,0 +1,236 @@
+package launch_import
+
+import (
+	"bufio"
+	"errors"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	"github.com/shopspring/decimal"
+)
+
+// OFXHeaderLen is the size of the beginning of the file searched for the OFX
+// header
+var OFXHeaderLen = 1024
+
+type OFXOptions struct {
+	// AccountID is the account of the launches, the account of the statement
+	// is not read
+	AccountID int64
+}
+
+// OFX reads the STMTTRN of the bank and credit card statements of an OFX 1.x
+// (SGML, the closing tags of the elements are optional) or 2.x (XML) file
+type OFX struct {
+	Reader  *bufio.Reader
+	Options *OFXOptions
+	// line is the line of the file being read
+	line int
+	// currency is the CURDEF of the statement being read
+	currency string
+}
+
+// NewOFX checks the beginning of the file is an OFX, the transactions are
+// only read one at a time by Read
+func NewOFX(reader io.Reader, options *OFXOptions) (launch_import.Reader, error) {
+	if options.AccountID <= 0 {
+		return nil, errors.New("The param account_id is empty")
+	}
+
+	launchImportOFX := &OFX{
+		Reader:  bufio.NewReaderSize(reader, OFXHeaderLen),
+		Options: options,
+		line:    1,
+	}
+
+	header, err := launchImportOFX.Reader.Peek(OFXHeaderLen)
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if !strings.Contains(strings.ToUpper(string(header)), "OFX") {
+		return nil, errors.New("The file is not an OFX")
+	}
+
+	return launchImportOFX, nil
+}
+
+func (launchImportOFX *OFX) Read() (*model.CashLaunchImportRecord, error) {
+	var modelCashLaunchImportRecord *model.CashLaunchImportRecord
+
+	elements := map[string]string{}
+	element := ""
+
+	for {
+		token, tag, err := launchImportOFX.next()
+
+		if err == io.EOF && modelCashLaunchImportRecord != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !tag {
+			if element == "CURDEF" {
+				launchImportOFX.currency = strings.ToUpper(token)
+			}
+
+			if modelCashLaunchImportRecord != nil && element != "" {
+				elements[element] = token
+			}
+
+			element = ""
+			continue
+		}
+
+		switch {
+		case token == "STMTTRN":
+			modelCashLaunchImportRecord = &model.CashLaunchImportRecord{Line: launchImportOFX.line}
+			elements = map[string]string{}
+		case token == "/STMTTRN" && modelCashLaunchImportRecord != nil:
+			launchImportOFX.recordLoad(modelCashLaunchImportRecord, elements)
+
+			return modelCashLaunchImportRecord, nil
+		case strings.HasPrefix(token, "/") || strings.HasPrefix(token, "?") || strings.HasPrefix(token, "!"):
+			element = ""
+		default:
+			element = token
+		}
+	}
+}
+
+// recordLoad maps the elements of the STMTTRN to the launch of the record
+func (launchImportOFX *OFX) recordLoad(modelCashLaunchImportRecord *model.CashLaunchImportRecord, elements map[string]string) {
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+	modelCashLaunch.AccountID = launchImportOFX.Options.AccountID
+	modelCashLaunch.ExternalID = elements["FITID"]
+	modelCashLaunch.Currency = launchImportOFX.currency
+	modelCashLaunchImportRecord.Errors = []string{}
+
+	if currency := elements["CURSYM"]; currency != "" {
+		modelCashLaunch.Currency = strings.ToUpper(currency)
+	}
+
+	modelCashLaunch.Description = elements["MEMO"]
+
+	if modelCashLaunch.Description == "" {
+		modelCashLaunch.Description = elements["NAME"]
+	}
+
+	if modelCashLaunch.ExternalID == "" {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The FITID is empty")
+	}
+
+	// the date is YYYYMMDD followed by the optional time and time zone, the
+	// date of the bank is kept as is
+	datePosted := elements["DTPOSTED"]
+
+	if datePosted == "" {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The DTPOSTED is empty")
+	} else {
+		var err error
+
+		if len(datePosted) >= 8 {
+			modelCashLaunch.ReferenceDate, err = time.Parse("20060102", datePosted[:8])
+		}
+
+		if len(datePosted) < 8 || err != nil {
+			modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The DTPOSTED is invalid")
+		}
+	}
+
+	// some banks write the amount with the decimal comma
+	amount := elements["TRNAMT"]
+
+	if !strings.Contains(amount, ".") {
+		amount = strings.ReplaceAll(amount, ",", ".")
+	}
+
+	if amount == "" {
+		modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The TRNAMT is empty")
+	} else {
+		value, err := decimal.NewFromString(amount)
+
+		if err != nil {
+			modelCashLaunchImportRecord.Errors = append(modelCashLaunchImportRecord.Errors, "The TRNAMT is invalid")
+		}
+
+		modelCashLaunch.Type = "C"
+		modelCashLaunch.Value = value
+
+		if value.IsNegative() {
+			modelCashLaunch.Type = "D"
+			modelCashLaunch.Value = value.Neg()
+		}
+	}
+}
+
+// next returns the name of the next tag in upper case, or the next text
+// between tags, skipping the blank texts
+func (launchImportOFX *OFX) next() (string, bool, error) {
+	for {
+		first, err := launchImportOFX.Reader.ReadByte()
+
+		if err != nil {
+			return "", false, err
+		}
+
+		if first == '<' {
+			token, err := launchImportOFX.Reader.ReadString('>')
+
+			if err != nil {
+				return "", false, err
+			}
+
+			launchImportOFX.line += strings.Count(token, "\n")
+
+			return strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(token, ">"))), true, nil
+		}
+
+		launchImportOFX.Reader.UnreadByte()
+
+		token, err := launchImportOFX.Reader.ReadString('<')
+
+		if err == nil {
+			launchImportOFX.Reader.UnreadByte()
+			token = strings.TrimSuffix(token, "<")
+		} else if err != io.EOF {
+			return "", false, err
+		}
+
+		launchImportOFX.line += strings.Count(token, "\n")
+		token = strings.TrimSpace(token)
+
+		if token != "" {
+			return html.UnescapeString(ofxTextDecode(token)), false, nil
+		}
+
+		if err == io.EOF {
+			return "", false, io.EOF
+		}
+	}
+}
+
+// ofxTextDecode converts the texts of the OFX 1.x files in the CHARSET 1252 or
+// ISO-8859-1, which are not valid UTF-8, reading each byte as a Latin-1 rune
+func ofxTextDecode(text string) string {
+	if utf8.ValidString(text) {
+		return text
+	}
+
+	runes := make([]rune, 0, len(text))
+
+	for idx := 0; idx < len(text); idx++ {
+		runes = append(runes, rune(text[idx]))
+	}
+
+	return string(runes)
+}
@@ -30,6 +30,11 @@ func (rwr *ResponseWriteRecorder) Write(body []byte) (int, error) {
 	return rwr.ResponseWriter.Write(body)
 }
 
+// Unwrap lets http.NewResponseController reach the connection of the request
+func (rwr *ResponseWriteRecorder) Unwrap() http.ResponseWriter {
+	return rwr.ResponseWriter
+}
+
 // Request.RemoteAddress contains port, which we want to remove i.e.:
 // "[::1]:58292" => "[::1]"
 func ipAddrFromRemoteAddr(remoteAddr string) string {
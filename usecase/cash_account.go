@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+)
+
+var (
+	CashAccountNameMinLen = 3
+	CashAccountNameMaxLen = 100
+
+	CashAccountMessageNameEmptyError = "The name is empty"
+	CashAccountMessageNameSizeError  = fmt.Sprintf("The name size is not between %v and %v", CashAccountNameMinLen, CashAccountNameMaxLen)
+)
+
+type CashAccount interface {
+	Insert(modelCashAccount *model.CashAccount) (*model.CashAccount, error)
+	List() (model.CashAccounts, error)
+	GetByID(id int64) (*model.CashAccount, error)
+	Update(modelCashAccount *model.CashAccount) (*model.CashAccount, error)
+	DeleteByID(id int64) error
+}
+
+type UseCaseCashAccount struct {
+	RepositoryCashAccount repository.CashAccount
+}
+
+func NewCashAccount(repositoryCashAccount repository.CashAccount) CashAccount {
+	return &UseCaseCashAccount{
+		RepositoryCashAccount: repositoryCashAccount,
+	}
+}
+
+func (useCaseCashAccount *UseCaseCashAccount) Insert(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	err := cashAccountModelValidate(modelCashAccount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashAccount.CreatedAt = time.Now().UTC()
+	modelCashAccount.UpdatedAt = modelCashAccount.CreatedAt
+
+	return useCaseCashAccount.RepositoryCashAccount.Insert(modelCashAccount)
+}
+
+func (useCaseCashAccount *UseCaseCashAccount) List() (model.CashAccounts, error) {
+	return useCaseCashAccount.RepositoryCashAccount.List()
+}
+
+func (useCaseCashAccount *UseCaseCashAccount) GetByID(id int64) (*model.CashAccount, error) {
+	return useCaseCashAccount.RepositoryCashAccount.GetByID(id)
+}
+
+func (useCaseCashAccount *UseCaseCashAccount) Update(modelCashAccount *model.CashAccount) (*model.CashAccount, error) {
+	err := cashAccountModelValidate(modelCashAccount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashAccount.UpdatedAt = time.Now().UTC()
+
+	return useCaseCashAccount.RepositoryCashAccount.Update(modelCashAccount)
+}
+
+// DeleteByID removes an account, the repository rejects it with
+// repository.ErrReferenced while the account still has launches
+func (useCaseCashAccount *UseCaseCashAccount) DeleteByID(id int64) error {
+	return useCaseCashAccount.RepositoryCashAccount.DeleteByID(id)
+}
+
+func cashAccountModelValidate(modelCashAccount *model.CashAccount) error {
+	messages := []string{}
+
+	CashAccountModelFormat(modelCashAccount)
+
+	if modelCashAccount.Name == "" {
+		messages = append(messages, CashAccountMessageNameEmptyError)
+	} else if len(modelCashAccount.Name) < CashAccountNameMinLen ||
+		len(modelCashAccount.Name) > CashAccountNameMaxLen {
+		messages = append(messages, CashAccountMessageNameSizeError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func CashAccountModelFormat(modelCashAccount *model.CashAccount) {
+	modelCashAccount.Name = util.FormatTitle(modelCashAccount.Name)
+}
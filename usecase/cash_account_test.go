@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashAccountInsert(t *testing.T) {
+	type test struct {
+		name             string
+		inputCashAccount *model.CashAccount
+		wantError        error
+		assert           func(t *testing.T, tt *test, resultCashAccount *model.CashAccount, err error)
+	}
+
+	tests := []test{
+		{
+			name:             "NameEmptyError",
+			inputCashAccount: &model.CashAccount{Name: "   "},
+			wantError:        usecase.ErrModelValidate{Message: usecase.CashAccountMessageNameEmptyError},
+		},
+		{
+			name:             "NameSizeLessError",
+			inputCashAccount: &model.CashAccount{Name: "CX"},
+			wantError:        usecase.ErrModelValidate{Message: usecase.CashAccountMessageNameSizeError},
+		},
+		{
+			name:             "NameSizeGreaterError",
+			inputCashAccount: &model.CashAccount{Name: strings.Repeat("X", usecase.CashAccountNameMaxLen+1)},
+			wantError:        usecase.ErrModelValidate{Message: usecase.CashAccountMessageNameSizeError},
+		},
+		{
+			name:             "DuplicateKeyError",
+			inputCashAccount: &model.CashAccount{Name: " caixa "},
+			wantError:        repository.ErrDuplicateKey{Message: "Key (name)=(CAIXA) already exists."},
+		},
+		{
+			name:             "Success",
+			inputCashAccount: &model.CashAccount{Name: "banco  001 ag 1234"},
+			assert: func(t *testing.T, tt *test, resultCashAccount *model.CashAccount, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashAccount)
+				assert.NotEqual(t, int64(0), resultCashAccount.ID)
+				assert.Equal(t, "BANCO 001 AG 1234", resultCashAccount.Name)
+				assert.False(t, resultCashAccount.CreatedAt.IsZero())
+				assert.Equal(t, resultCashAccount.CreatedAt, resultCashAccount.UpdatedAt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashAccount := usecase.NewCashAccount(repository.CashAccount())
+
+			modelCashAccount := *tt.inputCashAccount
+
+			resultCashAccount, err := usecaseCashAccount.Insert(&modelCashAccount)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashAccount, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashAccount)
+			}
+		})
+	}
+}
+
+func TestCashAccountGetByID(t *testing.T) {
+	type test struct {
+		name      string
+		inputID   int64
+		wantName  string
+		wantError error
+	}
+
+	tests := []test{
+		{
+			name:      "NotFoundError",
+			inputID:   999,
+			wantError: repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:     "Success",
+			inputID:  1,
+			wantName: "CAIXA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashAccount := usecase.NewCashAccount(repository.CashAccount())
+
+			resultCashAccount, err := usecaseCashAccount.GetByID(tt.inputID)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("GetByID() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.wantError == nil {
+				assert.Equal(t, tt.wantName, resultCashAccount.Name)
+			}
+		})
+	}
+}
+
+func TestCashAccountUpdate(t *testing.T) {
+	type test struct {
+		name             string
+		inputCashAccount *model.CashAccount
+		wantError        error
+	}
+
+	tests := []test{
+		{
+			name:             "NameEmptyError",
+			inputCashAccount: &model.CashAccount{ID: 2},
+			wantError:        usecase.ErrModelValidate{Message: usecase.CashAccountMessageNameEmptyError},
+		},
+		{
+			name:             "NotFoundError",
+			inputCashAccount: &model.CashAccount{ID: 999, Name: "CONTA"},
+			wantError:        repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:             "DuplicateKeyError",
+			inputCashAccount: &model.CashAccount{ID: 2, Name: "CAIXA"},
+			wantError:        repository.ErrDuplicateKey{Message: "Key (name)=(CAIXA) already exists."},
+		},
+		{
+			name:             "Success",
+			inputCashAccount: &model.CashAccount{ID: 2, Name: "banco"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashAccount := usecase.NewCashAccount(repository.CashAccount())
+
+			modelCashAccount := *tt.inputCashAccount
+
+			resultCashAccount, err := usecaseCashAccount.Update(&modelCashAccount)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Update() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.wantError == nil {
+				assert.Equal(t, "BANCO", resultCashAccount.Name)
+				assert.False(t, resultCashAccount.CreatedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestCashAccountDeleteByID(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashAccount := usecase.NewCashAccount(repositoryInMemory.CashAccount())
+
+	modelCashAccount, err := usecaseCashAccount.Insert(&model.CashAccount{Name: "CONTA SEM LANCAMENTOS"})
+	assert.Nil(t, err)
+
+	type test struct {
+		name      string
+		inputID   int64
+		wantError error
+	}
+
+	tests := []test{
+		{
+			name:      "NotFoundError",
+			inputID:   999,
+			wantError: repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:      "ReferencedError",
+			inputID:   1,
+			wantError: repository.ErrReferenced{Message: "Key (id)=(1) is still referenced from table \"cash_launch\".", Table: "cash_launch"},
+		},
+		{
+			name:    "Success",
+			inputID: modelCashAccount.ID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usecaseCashAccount.DeleteByID(tt.inputID)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Delete() got error = %v, want = %v.", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
-	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
 )
 
 var (
@@ -17,11 +16,33 @@ var (
 	CashBalanceDailyRangeReferenceDateToBetweenError     = fmt.Sprintf("The param to value is not between %v and %v", CashLaunchReferenceDateMin, CashLaunchReferenceDateMax)
 	CashBalanceDailyRangeReferenceDateToSmallerFromError = "The param to is smaller the param from"
 	CashBalanceDailyRangeReferenceDateRangeError         = "the range is greater than 31 days"
+	CashBalanceDailyAccountIDInvalidError                = "The param account_id is less than 0"
+	CashBalanceSummaryGranularityInvalidError            = "The param granularity is not day, week, month, quarter or year"
+)
+
+var (
+	CashBalanceSummaryGranularityDay     = "day"
+	CashBalanceSummaryGranularityWeek    = "week"
+	CashBalanceSummaryGranularityMonth   = "month"
+	CashBalanceSummaryGranularityQuarter = "quarter"
+	CashBalanceSummaryGranularityYear    = "year"
+	// CashBalanceSummaryGranularityPeriodsMax limits the number of periods of
+	// the range by granularity, so the coarse ones accept several years
+	CashBalanceSummaryGranularityPeriodsMax = map[string]int{
+		CashBalanceSummaryGranularityDay:     31,
+		CashBalanceSummaryGranularityWeek:    53,
+		CashBalanceSummaryGranularityMonth:   60,
+		CashBalanceSummaryGranularityQuarter: 40,
+		CashBalanceSummaryGranularityYear:    20,
+	}
 )
 
 type CashBalanceDaily interface {
-	GetByReferenceDate(referenceDate time.Time) (*model.CashBalanceDaily, error)
+	GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error)
 	GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error)
+	GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error)
+	GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error)
+	Rebuild() (model.CashBalanceDailyDrifts, error)
 }
 
 type UseCaseCashBalanceDaily struct {
@@ -34,14 +55,21 @@ func NewCashBalanceDaily(repositoryCashBalanceDaily repository.CashBalanceDaily)
 	}
 }
 
-func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetByReferenceDate(referenceDate time.Time) (*model.CashBalanceDaily, error) {
+// GetByReferenceDate returns the balance of the account, or of all accounts
+// combined when the account is zero, including the deleted launches only when
+// includeDeleted and the pending launches only when projected
+func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error) {
 	err := cashLaunchReferenceDateValidate(referenceDate)
 
 	if err != nil {
 		return nil, err
 	}
 
-	modelCashBalanceDaily, err := useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetByReferenceDate(referenceDate)
+	if accountID < 0 {
+		return nil, ErrParamValidate{Message: CashBalanceDailyAccountIDInvalidError}
+	}
+
+	modelCashBalanceDaily, err := useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, includeDeleted, projected)
 
 	if err != nil {
 		if _, ok := err.(repository.ErrNotFound); ok {
@@ -51,8 +79,6 @@ func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetByReferenceDate(refer
 		}
 	}
 
-	modelCashBalanceDaily.Value = util.MathRoundPrecision(modelCashBalanceDaily.Value, 2)
-
 	return modelCashBalanceDaily, err
 }
 
@@ -63,26 +89,107 @@ func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetByRangeReferenceDate(
 		return nil, err
 	}
 
-	return useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+	modelCashBalanceDailies, err := useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+
+	if err != nil || !cashBalanceGetByRangeReferenceDateParams.Fill {
+		return modelCashBalanceDailies, err
+	}
+
+	return useCaseCashBalanceDaily.cashBalanceDailiesFill(modelCashBalanceDailies, cashBalanceGetByRangeReferenceDateParams)
 }
 
-func CashBalanceDailyRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) error {
-	messages := []string{}
+// cashBalanceDailiesFill returns every day of the range, the days without
+// launches carry forward the closing balance of the previous day
+func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) cashBalanceDailiesFill(modelCashBalanceDailies model.CashBalanceDailies, cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error) {
+	modelCashBalanceDailyFrom, err := useCaseCashBalanceDaily.GetByReferenceDate(cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.AccountID, cashBalanceGetByRangeReferenceDateParams.IncludeDeleted, cashBalanceGetByRangeReferenceDateParams.Projected)
 
-	if cashBalanceGetByRangeReferenceDateParams.From.IsZero() {
-		messages = append(messages, CashBalanceDailyRangeReferenceDateFromEmptyError)
-	} else if cashBalanceGetByRangeReferenceDateParams.From.Before(CashLaunchReferenceDateMin) ||
-		cashBalanceGetByRangeReferenceDateParams.From.After(CashLaunchReferenceDateMax) {
-		messages = append(messages, CashBalanceDailyRangeReferenceDateFromBetweenError)
+	if err != nil {
+		return nil, err
 	}
 
-	if cashBalanceGetByRangeReferenceDateParams.To.IsZero() {
-		messages = append(messages, CashBalanceDailyRangeReferenceDateToEmptyError)
-	} else if cashBalanceGetByRangeReferenceDateParams.To.Before(CashLaunchReferenceDateMin) ||
-		cashBalanceGetByRangeReferenceDateParams.To.After(CashLaunchReferenceDateMax) {
-		messages = append(messages, CashBalanceDailyRangeReferenceDateToBetweenError)
+	closingBalance := modelCashBalanceDailyFrom.OpeningBalance
+	modelCashBalanceDailiesFilled := model.CashBalanceDailies{}
+	idx := 0
+
+	for referenceDate := cashBalanceGetByRangeReferenceDateParams.From; !referenceDate.After(cashBalanceGetByRangeReferenceDateParams.To); referenceDate = referenceDate.AddDate(0, 0, 1) {
+		if idx < len(modelCashBalanceDailies) && modelCashBalanceDailies[idx].ReferenceDate.Equal(referenceDate) {
+			modelCashBalanceDailiesFilled = append(modelCashBalanceDailiesFilled, modelCashBalanceDailies[idx])
+			closingBalance = modelCashBalanceDailies[idx].ClosingBalance
+			idx++
+			continue
+		}
+
+		modelCashBalanceDailiesFilled = append(modelCashBalanceDailiesFilled, model.CashBalanceDaily{
+			ReferenceDate:  referenceDate,
+			OpeningBalance: closingBalance,
+			ClosingBalance: closingBalance,
+		})
 	}
 
+	return modelCashBalanceDailiesFilled, nil
+}
+
+// GetCurrenciesByRangeReferenceDate breaks down the daily totals by the
+// original currency of the launches
+func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error) {
+	err := CashBalanceDailyRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDateParams)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+}
+
+// GetSummaryByRangeReferenceDate aggregates the balance of the range by day,
+// week, month, quarter or year, returning only the periods with launches
+func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error) {
+	if cashBalanceGetByRangeReferenceDateParams.Granularity == "" {
+		cashBalanceGetByRangeReferenceDateParams.Granularity = CashBalanceSummaryGranularityDay
+	}
+
+	err := CashBalanceSummaryRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDateParams)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashBalanceSummaries, err := useCaseCashBalanceDaily.RepositoryCashBalanceDaily.GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the first and the last periods are clipped to the range
+	for idx := range modelCashBalanceSummaries {
+		modelCashBalanceSummaries[idx].From = modelCashBalanceSummaries[idx].Period
+		modelCashBalanceSummaries[idx].To = model.CashBalanceSummaryPeriodNext(modelCashBalanceSummaries[idx].Period, cashBalanceGetByRangeReferenceDateParams.Granularity).AddDate(0, 0, -1)
+
+		if modelCashBalanceSummaries[idx].From.Before(cashBalanceGetByRangeReferenceDateParams.From) {
+			modelCashBalanceSummaries[idx].From = cashBalanceGetByRangeReferenceDateParams.From
+		}
+
+		if modelCashBalanceSummaries[idx].To.After(cashBalanceGetByRangeReferenceDateParams.To) {
+			modelCashBalanceSummaries[idx].To = cashBalanceGetByRangeReferenceDateParams.To
+		}
+	}
+
+	return modelCashBalanceSummaries, nil
+}
+
+func (useCaseCashBalanceDaily *UseCaseCashBalanceDaily) Rebuild() (model.CashBalanceDailyDrifts, error) {
+	modelCashBalanceDailyDrifts, err := useCaseCashBalanceDaily.RepositoryCashBalanceDaily.Rebuild()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashBalanceDailyDrifts, nil
+}
+
+func CashBalanceDailyRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) error {
+	messages := cashBalanceDailyRangeReferenceDateParamsValidate(cashBalanceGetByRangeReferenceDateParams)
+
 	if len(messages) > 0 {
 		return ErrParamValidate{Message: strings.Join(messages, ";")}
 	}
@@ -101,3 +208,62 @@ func CashBalanceDailyRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDa
 
 	return nil
 }
+
+// CashBalanceSummaryRangeReferenceDateValidate limits the range by the number
+// of periods of the granularity instead of the number of days
+func CashBalanceSummaryRangeReferenceDateValidate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) error {
+	messages := cashBalanceDailyRangeReferenceDateParamsValidate(cashBalanceGetByRangeReferenceDateParams)
+
+	periodsMax, ok := CashBalanceSummaryGranularityPeriodsMax[cashBalanceGetByRangeReferenceDateParams.Granularity]
+
+	if !ok {
+		messages = append(messages, CashBalanceSummaryGranularityInvalidError)
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	if cashBalanceGetByRangeReferenceDateParams.To.Before(cashBalanceGetByRangeReferenceDateParams.From) {
+		return ErrParamValidate{Message: CashBalanceDailyRangeReferenceDateToSmallerFromError}
+	}
+
+	periods := 0
+	period := model.CashBalanceSummaryPeriodOf(cashBalanceGetByRangeReferenceDateParams.From, cashBalanceGetByRangeReferenceDateParams.Granularity)
+
+	for !period.After(cashBalanceGetByRangeReferenceDateParams.To) {
+		periods++
+
+		if periods > periodsMax {
+			return ErrParamValidate{Message: fmt.Sprintf("the range is greater than %v %ss", periodsMax, cashBalanceGetByRangeReferenceDateParams.Granularity)}
+		}
+
+		period = model.CashBalanceSummaryPeriodNext(period, cashBalanceGetByRangeReferenceDateParams.Granularity)
+	}
+
+	return nil
+}
+
+func cashBalanceDailyRangeReferenceDateParamsValidate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) []string {
+	messages := []string{}
+
+	if cashBalanceGetByRangeReferenceDateParams.From.IsZero() {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateFromEmptyError)
+	} else if cashBalanceGetByRangeReferenceDateParams.From.Before(CashLaunchReferenceDateMin) ||
+		cashBalanceGetByRangeReferenceDateParams.From.After(CashLaunchReferenceDateMax) {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateFromBetweenError)
+	}
+
+	if cashBalanceGetByRangeReferenceDateParams.To.IsZero() {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateToEmptyError)
+	} else if cashBalanceGetByRangeReferenceDateParams.To.Before(CashLaunchReferenceDateMin) ||
+		cashBalanceGetByRangeReferenceDateParams.To.After(CashLaunchReferenceDateMax) {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateToBetweenError)
+	}
+
+	if cashBalanceGetByRangeReferenceDateParams.AccountID < 0 {
+		messages = append(messages, CashBalanceDailyAccountIDInvalidError)
+	}
+
+	return messages
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+)
+
+var (
+	CashBalanceDailyCacheKeyPrefix     = "cash_balance_daily:"
+	CashBalanceDailyCacheGenerationKey = CashBalanceDailyCacheKeyPrefix + "generation"
+)
+
+// UseCaseCashBalanceDailyCache decorates a CashBalanceDaily use case storing
+// the balance of each reference date in the cache (cache-aside). The keys
+// include the generation of the balances, bumped by every launch written, so
+// a balance read before a write and cached after it is never read again.
+type UseCaseCashBalanceDailyCache struct {
+	UseCaseCashBalanceDaily CashBalanceDaily
+	Cache                   cache.Cache
+}
+
+func NewCashBalanceDailyCache(useCaseCashBalanceDaily CashBalanceDaily, cache cache.Cache) CashBalanceDaily {
+	return &UseCaseCashBalanceDailyCache{
+		UseCaseCashBalanceDaily: useCaseCashBalanceDaily,
+		Cache:                   cache,
+	}
+}
+
+func (useCaseCashBalanceDailyCache *UseCaseCashBalanceDailyCache) GetByReferenceDate(referenceDate time.Time, accountID int64, includeDeleted bool, projected bool) (*model.CashBalanceDaily, error) {
+	// only the balances without the deleted and the pending launches are cached
+	if includeDeleted || projected {
+		return useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, includeDeleted, projected)
+	}
+
+	// any cache error falls back to the use case
+	generation, err := CashBalanceDailyCacheGeneration(useCaseCashBalanceDailyCache.Cache)
+
+	if err != nil {
+		return useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, includeDeleted, projected)
+	}
+
+	cacheKey := CashBalanceDailyCacheKey(generation, referenceDate, accountID)
+
+	modelCashBalanceDaily := &model.CashBalanceDaily{}
+
+	err = useCaseCashBalanceDailyCache.Cache.Get(cacheKey, modelCashBalanceDaily)
+
+	if err == nil {
+		return modelCashBalanceDaily, nil
+	}
+
+	modelCashBalanceDaily, err = useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, includeDeleted, projected)
+
+	if err != nil {
+		return nil, err
+	}
+
+	useCaseCashBalanceDailyCache.Cache.Set(cacheKey, modelCashBalanceDaily)
+
+	return modelCashBalanceDaily, nil
+}
+
+func (useCaseCashBalanceDailyCache *UseCaseCashBalanceDailyCache) GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailies, error) {
+	// the generation is read before the balances like on GetByReferenceDate
+	generation, errGeneration := CashBalanceDailyCacheGeneration(useCaseCashBalanceDailyCache.Cache)
+
+	modelCashBalanceDailies, err := useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if errGeneration != nil || cashBalanceGetByRangeReferenceDateParams.IncludeDeleted || cashBalanceGetByRangeReferenceDateParams.Projected {
+		return modelCashBalanceDailies, nil
+	}
+
+	// warm up the cache with the balance of each day returned
+	for _, modelCashBalanceDaily := range modelCashBalanceDailies {
+		useCaseCashBalanceDailyCache.Cache.Set(CashBalanceDailyCacheKey(generation, modelCashBalanceDaily.ReferenceDate, cashBalanceGetByRangeReferenceDateParams.AccountID), modelCashBalanceDaily)
+	}
+
+	return modelCashBalanceDailies, nil
+}
+
+func (useCaseCashBalanceDailyCache *UseCaseCashBalanceDailyCache) GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceDailyCurrencies, error) {
+	return useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetCurrenciesByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+}
+
+func (useCaseCashBalanceDailyCache *UseCaseCashBalanceDailyCache) GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams *model.CashBalanceDailyRangeReferenceDate) (model.CashBalanceSummaries, error) {
+	return useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.GetSummaryByRangeReferenceDate(cashBalanceGetByRangeReferenceDateParams)
+}
+
+func (useCaseCashBalanceDailyCache *UseCaseCashBalanceDailyCache) Rebuild() (model.CashBalanceDailyDrifts, error) {
+	modelCashBalanceDailyDrifts, err := useCaseCashBalanceDailyCache.UseCaseCashBalanceDaily.Rebuild()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(modelCashBalanceDailyDrifts) == 0 {
+		return modelCashBalanceDailyDrifts, nil
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashBalanceDailyCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashBalanceDailyDrifts, nil
+}
+
+// CashBalanceDailyCacheGeneration returns the current generation of the
+// cached balances, zero before the first launch written
+func CashBalanceDailyCacheGeneration(cacheBalanceDaily cache.Cache) (int64, error) {
+	var generation int64
+
+	err := cacheBalanceDaily.Get(CashBalanceDailyCacheGenerationKey, &generation)
+
+	if _, ok := err.(cache.ErrNotFound); ok {
+		return 0, nil
+	}
+
+	return generation, err
+}
+
+// CashBalanceDailyCacheKey returns the key of the balance of the account (zero
+// for all accounts combined) on the reference date in the generation
+func CashBalanceDailyCacheKey(generation int64, referenceDate time.Time, accountID int64) string {
+	return fmt.Sprintf("%s%d:%d:%s", CashBalanceDailyCacheKeyPrefix, generation, accountID, referenceDate.Format("2006-01-02"))
+}
+
+// CashBalanceDailyCacheEvict bumps the generation of the cached balances,
+// since a launch changes the running balance of every following day, the
+// balances of the previous generation are left to expire
+func CashBalanceDailyCacheEvict(cacheBalanceDaily cache.Cache) error {
+	_, err := cacheBalanceDaily.Increment(CashBalanceDailyCacheGenerationKey)
+
+	return err
+}
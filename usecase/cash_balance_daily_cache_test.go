@@ -0,0 +1,233 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	cache_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache/in_memory"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashBalanceDailyCacheGetByReferenceDate(t *testing.T) {
+	type test struct {
+		name                  string
+		cacheError            bool
+		cacheCashBalanceDaily *model.CashBalanceDaily
+		wantCashBalanceDaily  *model.CashBalanceDaily
+		wantCached            bool
+	}
+
+	referenceDate := time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC)
+
+	tests := []test{
+		{
+			name: "CacheMiss",
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  referenceDate,
+				TotalCredit:    decimal.RequireFromString("987.65"),
+				TotalDebit:     decimal.RequireFromString("12.34"),
+				Value:          decimal.RequireFromString("975.31"),
+				ClosingBalance: decimal.RequireFromString("975.31"),
+			},
+			wantCached: true,
+		},
+		{
+			name: "CacheHit",
+			cacheCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  referenceDate,
+				OpeningBalance: decimal.RequireFromString("1.11"),
+				ClosingBalance: decimal.RequireFromString("1.11"),
+			},
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  referenceDate,
+				OpeningBalance: decimal.RequireFromString("1.11"),
+				ClosingBalance: decimal.RequireFromString("1.11"),
+			},
+			wantCached: true,
+		},
+		{
+			name:       "CacheError",
+			cacheError: true,
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  referenceDate,
+				TotalCredit:    decimal.RequireFromString("987.65"),
+				TotalDebit:     decimal.RequireFromString("12.34"),
+				Value:          decimal.RequireFromString("975.31"),
+				ClosingBalance: decimal.RequireFromString("975.31"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			cache, _ := cache_in_memory.NewInMemory(false)
+
+			if tt.cacheCashBalanceDaily != nil {
+				cache.Set(usecase.CashBalanceDailyCacheKey(0, referenceDate, 0), tt.cacheCashBalanceDaily)
+			}
+
+			cache.(*cache_in_memory.InMemory).Error = tt.cacheError
+
+			usecaseCashBalanceDaily := usecase.NewCashBalanceDailyCache(usecase.NewCashBalanceDaily(repository.CashBalanceDaily()), cache)
+
+			resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 0, false, false)
+
+			if err != nil {
+				t.Errorf("GetByReferenceDate() got error = %v, want = nil.", err)
+			}
+
+			if !equalJSON(tt.wantCashBalanceDaily, resultCashBalanceDaily) {
+				t.Errorf("GetByReferenceDate() got result = %v, want = %v.", resultCashBalanceDaily, tt.wantCashBalanceDaily)
+			}
+
+			cache.(*cache_in_memory.InMemory).Error = false
+
+			cachedCashBalanceDaily := &model.CashBalanceDaily{}
+			err = cache.Get(usecase.CashBalanceDailyCacheKey(0, referenceDate, 0), cachedCashBalanceDaily)
+
+			assert.Equal(t, tt.wantCached, err == nil)
+
+			if tt.wantCached {
+				assert.True(t, equalJSON(tt.wantCashBalanceDaily, cachedCashBalanceDaily))
+			}
+		})
+	}
+}
+
+func TestCashLaunchCacheEvict(t *testing.T) {
+	referenceDate := time.Date(2010, 05, 10, 00, 00, 00, 000, time.UTC)
+
+	repository, _ := repository_in_memory.NewInMemory(false)
+	cache, _ := cache_in_memory.NewInMemory(false)
+
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDailyCache(usecase.NewCashBalanceDaily(repository.CashBalanceDaily()), cache)
+	usecaseCashLaunch := usecase.NewCashLaunchCache(usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil), cache)
+
+	// the balances cached before a write are not read in the new generation
+	assertEvicted := func(t *testing.T, generationBefore int64) {
+		generation, err := usecase.CashBalanceDailyCacheGeneration(cache)
+		assert.Nil(t, err)
+		assert.Equal(t, generationBefore+1, generation)
+
+		for _, days := range []int{-1, 0, 1, 30} {
+			assert.Nil(t, cache.Get(usecase.CashBalanceDailyCacheKey(generationBefore, referenceDate.AddDate(0, 0, days), 0), &model.CashBalanceDaily{}))
+			assert.NotNil(t, cache.Get(usecase.CashBalanceDailyCacheKey(generation, referenceDate.AddDate(0, 0, days), 0), &model.CashBalanceDaily{}))
+		}
+	}
+
+	warmUp := func() int64 {
+		for _, days := range []int{-1, 0, 1, 30} {
+			usecaseCashBalanceDaily.GetByReferenceDate(referenceDate.AddDate(0, 0, days), 0, false, false)
+		}
+
+		generation, _ := usecase.CashBalanceDailyCacheGeneration(cache)
+
+		return generation
+	}
+
+	var modelCashLaunch *model.CashLaunch
+
+	t.Run("Insert", func(t *testing.T) {
+		generation := warmUp()
+
+		var err error
+
+		modelCashLaunch, err = usecaseCashLaunch.Insert(&model.CashLaunch{
+			AccountID:     1,
+			ReferenceDate: referenceDate,
+			Type:          "C",
+			Description:   "Description Cache",
+			Value:         decimal.RequireFromString("10"),
+		}, modelAuditDefault)
+
+		assert.Nil(t, err)
+
+		assertEvicted(t, generation)
+
+		resultCashBalanceDaily, _ := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate.AddDate(0, 0, 30), 0, false, false)
+
+		assert.Equal(t, "972.97", resultCashBalanceDaily.OpeningBalance.String())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		generation := warmUp()
+
+		modelCashLaunch.ReferenceDate = referenceDate.AddDate(0, 0, 1)
+
+		_, err := usecaseCashLaunch.Update(modelCashLaunch, modelAuditDefault)
+
+		assert.Nil(t, err)
+
+		assertEvicted(t, generation)
+	})
+
+	t.Run("DeleteByID", func(t *testing.T) {
+		generation := warmUp()
+
+		err := usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+
+		assert.Nil(t, err)
+
+		assertEvicted(t, generation)
+	})
+
+	t.Run("EvictError", func(t *testing.T) {
+		cache.(*cache_in_memory.InMemory).Error = true
+		defer func() { cache.(*cache_in_memory.InMemory).Error = false }()
+
+		modelCashLaunchInsert, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+			AccountID:     1,
+			ReferenceDate: referenceDate,
+			Type:          "C",
+			Description:   "Description Cache Error",
+			Value:         decimal.RequireFromString("10"),
+		}, modelAuditDefault)
+
+		// the launch is persisted and the eviction error is returned
+		assert.NotNil(t, err)
+		assert.Nil(t, modelCashLaunchInsert)
+
+		cache.(*cache_in_memory.InMemory).Error = false
+
+		modelCashLaunches, _, err := usecaseCashLaunch.List(&model.CashLaunchFilter{ReferenceDateFrom: referenceDate, ReferenceDateTo: referenceDate, Description: "Description Cache Error"})
+		assert.Nil(t, err)
+		assert.Len(t, modelCashLaunches, 1)
+
+		assert.Nil(t, usecaseCashLaunch.DeleteByID(modelCashLaunches[0].ID, modelAuditDefault))
+	})
+}
+
+func TestCashTransferCacheEvict(t *testing.T) {
+	referenceDate := time.Date(2010, 06, 10, 00, 00, 00, 000, time.UTC)
+
+	repository, _ := repository_in_memory.NewInMemory(false)
+	cache, _ := cache_in_memory.NewInMemory(false)
+
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDailyCache(usecase.NewCashBalanceDaily(repository.CashBalanceDaily()), cache)
+	usecaseCashTransfer := usecase.NewCashTransferCache(usecase.NewCashTransfer(repository.CashTransfer(), repository.CashAccount(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault), cache)
+
+	usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+
+	_, err := usecaseCashTransfer.Insert(&model.CashTransfer{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		ReferenceDate: referenceDate,
+		Description:   "Transfer Cache",
+		Value:         decimal.RequireFromString("10"),
+	}, modelAuditDefault)
+
+	assert.Nil(t, err)
+
+	generation, err := usecase.CashBalanceDailyCacheGeneration(cache)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), generation)
+
+	resultCashBalanceDaily, _ := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+
+	assert.Equal(t, "10", resultCashBalanceDaily.TotalDebit.String())
+}
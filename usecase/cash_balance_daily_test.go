@@ -8,12 +8,15 @@ import (
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
 	type test struct {
 		name                 string
 		inputReferenceDate   time.Time
+		inputAccountID       int64
 		wantCashBalanceDaily *model.CashBalanceDaily
 		wantError            error
 	}
@@ -41,8 +44,51 @@ func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
 			name:               "SuccessFound",
 			inputReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
 			wantCashBalanceDaily: &model.CashBalanceDaily{
-				ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
-				Value:         975.31,
+				ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+				TotalCredit:    decimal.RequireFromString("987.65"),
+				TotalDebit:     decimal.RequireFromString("12.34"),
+				Value:          decimal.RequireFromString("975.31"),
+				ClosingBalance: decimal.RequireFromString("975.31"),
+			},
+			wantError: nil,
+		},
+		{
+			name:               "SuccessCarriedForward",
+			inputReferenceDate: time.Date(2001, 01, 10, 00, 00, 00, 000, time.UTC),
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  time.Date(2001, 01, 10, 00, 00, 00, 000, time.UTC),
+				OpeningBalance: decimal.RequireFromString("975.31"),
+				ClosingBalance: decimal.RequireFromString("975.31"),
+			},
+			wantError: nil,
+		},
+		{
+			name:               "AccountIDError",
+			inputReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+			inputAccountID:     -1,
+			wantError:          usecase.ErrParamValidate{Message: usecase.CashBalanceDailyAccountIDInvalidError},
+		},
+		{
+			name:               "SuccessAccount",
+			inputReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+			inputAccountID:     1,
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+				TotalCredit:    decimal.Zero,
+				TotalDebit:     decimal.RequireFromString("12.34"),
+				Value:          decimal.RequireFromString("-12.34"),
+				ClosingBalance: decimal.RequireFromString("-12.34"),
+			},
+			wantError: nil,
+		},
+		{
+			name:               "SuccessAccountCarriedForward",
+			inputReferenceDate: time.Date(2001, 11, 22, 00, 00, 00, 000, time.UTC),
+			inputAccountID:     2,
+			wantCashBalanceDaily: &model.CashBalanceDaily{
+				ReferenceDate:  time.Date(2001, 11, 22, 00, 00, 00, 000, time.UTC),
+				OpeningBalance: decimal.RequireFromString("987.65"),
+				ClosingBalance: decimal.RequireFromString("987.65"),
 			},
 			wantError: nil,
 		},
@@ -55,13 +101,13 @@ func TestCashBalanceDailyGetByReferenceDate(t *testing.T) {
 
 			usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryCashBalanceDaily)
 
-			resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(tt.inputReferenceDate)
+			resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(tt.inputReferenceDate, tt.inputAccountID, false, false)
 
 			if !reflect.DeepEqual(err, tt.wantError) {
 				t.Errorf("GetByReferenceDate() got error = %v, want = %v.", err, tt.wantError)
 			}
 
-			if !reflect.DeepEqual(resultCashBalanceDaily, tt.wantCashBalanceDaily) {
+			if !equalJSON(tt.wantCashBalanceDaily, resultCashBalanceDaily) {
 				t.Errorf("GetByReferenceDate() got result = %v, want = %v.", resultCashBalanceDaily, tt.wantCashBalanceDaily)
 			}
 		})
@@ -147,8 +193,75 @@ func TestCashBalanceDailyGetByRangeReferenceDate(t *testing.T) {
 			},
 			wantCashBalanceDailies: model.CashBalanceDailies{
 				{
-					ReferenceDate: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
-					Value:         975.31,
+					ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+			},
+			wantError: nil,
+		},
+		{
+			name: "SuccessCarriedForward",
+			inputCashBalanceDailyRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2001, 11, 01, 00, 00, 00, 000, time.UTC),
+				To:   time.Date(2001, 11, 30, 00, 00, 00, 000, time.UTC),
+			},
+			wantCashBalanceDailies: model.CashBalanceDailies{
+				{
+					ReferenceDate:  time.Date(2001, 11, 22, 00, 00, 00, 000, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("-12.34"),
+					ClosingBalance: decimal.RequireFromString("962.97"),
+				},
+			},
+			wantError: nil,
+		},
+		{
+			name: "SuccessFill",
+			inputCashBalanceDailyRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+				To:   time.Date(2000, 11, 23, 00, 00, 00, 000, time.UTC),
+				Fill: true,
+			},
+			wantCashBalanceDailies: model.CashBalanceDailies{
+				{
+					ReferenceDate: time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+				},
+				{
+					ReferenceDate:  time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+				{
+					ReferenceDate:  time.Date(2000, 11, 23, 00, 00, 00, 000, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+			},
+			wantError: nil,
+		},
+		{
+			name: "SuccessFillCarriedForward",
+			inputCashBalanceDailyRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2001, 11, 01, 00, 00, 00, 000, time.UTC),
+				To:   time.Date(2001, 11, 02, 00, 00, 00, 000, time.UTC),
+				Fill: true,
+			},
+			wantCashBalanceDailies: model.CashBalanceDailies{
+				{
+					ReferenceDate:  time.Date(2001, 11, 01, 00, 00, 00, 000, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+				{
+					ReferenceDate:  time.Date(2001, 11, 02, 00, 00, 00, 000, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
 				},
 			},
 			wantError: nil,
@@ -168,9 +281,257 @@ func TestCashBalanceDailyGetByRangeReferenceDate(t *testing.T) {
 				t.Errorf("GetByReferenceDate() got error = %v, want = %v.", err, tt.wantError)
 			}
 
-			if !reflect.DeepEqual(resultCashBalanceDailies, tt.wantCashBalanceDailies) {
+			if !equalJSON(tt.wantCashBalanceDailies, resultCashBalanceDailies) {
 				t.Errorf("GetByReferenceDate() got result = %v, want = %v.", resultCashBalanceDailies, tt.wantCashBalanceDailies)
 			}
 		})
 	}
 }
+
+func TestCashBalanceDailyRebuild(t *testing.T) {
+	// an account without launches, so the rebuilt balance is always zero
+	accountID := int64(999)
+	referenceDate := time.Date(2003, 07, 15, 00, 00, 00, 000, time.UTC)
+
+	cashBalanceDailyDrifted := model.CashBalanceDaily{
+		ReferenceDate:  referenceDate,
+		OpeningBalance: decimal.Zero,
+		TotalCredit:    decimal.RequireFromString("100"),
+		TotalDebit:     decimal.Zero,
+		Value:          decimal.RequireFromString("100"),
+		ClosingBalance: decimal.RequireFromString("100"),
+	}
+
+	type test struct {
+		name                       string
+		repoError                  bool
+		inputStored                []repository_in_memory.InMemoryCashBalanceDailyStored
+		wantCashBalanceDailyDrifts model.CashBalanceDailyDrifts
+		wantErrorMessage           string
+	}
+
+	tests := []test{
+		{
+			name:             "RepositoryError",
+			repoError:        true,
+			wantErrorMessage: "Error persist in database",
+		},
+		{
+			name:                       "Success",
+			wantCashBalanceDailyDrifts: model.CashBalanceDailyDrifts{},
+		},
+		{
+			name:        "DriftSuccess",
+			inputStored: []repository_in_memory.InMemoryCashBalanceDailyStored{{AccountID: accountID, CashBalanceDaily: cashBalanceDailyDrifted}},
+			wantCashBalanceDailyDrifts: model.CashBalanceDailyDrifts{
+				{
+					AccountID:     accountID,
+					ReferenceDate: referenceDate,
+					Stored:        cashBalanceDailyDrifted,
+					Rebuilt:       model.CashBalanceDaily{ReferenceDate: referenceDate},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			repositoryCashBalanceDaily := repository.CashBalanceDaily()
+
+			if tt.inputStored != nil {
+				repository_in_memory.InMemoryCashBalanceDailiesStored = tt.inputStored
+
+				// the drifted balance is read until it is rebuilt
+				modelCashBalanceDaily, err := repositoryCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, false, false)
+				assert.Nil(t, err)
+				assert.Equal(t, "100", modelCashBalanceDaily.ClosingBalance.String())
+			}
+
+			usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryCashBalanceDaily)
+
+			resultCashBalanceDailyDrifts, err := usecaseCashBalanceDaily.Rebuild()
+
+			if (err != nil || tt.wantErrorMessage != "") && (err == nil || err.Error() != tt.wantErrorMessage) {
+				t.Errorf("Rebuild() got error = %v, want = %v.", err, tt.wantErrorMessage)
+			}
+
+			if !equalJSON(tt.wantCashBalanceDailyDrifts, resultCashBalanceDailyDrifts) {
+				t.Errorf("Rebuild() got result = %v, want = %v.", resultCashBalanceDailyDrifts, tt.wantCashBalanceDailyDrifts)
+			}
+
+			if tt.inputStored != nil {
+				modelCashBalanceDaily, err := repositoryCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, false, false)
+				assert.Nil(t, err)
+				assert.True(t, modelCashBalanceDaily.ClosingBalance.IsZero())
+			}
+		})
+	}
+}
+
+func TestCashBalanceDailyGetCurrenciesByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name                           string
+		inputRangeReferenceDate        *model.CashBalanceDailyRangeReferenceDate
+		wantCashBalanceDailyCurrencies model.CashBalanceDailyCurrencies
+		wantError                      error
+	}
+
+	referenceDate := time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC)
+
+	tests := []test{
+		{
+			name: "ParamToSmallerFromError",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: referenceDate,
+				To:   referenceDate.AddDate(0, 0, -1),
+			},
+			wantError: usecase.ErrParamValidate{Message: usecase.CashBalanceDailyRangeReferenceDateToSmallerFromError},
+		},
+		{
+			name: "Success",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: referenceDate,
+				To:   referenceDate,
+			},
+			wantCashBalanceDailyCurrencies: model.CashBalanceDailyCurrencies{
+				{
+					ReferenceDate: referenceDate,
+					Currency:      "BRL",
+					TotalCredit:   decimal.RequireFromString("987.65"),
+					TotalDebit:    decimal.RequireFromString("12.34"),
+					Value:         decimal.RequireFromString("975.31"),
+					BaseValue:     decimal.RequireFromString("975.31"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repository.CashBalanceDaily())
+
+			resultCashBalanceDailyCurrencies, err := usecaseCashBalanceDaily.GetCurrenciesByRangeReferenceDate(tt.inputRangeReferenceDate)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("GetCurrenciesByRangeReferenceDate() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if !equalJSON(tt.wantCashBalanceDailyCurrencies, resultCashBalanceDailyCurrencies) {
+				t.Errorf("GetCurrenciesByRangeReferenceDate() got result = %v, want = %v.", resultCashBalanceDailyCurrencies, tt.wantCashBalanceDailyCurrencies)
+			}
+		})
+	}
+}
+
+func TestCashBalanceDailyGetSummaryByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name                     string
+		inputRangeReferenceDate  *model.CashBalanceDailyRangeReferenceDate
+		wantCashBalanceSummaries model.CashBalanceSummaries
+		wantError                error
+	}
+
+	tests := []test{
+		{
+			name: "ParamGranularityInvalidError",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From:        time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Granularity: "semester",
+			},
+			wantError: usecase.ErrParamValidate{Message: usecase.CashBalanceSummaryGranularityInvalidError},
+		},
+		{
+			name: "ParamToSmallerFromError",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From:        time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Granularity: usecase.CashBalanceSummaryGranularityYear,
+			},
+			wantError: usecase.ErrParamValidate{Message: usecase.CashBalanceDailyRangeReferenceDateToSmallerFromError},
+		},
+		{
+			name: "ParamRangeDayError",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantError: usecase.ErrParamValidate{Message: "the range is greater than 31 days"},
+		},
+		{
+			name: "ParamRangeMonthError",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From:        time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
+				Granularity: usecase.CashBalanceSummaryGranularityMonth,
+			},
+			wantError: usecase.ErrParamValidate{Message: "the range is greater than 60 months"},
+		},
+		{
+			name: "SuccessYear",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From:        time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2004, 12, 31, 0, 0, 0, 0, time.UTC),
+				Granularity: usecase.CashBalanceSummaryGranularityYear,
+			},
+			wantCashBalanceSummaries: model.CashBalanceSummaries{
+				{
+					Period:         time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+					From:           time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC),
+					To:             time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+				{
+					Period:         time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+					From:           time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:             time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC),
+					OpeningBalance: decimal.RequireFromString("975.31"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("-12.34"),
+					ClosingBalance: decimal.RequireFromString("962.97"),
+				},
+			},
+		},
+		{
+			name: "SuccessWeek",
+			inputRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From:        time.Date(2000, 11, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2000, 11, 30, 0, 0, 0, 0, time.UTC),
+				Granularity: usecase.CashBalanceSummaryGranularityWeek,
+			},
+			wantCashBalanceSummaries: model.CashBalanceSummaries{
+				{
+					Period:         time.Date(2000, 11, 20, 0, 0, 0, 0, time.UTC),
+					From:           time.Date(2000, 11, 20, 0, 0, 0, 0, time.UTC),
+					To:             time.Date(2000, 11, 26, 0, 0, 0, 0, time.UTC),
+					TotalCredit:    decimal.RequireFromString("987.65"),
+					TotalDebit:     decimal.RequireFromString("12.34"),
+					Value:          decimal.RequireFromString("975.31"),
+					ClosingBalance: decimal.RequireFromString("975.31"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repository.CashBalanceDaily())
+
+			resultCashBalanceSummaries, err := usecaseCashBalanceDaily.GetSummaryByRangeReferenceDate(tt.inputRangeReferenceDate)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("GetSummaryByRangeReferenceDate() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if !equalJSON(tt.wantCashBalanceSummaries, resultCashBalanceSummaries) {
+				t.Errorf("GetSummaryByRangeReferenceDate() got result = %v, want = %v.", resultCashBalanceSummaries, tt.wantCashBalanceSummaries)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CashBudgetViewMonth     = "month"
+	CashBudgetViewYTD       = "ytd"
+	CashBudgetViews         = []string{CashBudgetViewMonth, CashBudgetViewYTD}
+	CashBudgetListMonthsMax = 120
+
+	CashBudgetMessageCategoryIDError             = "The category_id is less than 0"
+	CashBudgetMessageCategoryOrPatternEmptyError = "The category_id and the pattern are empty"
+	CashBudgetMessageCategoryAndPatternError     = "The category_id and the pattern are both informed"
+	CashBudgetMessageCategoryNotFoundError       = "The category_id does not exist"
+	CashBudgetMessagePeriodEmptyError            = "The period is empty"
+	CashBudgetMessageValueError                  = "The value is less than or equal to 0"
+
+	CashBudgetMessageListToSmallerFromError   = "The param to is smaller the param from"
+	CashBudgetMessageListRangeError           = fmt.Sprintf("The range is greater than %v months", CashBudgetListMonthsMax)
+	CashBudgetMessageVariancePeriodEmptyError = "The param period is empty"
+	CashBudgetMessageVarianceViewInvalidError = fmt.Sprintf("The param view not in ['%v']", strings.Join(CashBudgetViews, "', '"))
+)
+
+type CashBudget interface {
+	Insert(modelCashBudget *model.CashBudget) (*model.CashBudget, error)
+	List(from time.Time, to time.Time) (model.CashBudgets, error)
+	GetByID(id int64) (*model.CashBudget, error)
+	Update(modelCashBudget *model.CashBudget) (*model.CashBudget, error)
+	DeleteByID(id int64) error
+	Variance(period time.Time, view string) (*model.CashBudgetVariance, error)
+}
+
+type UseCaseCashBudget struct {
+	RepositoryCashBudget   repository.CashBudget
+	RepositoryCashCategory repository.CashCategory
+}
+
+func NewCashBudget(repositoryCashBudget repository.CashBudget, repositoryCashCategory repository.CashCategory) CashBudget {
+	return &UseCaseCashBudget{
+		RepositoryCashBudget:   repositoryCashBudget,
+		RepositoryCashCategory: repositoryCashCategory,
+	}
+}
+
+func (useCaseCashBudget *UseCaseCashBudget) Insert(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	err := useCaseCashBudget.cashBudgetValidate(modelCashBudget)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashBudget.CreatedAt = time.Now().UTC()
+	modelCashBudget.UpdatedAt = modelCashBudget.CreatedAt
+
+	return useCaseCashBudget.RepositoryCashBudget.Insert(modelCashBudget)
+}
+
+// List returns the budgets of the months between from and to, by default
+// the months of the current year
+func (useCaseCashBudget *UseCaseCashBudget) List(from time.Time, to time.Time) (model.CashBudgets, error) {
+	if to.IsZero() {
+		to = time.Date(time.Now().UTC().Year(), 12, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if from.IsZero() {
+		from = time.Date(to.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	from = CashPeriodOf(from)
+	to = CashPeriodOf(to)
+
+	if to.Before(from) {
+		return nil, ErrParamValidate{Message: CashBudgetMessageListToSmallerFromError}
+	}
+
+	if !to.Before(from.AddDate(0, CashBudgetListMonthsMax, 0)) {
+		return nil, ErrParamValidate{Message: CashBudgetMessageListRangeError}
+	}
+
+	return useCaseCashBudget.RepositoryCashBudget.List(from, to)
+}
+
+func (useCaseCashBudget *UseCaseCashBudget) GetByID(id int64) (*model.CashBudget, error) {
+	return useCaseCashBudget.RepositoryCashBudget.GetByID(id)
+}
+
+func (useCaseCashBudget *UseCaseCashBudget) Update(modelCashBudget *model.CashBudget) (*model.CashBudget, error) {
+	err := useCaseCashBudget.cashBudgetValidate(modelCashBudget)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashBudget.UpdatedAt = time.Now().UTC()
+
+	return useCaseCashBudget.RepositoryCashBudget.Update(modelCashBudget)
+}
+
+func (useCaseCashBudget *UseCaseCashBudget) DeleteByID(id int64) error {
+	return useCaseCashBudget.RepositoryCashBudget.DeleteByID(id)
+}
+
+// Variance compares the budgets with the approved launches of the same type
+// of the month, or of the months from january until the month in the ytd
+// view. The budgets of a category include the launches of its subcategories
+// and the budgets of a pattern the launches whose description matches it.
+func (useCaseCashBudget *UseCaseCashBudget) Variance(period time.Time, view string) (*model.CashBudgetVariance, error) {
+	if view == "" {
+		view = CashBudgetViewMonth
+	}
+
+	messages := []string{}
+
+	if period.IsZero() {
+		messages = append(messages, CashBudgetMessageVariancePeriodEmptyError)
+	}
+
+	if view != CashBudgetViewMonth && view != CashBudgetViewYTD {
+		messages = append(messages, CashBudgetMessageVarianceViewInvalidError)
+	}
+
+	if len(messages) > 0 {
+		return nil, ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	period = CashPeriodOf(period)
+
+	modelCashBudgetVariance := &model.CashBudgetVariance{
+		Period: period,
+		View:   view,
+		From:   period,
+		To:     period.AddDate(0, 1, -1),
+		Items:  model.CashBudgetVarianceItems{},
+	}
+
+	if view == CashBudgetViewYTD {
+		modelCashBudgetVariance.From = time.Date(period.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	modelCashBudgets, err := useCaseCashBudget.RepositoryCashBudget.List(modelCashBudgetVariance.From, period)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategories, err := useCaseCashBudget.RepositoryCashCategory.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryByID := map[int64]model.CashCategory{}
+
+	for _, modelCashCategory := range modelCashCategories {
+		modelCashCategoryByID[modelCashCategory.ID] = modelCashCategory
+	}
+
+	// the budgets of the same category or pattern and type are summed in
+	// the ytd view
+	for _, modelCashBudget := range modelCashBudgets {
+		idx := cashBudgetVarianceItemIndex(modelCashBudgetVariance.Items, &modelCashBudget)
+
+		if idx < 0 {
+			modelCashBudgetVariance.Items = append(modelCashBudgetVariance.Items, model.CashBudgetVarianceItem{
+				CategoryID:  modelCashBudget.CategoryID,
+				Path:        cashCategoryPath(modelCashCategoryByID, modelCashBudget.CategoryID),
+				Pattern:     modelCashBudget.Pattern,
+				PatternType: modelCashBudget.PatternType,
+				Type:        modelCashBudget.Type,
+				Budgeted:    decimal.Zero,
+				Actual:      decimal.Zero,
+			})
+			idx = len(modelCashBudgetVariance.Items) - 1
+		}
+
+		modelCashBudgetVariance.Items[idx].Budgeted = modelCashBudgetVariance.Items[idx].Budgeted.Add(modelCashBudget.Value)
+	}
+
+	if len(modelCashBudgetVariance.Items) == 0 {
+		return modelCashBudgetVariance, nil
+	}
+
+	// the launches are summed by category in the repository and the totals of
+	// the subcategories are added to the category of the budget
+	modelCashBudgetActuals, err := useCaseCashBudget.RepositoryCashBudget.ListActualByCategory(modelCashBudgetVariance.From, modelCashBudgetVariance.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the patterns are matched here with the category rule matcher, the same
+	// engine that validated them, over the totals by description
+	modelCashBudgetActualsByDescription := model.CashBudgetActuals{}
+
+	for _, modelCashBudgetVarianceItem := range modelCashBudgetVariance.Items {
+		if modelCashBudgetVarianceItem.CategoryID == 0 {
+			modelCashBudgetActualsByDescription, err = useCaseCashBudget.RepositoryCashBudget.ListActualByDescription(modelCashBudgetVariance.From, modelCashBudgetVariance.To)
+
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+
+	for idx := range modelCashBudgetVariance.Items {
+		modelCashBudgetVarianceItem := &modelCashBudgetVariance.Items[idx]
+
+		if modelCashBudgetVarianceItem.CategoryID == 0 {
+			matcher := newCashPatternMatcher(modelCashBudgetVarianceItem.Pattern, modelCashBudgetVarianceItem.PatternType)
+
+			for _, modelCashBudgetActual := range modelCashBudgetActualsByDescription {
+				if modelCashBudgetActual.Type == modelCashBudgetVarianceItem.Type && matcher.Match(modelCashBudgetActual.Description) {
+					modelCashBudgetVarianceItem.Actual = modelCashBudgetVarianceItem.Actual.Add(modelCashBudgetActual.Actual)
+				}
+			}
+		}
+
+		for _, modelCashBudgetActual := range modelCashBudgetActuals {
+			if cashBudgetVarianceItemMatch(modelCashCategoryByID, modelCashBudgetVarianceItem, &modelCashBudgetActual) {
+				modelCashBudgetVarianceItem.Actual = modelCashBudgetVarianceItem.Actual.Add(modelCashBudgetActual.Actual)
+			}
+		}
+
+		modelCashBudgetVarianceItem.Variance = modelCashBudgetVarianceItem.Actual.Sub(modelCashBudgetVarianceItem.Budgeted)
+		modelCashBudgetVarianceItem.VariancePercent = modelCashBudgetVarianceItem.Variance.Div(modelCashBudgetVarianceItem.Budgeted).Mul(decimal.NewFromInt(100)).Round(2)
+	}
+
+	sort.SliceStable(modelCashBudgetVariance.Items, func(i, j int) bool {
+		itemI, itemJ := modelCashBudgetVariance.Items[i], modelCashBudgetVariance.Items[j]
+
+		if itemI.Type != itemJ.Type {
+			return itemI.Type < itemJ.Type
+		}
+
+		if itemI.CategoryID != itemJ.CategoryID {
+			return itemI.CategoryID < itemJ.CategoryID
+		}
+
+		if itemI.PatternType != itemJ.PatternType {
+			return itemI.PatternType < itemJ.PatternType
+		}
+
+		return itemI.Pattern < itemJ.Pattern
+	})
+
+	return modelCashBudgetVariance, nil
+}
+
+func (useCaseCashBudget *UseCaseCashBudget) cashBudgetValidate(modelCashBudget *model.CashBudget) error {
+	err := cashBudgetModelValidate(modelCashBudget)
+
+	if err != nil {
+		return err
+	}
+
+	if modelCashBudget.CategoryID == 0 {
+		return nil
+	}
+
+	_, err = useCaseCashBudget.RepositoryCashCategory.GetByID(modelCashBudget.CategoryID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashBudgetMessageCategoryNotFoundError}
+	}
+
+	return err
+}
+
+// cashBudgetVarianceItemIndex returns the item of the category or pattern and
+// type of the budget or -1 when the report does not have it yet
+func cashBudgetVarianceItemIndex(modelCashBudgetVarianceItems model.CashBudgetVarianceItems, modelCashBudget *model.CashBudget) int {
+	for idx, modelCashBudgetVarianceItem := range modelCashBudgetVarianceItems {
+		if modelCashBudgetVarianceItem.CategoryID == modelCashBudget.CategoryID &&
+			modelCashBudgetVarianceItem.PatternType == modelCashBudget.PatternType &&
+			modelCashBudgetVarianceItem.Pattern == modelCashBudget.Pattern &&
+			modelCashBudgetVarianceItem.Type == modelCashBudget.Type {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// cashBudgetVarianceItemMatch checks the total is of the type and of the
+// category of the item or one of its subcategories
+func cashBudgetVarianceItemMatch(modelCashCategoryByID map[int64]model.CashCategory, modelCashBudgetVarianceItem *model.CashBudgetVarianceItem, modelCashBudgetActual *model.CashBudgetActual) bool {
+	if modelCashBudgetVarianceItem.CategoryID == 0 || modelCashBudgetActual.Type != modelCashBudgetVarianceItem.Type {
+		return false
+	}
+
+	id := modelCashBudgetActual.CategoryID
+
+	for depth := 0; id != 0 && depth <= len(modelCashCategoryByID); depth++ {
+		if id == modelCashBudgetVarianceItem.CategoryID {
+			return true
+		}
+
+		id = modelCashCategoryByID[id].ParentID
+	}
+
+	return false
+}
+
+func cashBudgetModelValidate(modelCashBudget *model.CashBudget) error {
+	messages := []string{}
+
+	CashBudgetModelFormat(modelCashBudget)
+
+	if modelCashBudget.CategoryID < 0 {
+		messages = append(messages, CashBudgetMessageCategoryIDError)
+	} else if modelCashBudget.CategoryID == 0 && modelCashBudget.Pattern == "" {
+		messages = append(messages, CashBudgetMessageCategoryOrPatternEmptyError)
+	} else if modelCashBudget.CategoryID > 0 && modelCashBudget.Pattern != "" {
+		messages = append(messages, CashBudgetMessageCategoryAndPatternError)
+	}
+
+	if modelCashBudget.Pattern != "" {
+		if modelCashBudget.PatternType != "substring" && modelCashBudget.PatternType != "regex" {
+			messages = append(messages, CashCategoryRuleMessagePatternTypeInvalidError)
+		}
+
+		if len(modelCashBudget.Pattern) > CashCategoryRulePatternMaxLen {
+			messages = append(messages, CashCategoryRuleMessagePatternSizeError)
+		} else if modelCashBudget.PatternType == "regex" {
+			if _, err := regexp.Compile("(?i)" + modelCashBudget.Pattern); err != nil {
+				messages = append(messages, CashCategoryRuleMessagePatternRegexError)
+			}
+		}
+	}
+
+	if modelCashBudget.Type == "" {
+		messages = append(messages, CashCategoryRuleMessageTypeEmptyError)
+	} else if modelCashBudget.Type != "C" && modelCashBudget.Type != "D" {
+		messages = append(messages, CashCategoryRuleMessageTypeInvalidError)
+	}
+
+	if modelCashBudget.Period.IsZero() {
+		messages = append(messages, CashBudgetMessagePeriodEmptyError)
+	}
+
+	if !modelCashBudget.Value.IsPositive() {
+		messages = append(messages, CashBudgetMessageValueError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func CashBudgetModelFormat(modelCashBudget *model.CashBudget) {
+	modelCashBudget.PatternType = util.FormatTextWithoutSpace(strings.ToLower(modelCashBudget.PatternType))
+	modelCashBudget.Type = util.FormatTextWithoutSpace(util.FormatTitle(modelCashBudget.Type))
+	modelCashBudget.Value = modelCashBudget.Value.Round(2)
+
+	if modelCashBudget.PatternType == "regex" {
+		modelCashBudget.Pattern = strings.TrimSpace(modelCashBudget.Pattern)
+	} else {
+		modelCashBudget.Pattern = util.FormatTitle(modelCashBudget.Pattern)
+	}
+
+	// the budget of a category has no pattern
+	if modelCashBudget.Pattern == "" {
+		modelCashBudget.PatternType = ""
+	}
+
+	if !modelCashBudget.Period.IsZero() {
+		modelCashBudget.Period = CashPeriodOf(modelCashBudget.Period)
+	}
+}
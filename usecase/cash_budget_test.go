@@ -0,0 +1,255 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashBudgetInsert(t *testing.T) {
+	type test struct {
+		name            string
+		inputCashBudget *model.CashBudget
+		wantError       error
+		assert          func(t *testing.T, tt *test, resultCashBudget *model.CashBudget, err error)
+	}
+
+	tests := []test{
+		{
+			name:            "EmptyError",
+			inputCashBudget: &model.CashBudget{},
+			wantError:       usecase.ErrModelValidate{Message: usecase.CashBudgetMessageCategoryOrPatternEmptyError + ";" + usecase.CashCategoryRuleMessageTypeEmptyError + ";" + usecase.CashBudgetMessagePeriodEmptyError + ";" + usecase.CashBudgetMessageValueError},
+		},
+		{
+			name: "CategoryAndPatternError",
+			inputCashBudget: &model.CashBudget{
+				CategoryID:  2,
+				Pattern:     "ALUGUEL(",
+				PatternType: "regex",
+				Type:        "D",
+				Period:      time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC),
+				Value:       decimal.RequireFromString("100"),
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashBudgetMessageCategoryAndPatternError + ";" + usecase.CashCategoryRuleMessagePatternRegexError},
+		},
+		{
+			name: "CategoryNotFoundError",
+			inputCashBudget: &model.CashBudget{
+				CategoryID: 999,
+				Type:       "D",
+				Period:     time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC),
+				Value:      decimal.RequireFromString("100"),
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashBudgetMessageCategoryNotFoundError},
+		},
+		{
+			name: "DuplicateKeyError",
+			inputCashBudget: &model.CashBudget{
+				CategoryID: 2,
+				Type:       "D",
+				Period:     time.Date(2019, 8, 20, 0, 0, 0, 0, time.UTC),
+				Value:      decimal.RequireFromString("100"),
+			},
+			assert: func(t *testing.T, tt *test, resultCashBudget *model.CashBudget, err error) {
+				_, ok := err.(repository.ErrDuplicateKey)
+				assert.True(t, ok)
+				assert.Nil(t, resultCashBudget)
+			},
+		},
+		{
+			name: "Success",
+			inputCashBudget: &model.CashBudget{
+				Pattern:     " conta  energia ",
+				PatternType: " Substring ",
+				Type:        "d",
+				Period:      time.Date(2032, 3, 15, 10, 0, 0, 0, time.UTC),
+				Value:       decimal.RequireFromString("200.005"),
+			},
+			assert: func(t *testing.T, tt *test, resultCashBudget *model.CashBudget, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashBudget)
+				assert.NotEqual(t, int64(0), resultCashBudget.ID)
+				assert.Equal(t, "CONTA ENERGIA", resultCashBudget.Pattern)
+				assert.Equal(t, "substring", resultCashBudget.PatternType)
+				assert.Equal(t, "D", resultCashBudget.Type)
+				assert.Equal(t, time.Date(2032, 3, 1, 0, 0, 0, 0, time.UTC), resultCashBudget.Period)
+				assert.Equal(t, "200.01", resultCashBudget.Value.String())
+				assert.False(t, resultCashBudget.CreatedAt.IsZero())
+
+				repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+				assert.Nil(t, repositoryInMemory.CashBudget().DeleteByID(resultCashBudget.ID))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashBudget := usecase.NewCashBudget(repository.CashBudget(), repository.CashCategory())
+
+			modelCashBudget := *tt.inputCashBudget
+
+			resultCashBudget, err := usecaseCashBudget.Insert(&modelCashBudget)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashBudget, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashBudget)
+			}
+		})
+	}
+}
+
+func TestCashBudgetList(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashBudget := usecase.NewCashBudget(repositoryInMemory.CashBudget(), repositoryInMemory.CashCategory())
+
+	_, err := usecaseCashBudget.List(time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashBudgetMessageListToSmallerFromError}, err)
+
+	_, err = usecaseCashBudget.List(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashBudgetMessageListRangeError}, err)
+
+	resultCashBudgets, err := usecaseCashBudget.List(time.Time{}, time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashBudgets, 1)
+	assert.Equal(t, int64(1), resultCashBudgets[0].ID)
+}
+
+func TestCashBudgetVariance(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashBudget := usecase.NewCashBudget(repositoryInMemory.CashBudget(), repositoryInMemory.CashCategory())
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, usecase.CashLaunchApprovalThresholds{"D": decimal.NewFromInt(5000)})
+
+	_, err := usecaseCashBudget.Variance(time.Time{}, "week")
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashBudgetMessageVariancePeriodEmptyError + ";" + usecase.CashBudgetMessageVarianceViewInvalidError}, err)
+
+	modelCashBudgetIDs := []int64{}
+
+	for _, modelCashBudget := range []model.CashBudget{
+		{CategoryID: 1, Type: "D", Period: time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromInt(1000)},
+		{CategoryID: 1, Type: "D", Period: time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromInt(1000)},
+		{Pattern: "energia", PatternType: "substring", Type: "D", Period: time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromInt(200)},
+		{CategoryID: 3, Type: "C", Period: time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromInt(500)},
+	} {
+		resultCashBudget, err := usecaseCashBudget.Insert(&modelCashBudget)
+		assert.Nil(t, err)
+
+		modelCashBudgetIDs = append(modelCashBudgetIDs, resultCashBudget.ID)
+	}
+
+	modelCashLaunchIDs := []int64{}
+
+	for _, modelCashLaunch := range []model.CashLaunch{
+		// the launches of the subcategory count for the budget of the category
+		{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 1, 10, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel janeiro", Value: decimal.NewFromInt(900)},
+		{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 2, 5, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel fevereiro", Value: decimal.NewFromInt(1100)},
+		{AccountID: 2, ReferenceDate: time.Date(2032, 2, 6, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Conta Energia", Value: decimal.NewFromInt(250)},
+		// pending approval
+		{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 2, 7, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Equipamento", Value: decimal.NewFromInt(6000)},
+		{AccountID: 1, CategoryID: 3, ReferenceDate: time.Date(2032, 2, 8, 0, 0, 0, 0, time.UTC), Type: "C", Description: "Venda", Value: decimal.NewFromInt(400)},
+		// other type of the category
+		{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 2, 9, 0, 0, 0, 0, time.UTC), Type: "C", Description: "Reembolso", Value: decimal.NewFromInt(50)},
+		// other month
+		{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 3, 1, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel marco", Value: decimal.NewFromInt(1000)},
+	} {
+		resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
+		assert.Nil(t, err)
+
+		modelCashLaunchIDs = append(modelCashLaunchIDs, resultCashLaunch.ID)
+	}
+
+	// a reversed launch and its reversal are left out of the actual
+	modelCashLaunchReversed, err := usecaseCashLaunch.Insert(&model.CashLaunch{AccountID: 1, CategoryID: 2, ReferenceDate: time.Date(2032, 2, 10, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel duplicado", Value: decimal.NewFromInt(300)}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashLaunchReversals, err := usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunchReversed.ID, ReferenceDate: time.Date(2032, 2, 11, 0, 0, 0, 0, time.UTC)}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, modelCashLaunchReversals, 1)
+
+	resultCashBudgetVariance, err := usecaseCashBudget.Variance(time.Date(2032, 2, 20, 0, 0, 0, 0, time.UTC), "")
+	assert.Nil(t, err)
+	assert.Equal(t, usecase.CashBudgetViewMonth, resultCashBudgetVariance.View)
+	assert.Equal(t, time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), resultCashBudgetVariance.From)
+	assert.Equal(t, time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC), resultCashBudgetVariance.To)
+	assert.Len(t, resultCashBudgetVariance.Items, 3)
+
+	modelCashBudgetVarianceItem := resultCashBudgetVariance.Items[0]
+	assert.Equal(t, int64(3), modelCashBudgetVarianceItem.CategoryID)
+	assert.Equal(t, "RECEITAS", modelCashBudgetVarianceItem.Path)
+	assert.Equal(t, "500", modelCashBudgetVarianceItem.Budgeted.String())
+	assert.Equal(t, "400", modelCashBudgetVarianceItem.Actual.String())
+	assert.Equal(t, "-100", modelCashBudgetVarianceItem.Variance.String())
+	assert.Equal(t, "-20", modelCashBudgetVarianceItem.VariancePercent.String())
+
+	modelCashBudgetVarianceItem = resultCashBudgetVariance.Items[1]
+	assert.Equal(t, int64(0), modelCashBudgetVarianceItem.CategoryID)
+	assert.Equal(t, "ENERGIA", modelCashBudgetVarianceItem.Pattern)
+	assert.Equal(t, "250", modelCashBudgetVarianceItem.Actual.String())
+	assert.Equal(t, "50", modelCashBudgetVarianceItem.Variance.String())
+	assert.Equal(t, "25", modelCashBudgetVarianceItem.VariancePercent.String())
+
+	modelCashBudgetVarianceItem = resultCashBudgetVariance.Items[2]
+	assert.Equal(t, int64(1), modelCashBudgetVarianceItem.CategoryID)
+	assert.Equal(t, "D", modelCashBudgetVarianceItem.Type)
+	assert.Equal(t, "1000", modelCashBudgetVarianceItem.Budgeted.String())
+	assert.Equal(t, "1100", modelCashBudgetVarianceItem.Actual.String())
+	assert.Equal(t, "10", modelCashBudgetVarianceItem.VariancePercent.String())
+
+	// the budgets and the launches from january are summed
+	resultCashBudgetVariance, err = usecaseCashBudget.Variance(time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), usecase.CashBudgetViewYTD)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC), resultCashBudgetVariance.From)
+	assert.Len(t, resultCashBudgetVariance.Items, 3)
+
+	modelCashBudgetVarianceItem = resultCashBudgetVariance.Items[2]
+	assert.Equal(t, "2000", modelCashBudgetVarianceItem.Budgeted.String())
+	assert.Equal(t, "2000", modelCashBudgetVarianceItem.Actual.String())
+	assert.Equal(t, "0", modelCashBudgetVarianceItem.Variance.String())
+	assert.Equal(t, "0", modelCashBudgetVarianceItem.VariancePercent.String())
+
+	// the months without budget return no item
+	resultCashBudgetVariance, err = usecaseCashBudget.Variance(time.Date(2032, 3, 1, 0, 0, 0, 0, time.UTC), usecase.CashBudgetViewMonth)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashBudgetVariance.Items, 0)
+
+	// the regex is matched with the same engine that validated it, where \b is
+	// a word boundary
+	resultCashBudget, err := usecaseCashBudget.Insert(&model.CashBudget{Pattern: `\baluguel\b`, PatternType: "regex", Type: "D", Period: time.Date(2032, 3, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromInt(800)})
+	assert.Nil(t, err)
+
+	modelCashBudgetIDs = append(modelCashBudgetIDs, resultCashBudget.ID)
+
+	resultCashBudgetVariance, err = usecaseCashBudget.Variance(time.Date(2032, 3, 1, 0, 0, 0, 0, time.UTC), usecase.CashBudgetViewMonth)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashBudgetVariance.Items, 1)
+	assert.Equal(t, "1000", resultCashBudgetVariance.Items[0].Actual.String())
+	assert.Equal(t, "25", resultCashBudgetVariance.Items[0].VariancePercent.String())
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err = usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+
+	for _, modelCashBudgetID := range modelCashBudgetIDs {
+		err = usecaseCashBudget.DeleteByID(modelCashBudgetID)
+		assert.Nil(t, err)
+	}
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashBudgetError := usecase.NewCashBudget(repositoryInMemoryError.CashBudget(), repositoryInMemoryError.CashCategory())
+
+	_, err = usecaseCashBudgetError.Variance(time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC), usecase.CashBudgetViewMonth)
+	assert.NotNil(t, err)
+}
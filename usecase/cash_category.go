@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CashCategoryNameMinLen = 3
+	CashCategoryNameMaxLen = 100
+	// CashCategoryParentDepthMax bounds the walk up the parents, which would
+	// never end on a cycle left by concurrent updates
+	CashCategoryParentDepthMax = 100
+
+	CashCategoryMessageNameEmptyError       = "The name is empty"
+	CashCategoryMessageNameSizeError        = fmt.Sprintf("The name size is not between %v and %v", CashCategoryNameMinLen, CashCategoryNameMaxLen)
+	CashCategoryMessageParentIDInvalidError = "The parent_id is less than 0"
+	CashCategoryMessageParentNotFoundError  = "The parent_id does not exist"
+	CashCategoryMessageParentCycleError     = "The parent_id is the category itself or one of its subcategories"
+	CashCategoryMessageParentDepthError     = fmt.Sprintf("The parent_id has more than %v parents or its parents form a cycle", CashCategoryParentDepthMax)
+
+	// CashCategoryTotalPathUncategorized names the totals of the launches without category
+	CashCategoryTotalPathUncategorized = "SEM CATEGORIA"
+	CashCategoryTotalPathSeparator     = " / "
+)
+
+type CashCategory interface {
+	Insert(modelCashCategory *model.CashCategory) (*model.CashCategory, error)
+	List() (model.CashCategories, error)
+	GetByID(id int64) (*model.CashCategory, error)
+	Update(modelCashCategory *model.CashCategory) (*model.CashCategory, error)
+	DeleteByID(id int64) error
+	GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) (model.CashCategoryTotals, error)
+}
+
+type UseCaseCashCategory struct {
+	RepositoryCashCategory repository.CashCategory
+}
+
+func NewCashCategory(repositoryCashCategory repository.CashCategory) CashCategory {
+	return &UseCaseCashCategory{
+		RepositoryCashCategory: repositoryCashCategory,
+	}
+}
+
+func (useCaseCashCategory *UseCaseCashCategory) Insert(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	err := cashCategoryModelValidate(modelCashCategory)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashCategory.cashCategoryParentValidate(modelCashCategory)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategory.CreatedAt = time.Now().UTC()
+	modelCashCategory.UpdatedAt = modelCashCategory.CreatedAt
+
+	return useCaseCashCategory.RepositoryCashCategory.Insert(modelCashCategory)
+}
+
+func (useCaseCashCategory *UseCaseCashCategory) List() (model.CashCategories, error) {
+	return useCaseCashCategory.RepositoryCashCategory.List()
+}
+
+func (useCaseCashCategory *UseCaseCashCategory) GetByID(id int64) (*model.CashCategory, error) {
+	return useCaseCashCategory.RepositoryCashCategory.GetByID(id)
+}
+
+func (useCaseCashCategory *UseCaseCashCategory) Update(modelCashCategory *model.CashCategory) (*model.CashCategory, error) {
+	err := cashCategoryModelValidate(modelCashCategory)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashCategory.cashCategoryParentValidate(modelCashCategory)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategory.UpdatedAt = time.Now().UTC()
+
+	return useCaseCashCategory.RepositoryCashCategory.Update(modelCashCategory)
+}
+
+// DeleteByID removes a category, the repository rejects it with
+// repository.ErrReferenced while the category has launches or subcategories
+func (useCaseCashCategory *UseCaseCashCategory) DeleteByID(id int64) error {
+	return useCaseCashCategory.RepositoryCashCategory.DeleteByID(id)
+}
+
+// GetTotalsByRangeReferenceDate returns the credits and debits of every
+// category in the period, the rollup totals add the subcategories to their
+// parents up to the root of the chart of accounts
+func (useCaseCashCategory *UseCaseCashCategory) GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) (model.CashCategoryTotals, error) {
+	err := cashCategoryRangeReferenceDateValidate(cashCategoryRangeReferenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategories, err := useCaseCashCategory.RepositoryCashCategory.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryTotalsLaunch, err := useCaseCashCategory.RepositoryCashCategory.GetTotalsByRangeReferenceDate(cashCategoryRangeReferenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryByID := map[int64]model.CashCategory{}
+	modelCashCategoryTotalByID := map[int64]*model.CashCategoryTotal{}
+	modelCashCategoryTotals := model.CashCategoryTotals{}
+
+	for _, modelCashCategory := range modelCashCategories {
+		modelCashCategoryByID[modelCashCategory.ID] = modelCashCategory
+	}
+
+	for _, modelCashCategory := range modelCashCategories {
+		modelCashCategoryTotals = append(modelCashCategoryTotals, model.CashCategoryTotal{
+			CategoryID:        modelCashCategory.ID,
+			ParentID:          modelCashCategory.ParentID,
+			Path:              cashCategoryPath(modelCashCategoryByID, modelCashCategory.ID),
+			TotalCredit:       decimal.Zero,
+			TotalDebit:        decimal.Zero,
+			Value:             decimal.Zero,
+			RollupTotalCredit: decimal.Zero,
+			RollupTotalDebit:  decimal.Zero,
+			RollupValue:       decimal.Zero,
+		})
+	}
+
+	for _, modelCashCategoryTotalLaunch := range modelCashCategoryTotalsLaunch {
+		if modelCashCategoryTotalLaunch.CategoryID == 0 {
+			modelCashCategoryTotals = append(modelCashCategoryTotals, model.CashCategoryTotal{
+				Path:              CashCategoryTotalPathUncategorized,
+				RollupTotalCredit: decimal.Zero,
+				RollupTotalDebit:  decimal.Zero,
+				RollupValue:       decimal.Zero,
+			})
+		}
+	}
+
+	sort.Slice(modelCashCategoryTotals, func(i, j int) bool {
+		return modelCashCategoryTotals[i].Path < modelCashCategoryTotals[j].Path
+	})
+
+	for idx := range modelCashCategoryTotals {
+		modelCashCategoryTotalByID[modelCashCategoryTotals[idx].CategoryID] = &modelCashCategoryTotals[idx]
+	}
+
+	for _, modelCashCategoryTotalLaunch := range modelCashCategoryTotalsLaunch {
+		modelCashCategoryTotal, ok := modelCashCategoryTotalByID[modelCashCategoryTotalLaunch.CategoryID]
+
+		if !ok {
+			continue
+		}
+
+		modelCashCategoryTotal.TotalCredit = modelCashCategoryTotalLaunch.TotalCredit
+		modelCashCategoryTotal.TotalDebit = modelCashCategoryTotalLaunch.TotalDebit
+		modelCashCategoryTotal.Value = modelCashCategoryTotalLaunch.TotalCredit.Sub(modelCashCategoryTotalLaunch.TotalDebit)
+
+		// the depth is bounded by the number of categories so a broken
+		// hierarchy can not loop forever
+		for depth := 0; ok && depth <= len(modelCashCategories); depth++ {
+			modelCashCategoryTotal.RollupTotalCredit = modelCashCategoryTotal.RollupTotalCredit.Add(modelCashCategoryTotalLaunch.TotalCredit)
+			modelCashCategoryTotal.RollupTotalDebit = modelCashCategoryTotal.RollupTotalDebit.Add(modelCashCategoryTotalLaunch.TotalDebit)
+			modelCashCategoryTotal.RollupValue = modelCashCategoryTotal.RollupTotalCredit.Sub(modelCashCategoryTotal.RollupTotalDebit)
+
+			if modelCashCategoryTotal.ParentID == 0 {
+				break
+			}
+
+			modelCashCategoryTotal, ok = modelCashCategoryTotalByID[modelCashCategoryTotal.ParentID]
+		}
+	}
+
+	return modelCashCategoryTotals, nil
+}
+
+// cashCategoryParentValidate checks the parent exists and is not the category
+// itself or one of its subcategories, walking at most
+// CashCategoryParentDepthMax parents
+func (useCaseCashCategory *UseCaseCashCategory) cashCategoryParentValidate(modelCashCategory *model.CashCategory) error {
+	parentID := modelCashCategory.ParentID
+
+	for depth := 0; parentID != 0; depth++ {
+		if depth >= CashCategoryParentDepthMax {
+			return ErrModelValidate{Message: CashCategoryMessageParentDepthError}
+		}
+
+		if parentID == modelCashCategory.ID {
+			return ErrModelValidate{Message: CashCategoryMessageParentCycleError}
+		}
+
+		modelCashCategoryParent, err := useCaseCashCategory.RepositoryCashCategory.GetByID(parentID)
+
+		if err != nil {
+			if _, ok := err.(repository.ErrNotFound); ok && depth == 0 {
+				return ErrModelValidate{Message: CashCategoryMessageParentNotFoundError}
+			}
+
+			return err
+		}
+
+		parentID = modelCashCategoryParent.ParentID
+	}
+
+	return nil
+}
+
+func cashCategoryModelValidate(modelCashCategory *model.CashCategory) error {
+	messages := []string{}
+
+	CashCategoryModelFormat(modelCashCategory)
+
+	if modelCashCategory.ParentID < 0 {
+		messages = append(messages, CashCategoryMessageParentIDInvalidError)
+	}
+
+	if modelCashCategory.Name == "" {
+		messages = append(messages, CashCategoryMessageNameEmptyError)
+	} else if len(modelCashCategory.Name) < CashCategoryNameMinLen ||
+		len(modelCashCategory.Name) > CashCategoryNameMaxLen {
+		messages = append(messages, CashCategoryMessageNameSizeError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// cashCategoryRangeReferenceDateValidate validates the period of the totals,
+// which unlike the daily balance is not limited to 31 days
+func cashCategoryRangeReferenceDateValidate(cashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate) error {
+	messages := []string{}
+
+	if cashCategoryRangeReferenceDate.From.IsZero() {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateFromEmptyError)
+	} else if cashCategoryRangeReferenceDate.From.Before(CashLaunchReferenceDateMin) ||
+		cashCategoryRangeReferenceDate.From.After(CashLaunchReferenceDateMax) {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateFromBetweenError)
+	}
+
+	if cashCategoryRangeReferenceDate.To.IsZero() {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateToEmptyError)
+	} else if cashCategoryRangeReferenceDate.To.Before(CashLaunchReferenceDateMin) ||
+		cashCategoryRangeReferenceDate.To.After(CashLaunchReferenceDateMax) {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateToBetweenError)
+	}
+
+	if cashCategoryRangeReferenceDate.AccountID < 0 {
+		messages = append(messages, CashBalanceDailyAccountIDInvalidError)
+	}
+
+	if len(messages) == 0 && cashCategoryRangeReferenceDate.To.Before(cashCategoryRangeReferenceDate.From) {
+		messages = append(messages, CashBalanceDailyRangeReferenceDateToSmallerFromError)
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// cashCategoryPath joins the names from the root of the chart of accounts
+// down to the category
+func cashCategoryPath(modelCashCategoryByID map[int64]model.CashCategory, id int64) string {
+	names := []string{}
+
+	for depth := 0; id != 0 && depth <= len(modelCashCategoryByID); depth++ {
+		modelCashCategory, ok := modelCashCategoryByID[id]
+
+		if !ok {
+			break
+		}
+
+		names = append([]string{modelCashCategory.Name}, names...)
+		id = modelCashCategory.ParentID
+	}
+
+	return strings.Join(names, CashCategoryTotalPathSeparator)
+}
+
+func CashCategoryModelFormat(modelCashCategory *model.CashCategory) {
+	modelCashCategory.Name = util.FormatTitle(modelCashCategory.Name)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+)
+
+var (
+	CashCategoryRulePatternMaxLen = 100
+	CashCategoryRulePatternTypes  = []string{"substring", "regex"}
+
+	CashCategoryRuleMessageCategoryIDEmptyError    = "The category_id is empty"
+	CashCategoryRuleMessageCategoryNotFoundError   = "The category_id does not exist"
+	CashCategoryRuleMessagePatternEmptyError       = "The pattern is empty"
+	CashCategoryRuleMessagePatternSizeError        = fmt.Sprintf("The pattern size is greater than %v", CashCategoryRulePatternMaxLen)
+	CashCategoryRuleMessagePatternTypeInvalidError = fmt.Sprintf("The pattern_type not in ['%v']", strings.Join(CashCategoryRulePatternTypes, "', '"))
+	CashCategoryRuleMessagePatternRegexError       = "The pattern is not a valid regular expression"
+	CashCategoryRuleMessageTypeEmptyError          = "The type is empty"
+	CashCategoryRuleMessageTypeInvalidError        = "The type not in ['C', 'D']"
+	CashCategoryRuleMessageValueFromError          = "The value_from is less than 0"
+	CashCategoryRuleMessageValueToError            = "The value_to is less than 0"
+	CashCategoryRuleMessageValueToSmallerFromError = "The value_to is smaller the value_from"
+	CashCategoryRuleMessagePriorityError           = "The priority is less than 0"
+)
+
+type CashCategoryRule interface {
+	Insert(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error)
+	List() (model.CashCategoryRules, error)
+	GetByID(id int64) (*model.CashCategoryRule, error)
+	Update(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error)
+	DeleteByID(id int64) error
+	Match(modelCashLaunch *model.CashLaunch) (*model.CashCategoryRule, error)
+}
+
+type UseCaseCashCategoryRule struct {
+	RepositoryCashCategoryRule repository.CashCategoryRule
+	RepositoryCashCategory     repository.CashCategory
+}
+
+func NewCashCategoryRule(repositoryCashCategoryRule repository.CashCategoryRule, repositoryCashCategory repository.CashCategory) CashCategoryRule {
+	return &UseCaseCashCategoryRule{
+		RepositoryCashCategoryRule: repositoryCashCategoryRule,
+		RepositoryCashCategory:     repositoryCashCategory,
+	}
+}
+
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) Insert(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	err := cashCategoryRuleModelValidate(modelCashCategoryRule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashCategoryRule.cashCategoryValidate(modelCashCategoryRule.CategoryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryRule.CreatedAt = time.Now().UTC()
+	modelCashCategoryRule.UpdatedAt = modelCashCategoryRule.CreatedAt
+
+	return useCaseCashCategoryRule.RepositoryCashCategoryRule.Insert(modelCashCategoryRule)
+}
+
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) List() (model.CashCategoryRules, error) {
+	return useCaseCashCategoryRule.RepositoryCashCategoryRule.List()
+}
+
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) GetByID(id int64) (*model.CashCategoryRule, error) {
+	return useCaseCashCategoryRule.RepositoryCashCategoryRule.GetByID(id)
+}
+
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) Update(modelCashCategoryRule *model.CashCategoryRule) (*model.CashCategoryRule, error) {
+	err := cashCategoryRuleModelValidate(modelCashCategoryRule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashCategoryRule.cashCategoryValidate(modelCashCategoryRule.CategoryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryRule.UpdatedAt = time.Now().UTC()
+
+	return useCaseCashCategoryRule.RepositoryCashCategoryRule.Update(modelCashCategoryRule)
+}
+
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) DeleteByID(id int64) error {
+	return useCaseCashCategoryRule.RepositoryCashCategoryRule.DeleteByID(id)
+}
+
+// Match returns the rule that categorizes the sample launch on the insert
+// or repository.ErrNotFound when the launch stays without category
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) Match(modelCashLaunch *model.CashLaunch) (*model.CashCategoryRule, error) {
+	err := cashCategoryRuleMatchValidate(modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashCategoryRules, err := useCaseCashCategoryRule.RepositoryCashCategoryRule.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the value of the sample is in the base currency
+	modelCashLaunch.BaseValue = modelCashLaunch.Value
+
+	modelCashCategoryRule := newCashCategoryRuleMatchers(modelCashCategoryRules).Match(modelCashLaunch)
+
+	if modelCashCategoryRule == nil {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	return modelCashCategoryRule, nil
+}
+
+// cashCategoryValidate checks the category assigned by the rule exists
+func (useCaseCashCategoryRule *UseCaseCashCategoryRule) cashCategoryValidate(categoryID int64) error {
+	_, err := useCaseCashCategoryRule.RepositoryCashCategory.GetByID(categoryID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashCategoryRuleMessageCategoryNotFoundError}
+	}
+
+	return err
+}
+
+// cashPatternMatcher compares a pattern of a rule or budget with the
+// descriptions ignoring the case, the regex is compiled once
+type cashPatternMatcher struct {
+	regex         bool
+	patternRegexp *regexp.Regexp
+	pattern       string
+}
+
+// newCashPatternMatcher compiles the pattern, a stored regex that no longer
+// compiles matches nothing
+func newCashPatternMatcher(pattern string, patternType string) cashPatternMatcher {
+	if patternType == "regex" {
+		patternRegexp, _ := regexp.Compile("(?i)" + pattern)
+
+		return cashPatternMatcher{regex: true, patternRegexp: patternRegexp}
+	}
+
+	return cashPatternMatcher{pattern: strings.ToUpper(pattern)}
+}
+
+func (matcher cashPatternMatcher) Match(description string) bool {
+	if matcher.regex {
+		return matcher.patternRegexp != nil && matcher.patternRegexp.MatchString(description)
+	}
+
+	return strings.Contains(strings.ToUpper(description), matcher.pattern)
+}
+
+// cashCategoryRuleMatcher is a rule with its pattern compiled
+type cashCategoryRuleMatcher struct {
+	modelCashCategoryRule model.CashCategoryRule
+	cashPatternMatcher    cashPatternMatcher
+}
+
+// cashCategoryRuleMatchers are the rules in the priority order of the list,
+// compiled once when the rules are loaded
+type cashCategoryRuleMatchers []cashCategoryRuleMatcher
+
+func newCashCategoryRuleMatchers(modelCashCategoryRules model.CashCategoryRules) cashCategoryRuleMatchers {
+	matchers := cashCategoryRuleMatchers{}
+
+	for _, modelCashCategoryRule := range modelCashCategoryRules {
+		matchers = append(matchers, cashCategoryRuleMatcher{
+			modelCashCategoryRule: modelCashCategoryRule,
+			cashPatternMatcher:    newCashPatternMatcher(modelCashCategoryRule.Pattern, modelCashCategoryRule.PatternType),
+		})
+	}
+
+	return matchers
+}
+
+// Match returns the first rule matched by the type, the base value and the
+// description of the formatted launch or nil when no rule matches
+func (matchers cashCategoryRuleMatchers) Match(modelCashLaunch *model.CashLaunch) *model.CashCategoryRule {
+	for idx := range matchers {
+		modelCashCategoryRule := &matchers[idx].modelCashCategoryRule
+
+		if modelCashCategoryRule.Type != modelCashLaunch.Type {
+			continue
+		}
+
+		if !modelCashCategoryRule.ValueFrom.IsZero() && modelCashLaunch.BaseValue.LessThan(modelCashCategoryRule.ValueFrom) {
+			continue
+		}
+
+		if !modelCashCategoryRule.ValueTo.IsZero() && modelCashLaunch.BaseValue.GreaterThan(modelCashCategoryRule.ValueTo) {
+			continue
+		}
+
+		if matchers[idx].cashPatternMatcher.Match(modelCashLaunch.Description) {
+			return modelCashCategoryRule
+		}
+	}
+
+	return nil
+}
+
+func cashCategoryRuleModelValidate(modelCashCategoryRule *model.CashCategoryRule) error {
+	messages := []string{}
+
+	CashCategoryRuleModelFormat(modelCashCategoryRule)
+
+	if modelCashCategoryRule.CategoryID <= 0 {
+		messages = append(messages, CashCategoryRuleMessageCategoryIDEmptyError)
+	}
+
+	if modelCashCategoryRule.PatternType != "substring" && modelCashCategoryRule.PatternType != "regex" {
+		messages = append(messages, CashCategoryRuleMessagePatternTypeInvalidError)
+	}
+
+	if modelCashCategoryRule.Pattern == "" {
+		messages = append(messages, CashCategoryRuleMessagePatternEmptyError)
+	} else if len(modelCashCategoryRule.Pattern) > CashCategoryRulePatternMaxLen {
+		messages = append(messages, CashCategoryRuleMessagePatternSizeError)
+	} else if modelCashCategoryRule.PatternType == "regex" {
+		if _, err := regexp.Compile("(?i)" + modelCashCategoryRule.Pattern); err != nil {
+			messages = append(messages, CashCategoryRuleMessagePatternRegexError)
+		}
+	}
+
+	if modelCashCategoryRule.Type == "" {
+		messages = append(messages, CashCategoryRuleMessageTypeEmptyError)
+	} else if modelCashCategoryRule.Type != "C" && modelCashCategoryRule.Type != "D" {
+		messages = append(messages, CashCategoryRuleMessageTypeInvalidError)
+	}
+
+	if modelCashCategoryRule.ValueFrom.IsNegative() {
+		messages = append(messages, CashCategoryRuleMessageValueFromError)
+	}
+
+	if modelCashCategoryRule.ValueTo.IsNegative() {
+		messages = append(messages, CashCategoryRuleMessageValueToError)
+	} else if modelCashCategoryRule.ValueTo.IsPositive() && modelCashCategoryRule.ValueTo.LessThan(modelCashCategoryRule.ValueFrom) {
+		messages = append(messages, CashCategoryRuleMessageValueToSmallerFromError)
+	}
+
+	if modelCashCategoryRule.Priority < 0 {
+		messages = append(messages, CashCategoryRuleMessagePriorityError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// cashCategoryRuleMatchValidate validates only the fields of the sample
+// launch compared by the rules
+func cashCategoryRuleMatchValidate(modelCashLaunch *model.CashLaunch) error {
+	messages := []string{}
+
+	CashLaunchModelFormat(modelCashLaunch)
+
+	if modelCashLaunch.Type == "" {
+		messages = append(messages, CashLaunchMessageTypeEmptyError)
+	} else if modelCashLaunch.Type != "C" && modelCashLaunch.Type != "D" {
+		messages = append(messages, CashLaunchMessageTypeInvalidError)
+	}
+
+	if modelCashLaunch.Description == "" {
+		messages = append(messages, CashLaunchMessageDescriptionEmptyError)
+	}
+
+	if !modelCashLaunch.Value.IsPositive() {
+		messages = append(messages, CashLaunchMessageValueError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func CashCategoryRuleModelFormat(modelCashCategoryRule *model.CashCategoryRule) {
+	modelCashCategoryRule.PatternType = util.FormatTextWithoutSpace(strings.ToLower(modelCashCategoryRule.PatternType))
+	modelCashCategoryRule.Type = util.FormatTextWithoutSpace(util.FormatTitle(modelCashCategoryRule.Type))
+	modelCashCategoryRule.ValueFrom = modelCashCategoryRule.ValueFrom.Round(2)
+	modelCashCategoryRule.ValueTo = modelCashCategoryRule.ValueTo.Round(2)
+
+	if modelCashCategoryRule.PatternType == "regex" {
+		modelCashCategoryRule.Pattern = strings.TrimSpace(modelCashCategoryRule.Pattern)
+	} else {
+		modelCashCategoryRule.Pattern = util.FormatTitle(modelCashCategoryRule.Pattern)
+	}
+}
@@ -0,0 +1,168 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashCategoryRuleInsert(t *testing.T) {
+	type test struct {
+		name                  string
+		inputCashCategoryRule *model.CashCategoryRule
+		wantError             error
+		assert                func(t *testing.T, tt *test, resultCashCategoryRule *model.CashCategoryRule, err error)
+	}
+
+	tests := []test{
+		{
+			name:                  "EmptyError",
+			inputCashCategoryRule: &model.CashCategoryRule{},
+			wantError:             usecase.ErrModelValidate{Message: usecase.CashCategoryRuleMessageCategoryIDEmptyError + ";" + usecase.CashCategoryRuleMessagePatternTypeInvalidError + ";" + usecase.CashCategoryRuleMessagePatternEmptyError + ";" + usecase.CashCategoryRuleMessageTypeEmptyError},
+		},
+		{
+			name: "PatternRegexError",
+			inputCashCategoryRule: &model.CashCategoryRule{
+				CategoryID:  2,
+				Pattern:     "ALUGUEL(",
+				PatternType: "regex",
+				Type:        "D",
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashCategoryRuleMessagePatternRegexError},
+		},
+		{
+			name: "ValueError",
+			inputCashCategoryRule: &model.CashCategoryRule{
+				CategoryID:  2,
+				Pattern:     "ALUGUEL",
+				PatternType: "substring",
+				Type:        "D",
+				ValueFrom:   decimal.RequireFromString("100"),
+				ValueTo:     decimal.RequireFromString("10"),
+				Priority:    -1,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashCategoryRuleMessageValueToSmallerFromError + ";" + usecase.CashCategoryRuleMessagePriorityError},
+		},
+		{
+			name: "CategoryNotFoundError",
+			inputCashCategoryRule: &model.CashCategoryRule{
+				CategoryID:  999,
+				Pattern:     "ALUGUEL",
+				PatternType: "substring",
+				Type:        "D",
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashCategoryRuleMessageCategoryNotFoundError},
+		},
+		{
+			name: "Success",
+			inputCashCategoryRule: &model.CashCategoryRule{
+				CategoryID:  2,
+				Pattern:     " ^aluguel sala \\d+$ ",
+				PatternType: " Regex ",
+				Type:        "d",
+				ValueFrom:   decimal.RequireFromString("1000"),
+				Priority:    5,
+			},
+			assert: func(t *testing.T, tt *test, resultCashCategoryRule *model.CashCategoryRule, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashCategoryRule)
+				assert.NotEqual(t, int64(0), resultCashCategoryRule.ID)
+				assert.Equal(t, "^aluguel sala \\d+$", resultCashCategoryRule.Pattern)
+				assert.Equal(t, "regex", resultCashCategoryRule.PatternType)
+				assert.Equal(t, "D", resultCashCategoryRule.Type)
+				assert.False(t, resultCashCategoryRule.CreatedAt.IsZero())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashCategoryRule := usecase.NewCashCategoryRule(repository.CashCategoryRule(), repository.CashCategory())
+
+			modelCashCategoryRule := *tt.inputCashCategoryRule
+
+			resultCashCategoryRule, err := usecaseCashCategoryRule.Insert(&modelCashCategoryRule)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashCategoryRule, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashCategoryRule)
+			}
+		})
+	}
+}
+
+func TestCashCategoryRuleMatch(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashCategoryRule := usecase.NewCashCategoryRule(repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashCategory())
+
+	modelCashCategoryRuleRegex, err := usecaseCashCategoryRule.Insert(&model.CashCategoryRule{
+		CategoryID:  3,
+		Pattern:     "^SALARIO",
+		PatternType: "regex",
+		Type:        "C",
+		ValueFrom:   decimal.RequireFromString("100"),
+		ValueTo:     decimal.RequireFromString("10000"),
+	})
+	assert.Nil(t, err)
+
+	type test struct {
+		name            string
+		inputCashLaunch *model.CashLaunch
+		wantID          int64
+		wantError       error
+	}
+
+	tests := []test{
+		{
+			name:            "ValidateError",
+			inputCashLaunch: &model.CashLaunch{},
+			wantError:       usecase.ErrModelValidate{Message: usecase.CashLaunchMessageTypeEmptyError + ";" + usecase.CashLaunchMessageDescriptionEmptyError + ";" + usecase.CashLaunchMessageValueError},
+		},
+		{
+			name:            "SubstringSuccess",
+			inputCashLaunch: &model.CashLaunch{Type: "d", Description: "pagamento aluguel maio", Value: decimal.RequireFromString("10")},
+			wantID:          1,
+		},
+		{
+			name:            "RegexSuccess",
+			inputCashLaunch: &model.CashLaunch{Type: "C", Description: "salario maio", Value: decimal.RequireFromString("5000")},
+			wantID:          modelCashCategoryRuleRegex.ID,
+		},
+		{
+			name:            "TypeNotFoundError",
+			inputCashLaunch: &model.CashLaunch{Type: "C", Description: "ALUGUEL RECEBIDO", Value: decimal.RequireFromString("10")},
+			wantError:       repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:            "ValueNotFoundError",
+			inputCashLaunch: &model.CashLaunch{Type: "C", Description: "SALARIO MAIO", Value: decimal.RequireFromString("10000.01")},
+			wantError:       repository.ErrNotFound{Message: "not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultCashCategoryRule, err := usecaseCashCategoryRule.Match(tt.inputCashLaunch)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Match() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.wantError == nil {
+				assert.Equal(t, tt.wantID, resultCashCategoryRule.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package usecase_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashCategoryInsert(t *testing.T) {
+	type test struct {
+		name              string
+		inputCashCategory *model.CashCategory
+		wantError         error
+		assert            func(t *testing.T, tt *test, resultCashCategory *model.CashCategory, err error)
+	}
+
+	tests := []test{
+		{
+			name:              "NameEmptyError",
+			inputCashCategory: &model.CashCategory{Name: "   "},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageNameEmptyError},
+		},
+		{
+			name:              "NameSizeGreaterError",
+			inputCashCategory: &model.CashCategory{Name: strings.Repeat("X", usecase.CashCategoryNameMaxLen+1)},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageNameSizeError},
+		},
+		{
+			name:              "ParentIDInvalidError",
+			inputCashCategory: &model.CashCategory{ParentID: -1, Name: "CATEGORIA"},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageParentIDInvalidError},
+		},
+		{
+			name:              "ParentNotFoundError",
+			inputCashCategory: &model.CashCategory{ParentID: 999, Name: "CATEGORIA"},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageParentNotFoundError},
+		},
+		{
+			name:              "DuplicateKeyError",
+			inputCashCategory: &model.CashCategory{ParentID: 1, Name: " aluguel "},
+			wantError:         repository.ErrDuplicateKey{Message: "Key (parent_id, name)=(1, ALUGUEL) already exists."},
+		},
+		{
+			name:              "Success",
+			inputCashCategory: &model.CashCategory{ParentID: 1, Name: "energia  eletrica"},
+			assert: func(t *testing.T, tt *test, resultCashCategory *model.CashCategory, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashCategory)
+				assert.NotEqual(t, int64(0), resultCashCategory.ID)
+				assert.Equal(t, int64(1), resultCashCategory.ParentID)
+				assert.Equal(t, "ENERGIA ELETRICA", resultCashCategory.Name)
+				assert.False(t, resultCashCategory.CreatedAt.IsZero())
+				assert.Equal(t, resultCashCategory.CreatedAt, resultCashCategory.UpdatedAt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashCategory := usecase.NewCashCategory(repository.CashCategory())
+
+			modelCashCategory := *tt.inputCashCategory
+
+			resultCashCategory, err := usecaseCashCategory.Insert(&modelCashCategory)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashCategory, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashCategory)
+			}
+		})
+	}
+}
+
+func TestCashCategoryUpdate(t *testing.T) {
+	type test struct {
+		name              string
+		inputCashCategory *model.CashCategory
+		wantError         error
+	}
+
+	tests := []test{
+		{
+			name:              "ParentItselfError",
+			inputCashCategory: &model.CashCategory{ID: 1, ParentID: 1, Name: "DESPESAS"},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageParentCycleError},
+		},
+		{
+			name:              "ParentSubcategoryError",
+			inputCashCategory: &model.CashCategory{ID: 1, ParentID: 2, Name: "DESPESAS"},
+			wantError:         usecase.ErrModelValidate{Message: usecase.CashCategoryMessageParentCycleError},
+		},
+		{
+			name:              "NotFoundError",
+			inputCashCategory: &model.CashCategory{ID: 999, Name: "CATEGORIA"},
+			wantError:         repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:              "Success",
+			inputCashCategory: &model.CashCategory{ID: 2, ParentID: 1, Name: "aluguel"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashCategory := usecase.NewCashCategory(repository.CashCategory())
+
+			modelCashCategory := *tt.inputCashCategory
+
+			resultCashCategory, err := usecaseCashCategory.Update(&modelCashCategory)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Update() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.wantError == nil {
+				assert.Equal(t, "ALUGUEL", resultCashCategory.Name)
+				assert.False(t, resultCashCategory.CreatedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestCashCategoryParentCycle(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	repositoryCashCategory := repositoryInMemory.CashCategory()
+	usecaseCashCategory := usecase.NewCashCategory(repositoryCashCategory)
+
+	modelCashCategoryFirst, err := usecaseCashCategory.Insert(&model.CashCategory{Name: "CICLO PRIMEIRA"})
+	assert.Nil(t, err)
+
+	modelCashCategorySecond, err := usecaseCashCategory.Insert(&model.CashCategory{ParentID: modelCashCategoryFirst.ID, Name: "CICLO SEGUNDA"})
+	assert.Nil(t, err)
+
+	// the cycle left by two concurrent updates is written straight to the repository
+	modelCashCategoryFirst.ParentID = modelCashCategorySecond.ID
+	_, err = repositoryCashCategory.Update(modelCashCategoryFirst)
+	assert.Nil(t, err)
+
+	defer func() {
+		modelCashCategoryFirst.ParentID = 0
+		repositoryCashCategory.Update(modelCashCategoryFirst)
+	}()
+
+	_, err = usecaseCashCategory.Insert(&model.CashCategory{ParentID: modelCashCategoryFirst.ID, Name: "CICLO TERCEIRA"})
+
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashCategoryMessageParentDepthError}, err)
+}
+
+func TestCashCategoryDeleteByID(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashCategory := usecase.NewCashCategory(repositoryInMemory.CashCategory())
+
+	modelCashCategory, err := usecaseCashCategory.Insert(&model.CashCategory{Name: "CATEGORIA SEM LANCAMENTOS"})
+	assert.Nil(t, err)
+
+	type test struct {
+		name      string
+		inputID   int64
+		wantError error
+	}
+
+	tests := []test{
+		{
+			name:      "NotFoundError",
+			inputID:   999,
+			wantError: repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:      "ReferencedLaunchError",
+			inputID:   2,
+			wantError: repository.ErrReferenced{Message: "Key (id)=(2) is still referenced from table \"cash_launch\".", Table: "cash_launch"},
+		},
+		{
+			name:      "ReferencedSubcategoryError",
+			inputID:   1,
+			wantError: repository.ErrReferenced{Message: "Key (id)=(1) is still referenced from table \"cash_category\".", Table: "cash_category"},
+		},
+		{
+			name:    "Success",
+			inputID: modelCashCategory.ID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usecaseCashCategory.DeleteByID(tt.inputID)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Delete() got error = %v, want = %v.", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCashCategoryGetTotalsByRangeReferenceDate(t *testing.T) {
+	type test struct {
+		name                                string
+		inputCashCategoryRangeReferenceDate *model.CashBalanceDailyRangeReferenceDate
+		wantError                           error
+		assert                              func(t *testing.T, resultCashCategoryTotals model.CashCategoryTotals)
+	}
+
+	tests := []test{
+		{
+			name:                                "RangeEmptyError",
+			inputCashCategoryRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{AccountID: -1},
+			wantError:                           usecase.ErrParamValidate{Message: usecase.CashBalanceDailyRangeReferenceDateFromEmptyError + ";" + usecase.CashBalanceDailyRangeReferenceDateToEmptyError + ";" + usecase.CashBalanceDailyAccountIDInvalidError},
+		},
+		{
+			name: "ToSmallerFromError",
+			inputCashCategoryRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2001, 11, 30, 00, 00, 00, 000, time.UTC),
+				To:   time.Date(2001, 11, 01, 00, 00, 00, 000, time.UTC),
+			},
+			wantError: usecase.ErrParamValidate{Message: usecase.CashBalanceDailyRangeReferenceDateToSmallerFromError},
+		},
+		{
+			name: "Success",
+			inputCashCategoryRangeReferenceDate: &model.CashBalanceDailyRangeReferenceDate{
+				From: time.Date(2001, 11, 01, 00, 00, 00, 000, time.UTC),
+				To:   time.Date(2001, 11, 30, 00, 00, 00, 000, time.UTC),
+			},
+			assert: func(t *testing.T, resultCashCategoryTotals model.CashCategoryTotals) {
+				modelCashCategoryTotalByID := map[int64]model.CashCategoryTotal{}
+
+				for _, modelCashCategoryTotal := range resultCashCategoryTotals {
+					modelCashCategoryTotalByID[modelCashCategoryTotal.CategoryID] = modelCashCategoryTotal
+				}
+
+				_, ok := modelCashCategoryTotalByID[0]
+				assert.False(t, ok)
+
+				assert.Equal(t, "DESPESAS / ALUGUEL", modelCashCategoryTotalByID[2].Path)
+				assert.Equal(t, "12.34", modelCashCategoryTotalByID[2].TotalDebit.String())
+				assert.Equal(t, "-12.34", modelCashCategoryTotalByID[2].Value.String())
+				assert.Equal(t, "-12.34", modelCashCategoryTotalByID[2].RollupValue.String())
+
+				assert.Equal(t, "DESPESAS", modelCashCategoryTotalByID[1].Path)
+				assert.True(t, modelCashCategoryTotalByID[1].TotalDebit.IsZero())
+				assert.Equal(t, "12.34", modelCashCategoryTotalByID[1].RollupTotalDebit.String())
+				assert.Equal(t, "-12.34", modelCashCategoryTotalByID[1].RollupValue.String())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashCategory := usecase.NewCashCategory(repository.CashCategory())
+
+			resultCashCategoryTotals, err := usecaseCashCategory.GetTotalsByRangeReferenceDate(tt.inputCashCategoryRangeReferenceDate)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("GetTotalsByRangeReferenceDate() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, resultCashCategoryTotals)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CashForecastDaysMax = 366
+
+	CashForecastMessageToEmptyError         = "The param to is empty"
+	CashForecastMessageToNotAfterTodayError = "The param to is not after the current date"
+	CashForecastMessageRangeError           = fmt.Sprintf("The param to is more than %v days after the current date", CashForecastDaysMax)
+	CashForecastMessageAccountNotFoundError = "The param account_id does not exist"
+)
+
+type CashForecast interface {
+	Forecast(accountID int64, to time.Time, now time.Time) (*model.CashForecast, error)
+}
+
+type UseCaseCashForecast struct {
+	RepositoryCashBalanceDaily repository.CashBalanceDaily
+	RepositoryCashLaunch       repository.CashLaunch
+	RepositoryCashRecurrence   repository.CashRecurrence
+	RepositoryCashAccount      repository.CashAccount
+	RepositoryExchangeRate     repository.ExchangeRate
+	BaseCurrency               string
+}
+
+func NewCashForecast(repositoryCashBalanceDaily repository.CashBalanceDaily, repositoryCashLaunch repository.CashLaunch, repositoryCashRecurrence repository.CashRecurrence, repositoryCashAccount repository.CashAccount, repositoryExchangeRate repository.ExchangeRate, baseCurrency string) CashForecast {
+	return &UseCaseCashForecast{
+		RepositoryCashBalanceDaily: repositoryCashBalanceDaily,
+		RepositoryCashLaunch:       repositoryCashLaunch,
+		RepositoryCashRecurrence:   repositoryCashRecurrence,
+		RepositoryCashAccount:      repositoryCashAccount,
+		RepositoryExchangeRate:     repositoryExchangeRate,
+		BaseCurrency:               baseCurrency,
+	}
+}
+
+// Forecast projects the balance of the account, or of all accounts combined
+// when the account is zero, for each day after now until the date. The
+// projection starts from the closing balance of the current date and adds the
+// approved and pending launches with a future reference date, without the
+// transfers when the accounts are combined, and the occurrences of the
+// recurring templates not yet created, converted into the base currency by
+// the last exchange rate published. The pending launches up to the current
+// date are not in the closing balance and fall on the first projected day.
+func (useCaseCashForecast *UseCaseCashForecast) Forecast(accountID int64, to time.Time, now time.Time) (*model.CashForecast, error) {
+	today := cashRecurrenceDate(now.UTC())
+
+	err := useCaseCashForecast.cashForecastValidate(accountID, to, today)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashBalanceDaily, err := useCaseCashForecast.RepositoryCashBalanceDaily.GetByReferenceDate(today, accountID, false, false)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		modelCashBalanceDaily, err = &model.CashBalanceDaily{ReferenceDate: today}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashForecast := &model.CashForecast{
+		AccountID:            accountID,
+		ReferenceDate:        today,
+		To:                   to,
+		OpeningBalance:       modelCashBalanceDaily.ClosingBalance,
+		ClosingBalance:       modelCashBalanceDaily.ClosingBalance,
+		Days:                 model.CashForecastDays{},
+		SkippedRecurrenceIDs: []int64{},
+	}
+
+	for referenceDate := today.AddDate(0, 0, 1); !referenceDate.After(to); referenceDate = referenceDate.AddDate(0, 0, 1) {
+		modelCashForecast.Days = append(modelCashForecast.Days, model.CashForecastDay{ReferenceDate: referenceDate})
+	}
+
+	modelCashLaunchesPending, err := cashLaunchListAll(useCaseCashForecast.RepositoryCashLaunch, &model.CashLaunchFilter{
+		AccountID:       accountID,
+		ReferenceDateTo: today,
+		Status:          repository.CashLaunchStatusPending,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunches, err := cashLaunchListAll(useCaseCashForecast.RepositoryCashLaunch, &model.CashLaunchFilter{
+		AccountID:         accountID,
+		ReferenceDateFrom: today.AddDate(0, 0, 1),
+		ReferenceDateTo:   to,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelCashLaunch := range append(modelCashLaunchesPending, modelCashLaunches...) {
+		// the transfers between the accounts do not change the combined balance
+		if accountID == 0 && modelCashLaunch.TransferID != 0 {
+			continue
+		}
+
+		forecastDate := modelCashLaunch.ReferenceDate
+
+		if !forecastDate.After(today) {
+			forecastDate = today.AddDate(0, 0, 1)
+		}
+
+		modelCashForecastDay := cashForecastDay(modelCashForecast, today, forecastDate)
+
+		switch modelCashLaunch.Status {
+		case repository.CashLaunchStatusApproved:
+			modelCashForecastDay.ScheduledValue = modelCashForecastDay.ScheduledValue.Add(cashForecastDayApply(modelCashForecastDay, modelCashLaunch.Type, modelCashLaunch.BaseValue))
+		case repository.CashLaunchStatusPending:
+			modelCashForecastDay.PendingValue = modelCashForecastDay.PendingValue.Add(cashForecastDayApply(modelCashForecastDay, modelCashLaunch.Type, modelCashLaunch.BaseValue))
+		}
+	}
+
+	err = useCaseCashForecast.cashForecastRecurrenceApply(modelCashForecast, today)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range modelCashForecast.Days {
+		modelCashForecastDay := &modelCashForecast.Days[idx]
+
+		modelCashForecastDay.OpeningBalance = modelCashForecast.ClosingBalance
+		modelCashForecastDay.ClosingBalance = modelCashForecastDay.OpeningBalance.Add(modelCashForecastDay.Value)
+		modelCashForecastDay.Negative = modelCashForecastDay.ClosingBalance.IsNegative()
+
+		if modelCashForecastDay.Negative && modelCashForecast.FirstNegativeDate == nil {
+			firstNegativeDate := modelCashForecastDay.ReferenceDate
+			modelCashForecast.FirstNegativeDate = &firstNegativeDate
+		}
+
+		modelCashForecast.ClosingBalance = modelCashForecastDay.ClosingBalance
+	}
+
+	return modelCashForecast, nil
+}
+
+// cashForecastRecurrenceApply adds the occurrences of the templates of the
+// account after its last_date, which are created by the job only when they
+// are due. The occurrences already due and not yet created by the job fall on
+// the first projected day. The templates without an exchange rate to the base
+// currency are skipped and listed in the forecast.
+func (useCaseCashForecast *UseCaseCashForecast) cashForecastRecurrenceApply(modelCashForecast *model.CashForecast, today time.Time) error {
+	modelCashRecurrences, err := useCaseCashForecast.RepositoryCashRecurrence.List()
+
+	if err != nil {
+		return err
+	}
+
+	for idx := range modelCashRecurrences {
+		modelCashRecurrence := &modelCashRecurrences[idx]
+
+		if modelCashForecast.AccountID != 0 && modelCashRecurrence.AccountID != modelCashForecast.AccountID {
+			continue
+		}
+
+		// the value of the template in the base currency at the current rate
+		modelCashLaunch := &model.CashLaunch{
+			ReferenceDate: today,
+			Value:         modelCashRecurrence.Value,
+			Currency:      modelCashRecurrence.Currency,
+		}
+
+		if modelCashLaunch.Currency == "" {
+			modelCashLaunch.Currency = useCaseCashForecast.BaseCurrency
+		}
+
+		err = cashLaunchExchangeRateApply(useCaseCashForecast.RepositoryExchangeRate, useCaseCashForecast.BaseCurrency, modelCashLaunch)
+
+		if _, ok := err.(ErrModelValidate); ok {
+			modelCashForecast.SkippedRecurrenceIDs = append(modelCashForecast.SkippedRecurrenceIDs, modelCashRecurrence.ID)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for referenceDate := CashRecurrenceNextDate(modelCashRecurrence, modelCashRecurrence.LastDate); !referenceDate.After(modelCashForecast.To) &&
+			(modelCashRecurrence.EndDate.IsZero() || !referenceDate.After(modelCashRecurrence.EndDate)); referenceDate = CashRecurrenceNextDate(modelCashRecurrence, referenceDate) {
+			forecastDate := referenceDate
+
+			if !forecastDate.After(today) {
+				forecastDate = today.AddDate(0, 0, 1)
+			}
+
+			modelCashForecastDay := cashForecastDay(modelCashForecast, today, forecastDate)
+
+			modelCashForecastDay.RecurringValue = modelCashForecastDay.RecurringValue.Add(cashForecastDayApply(modelCashForecastDay, modelCashRecurrence.Type, modelCashLaunch.BaseValue))
+		}
+	}
+
+	return nil
+}
+
+func (useCaseCashForecast *UseCaseCashForecast) cashForecastValidate(accountID int64, to time.Time, today time.Time) error {
+	messages := []string{}
+
+	if to.IsZero() {
+		messages = append(messages, CashForecastMessageToEmptyError)
+	} else if !to.After(today) {
+		messages = append(messages, CashForecastMessageToNotAfterTodayError)
+	} else if to.After(today.AddDate(0, 0, CashForecastDaysMax)) {
+		messages = append(messages, CashForecastMessageRangeError)
+	}
+
+	if accountID < 0 {
+		messages = append(messages, CashBalanceDailyAccountIDInvalidError)
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	if accountID == 0 {
+		return nil
+	}
+
+	_, err := useCaseCashForecast.RepositoryCashAccount.GetByID(accountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrParamValidate{Message: CashForecastMessageAccountNotFoundError}
+	}
+
+	return err
+}
+
+// cashForecastDay returns the day of the forecast of the reference date
+func cashForecastDay(modelCashForecast *model.CashForecast, today time.Time, referenceDate time.Time) *model.CashForecastDay {
+	return &modelCashForecast.Days[int(referenceDate.Sub(today).Hours()/24)-1]
+}
+
+// cashForecastDayApply adds the value to the totals of the day and returns
+// the value signed by the type
+func cashForecastDayApply(modelCashForecastDay *model.CashForecastDay, launchType string, value decimal.Decimal) decimal.Decimal {
+	if launchType == "D" {
+		modelCashForecastDay.TotalDebit = modelCashForecastDay.TotalDebit.Add(value)
+		value = value.Neg()
+	} else {
+		modelCashForecastDay.TotalCredit = modelCashForecastDay.TotalCredit.Add(value)
+	}
+
+	modelCashForecastDay.Value = modelCashForecastDay.Value.Add(value)
+
+	return value
+}
@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashForecast(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, usecase.CashLaunchApprovalThresholds{"D": decimal.NewFromInt(5000)})
+	usecaseCashForecast := usecase.NewCashForecast(repositoryInMemory.CashBalanceDaily(), repositoryInMemory.CashLaunch(), repositoryInMemory.CashRecurrence(), repositoryInMemory.CashAccount(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault)
+
+	now := time.Date(2031, 3, 10, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2031, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2031, 4, 5, 0, 0, 0, 0, time.UTC)
+
+	_, err := usecaseCashForecast.Forecast(-1, time.Time{}, now)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashForecastMessageToEmptyError + ";" + usecase.CashBalanceDailyAccountIDInvalidError}, err)
+
+	_, err = usecaseCashForecast.Forecast(1, today, now)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashForecastMessageToNotAfterTodayError}, err)
+
+	_, err = usecaseCashForecast.Forecast(1, today.AddDate(0, 0, usecase.CashForecastDaysMax+1), now)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashForecastMessageRangeError}, err)
+
+	_, err = usecaseCashForecast.Forecast(99, to, now)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashForecastMessageAccountNotFoundError}, err)
+
+	modelCashLaunchIDs := []int64{}
+
+	for _, modelCashLaunch := range []model.CashLaunch{
+		{AccountID: 1, ReferenceDate: today, Type: "C", Description: "Aporte", Value: decimal.NewFromInt(1000000)},
+		{AccountID: 1, ReferenceDate: time.Date(2031, 3, 12, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel", Value: decimal.NewFromInt(300)},
+		{AccountID: 1, ReferenceDate: time.Date(2031, 3, 15, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Equipamento", Value: decimal.NewFromInt(6000)},
+		{AccountID: 1, ReferenceDate: time.Date(2031, 3, 15, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Equipamento cancelado", Value: decimal.NewFromInt(7000)},
+		{AccountID: 2, ReferenceDate: time.Date(2031, 3, 12, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Tarifa", Value: decimal.NewFromInt(5)},
+		{AccountID: 1, ReferenceDate: time.Date(2031, 4, 6, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Imposto", Value: decimal.NewFromInt(80)},
+		// pending before the current date
+		{AccountID: 1, ReferenceDate: time.Date(2031, 3, 8, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Servidor", Value: decimal.NewFromInt(5500)},
+	} {
+		resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
+		assert.Nil(t, err)
+
+		modelCashLaunchIDs = append(modelCashLaunchIDs, resultCashLaunch.ID)
+	}
+
+	usecaseCashTransfer := usecase.NewCashTransfer(repositoryInMemory.CashTransfer(), repositoryInMemory.CashAccount(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault)
+
+	resultCashTransfer, err := usecaseCashTransfer.Insert(&model.CashTransfer{FromAccountID: 1, ToAccountID: 2, ReferenceDate: time.Date(2031, 3, 13, 0, 0, 0, 0, time.UTC), Description: "Reserva", Value: decimal.NewFromInt(50)}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// deleting one side deletes the transfer
+	modelCashLaunchIDs = append(modelCashLaunchIDs, resultCashTransfer.Debit.ID)
+
+	_, err = usecaseCashLaunch.Review(&model.CashLaunchReview{ID: modelCashLaunchIDs[3], Status: repository.CashLaunchStatusRejected, Comment: "cancelada"}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// the occurrences not yet created after the last_date up to the end_date
+	// of the template
+	modelCashRecurrence, err := repositoryInMemory.CashRecurrence().Insert(&model.CashRecurrence{
+		AccountID:   1,
+		Type:        "D",
+		Description: "FOLHA",
+		Value:       decimal.NewFromInt(1000000000),
+		Currency:    baseCurrencyDefault,
+		Frequency:   "monthly",
+		Day:         1,
+		StartDate:   time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2031, 12, 31, 0, 0, 0, 0, time.UTC),
+		LastDate:    time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+
+	modelCashRecurrenceOther, err := repositoryInMemory.CashRecurrence().Insert(&model.CashRecurrence{
+		AccountID:   2,
+		Type:        "C",
+		Description: "RENDIMENTO",
+		Value:       decimal.NewFromInt(10),
+		Currency:    baseCurrencyDefault,
+		Frequency:   "daily",
+		StartDate:   time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		LastDate:    today.AddDate(0, 0, -1),
+	})
+	assert.Nil(t, err)
+
+	// the template without an exchange rate is skipped
+	modelCashRecurrenceWithoutRate, err := repositoryInMemory.CashRecurrence().Insert(&model.CashRecurrence{
+		AccountID:   1,
+		Type:        "D",
+		Description: "ASSINATURA",
+		Value:       decimal.NewFromInt(20),
+		Currency:    "JPY",
+		Frequency:   "monthly",
+		Day:         20,
+		StartDate:   time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		LastDate:    time.Date(2031, 2, 20, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+
+	modelCashBalanceDaily, err := repositoryInMemory.CashBalanceDaily().GetByReferenceDate(today, 1, false, false)
+	assert.Nil(t, err)
+
+	resultCashForecast, err := usecaseCashForecast.Forecast(1, to, now)
+	assert.Nil(t, err)
+	assert.Equal(t, today, resultCashForecast.ReferenceDate)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.String(), resultCashForecast.OpeningBalance.String())
+	assert.Len(t, resultCashForecast.Days, 26)
+	assert.Equal(t, time.Date(2031, 3, 11, 0, 0, 0, 0, time.UTC), resultCashForecast.Days[0].ReferenceDate)
+	assert.Equal(t, to, resultCashForecast.Days[25].ReferenceDate)
+	assert.Equal(t, []int64{modelCashRecurrenceWithoutRate.ID}, resultCashForecast.SkippedRecurrenceIDs)
+
+	// the pending launch before the current date falls on the first projected
+	// day
+	assert.Equal(t, "-5500", resultCashForecast.Days[0].PendingValue.String())
+
+	modelCashForecastDay := resultCashForecast.Days[1]
+	assert.Equal(t, "-300", modelCashForecastDay.ScheduledValue.String())
+	assert.Equal(t, "300", modelCashForecastDay.TotalDebit.String())
+	assert.Equal(t, resultCashForecast.OpeningBalance.Sub(decimal.NewFromInt(5800)).String(), modelCashForecastDay.ClosingBalance.String())
+
+	assert.Equal(t, "-50", resultCashForecast.Days[2].ScheduledValue.String())
+
+	// the pending launch is projected and the rejected one is not
+	modelCashForecastDay = resultCashForecast.Days[4]
+	assert.Equal(t, "-6000", modelCashForecastDay.PendingValue.String())
+	assert.Equal(t, "6000", modelCashForecastDay.TotalDebit.String())
+	assert.Equal(t, "0", modelCashForecastDay.ScheduledValue.String())
+
+	modelCashForecastDay = resultCashForecast.Days[21]
+	assert.Equal(t, time.Date(2031, 4, 1, 0, 0, 0, 0, time.UTC), modelCashForecastDay.ReferenceDate)
+	assert.Equal(t, "-1000000000", modelCashForecastDay.RecurringValue.String())
+	assert.Equal(t, "-1000000000", modelCashForecastDay.Value.String())
+	assert.True(t, modelCashForecastDay.Negative)
+	assert.False(t, resultCashForecast.Days[20].Negative)
+	assert.Equal(t, modelCashForecastDay.ReferenceDate, *resultCashForecast.FirstNegativeDate)
+	assert.Equal(t, resultCashForecast.OpeningBalance.Sub(decimal.NewFromInt(11850)).Sub(decimal.NewFromInt(1000000000)).String(), resultCashForecast.ClosingBalance.String())
+
+	resultCashForecast, err = usecaseCashForecast.Forecast(1, time.Date(2031, 3, 31, 0, 0, 0, 0, time.UTC), now)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashForecast.Days, 21)
+	assert.Nil(t, resultCashForecast.FirstNegativeDate)
+
+	// all accounts combined
+	resultCashForecast, err = usecaseCashForecast.Forecast(0, to, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "-305", resultCashForecast.Days[1].ScheduledValue.String())
+	assert.Equal(t, "10", resultCashForecast.Days[1].RecurringValue.String())
+
+	// the occurrence of the current date not yet created by the job falls on
+	// the first projected day
+	assert.Equal(t, "20", resultCashForecast.Days[0].RecurringValue.String())
+
+	// the transfer between the accounts is left out of the combined balance
+	assert.Equal(t, "0", resultCashForecast.Days[2].ScheduledValue.String())
+	assert.Equal(t, "0", resultCashForecast.Days[2].TotalDebit.String())
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err = usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+
+	for _, modelCashRecurrenceID := range []int64{modelCashRecurrence.ID, modelCashRecurrenceOther.ID, modelCashRecurrenceWithoutRate.ID} {
+		err = repositoryInMemory.CashRecurrence().DeleteByID(modelCashRecurrenceID)
+		assert.Nil(t, err)
+	}
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashForecastError := usecase.NewCashForecast(repositoryInMemoryError.CashBalanceDaily(), repositoryInMemoryError.CashLaunch(), repositoryInMemoryError.CashRecurrence(), repositoryInMemoryError.CashAccount(), repositoryInMemoryError.ExchangeRate(), baseCurrencyDefault)
+
+	_, err = usecaseCashForecastError.Forecast(0, to, now)
+	assert.NotNil(t, err)
+}
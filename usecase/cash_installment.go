@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CashInstallmentCountMax = 120
+
+	CashInstallmentMessageCountError               = fmt.Sprintf("The installment_count is not between 0 and %v", CashInstallmentCountMax)
+	CashInstallmentMessageFirstDueDateBetweenError = fmt.Sprintf("The first_due_date value is not between %v and %v", CashLaunchReferenceDateMin, CashLaunchReferenceDateMax)
+	CashInstallmentMessageLastDueDateError         = fmt.Sprintf("The due date of the last installment is after %v", CashLaunchReferenceDateMax)
+	CashInstallmentMessageValueSplitError          = "The value is less than 0.01 per installment"
+	CashInstallmentMessageRemainingNotFoundError   = "The installment group has no remaining installments"
+)
+
+type CashInstallment interface {
+	ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error)
+	Update(modelCashInstallment *model.CashInstallment, modelAudit model.Audit) (model.CashLaunches, error)
+	DeleteByGroupID(groupID int64, modelAudit model.Audit) (model.CashLaunches, error)
+}
+
+type UseCaseCashInstallment struct {
+	RepositoryCashInstallment repository.CashInstallment
+	RepositoryCashAccount     repository.CashAccount
+	RepositoryCashCategory    repository.CashCategory
+	RepositoryExchangeRate    repository.ExchangeRate
+	BaseCurrency              string
+	ApprovalThresholds        CashLaunchApprovalThresholds
+}
+
+func NewCashInstallment(repositoryCashInstallment repository.CashInstallment, repositoryCashAccount repository.CashAccount, repositoryCashCategory repository.CashCategory, repositoryExchangeRate repository.ExchangeRate, baseCurrency string, approvalThresholds CashLaunchApprovalThresholds) CashInstallment {
+	return &UseCaseCashInstallment{
+		RepositoryCashInstallment: repositoryCashInstallment,
+		RepositoryCashAccount:     repositoryCashAccount,
+		RepositoryCashCategory:    repositoryCashCategory,
+		RepositoryExchangeRate:    repositoryExchangeRate,
+		BaseCurrency:              baseCurrency,
+		ApprovalThresholds:        approvalThresholds,
+	}
+}
+
+// ListByGroupID returns every installment of the group, the ones already due
+// included and the deleted ones only when includeDeleted
+func (useCaseCashInstallment *UseCaseCashInstallment) ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashInstallment.RepositoryCashInstallment.ListByGroupID(groupID, includeDeleted)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(modelCashLaunches) == 0 {
+		return nil, repository.ErrNotFound{Message: "not found"}
+	}
+
+	return modelCashLaunches, nil
+}
+
+// Update changes the account, category and description of the remaining
+// installments, the ones due from today on, and splits the value among them
+func (useCaseCashInstallment *UseCaseCashInstallment) Update(modelCashInstallment *model.CashInstallment, modelAudit model.Audit) (model.CashLaunches, error) {
+	err := useCaseCashInstallment.cashInstallmentValidate(modelCashInstallment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunches, err := useCaseCashInstallment.ListByGroupID(modelCashInstallment.ID, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchesRemaining := cashInstallmentRemaining(modelCashLaunches, time.Now().UTC())
+
+	if len(modelCashLaunchesRemaining) == 0 {
+		return nil, ErrModelValidate{Message: CashInstallmentMessageRemainingNotFoundError}
+	}
+
+	values, err := CashInstallmentValues(modelCashInstallment.Value, len(modelCashLaunchesRemaining))
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := time.Now().UTC()
+
+	for idx := range modelCashLaunchesRemaining {
+		modelCashLaunch := &modelCashLaunchesRemaining[idx]
+		modelCashLaunchCurrent := *modelCashLaunch
+
+		modelCashLaunch.AccountID = modelCashInstallment.AccountID
+		modelCashLaunch.CategoryID = modelCashInstallment.CategoryID
+		modelCashLaunch.Description = modelCashInstallment.Description
+		modelCashLaunch.Value = values[idx]
+
+		err = cashLaunchExchangeRateApply(useCaseCashInstallment.RepositoryExchangeRate, useCaseCashInstallment.BaseCurrency, modelCashLaunch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cashLaunchStatusApply(useCaseCashInstallment.ApprovalThresholds, modelCashLaunch, &modelCashLaunchCurrent)
+
+		modelCashLaunch.UpdatedAt = updatedAt
+	}
+
+	modelCashLaunchesRemaining, err = useCaseCashInstallment.RepositoryCashInstallment.Update(modelCashLaunchesRemaining, modelAudit)
+
+	return modelCashLaunchesRemaining, cashPeriodRepositoryError(err)
+}
+
+// DeleteByGroupID cancels the remaining installments of the group, the ones
+// due from today on, keeping the ones already due
+func (useCaseCashInstallment *UseCaseCashInstallment) DeleteByGroupID(groupID int64, modelAudit model.Audit) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashInstallment.ListByGroupID(groupID, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	today := cashRecurrenceDate(time.Now().UTC())
+
+	if len(cashInstallmentRemaining(modelCashLaunches, today)) == 0 {
+		return nil, ErrModelValidate{Message: CashInstallmentMessageRemainingNotFoundError}
+	}
+
+	modelCashLaunches, err = useCaseCashInstallment.RepositoryCashInstallment.DeleteByGroupID(groupID, today, modelAudit)
+
+	return modelCashLaunches, cashPeriodRepositoryError(err)
+}
+
+// cashInstallmentValidate validates the changes of the remaining installments
+// and their account and category
+func (useCaseCashInstallment *UseCaseCashInstallment) cashInstallmentValidate(modelCashInstallment *model.CashInstallment) error {
+	messages := []string{}
+
+	modelCashInstallment.Description = util.FormatTitle(modelCashInstallment.Description)
+	modelCashInstallment.Value = modelCashInstallment.Value.Round(2)
+
+	if modelCashInstallment.AccountID <= 0 {
+		messages = append(messages, CashLaunchMessageAccountIDEmptyError)
+	}
+
+	if modelCashInstallment.CategoryID < 0 {
+		messages = append(messages, CashLaunchMessageCategoryIDInvalidError)
+	}
+
+	if modelCashInstallment.Description == "" {
+		messages = append(messages, CashLaunchMessageDescriptionEmptyError)
+	} else if len(modelCashInstallment.Description) < CashLaunchDescriptionMinLen ||
+		len(modelCashInstallment.Description) > CashLaunchDescriptionMaxLen {
+		messages = append(messages, CashLaunchMessageDescriptionSizeError)
+	}
+
+	if !modelCashInstallment.Value.IsPositive() {
+		messages = append(messages, CashLaunchMessageValueError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	_, err := useCaseCashInstallment.RepositoryCashAccount.GetByID(modelCashInstallment.AccountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageAccountNotFoundError}
+	} else if err != nil {
+		return err
+	}
+
+	if modelCashInstallment.CategoryID == 0 {
+		return nil
+	}
+
+	_, err = useCaseCashInstallment.RepositoryCashCategory.GetByID(modelCashInstallment.CategoryID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageCategoryNotFoundError}
+	}
+
+	return err
+}
+
+// cashInstallmentModelValidate validates the installment count and the first
+// due date of a launch being inserted, the first due date defaults to the
+// reference date
+func cashInstallmentModelValidate(modelCashLaunch *model.CashLaunch) error {
+	messages := []string{}
+
+	if modelCashLaunch.InstallmentCount < 0 || modelCashLaunch.InstallmentCount > CashInstallmentCountMax {
+		messages = append(messages, CashInstallmentMessageCountError)
+	}
+
+	if modelCashLaunch.InstallmentCount <= 1 {
+		modelCashLaunch.InstallmentCount = 0
+		modelCashLaunch.FirstDueDate = nil
+	} else if modelCashLaunch.FirstDueDate == nil {
+		firstDueDate := modelCashLaunch.ReferenceDate
+		modelCashLaunch.FirstDueDate = &firstDueDate
+	}
+
+	if modelCashLaunch.FirstDueDate != nil {
+		if modelCashLaunch.FirstDueDate.Before(CashLaunchReferenceDateMin) || modelCashLaunch.FirstDueDate.After(CashLaunchReferenceDateMax) {
+			messages = append(messages, CashInstallmentMessageFirstDueDateBetweenError)
+		} else if CashInstallmentDueDate(*modelCashLaunch.FirstDueDate, modelCashLaunch.InstallmentCount).After(CashLaunchReferenceDateMax) {
+			messages = append(messages, CashInstallmentMessageLastDueDateError)
+		}
+
+		if _, err := CashInstallmentValues(modelCashLaunch.Value, modelCashLaunch.InstallmentCount); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// CashInstallmentValues splits the value into count installments, the cents
+// that do not divide evenly go to the first installment so the installments
+// add up exactly to the value
+func CashInstallmentValues(value decimal.Decimal, count int) ([]decimal.Decimal, error) {
+	cents := value.Shift(2).IntPart()
+	centsInstallment := cents / int64(count)
+
+	if centsInstallment <= 0 {
+		return nil, ErrModelValidate{Message: CashInstallmentMessageValueSplitError}
+	}
+
+	values := make([]decimal.Decimal, count)
+
+	for idx := range values {
+		values[idx] = decimal.New(centsInstallment, -2)
+	}
+
+	values[0] = decimal.New(centsInstallment+cents%int64(count), -2)
+
+	return values, nil
+}
+
+// CashInstallmentDueDate returns the due date of the installment number, one
+// month after the other from the first due date. In a month without the day
+// of the first due date the installment is due on the last day of the month.
+func CashInstallmentDueDate(firstDueDate time.Time, number int) time.Time {
+	month := time.Date(firstDueDate.Year(), firstDueDate.Month()+time.Month(number-1), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := month.AddDate(0, 1, -1)
+
+	if firstDueDate.Day() < lastDay.Day() {
+		return time.Date(month.Year(), month.Month(), firstDueDate.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return lastDay
+}
+
+// cashInstallmentRemaining returns the installments due from the date on that
+// are neither deleted nor reversed
+func cashInstallmentRemaining(modelCashLaunches model.CashLaunches, now time.Time) model.CashLaunches {
+	today := cashRecurrenceDate(now)
+	modelCashLaunchesRemaining := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		if !modelCashLaunch.ReferenceDate.Before(today) && modelCashLaunch.DeletedAt == nil && modelCashLaunch.ReversedByID == 0 {
+			modelCashLaunchesRemaining = append(modelCashLaunchesRemaining, modelCashLaunch)
+		}
+	}
+
+	return modelCashLaunchesRemaining
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+)
+
+// UseCaseCashInstallmentCache decorates a CashInstallment use case evicting
+// the cached daily balances affected by the remaining installments written
+type UseCaseCashInstallmentCache struct {
+	UseCaseCashInstallment CashInstallment
+	Cache                  cache.Cache
+}
+
+func NewCashInstallmentCache(useCaseCashInstallment CashInstallment, cache cache.Cache) CashInstallment {
+	return &UseCaseCashInstallmentCache{
+		UseCaseCashInstallment: useCaseCashInstallment,
+		Cache:                  cache,
+	}
+}
+
+func (useCaseCashInstallmentCache *UseCaseCashInstallmentCache) ListByGroupID(groupID int64, includeDeleted bool) (model.CashLaunches, error) {
+	return useCaseCashInstallmentCache.UseCaseCashInstallment.ListByGroupID(groupID, includeDeleted)
+}
+
+func (useCaseCashInstallmentCache *UseCaseCashInstallmentCache) Update(modelCashInstallment *model.CashInstallment, modelAudit model.Audit) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashInstallmentCache.UseCaseCashInstallment.Update(modelCashInstallment, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashInstallmentCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunches, nil
+}
+
+func (useCaseCashInstallmentCache *UseCaseCashInstallmentCache) DeleteByGroupID(groupID int64, modelAudit model.Audit) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashInstallmentCache.UseCaseCashInstallment.DeleteByGroupID(groupID, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashInstallmentCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunches, nil
+}
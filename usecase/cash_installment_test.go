@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashInstallmentValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputValue decimal.Decimal
+		inputCount int
+		want       []string
+		wantError  error
+	}{
+		{
+			name:       "ExactSuccess",
+			inputValue: decimal.RequireFromString("90"),
+			inputCount: 3,
+			want:       []string{"30", "30", "30"},
+		},
+		{
+			name:       "CentsSuccess",
+			inputValue: decimal.RequireFromString("100"),
+			inputCount: 3,
+			want:       []string{"33.34", "33.33", "33.33"},
+		},
+		{
+			name:       "SplitError",
+			inputValue: decimal.RequireFromString("0.05"),
+			inputCount: 10,
+			wantError:  usecase.ErrModelValidate{Message: usecase.CashInstallmentMessageValueSplitError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultValues, err := usecase.CashInstallmentValues(tt.inputValue, tt.inputCount)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("CashInstallmentValues() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			if tt.wantError != nil {
+				return
+			}
+
+			total := decimal.Zero
+
+			for idx, resultValue := range resultValues {
+				assert.True(t, decimal.RequireFromString(tt.want[idx]).Equal(resultValue), "installment %v = %v", idx+1, resultValue)
+				total = total.Add(resultValue)
+			}
+
+			assert.True(t, tt.inputValue.Equal(total))
+		})
+	}
+}
+
+func TestCashInstallmentDueDate(t *testing.T) {
+	firstDueDate := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, firstDueDate, usecase.CashInstallmentDueDate(firstDueDate, 1))
+	assert.Equal(t, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), usecase.CashInstallmentDueDate(firstDueDate, 2))
+	assert.Equal(t, time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), usecase.CashInstallmentDueDate(firstDueDate, 3))
+	assert.Equal(t, time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), usecase.CashInstallmentDueDate(firstDueDate, 13))
+}
+
+func TestCashInstallmentInsert(t *testing.T) {
+	firstDueDate := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	type test struct {
+		name            string
+		inputCashLaunch *model.CashLaunch
+		wantError       error
+		assert          func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error)
+	}
+
+	tests := []test{
+		{
+			name: "CountError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:        1,
+				ReferenceDate:    firstDueDate,
+				Type:             "D",
+				Description:      "NOTEBOOK",
+				Value:            decimal.RequireFromString("100"),
+				InstallmentCount: usecase.CashInstallmentCountMax + 1,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashInstallmentMessageCountError},
+		},
+		{
+			name: "ValueSplitError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:        1,
+				ReferenceDate:    firstDueDate,
+				Type:             "D",
+				Description:      "NOTEBOOK",
+				Value:            decimal.RequireFromString("0.05"),
+				InstallmentCount: 10,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashInstallmentMessageValueSplitError},
+		},
+		{
+			name: "Success",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:        1,
+				CategoryID:       2,
+				ReferenceDate:    time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC),
+				Type:             "D",
+				Description:      "NOTEBOOK",
+				Value:            decimal.RequireFromString("100"),
+				InstallmentCount: 3,
+				FirstDueDate:     &firstDueDate,
+			},
+			assert: func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error) {
+				assert.Nil(t, err)
+				assert.NotEqual(t, int64(0), resultCashLaunch.InstallmentGroupID)
+				assert.Equal(t, 1, resultCashLaunch.InstallmentNumber)
+				assert.Nil(t, resultCashLaunch.FirstDueDate)
+
+				repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+				resultCashLaunches, err := repositoryInMemory.CashInstallment().ListByGroupID(resultCashLaunch.InstallmentGroupID, false)
+				assert.Nil(t, err)
+				assert.Len(t, resultCashLaunches, 3)
+
+				wantReferenceDates := []time.Time{firstDueDate, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)}
+				wantValues := []string{"33.34", "33.33", "33.33"}
+
+				for idx, modelCashLaunch := range resultCashLaunches {
+					assert.Equal(t, idx+1, modelCashLaunch.InstallmentNumber)
+					assert.Equal(t, 3, modelCashLaunch.InstallmentCount)
+					assert.Equal(t, int64(2), modelCashLaunch.CategoryID)
+					assert.Equal(t, wantReferenceDates[idx], modelCashLaunch.ReferenceDate)
+					assert.True(t, decimal.RequireFromString(wantValues[idx]).Equal(modelCashLaunch.Value))
+					assert.True(t, decimal.RequireFromString(wantValues[idx]).Equal(modelCashLaunch.BaseValue))
+				}
+
+				repositoryInMemory.CashInstallment().DeleteByGroupID(resultCashLaunch.InstallmentGroupID, time.Time{}, modelAuditDefault)
+			},
+		},
+		{
+			name: "SingleSuccess",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:        1,
+				ReferenceDate:    firstDueDate,
+				Type:             "D",
+				Description:      "NOTEBOOK",
+				Value:            decimal.RequireFromString("100"),
+				InstallmentCount: 1,
+				FirstDueDate:     &firstDueDate,
+			},
+			assert: func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(0), resultCashLaunch.InstallmentGroupID)
+				assert.Equal(t, 0, resultCashLaunch.InstallmentCount)
+				assert.Nil(t, resultCashLaunch.FirstDueDate)
+				assert.True(t, decimal.RequireFromString("100").Equal(resultCashLaunch.Value))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+			modelCashLaunch := *tt.inputCashLaunch
+
+			resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashLaunch, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashLaunch)
+			}
+		})
+	}
+}
+
+func TestCashInstallmentUpdateAndDelete(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashInstallment := usecase.NewCashInstallment(repositoryInMemory.CashInstallment(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	// two installments already due and four remaining
+	now := time.Now().UTC()
+	firstDueDate := time.Date(now.Year(), now.Month()-2, 1, 0, 0, 0, 0, time.UTC)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:        1,
+		ReferenceDate:    firstDueDate,
+		Type:             "D",
+		Description:      "NOTEBOOK",
+		Value:            decimal.RequireFromString("600"),
+		InstallmentCount: 6,
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	groupID := modelCashLaunch.InstallmentGroupID
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	remaining := 0
+
+	for number := 1; number <= 6; number++ {
+		if !usecase.CashInstallmentDueDate(firstDueDate, number).Before(today) {
+			remaining++
+		}
+	}
+
+	_, err = usecaseCashInstallment.ListByGroupID(999, false)
+	assert.Equal(t, repository.ErrNotFound{Message: "not found"}, err)
+
+	_, err = usecaseCashInstallment.Update(&model.CashInstallment{ID: groupID, AccountID: 999, Description: "NOTEBOOK", Value: decimal.RequireFromString("100")}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchMessageAccountNotFoundError}, err)
+
+	resultCashLaunches, err := usecaseCashInstallment.Update(&model.CashInstallment{ID: groupID, AccountID: 2, CategoryID: 2, Description: "notebook renegociado", Value: decimal.RequireFromString("100")}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, remaining)
+
+	total := decimal.Zero
+
+	for _, resultCashLaunch := range resultCashLaunches {
+		assert.Equal(t, int64(2), resultCashLaunch.AccountID)
+		assert.Equal(t, "NOTEBOOK RENEGOCIADO", resultCashLaunch.Description)
+		assert.False(t, resultCashLaunch.ReferenceDate.Before(today))
+		total = total.Add(resultCashLaunch.Value)
+	}
+
+	assert.True(t, decimal.RequireFromString("100").Equal(total))
+
+	// the installments already due are kept
+	resultCashLaunches, err = usecaseCashInstallment.DeleteByGroupID(groupID, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, remaining)
+
+	resultCashLaunches, err = usecaseCashInstallment.ListByGroupID(groupID, false)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 6-remaining)
+
+	for _, resultCashLaunch := range resultCashLaunches {
+		assert.Equal(t, int64(1), resultCashLaunch.AccountID)
+		assert.True(t, decimal.RequireFromString("100").Equal(resultCashLaunch.Value))
+	}
+
+	_, err = usecaseCashInstallment.DeleteByGroupID(groupID, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashInstallmentMessageRemainingNotFoundError}, err)
+
+	repositoryInMemory.CashInstallment().DeleteByGroupID(groupID, time.Time{}, modelAuditDefault)
+}
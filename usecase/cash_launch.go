@@ -1,13 +1,18 @@
 package usecase
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
 )
 
 var (
@@ -15,7 +20,12 @@ var (
 	CashLaunchReferenceDateMax  = time.Now().UTC().AddDate(10, 0, 0)
 	CashLaunchDescriptionMinLen = 3
 	CashLaunchDescriptionMaxLen = 100
+	CashLaunchExternalIDMaxLen  = 255
 
+	CashLaunchMessageAccountIDEmptyError       = "The account_id is empty"
+	CashLaunchMessageAccountNotFoundError      = "The account_id does not exist"
+	CashLaunchMessageCategoryIDInvalidError    = "The category_id is less than 0"
+	CashLaunchMessageCategoryNotFoundError     = "The category_id does not exist"
 	CashLaunchMessageReferenceDateEmptyError   = "The reference_date is empty"
 	CashLaunchMessageReferenceDateBetweenError = fmt.Sprintf("The reference_date value is not between %v and %v", CashLaunchReferenceDateMin, CashLaunchReferenceDateMax)
 	CashLaunchMessageTypeEmptyError            = "The type is empty"
@@ -23,61 +33,468 @@ var (
 	CashLaunchMessageDescriptionEmptyError     = "The description is empty"
 	CashLaunchMessageDescriptionSizeError      = fmt.Sprintf("The description size is not between %v and %v", CashLaunchDescriptionMinLen, CashLaunchDescriptionMaxLen)
 	CashLaunchMessageValueError                = "The value is less or equal 0"
+	CashLaunchMessageCurrencyInvalidError      = "The currency is not a ISO-4217 code"
+	CashLaunchMessageExternalIDSizeError       = fmt.Sprintf("The external_id size is greater than %v", CashLaunchExternalIDMaxLen)
+	CashLaunchMessageExchangeRateNotFoundError = "There is no exchange rate from %v to %v on the reference_date"
+	CashLaunchMessageTransferTypeError         = "The type of a transfer launch can not be changed"
+	CashLaunchMessageTransferAccountError      = "The account_id is the account of the other side of the transfer"
+	CashLaunchMessageDeletedError              = "The launch is deleted"
+	CashLaunchMessageReversalLinkedError       = "The launch is linked to a reversal"
+
+	CashLaunchListSorts        = []string{"reference_date", "type", "description", "value", "id"}
+	CashLaunchListSortDefault  = "reference_date"
+	CashLaunchListOrderDefault = "asc"
+	CashLaunchListLimitDefault = 100
+	CashLaunchListLimitMax     = 1000
+
+	CashLaunchMessageListReferenceDateToSmallerFromError = "The param reference_date_to is smaller the param reference_date_from"
+	CashLaunchMessageListTypeInvalidError                = "The param type not in ['C', 'D']"
+	CashLaunchMessageListValueToSmallerFromError         = "The param value_to is smaller the param value_from"
+	CashLaunchMessageListDescriptionSizeError            = fmt.Sprintf("The param description size is greater than %v", CashLaunchDescriptionMaxLen)
+	CashLaunchMessageListSortInvalidError                = fmt.Sprintf("The param sort not in ['%v']", strings.Join(CashLaunchListSorts, "', '"))
+	CashLaunchMessageListOrderInvalidError               = "The param order not in ['asc', 'desc']"
+	CashLaunchMessageListLimitError                      = fmt.Sprintf("The param limit is not between 1 and %v", CashLaunchListLimitMax)
+	CashLaunchMessageListNextInvalidError                = "The param next is invalid"
 )
 
 type CashLaunch interface {
-	Insert(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error)
-	List() (model.CashLaunches, error)
+	Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+	List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, string, error)
 	GetByID(id int64) (*model.CashLaunch, error)
-	Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error)
-	DeleteByID(id int64) error
+	Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error)
+	DeleteByID(id int64, modelAudit model.Audit) error
+	Reverse(modelCashLaunchReversal *model.CashLaunchReversal, modelAudit model.Audit) (model.CashLaunches, error)
+	ListAuditByID(id int64) (model.CashLaunchAudits, error)
+	Review(modelCashLaunchReview *model.CashLaunchReview, modelAudit model.Audit) (*model.CashLaunch, error)
+	Import(launchImportReader launch_import.Reader, mode string, modelAudit model.Audit) (*model.CashLaunchImportReport, error)
 }
 
 type UseCaseCashLaunch struct {
-	RepositoryCashLaunch repository.CashLaunch
+	RepositoryCashLaunch       repository.CashLaunch
+	RepositoryCashAccount      repository.CashAccount
+	RepositoryCashCategory     repository.CashCategory
+	RepositoryCashCategoryRule repository.CashCategoryRule
+	RepositoryCashInstallment  repository.CashInstallment
+	RepositoryCashPeriod       repository.CashPeriod
+	RepositoryExchangeRate     repository.ExchangeRate
+	BaseCurrency               string
+	ApprovalThresholds         CashLaunchApprovalThresholds
 }
 
-func NewCashLaunch(repositoryCashLaunch repository.CashLaunch) CashLaunch {
+func NewCashLaunch(repositoryCashLaunch repository.CashLaunch, repositoryCashAccount repository.CashAccount, repositoryCashCategory repository.CashCategory, repositoryCashCategoryRule repository.CashCategoryRule, repositoryCashInstallment repository.CashInstallment, repositoryCashPeriod repository.CashPeriod, repositoryExchangeRate repository.ExchangeRate, baseCurrency string, approvalThresholds CashLaunchApprovalThresholds) CashLaunch {
 	return &UseCaseCashLaunch{
-		RepositoryCashLaunch: repositoryCashLaunch,
+		RepositoryCashLaunch:       repositoryCashLaunch,
+		RepositoryCashAccount:      repositoryCashAccount,
+		RepositoryCashCategory:     repositoryCashCategory,
+		RepositoryCashCategoryRule: repositoryCashCategoryRule,
+		RepositoryCashInstallment:  repositoryCashInstallment,
+		RepositoryCashPeriod:       repositoryCashPeriod,
+		RepositoryExchangeRate:     repositoryExchangeRate,
+		BaseCurrency:               baseCurrency,
+		ApprovalThresholds:         approvalThresholds,
 	}
 }
 
-func (useCaseCashLaunch *UseCaseCashLaunch) Insert(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
-	err := cashLaunchModelValidate(modelCashLaunch)
+func (useCaseCashLaunch *UseCaseCashLaunch) Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	// the external_id is only set by Import
+	modelCashLaunch.ExternalID = ""
+
+	err := useCaseCashLaunch.cashLaunchInsertValidate(modelCashLaunch, nil)
 
 	if err != nil {
 		return nil, err
 	}
 
-	modelCashLaunch.CreatedAt = time.Now().UTC()
-	modelCashLaunch.UpdatedAt = modelCashLaunch.CreatedAt
+	if modelCashLaunch.InstallmentCount > 1 {
+		return useCaseCashLaunch.cashInstallmentInsert(modelCashLaunch, modelAudit)
+	}
 
-	return useCaseCashLaunch.RepositoryCashLaunch.Insert(modelCashLaunch)
+	err = useCaseCashLaunch.cashLaunchInsertApply(modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunch, err = useCaseCashLaunch.RepositoryCashLaunch.Insert(modelCashLaunch, modelAudit)
+
+	return modelCashLaunch, cashPeriodRepositoryError(err)
 }
 
-func (useCaseCashLaunch *UseCaseCashLaunch) List() (model.CashLaunches, error) {
-	return useCaseCashLaunch.RepositoryCashLaunch.List()
+// List returns a page of launches and the token of the next page, which is
+// empty on the last page
+func (useCaseCashLaunch *UseCaseCashLaunch) List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, string, error) {
+	err := cashLaunchFilterValidate(modelCashLaunchFilter)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	// one launch beyond the limit tells whether there is a next page
+	modelCashLaunchFilterRepository := *modelCashLaunchFilter
+	modelCashLaunchFilterRepository.Limit++
+
+	modelCashLaunches, err := useCaseCashLaunch.RepositoryCashLaunch.List(&modelCashLaunchFilterRepository)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(modelCashLaunches) <= modelCashLaunchFilter.Limit {
+		return modelCashLaunches, "", nil
+	}
+
+	modelCashLaunches = modelCashLaunches[:modelCashLaunchFilter.Limit]
+	modelCashLaunchLast := &modelCashLaunches[len(modelCashLaunches)-1]
+
+	next, err := cashLaunchCursorEncode(&model.CashLaunchCursor{
+		Sort:  modelCashLaunchFilter.Sort,
+		Order: modelCashLaunchFilter.Order,
+		Value: model.CashLaunchSortValue(modelCashLaunchLast, modelCashLaunchFilter.Sort),
+		ID:    modelCashLaunchLast.ID,
+	})
+
+	return modelCashLaunches, next, err
 }
 
 func (useCaseCashLaunch *UseCaseCashLaunch) GetByID(id int64) (*model.CashLaunch, error) {
 	return useCaseCashLaunch.RepositoryCashLaunch.GetByID(id)
 }
 
-func (useCaseCashLaunch *UseCaseCashLaunch) Update(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+func (useCaseCashLaunch *UseCaseCashLaunch) Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
 	err := cashLaunchModelValidate(modelCashLaunch)
 
 	if err != nil {
 		return nil, err
 	}
 
+	err = useCaseCashLaunch.cashAccountValidate(modelCashLaunch.AccountID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashLaunch.cashCategoryValidate(modelCashLaunch.CategoryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchCurrent, err := useCaseCashLaunch.cashLaunchCurrentValidate(modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cashLaunchExchangeRateApply(useCaseCashLaunch.RepositoryExchangeRate, useCaseCashLaunch.BaseCurrency, modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cashLaunchStatusApply(useCaseCashLaunch.ApprovalThresholds, modelCashLaunch, modelCashLaunchCurrent)
+
 	modelCashLaunch.UpdatedAt = time.Now().UTC()
 
-	return useCaseCashLaunch.RepositoryCashLaunch.Update(modelCashLaunch)
+	modelCashLaunch, err = useCaseCashLaunch.RepositoryCashLaunch.Update(modelCashLaunch, modelAudit)
+
+	return modelCashLaunch, cashPeriodRepositoryError(err)
+}
+
+// DeleteByID marks the launch as deleted, a deleted launch is not found again
+// and a launch linked to a reversal is kept as it is
+func (useCaseCashLaunch *UseCaseCashLaunch) DeleteByID(id int64, modelAudit model.Audit) error {
+	modelCashLaunch, err := useCaseCashLaunch.RepositoryCashLaunch.GetByID(id)
+
+	if err != nil {
+		return err
+	}
+
+	if modelCashLaunch.DeletedAt != nil {
+		return repository.ErrNotFound{Message: "not found"}
+	}
+
+	if modelCashLaunch.ReversalOfID != 0 || modelCashLaunch.ReversedByID != 0 {
+		return ErrModelValidate{Message: CashLaunchMessageReversalLinkedError}
+	}
+
+	err = cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, modelCashLaunch.ReferenceDate)
+
+	if err != nil {
+		return err
+	}
+
+	err = useCaseCashLaunch.RepositoryCashLaunch.DeleteByID(id, modelAudit)
+
+	return cashPeriodRepositoryError(err)
+}
+
+// cashLaunchInsertValidate checks a new launch and categorizes it with the
+// rules, loaded when nil, the links set by other use cases and the review are
+// cleared
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchInsertValidate(modelCashLaunch *model.CashLaunch, matchers cashCategoryRuleMatchers) error {
+	if modelCashLaunch.Currency == "" {
+		modelCashLaunch.Currency = useCaseCashLaunch.BaseCurrency
+	}
+
+	// the transfers are only created in pairs by the CashTransfer use case, the
+	// recurrences by the CashRecurrence use case and the reversals by Reverse
+	modelCashLaunch.TransferID = 0
+	modelCashLaunch.RecurrenceID = 0
+	modelCashLaunch.InstallmentGroupID = 0
+	modelCashLaunch.InstallmentNumber = 0
+	modelCashLaunch.ReversalOfID = 0
+	modelCashLaunch.ReversedByID = 0
+	modelCashLaunch.DeletedAt = nil
+	modelCashLaunch.Status = ""
+	modelCashLaunch.ReviewComment = ""
+	modelCashLaunch.ReviewedBy = ""
+	modelCashLaunch.ReviewedAt = nil
+
+	err := cashLaunchModelValidate(modelCashLaunch)
+
+	if err != nil {
+		return err
+	}
+
+	err = cashInstallmentModelValidate(modelCashLaunch)
+
+	if err != nil {
+		return err
+	}
+
+	err = useCaseCashLaunch.cashAccountValidate(modelCashLaunch.AccountID)
+
+	if err != nil {
+		return err
+	}
+
+	err = useCaseCashLaunch.cashCategoryRuleApply(modelCashLaunch, matchers)
+
+	if err != nil {
+		return err
+	}
+
+	return useCaseCashLaunch.cashCategoryValidate(modelCashLaunch.CategoryID)
+}
+
+// cashLaunchInsertApply checks the period of a new single launch is open and
+// sets its exchange rate, status and timestamps
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchInsertApply(modelCashLaunch *model.CashLaunch) error {
+	err := cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, modelCashLaunch.ReferenceDate)
+
+	if err != nil {
+		return err
+	}
+
+	err = cashLaunchExchangeRateApply(useCaseCashLaunch.RepositoryExchangeRate, useCaseCashLaunch.BaseCurrency, modelCashLaunch)
+
+	if err != nil {
+		return err
+	}
+
+	cashLaunchStatusApply(useCaseCashLaunch.ApprovalThresholds, modelCashLaunch, nil)
+
+	modelCashLaunch.CreatedAt = time.Now().UTC()
+	modelCashLaunch.UpdatedAt = modelCashLaunch.CreatedAt
+
+	return nil
+}
+
+// cashInstallmentInsert splits the launch into monthly installments from the
+// first due date linked by a new installment group and returns the first one
+func (useCaseCashLaunch *UseCaseCashLaunch) cashInstallmentInsert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	values, err := CashInstallmentValues(modelCashLaunch.Value, modelCashLaunch.InstallmentCount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now().UTC()
+	modelCashLaunches := model.CashLaunches{}
+
+	for idx, value := range values {
+		modelCashLaunchInstallment := *modelCashLaunch
+		modelCashLaunchInstallment.InstallmentNumber = idx + 1
+		modelCashLaunchInstallment.ReferenceDate = CashInstallmentDueDate(*modelCashLaunch.FirstDueDate, idx+1)
+		modelCashLaunchInstallment.FirstDueDate = nil
+		modelCashLaunchInstallment.Value = value
+
+		err = cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, modelCashLaunchInstallment.ReferenceDate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = cashLaunchExchangeRateApply(useCaseCashLaunch.RepositoryExchangeRate, useCaseCashLaunch.BaseCurrency, &modelCashLaunchInstallment)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cashLaunchStatusApply(useCaseCashLaunch.ApprovalThresholds, &modelCashLaunchInstallment, nil)
+
+		modelCashLaunchInstallment.CreatedAt = createdAt
+		modelCashLaunchInstallment.UpdatedAt = createdAt
+
+		modelCashLaunches = append(modelCashLaunches, modelCashLaunchInstallment)
+	}
+
+	modelCashLaunches, err = useCaseCashLaunch.RepositoryCashInstallment.Insert(modelCashLaunches, modelAudit)
+
+	if err != nil {
+		return nil, cashPeriodRepositoryError(err)
+	}
+
+	return &modelCashLaunches[0], nil
+}
+
+// cashAccountValidate checks the account of the launch exists
+func (useCaseCashLaunch *UseCaseCashLaunch) cashAccountValidate(accountID int64) error {
+	_, err := useCaseCashLaunch.RepositoryCashAccount.GetByID(accountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageAccountNotFoundError}
+	}
+
+	return err
+}
+
+// cashCategoryValidate checks the category of the launch exists, the launch
+// without category has the category_id 0
+func (useCaseCashLaunch *UseCaseCashLaunch) cashCategoryValidate(categoryID int64) error {
+	if categoryID == 0 {
+		return nil
+	}
+
+	_, err := useCaseCashLaunch.RepositoryCashCategory.GetByID(categoryID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageCategoryNotFoundError}
+	}
+
+	return err
 }
 
-func (useCaseCashLaunch *UseCaseCashLaunch) DeleteByID(id int64) error {
-	return useCaseCashLaunch.RepositoryCashLaunch.DeleteByID(id)
+// cashCategoryRuleApply categorizes the launch that arrives without category
+// with the first rule it matches by its value in the base currency, the rules
+// are loaded when they are nil
+func (useCaseCashLaunch *UseCaseCashLaunch) cashCategoryRuleApply(modelCashLaunch *model.CashLaunch, matchers cashCategoryRuleMatchers) error {
+	if modelCashLaunch.CategoryID != 0 {
+		return nil
+	}
+
+	if matchers == nil {
+		modelCashCategoryRules, err := useCaseCashLaunch.RepositoryCashCategoryRule.List()
+
+		if err != nil {
+			return err
+		}
+
+		matchers = newCashCategoryRuleMatchers(modelCashCategoryRules)
+	}
+
+	// the exchange rate is set again by cashLaunchInsertApply
+	modelCashLaunchBase := *modelCashLaunch
+
+	err := cashLaunchExchangeRateApply(useCaseCashLaunch.RepositoryExchangeRate, useCaseCashLaunch.BaseCurrency, &modelCashLaunchBase)
+
+	if err != nil {
+		return err
+	}
+
+	if modelCashCategoryRule := matchers.Match(&modelCashLaunchBase); modelCashCategoryRule != nil {
+		modelCashLaunch.CategoryID = modelCashCategoryRule.CategoryID
+	}
+
+	return nil
+}
+
+// cashLaunchCurrentValidate rejects the changes of a deleted launch, of a
+// launch linked to a reversal or moving from or into a closed period, keeps
+// the currency when it is not informed and keeps the launch linked to its
+// transfer, the type of each side can not change and the accounts of the
+// sides must differ. The current launch is returned to compare the changes.
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchCurrentValidate(modelCashLaunch *model.CashLaunch) (*model.CashLaunch, error) {
+	modelCashLaunchCurrent, err := useCaseCashLaunch.RepositoryCashLaunch.GetByID(modelCashLaunch.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashLaunchCurrent.DeletedAt != nil {
+		return nil, ErrModelValidate{Message: CashLaunchMessageDeletedError}
+	}
+
+	if modelCashLaunchCurrent.ReversalOfID != 0 || modelCashLaunchCurrent.ReversedByID != 0 {
+		return nil, ErrModelValidate{Message: CashLaunchMessageReversalLinkedError}
+	}
+
+	err = cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, modelCashLaunchCurrent.ReferenceDate, modelCashLaunch.ReferenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashLaunch.Currency == "" {
+		modelCashLaunch.Currency = modelCashLaunchCurrent.Currency
+	}
+
+	modelCashLaunch.TransferID = modelCashLaunchCurrent.TransferID
+
+	if modelCashLaunch.TransferID == 0 {
+		return modelCashLaunchCurrent, nil
+	}
+
+	if modelCashLaunch.Type != modelCashLaunchCurrent.Type {
+		return nil, ErrModelValidate{Message: CashLaunchMessageTransferTypeError}
+	}
+
+	modelCashLaunches, err := useCaseCashLaunch.RepositoryCashLaunch.List(&model.CashLaunchFilter{
+		TransferID: modelCashLaunch.TransferID,
+		Sort:       "id",
+		Order:      "asc",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelCashLaunchPair := range modelCashLaunches {
+		if modelCashLaunchPair.ID != modelCashLaunch.ID && modelCashLaunchPair.AccountID == modelCashLaunch.AccountID {
+			return nil, ErrModelValidate{Message: CashLaunchMessageTransferAccountError}
+		}
+	}
+
+	return modelCashLaunchCurrent, nil
+}
+
+// cashLaunchExchangeRateApply stores the rate of the reference date converting
+// the launch value into the base currency
+func cashLaunchExchangeRateApply(repositoryExchangeRate repository.ExchangeRate, baseCurrency string, modelCashLaunch *model.CashLaunch) error {
+	modelExchangeRates := model.ExchangeRates{}
+
+	if modelCashLaunch.Currency != baseCurrency {
+		var err error
+
+		modelExchangeRates, err = repositoryExchangeRate.GetByReferenceDate(modelCashLaunch.ReferenceDate)
+
+		if err != nil {
+			if _, ok := err.(repository.ErrNotFound); !ok {
+				return err
+			}
+		}
+	}
+
+	exchangeRate, ok := ExchangeRateConvert(modelExchangeRates, modelCashLaunch.Currency, baseCurrency)
+
+	if !ok {
+		return ErrModelValidate{Message: fmt.Sprintf(CashLaunchMessageExchangeRateNotFoundError, modelCashLaunch.Currency, baseCurrency)}
+	}
+
+	modelCashLaunch.ExchangeRate = exchangeRate
+	modelCashLaunch.BaseValue = modelCashLaunch.Value.Mul(exchangeRate).Round(2)
+
+	return nil
 }
 
 func cashLaunchModelValidate(modelCashLaunch *model.CashLaunch) error {
@@ -85,6 +502,14 @@ func cashLaunchModelValidate(modelCashLaunch *model.CashLaunch) error {
 
 	CashLaunchModelFormat(modelCashLaunch)
 
+	if modelCashLaunch.AccountID <= 0 {
+		messages = append(messages, CashLaunchMessageAccountIDEmptyError)
+	}
+
+	if modelCashLaunch.CategoryID < 0 {
+		messages = append(messages, CashLaunchMessageCategoryIDInvalidError)
+	}
+
 	err := cashLaunchReferenceDateValidate(modelCashLaunch.ReferenceDate)
 
 	if err != nil {
@@ -104,10 +529,19 @@ func cashLaunchModelValidate(modelCashLaunch *model.CashLaunch) error {
 		messages = append(messages, CashLaunchMessageDescriptionSizeError)
 	}
 
-	if modelCashLaunch.Value <= 0 {
+	if !modelCashLaunch.Value.IsPositive() {
 		messages = append(messages, CashLaunchMessageValueError)
 	}
 
+	// the empty currency is set to the base one on insert and kept on update
+	if modelCashLaunch.Currency != "" && !CurrencyValidate(modelCashLaunch.Currency) {
+		messages = append(messages, CashLaunchMessageCurrencyInvalidError)
+	}
+
+	if len(modelCashLaunch.ExternalID) > CashLaunchExternalIDMaxLen {
+		messages = append(messages, CashLaunchMessageExternalIDSizeError)
+	}
+
 	if len(messages) > 0 {
 		return ErrModelValidate{Message: strings.Join(messages, ";")}
 	}
@@ -132,8 +566,167 @@ func cashLaunchReferenceDateValidate(referenceDate time.Time) error {
 	return nil
 }
 
+// cashLaunchFilterValidate formats the filter, applies the default sort, order
+// and limit and decodes the cursor of the next page
+func cashLaunchFilterValidate(modelCashLaunchFilter *model.CashLaunchFilter) error {
+	messages := []string{}
+
+	modelCashLaunchFilter.Type = util.FormatTextWithoutSpace(util.FormatTitle(modelCashLaunchFilter.Type))
+	modelCashLaunchFilter.Status = util.FormatTextWithoutSpace(strings.ToLower(modelCashLaunchFilter.Status))
+	modelCashLaunchFilter.Description = util.FormatTitle(modelCashLaunchFilter.Description)
+	modelCashLaunchFilter.Sort = util.FormatTextWithoutSpace(strings.ToLower(modelCashLaunchFilter.Sort))
+	modelCashLaunchFilter.Order = util.FormatTextWithoutSpace(strings.ToLower(modelCashLaunchFilter.Order))
+
+	if !modelCashLaunchFilter.ReferenceDateFrom.IsZero() && !modelCashLaunchFilter.ReferenceDateTo.IsZero() &&
+		modelCashLaunchFilter.ReferenceDateTo.Before(modelCashLaunchFilter.ReferenceDateFrom) {
+		messages = append(messages, CashLaunchMessageListReferenceDateToSmallerFromError)
+	}
+
+	if modelCashLaunchFilter.Type != "" && modelCashLaunchFilter.Type != "C" && modelCashLaunchFilter.Type != "D" {
+		messages = append(messages, CashLaunchMessageListTypeInvalidError)
+	}
+
+	if modelCashLaunchFilter.Status != "" &&
+		modelCashLaunchFilter.Status != repository.CashLaunchStatusPending &&
+		modelCashLaunchFilter.Status != repository.CashLaunchStatusApproved &&
+		modelCashLaunchFilter.Status != repository.CashLaunchStatusRejected {
+		messages = append(messages, CashLaunchMessageListStatusInvalidError)
+	}
+
+	if modelCashLaunchFilter.ValueFrom.Valid && modelCashLaunchFilter.ValueTo.Valid &&
+		modelCashLaunchFilter.ValueTo.Decimal.LessThan(modelCashLaunchFilter.ValueFrom.Decimal) {
+		messages = append(messages, CashLaunchMessageListValueToSmallerFromError)
+	}
+
+	if len(modelCashLaunchFilter.Description) > CashLaunchDescriptionMaxLen {
+		messages = append(messages, CashLaunchMessageListDescriptionSizeError)
+	}
+
+	if modelCashLaunchFilter.Sort == "" {
+		modelCashLaunchFilter.Sort = CashLaunchListSortDefault
+	} else if !cashLaunchListSortValidate(modelCashLaunchFilter.Sort) {
+		messages = append(messages, CashLaunchMessageListSortInvalidError)
+	}
+
+	if modelCashLaunchFilter.Order == "" {
+		modelCashLaunchFilter.Order = CashLaunchListOrderDefault
+	} else if modelCashLaunchFilter.Order != "asc" && modelCashLaunchFilter.Order != "desc" {
+		messages = append(messages, CashLaunchMessageListOrderInvalidError)
+	}
+
+	if modelCashLaunchFilter.Limit == 0 {
+		modelCashLaunchFilter.Limit = CashLaunchListLimitDefault
+	} else if modelCashLaunchFilter.Limit < 0 || modelCashLaunchFilter.Limit > CashLaunchListLimitMax {
+		messages = append(messages, CashLaunchMessageListLimitError)
+	}
+
+	modelCashLaunchFilter.Cursor = nil
+
+	if modelCashLaunchFilter.Next != "" {
+		modelCashLaunchCursor, err := cashLaunchCursorDecode(modelCashLaunchFilter.Next)
+
+		// the cursor is only valid with the sort and order of the query that generated it
+		if err != nil ||
+			modelCashLaunchCursor.Sort != modelCashLaunchFilter.Sort ||
+			modelCashLaunchCursor.Order != modelCashLaunchFilter.Order {
+			messages = append(messages, CashLaunchMessageListNextInvalidError)
+		} else {
+			modelCashLaunchFilter.Cursor = modelCashLaunchCursor
+		}
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// cashLaunchListAll returns the launches of the filter reading every page of
+// the list in the order of the reference date and id
+func cashLaunchListAll(repositoryCashLaunch repository.CashLaunch, modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, error) {
+	modelCashLaunchFilter.Sort = CashLaunchListSortDefault
+	modelCashLaunchFilter.Order = CashLaunchListOrderDefault
+	modelCashLaunchFilter.Limit = CashLaunchListLimitMax
+	modelCashLaunchFilter.Cursor = nil
+
+	modelCashLaunches := model.CashLaunches{}
+
+	for {
+		modelCashLaunchesPage, err := repositoryCashLaunch.List(modelCashLaunchFilter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunches = append(modelCashLaunches, modelCashLaunchesPage...)
+
+		if len(modelCashLaunchesPage) < modelCashLaunchFilter.Limit {
+			return modelCashLaunches, nil
+		}
+
+		modelCashLaunchLast := &modelCashLaunchesPage[len(modelCashLaunchesPage)-1]
+
+		modelCashLaunchFilter.Cursor = &model.CashLaunchCursor{
+			Sort:  modelCashLaunchFilter.Sort,
+			Order: modelCashLaunchFilter.Order,
+			Value: model.CashLaunchSortValue(modelCashLaunchLast, modelCashLaunchFilter.Sort),
+			ID:    modelCashLaunchLast.ID,
+		}
+	}
+}
+
+func cashLaunchListSortValidate(sort string) bool {
+	for _, cashLaunchListSort := range CashLaunchListSorts {
+		if sort == cashLaunchListSort {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cashLaunchCursorEncode(modelCashLaunchCursor *model.CashLaunchCursor) (string, error) {
+	cursor, err := json.Marshal(modelCashLaunchCursor)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursor), nil
+}
+
+func cashLaunchCursorDecode(next string) (*model.CashLaunchCursor, error) {
+	cursor, err := base64.RawURLEncoding.DecodeString(next)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchCursor := &model.CashLaunchCursor{}
+
+	err = json.Unmarshal(cursor, modelCashLaunchCursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the value is sent to the repositories, so it must match the sort field type
+	switch modelCashLaunchCursor.Sort {
+	case "reference_date":
+		_, err = time.Parse("2006-01-02", modelCashLaunchCursor.Value)
+	case "value":
+		_, err = decimal.NewFromString(modelCashLaunchCursor.Value)
+	case "id":
+		_, err = strconv.ParseInt(modelCashLaunchCursor.Value, 10, 64)
+	}
+
+	return modelCashLaunchCursor, err
+}
+
 func CashLaunchModelFormat(modelCashLaunch *model.CashLaunch) {
 	modelCashLaunch.Description = util.FormatTitle(modelCashLaunch.Description)
 	modelCashLaunch.Type = util.FormatTextWithoutSpace(util.FormatTitle(modelCashLaunch.Type))
-	modelCashLaunch.Value = util.MathRoundPrecision(modelCashLaunch.Value, 2)
+	modelCashLaunch.Value = modelCashLaunch.Value.Round(2)
+	modelCashLaunch.Currency = util.FormatTextWithoutSpace(util.FormatTitle(modelCashLaunch.Currency))
 }
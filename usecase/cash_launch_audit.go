@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+)
+
+var (
+	AuditActorAnonymous = "anonymous"
+	AuditActorSystem    = "system"
+	AuditActorMaxLen    = 100
+)
+
+// ListAuditByID returns the history of the launch in the order of the
+// changes, including the history of a deleted launch
+func (useCaseCashLaunch *UseCaseCashLaunch) ListAuditByID(id int64) (model.CashLaunchAudits, error) {
+	_, err := useCaseCashLaunch.RepositoryCashLaunch.GetByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return useCaseCashLaunch.RepositoryCashLaunch.ListAuditByID(id)
+}
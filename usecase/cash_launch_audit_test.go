@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashLaunchListAuditByID(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	referenceDate := time.Date(2016, 01, 10, 00, 00, 00, 000, time.UTC)
+	modelAuditUpdate := model.Audit{Actor: "maria", RequestID: "request-update"}
+
+	_, err := usecaseCashLaunch.ListAuditByID(999)
+	assert.Equal(t, repository.ErrNotFound{Message: "not found"}, err)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDate,
+		Type:          "D",
+		Description:   "compra auditada",
+		Value:         decimal.RequireFromString("20"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashLaunchUpdate := *modelCashLaunch
+	modelCashLaunchUpdate.Value = decimal.RequireFromString("25")
+
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditUpdate)
+	assert.Nil(t, err)
+
+	modelCashLaunchReversals, err := usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID, ReferenceDate: referenceDate}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	resultCashLaunchAudits, err := usecaseCashLaunch.ListAuditByID(modelCashLaunch.ID)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunchAudits, 3)
+
+	// the history is kept in the order of the changes
+	assert.Equal(t, repository.CashLaunchAuditActionInsert, resultCashLaunchAudits[0].Action)
+	assert.Nil(t, resultCashLaunchAudits[0].Before)
+	assert.Equal(t, "20", resultCashLaunchAudits[0].After.Value.String())
+	assert.Equal(t, modelAuditDefault.Actor, resultCashLaunchAudits[0].Actor)
+
+	assert.Equal(t, repository.CashLaunchAuditActionUpdate, resultCashLaunchAudits[1].Action)
+	assert.Equal(t, "20", resultCashLaunchAudits[1].Before.Value.String())
+	assert.Equal(t, "25", resultCashLaunchAudits[1].After.Value.String())
+	assert.Equal(t, modelAuditUpdate.Actor, resultCashLaunchAudits[1].Actor)
+	assert.Equal(t, modelAuditUpdate.RequestID, resultCashLaunchAudits[1].RequestID)
+
+	assert.Equal(t, repository.CashLaunchAuditActionReversal, resultCashLaunchAudits[2].Action)
+	assert.Zero(t, resultCashLaunchAudits[2].Before.ReversedByID)
+	assert.Equal(t, modelCashLaunchReversals[0].ID, resultCashLaunchAudits[2].After.ReversedByID)
+
+	// the reversal has its own history
+	resultCashLaunchAudits, err = usecaseCashLaunch.ListAuditByID(modelCashLaunchReversals[0].ID)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunchAudits, 1)
+	assert.Equal(t, repository.CashLaunchAuditActionInsert, resultCashLaunchAudits[0].Action)
+}
+
+func TestCashLaunchListAuditByIDDelete(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashTransfer := usecase.NewCashTransfer(repositoryInMemory.CashTransfer(), repositoryInMemory.CashAccount(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault)
+
+	modelCashTransfer := *modelCashTransferDefault
+	modelCashTransfer.ReferenceDate = time.Date(2016, 01, 20, 00, 00, 00, 000, time.UTC)
+
+	resultCashTransfer, err := usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+	assert.Nil(t, err)
+
+	err = usecaseCashLaunch.DeleteByID(resultCashTransfer.Debit.ID, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// both sides of the transfer record the deletion
+	for _, id := range []int64{resultCashTransfer.Debit.ID, resultCashTransfer.Credit.ID} {
+		resultCashLaunchAudits, err := usecaseCashLaunch.ListAuditByID(id)
+		assert.Nil(t, err)
+		assert.Len(t, resultCashLaunchAudits, 2)
+		assert.Equal(t, repository.CashLaunchAuditActionInsert, resultCashLaunchAudits[0].Action)
+		assert.Equal(t, repository.CashLaunchAuditActionDelete, resultCashLaunchAudits[1].Action)
+		assert.Nil(t, resultCashLaunchAudits[1].Before.DeletedAt)
+		assert.NotNil(t, resultCashLaunchAudits[1].After.DeletedAt)
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+)
+
+// UseCaseCashLaunchCache decorates a CashLaunch use case evicting the cached
+// daily balances on every launch written, the eviction error is returned
+// although the launch is already persisted
+type UseCaseCashLaunchCache struct {
+	UseCaseCashLaunch CashLaunch
+	Cache             cache.Cache
+}
+
+func NewCashLaunchCache(useCaseCashLaunch CashLaunch, cache cache.Cache) CashLaunch {
+	return &UseCaseCashLaunchCache{
+		UseCaseCashLaunch: useCaseCashLaunch,
+		Cache:             cache,
+	}
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) Insert(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	modelCashLaunchInsert, err := useCaseCashLaunchCache.UseCaseCashLaunch.Insert(modelCashLaunch, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchInsert, nil
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) List(modelCashLaunchFilter *model.CashLaunchFilter) (model.CashLaunches, string, error) {
+	return useCaseCashLaunchCache.UseCaseCashLaunch.List(modelCashLaunchFilter)
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) GetByID(id int64) (*model.CashLaunch, error) {
+	return useCaseCashLaunchCache.UseCaseCashLaunch.GetByID(id)
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) Update(modelCashLaunch *model.CashLaunch, modelAudit model.Audit) (*model.CashLaunch, error) {
+	modelCashLaunchUpdate, err := useCaseCashLaunchCache.UseCaseCashLaunch.Update(modelCashLaunch, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchUpdate, nil
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) DeleteByID(id int64, modelAudit model.Audit) error {
+	err := useCaseCashLaunchCache.UseCaseCashLaunch.DeleteByID(id, modelAudit)
+
+	if err != nil {
+		return err
+	}
+
+	return CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) Reverse(modelCashLaunchReversal *model.CashLaunchReversal, modelAudit model.Audit) (model.CashLaunches, error) {
+	modelCashLaunchReversals, err := useCaseCashLaunchCache.UseCaseCashLaunch.Reverse(modelCashLaunchReversal, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchReversals, nil
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) Review(modelCashLaunchReview *model.CashLaunchReview, modelAudit model.Audit) (*model.CashLaunch, error) {
+	modelCashLaunch, err := useCaseCashLaunchCache.UseCaseCashLaunch.Review(modelCashLaunchReview, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunch, nil
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) Import(launchImportReader launch_import.Reader, mode string, modelAudit model.Audit) (*model.CashLaunchImportReport, error) {
+	modelCashLaunchImportReport, err := useCaseCashLaunchCache.UseCaseCashLaunch.Import(launchImportReader, mode, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashLaunchImportReport.Imported > 0 {
+		err = CashBalanceDailyCacheEvict(useCaseCashLaunchCache.Cache)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modelCashLaunchImportReport, nil
+}
+
+func (useCaseCashLaunchCache *UseCaseCashLaunchCache) ListAuditByID(id int64) (model.CashLaunchAudits, error) {
+	return useCaseCashLaunchCache.UseCaseCashLaunch.ListAuditByID(id)
+}
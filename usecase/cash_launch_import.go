@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+)
+
+// the modes of an import, all_or_nothing persists the lines only when every
+// line is valid and best_effort persists each valid line
+const (
+	CashLaunchImportModeAllOrNothing = "all_or_nothing"
+	CashLaunchImportModeBestEffort   = "best_effort"
+)
+
+// the status of each line of an import
+const (
+	CashLaunchImportStatusImported  = "imported"
+	CashLaunchImportStatusFailed    = "failed"
+	CashLaunchImportStatusSkipped   = "skipped"
+	CashLaunchImportStatusDuplicate = "duplicate"
+	CashLaunchImportStatusIgnored   = "ignored"
+)
+
+var (
+	// CashLaunchImportRowsMax is the number of rows kept in the report, the
+	// next rows are only counted
+	CashLaunchImportRowsMax = 10000
+
+	CashLaunchImportMessageModeInvalidError = "The param mode not in ['all_or_nothing', 'best_effort']"
+)
+
+// errCashLaunchImportFailed discards the launches of an all_or_nothing import
+// with failed lines
+var errCashLaunchImportFailed = errors.New("the import has failed lines")
+
+// cashLaunchImport is the state of an import in progress
+type cashLaunchImport struct {
+	launchImportReader          launch_import.Reader
+	modelCashLaunchImportReport *model.CashLaunchImportReport
+	// cashCategoryRuleMatchers categorize the lines without category, loaded
+	// and compiled once per import
+	cashCategoryRuleMatchers cashCategoryRuleMatchers
+}
+
+// Import validates each line read with the rules of Insert and reports the
+// result of every line. The lines are streamed from the reader and persisted
+// one at a time, in all_or_nothing mode within a single transaction, and only
+// the result of each line is kept. A line with the external_id of a launch of
+// its account, or of a previous line, is reported as duplicate and left out,
+// so the same file can be imported again.
+func (useCaseCashLaunch *UseCaseCashLaunch) Import(launchImportReader launch_import.Reader, mode string, modelAudit model.Audit) (*model.CashLaunchImportReport, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+
+	if mode == "" {
+		mode = CashLaunchImportModeAllOrNothing
+	}
+
+	if mode != CashLaunchImportModeAllOrNothing && mode != CashLaunchImportModeBestEffort {
+		return nil, ErrParamValidate{Message: CashLaunchImportMessageModeInvalidError}
+	}
+
+	modelCashCategoryRules, err := useCaseCashLaunch.RepositoryCashCategoryRule.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchImportReport := &model.CashLaunchImportReport{
+		Mode: mode,
+		Rows: model.CashLaunchImportRows{},
+	}
+
+	cashLaunchImport := &cashLaunchImport{
+		launchImportReader:          launchImportReader,
+		modelCashLaunchImportReport: modelCashLaunchImportReport,
+		cashCategoryRuleMatchers:    newCashCategoryRuleMatchers(modelCashCategoryRules),
+	}
+
+	if mode == CashLaunchImportModeBestEffort {
+		err = useCaseCashLaunch.cashLaunchImportBestEffort(cashLaunchImport, modelAudit)
+	} else {
+		err = useCaseCashLaunch.cashLaunchImportAllOrNothing(cashLaunchImport, modelAudit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashLaunchImportReport, nil
+}
+
+// cashLaunchImportBestEffort persists each valid line on its own
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchImportBestEffort(cashLaunchImport *cashLaunchImport, modelAudit model.Audit) error {
+	for {
+		modelCashLaunch, modelCashLaunchImportRow, err := useCaseCashLaunch.cashLaunchImportRead(cashLaunchImport)
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if modelCashLaunch != nil {
+			modelCashLaunchInsert, err := useCaseCashLaunch.RepositoryCashLaunch.Insert(modelCashLaunch, modelAudit)
+
+			// the same external_id imported meanwhile
+			if _, ok := err.(repository.ErrDuplicateKey); ok {
+				modelCashLaunchImportRow.Status = CashLaunchImportStatusDuplicate
+			} else if err != nil {
+				return cashPeriodRepositoryError(err)
+			} else {
+				modelCashLaunchImportRow.LaunchID = modelCashLaunchInsert.ID
+			}
+		}
+
+		cashLaunchImportReportAppend(cashLaunchImport.modelCashLaunchImportReport, modelCashLaunchImportRow)
+	}
+}
+
+// cashLaunchImportAllOrNothing persists the valid lines in a single
+// transaction, after the first failed line the next lines are only validated
+// and the transaction is discarded at the end. Only the launch of the last
+// line returned to the repository is held, until it is persisted.
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchImportAllOrNothing(cashLaunchImport *cashLaunchImport, modelAudit model.Audit) error {
+	modelCashLaunchImportReport := cashLaunchImport.modelCashLaunchImportReport
+	failed := false
+
+	var modelCashLaunchPersisted *model.CashLaunch
+	var modelCashLaunchImportRowPersisted model.CashLaunchImportRow
+
+	next := func() (*model.CashLaunch, error) {
+		// the launch returned before is persisted by now, with a zero id when
+		// the repository left out its external_id repeated in the file
+		if modelCashLaunchPersisted != nil {
+			if modelCashLaunchPersisted.ID == 0 {
+				modelCashLaunchImportRowPersisted.Status = CashLaunchImportStatusDuplicate
+			} else {
+				modelCashLaunchImportRowPersisted.LaunchID = modelCashLaunchPersisted.ID
+			}
+
+			cashLaunchImportReportAppend(modelCashLaunchImportReport, modelCashLaunchImportRowPersisted)
+			modelCashLaunchPersisted = nil
+		}
+
+		for {
+			modelCashLaunch, modelCashLaunchImportRow, err := useCaseCashLaunch.cashLaunchImportRead(cashLaunchImport)
+
+			if err == io.EOF && failed {
+				return nil, errCashLaunchImportFailed
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if modelCashLaunchImportRow.Status == CashLaunchImportStatusFailed {
+				failed = true
+			}
+
+			if modelCashLaunch == nil || failed {
+				cashLaunchImportReportAppend(modelCashLaunchImportReport, modelCashLaunchImportRow)
+				continue
+			}
+
+			modelCashLaunchPersisted, modelCashLaunchImportRowPersisted = modelCashLaunch, modelCashLaunchImportRow
+
+			return modelCashLaunch, nil
+		}
+	}
+
+	err := useCaseCashLaunch.RepositoryCashLaunch.Import(next, modelAudit)
+
+	if err == errCashLaunchImportFailed {
+		modelCashLaunchImportReport.Skipped += modelCashLaunchImportReport.Imported
+		modelCashLaunchImportReport.Imported = 0
+
+		for idx := range modelCashLaunchImportReport.Rows {
+			if modelCashLaunchImportReport.Rows[idx].Status == CashLaunchImportStatusImported {
+				modelCashLaunchImportReport.Rows[idx].Status = CashLaunchImportStatusSkipped
+				modelCashLaunchImportReport.Rows[idx].LaunchID = 0
+			}
+		}
+
+		return nil
+	}
+
+	return cashPeriodRepositoryError(err)
+}
+
+// cashLaunchImportRead reads and validates the next line returning its row,
+// the launch is nil when the line failed, is a duplicate or is ignored. The
+// validation errors are reported by line and any other error ends the
+// import.
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchImportRead(cashLaunchImport *cashLaunchImport) (*model.CashLaunch, model.CashLaunchImportRow, error) {
+	modelCashLaunchImportRecord, err := cashLaunchImport.launchImportReader.Read()
+
+	if err != nil {
+		return nil, model.CashLaunchImportRow{}, err
+	}
+
+	modelCashLaunchImportRow := model.CashLaunchImportRow{
+		Line:   modelCashLaunchImportRecord.Line,
+		Status: CashLaunchImportStatusImported,
+		Errors: modelCashLaunchImportRecord.Errors,
+	}
+
+	if modelCashLaunchImportRow.Errors == nil {
+		modelCashLaunchImportRow.Errors = []string{}
+	}
+
+	if modelCashLaunchImportRecord.Ignored {
+		modelCashLaunchImportRow.Status = CashLaunchImportStatusIgnored
+
+		return nil, modelCashLaunchImportRow, nil
+	}
+
+	modelCashLaunch := &modelCashLaunchImportRecord.CashLaunch
+
+	// the duplicates are left out before the validation, as the lines of a
+	// period closed after the first import
+	if len(modelCashLaunchImportRow.Errors) == 0 && modelCashLaunch.AccountID > 0 && modelCashLaunch.ExternalID != "" {
+		launchID, err := useCaseCashLaunch.cashLaunchImportDuplicate(modelCashLaunch)
+
+		if err != nil {
+			return nil, model.CashLaunchImportRow{}, err
+		}
+
+		if launchID >= 0 {
+			modelCashLaunchImportRow.Status = CashLaunchImportStatusDuplicate
+			modelCashLaunchImportRow.LaunchID = launchID
+			modelCashLaunch = nil
+		}
+	}
+
+	if modelCashLaunch != nil && len(modelCashLaunchImportRow.Errors) == 0 {
+		modelCashLaunchImportRow.Errors, err = useCaseCashLaunch.cashLaunchImportValidate(modelCashLaunch, cashLaunchImport.cashCategoryRuleMatchers)
+
+		if err != nil {
+			return nil, model.CashLaunchImportRow{}, err
+		}
+	}
+
+	if len(modelCashLaunchImportRow.Errors) > 0 {
+		modelCashLaunchImportRow.Status = CashLaunchImportStatusFailed
+		modelCashLaunch = nil
+	}
+
+	return modelCashLaunch, modelCashLaunchImportRow, nil
+}
+
+// cashLaunchImportValidate applies the rules of Insert to the launch of a line
+// returning the validation errors
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchImportValidate(modelCashLaunch *model.CashLaunch, matchers cashCategoryRuleMatchers) ([]string, error) {
+	// the installments are not split by the import
+	modelCashLaunch.InstallmentCount = 0
+	modelCashLaunch.FirstDueDate = nil
+
+	err := useCaseCashLaunch.cashLaunchInsertValidate(modelCashLaunch, matchers)
+
+	if err == nil {
+		err = useCaseCashLaunch.cashLaunchInsertApply(modelCashLaunch)
+	}
+
+	switch errValidate := err.(type) {
+	case nil:
+		return []string{}, nil
+	case ErrModelValidate:
+		return strings.Split(errValidate.Message, ";"), nil
+	case ErrParamValidate:
+		return strings.Split(errValidate.Message, ";"), nil
+	case ErrPeriodClosed:
+		return strings.Split(errValidate.Message, ";"), nil
+	default:
+		return nil, err
+	}
+}
+
+// cashLaunchImportDuplicate returns the id of the launch of the account with
+// the external_id of the line or -1 when the line is new, the lines repeated
+// in a file not yet committed are left out by the repository
+func (useCaseCashLaunch *UseCaseCashLaunch) cashLaunchImportDuplicate(modelCashLaunch *model.CashLaunch) (int64, error) {
+	modelCashLaunches, err := useCaseCashLaunch.RepositoryCashLaunch.List(&model.CashLaunchFilter{
+		AccountID:      modelCashLaunch.AccountID,
+		ExternalID:     modelCashLaunch.ExternalID,
+		IncludeDeleted: true,
+		Sort:           "id",
+		Order:          "asc",
+		Limit:          1,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(modelCashLaunches) > 0 {
+		return modelCashLaunches[0].ID, nil
+	}
+
+	return -1, nil
+}
+
+// cashLaunchImportReportAppend counts the row in the report and keeps it
+// while the report has less than CashLaunchImportRowsMax rows
+func cashLaunchImportReportAppend(modelCashLaunchImportReport *model.CashLaunchImportReport, modelCashLaunchImportRow model.CashLaunchImportRow) {
+	modelCashLaunchImportReport.Total++
+
+	switch modelCashLaunchImportRow.Status {
+	case CashLaunchImportStatusImported:
+		modelCashLaunchImportReport.Imported++
+	case CashLaunchImportStatusFailed:
+		modelCashLaunchImportReport.Failed++
+	case CashLaunchImportStatusSkipped:
+		modelCashLaunchImportReport.Skipped++
+	case CashLaunchImportStatusDuplicate:
+		modelCashLaunchImportReport.Duplicated++
+	case CashLaunchImportStatusIgnored:
+		modelCashLaunchImportReport.Ignored++
+	}
+
+	if len(modelCashLaunchImportReport.Rows) >= CashLaunchImportRowsMax {
+		modelCashLaunchImportReport.RowsTruncated = true
+		return
+	}
+
+	modelCashLaunchImportReport.Rows = append(modelCashLaunchImportReport.Rows, modelCashLaunchImportRow)
+}
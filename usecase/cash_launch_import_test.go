@@ -0,0 +1,572 @@
+package usecase_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	launch_import_cnab "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/cnab"
+	launch_import_csv "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/csv"
+	launch_import_ofx "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/ofx"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+// cashLaunchImportFixtureOptions maps the columns of testdata/cash_launch_import.csv
+func cashLaunchImportFixtureOptions() *launch_import_csv.CSVOptions {
+	return &launch_import_csv.CSVOptions{
+		Delimiter:        ';',
+		Header:           true,
+		Columns:          map[string]string{"reference_date": "Data", "description": "Histórico", "value": "Valor", "account_id": "Conta"},
+		DateFormat:       "DD/MM/YYYY",
+		DecimalSeparator: ",",
+	}
+}
+
+func TestCashLaunchImportCSV(t *testing.T) {
+	fixture, err := os.Open("testdata/cash_launch_import.csv")
+	assert.Nil(t, err)
+	defer fixture.Close()
+
+	launchImportReader, err := launch_import_csv.NewCSV(fixture, cashLaunchImportFixtureOptions())
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords := []*model.CashLaunchImportRecord{}
+
+	for {
+		modelCashLaunchImportRecord, err := launchImportReader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		assert.Nil(t, err)
+
+		modelCashLaunchImportRecords = append(modelCashLaunchImportRecords, modelCashLaunchImportRecord)
+	}
+
+	assert.Len(t, modelCashLaunchImportRecords, 5)
+
+	// the sign of the value tells the type without the type column
+	modelCashLaunch := modelCashLaunchImportRecords[0].CashLaunch
+	assert.Equal(t, 2, modelCashLaunchImportRecords[0].Line)
+	assert.Empty(t, modelCashLaunchImportRecords[0].Errors)
+	assert.Equal(t, int64(1), modelCashLaunch.AccountID)
+	assert.Equal(t, time.Date(2006, 2, 10, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "Venda balcão", modelCashLaunch.Description)
+	assert.Equal(t, "C", modelCashLaunch.Type)
+	assert.Equal(t, "1234.56", modelCashLaunch.Value.String())
+
+	modelCashLaunch = modelCashLaunchImportRecords[1].CashLaunch
+	assert.Equal(t, "D", modelCashLaunch.Type)
+	assert.Equal(t, "300", modelCashLaunch.Value.String())
+
+	assert.Empty(t, modelCashLaunchImportRecords[2].Errors)
+	assert.Equal(t, []string{"The reference_date is invalid"}, modelCashLaunchImportRecords[3].Errors)
+	assert.Equal(t, 6, modelCashLaunchImportRecords[4].Line)
+	assert.Equal(t, []string{"The column of the account_id is missing"}, modelCashLaunchImportRecords[4].Errors)
+
+	// the columns by position without header and the malformed line
+	launchImportReader, err = launch_import_csv.NewCSV(strings.NewReader("20060301,\"Aluguel\" recebido,D,100\n"), &launch_import_csv.CSVOptions{
+		Columns:    map[string]string{"reference_date": "1", "description": "2", "type": "3", "value": "4"},
+		DateFormat: "YYYYMMDD",
+		AccountID:  2,
+	})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecord, err := launchImportReader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, modelCashLaunchImportRecord.Line)
+	assert.Len(t, modelCashLaunchImportRecord.Errors, 1)
+
+	launchImportReader, err = launch_import_csv.NewCSV(strings.NewReader("20060301,Aluguel recebido,d,100\n"), &launch_import_csv.CSVOptions{
+		Columns:    map[string]string{"reference_date": "1", "description": "2", "type": "3", "value": "4"},
+		DateFormat: "YYYYMMDD",
+		AccountID:  2,
+	})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecord, err = launchImportReader.Read()
+	assert.Nil(t, err)
+	assert.Empty(t, modelCashLaunchImportRecord.Errors)
+	assert.Equal(t, int64(2), modelCashLaunchImportRecord.CashLaunch.AccountID)
+	assert.Equal(t, "D", modelCashLaunchImportRecord.CashLaunch.Type)
+
+	_, err = launchImportReader.Read()
+	assert.Equal(t, io.EOF, err)
+
+	// the options are checked before the lines
+	optionsErrors := []*launch_import_csv.CSVOptions{
+		{Header: true, DateFormat: "DD-MM-YYYY"},
+		{Header: true, DecimalSeparator: ";"},
+		{Header: true, Columns: map[string]string{"amount": "valor"}},
+		{Header: true, Columns: map[string]string{"reference_date": "dia", "description": "historico", "value": "valor"}, AccountID: 1},
+		{Header: true, Columns: map[string]string{"description": "historico", "value": "valor"}, AccountID: 1},
+		{Header: true, Columns: map[string]string{"reference_date": "data", "description": "historico", "value": "valor"}},
+		{Columns: map[string]string{"reference_date": "0", "description": "2", "value": "3"}, AccountID: 1},
+		{},
+	}
+
+	for _, options := range optionsErrors {
+		_, err = launch_import_csv.NewCSV(strings.NewReader("data,historico,valor\n"), options)
+		assert.NotNil(t, err, options)
+	}
+}
+
+func TestCashLaunchImport(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashLaunchFilter := &model.CashLaunchFilter{
+		AccountID:         1,
+		ReferenceDateFrom: time.Date(2006, 2, 1, 0, 0, 0, 0, time.UTC),
+		ReferenceDateTo:   time.Date(2006, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	fixtureImport := func(mode string) (*model.CashLaunchImportReport, error) {
+		fixture, err := os.Open("testdata/cash_launch_import.csv")
+		assert.Nil(t, err)
+		defer fixture.Close()
+
+		launchImportReader, err := launch_import_csv.NewCSV(fixture, cashLaunchImportFixtureOptions())
+		assert.Nil(t, err)
+
+		return usecaseCashLaunch.Import(launchImportReader, mode, modelAuditDefault)
+	}
+
+	_, err := fixtureImport("partial")
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashLaunchImportMessageModeInvalidError}, err)
+
+	// a failed line discards every line in the all_or_nothing mode
+	modelCashLaunchImportReport, err := fixtureImport("")
+	assert.Nil(t, err)
+	assert.Equal(t, usecase.CashLaunchImportModeAllOrNothing, modelCashLaunchImportReport.Mode)
+	assert.Equal(t, 5, modelCashLaunchImportReport.Total)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Skipped)
+	assert.Equal(t, usecase.CashLaunchImportStatusSkipped, modelCashLaunchImportReport.Rows[0].Status)
+	assert.Equal(t, int64(0), modelCashLaunchImportReport.Rows[0].LaunchID)
+	assert.Equal(t, 4, modelCashLaunchImportReport.Rows[2].Line)
+	assert.Equal(t, usecase.CashLaunchImportStatusFailed, modelCashLaunchImportReport.Rows[2].Status)
+	assert.Equal(t, []string{usecase.CashLaunchMessageDescriptionSizeError}, modelCashLaunchImportReport.Rows[2].Errors)
+
+	resultCashLaunches, _, err := usecaseCashLaunch.List(modelCashLaunchFilter)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 0)
+
+	// the valid lines are persisted in the best_effort mode
+	modelCashLaunchImportReport, err = fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Skipped)
+
+	resultCashLaunches, _, err = usecaseCashLaunch.List(modelCashLaunchFilter)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 2)
+	assert.Equal(t, modelCashLaunchImportReport.Rows[0].LaunchID, resultCashLaunches[0].ID)
+	assert.Equal(t, modelCashLaunchImportReport.Rows[1].LaunchID, resultCashLaunches[1].ID)
+	assert.Equal(t, "BRL", resultCashLaunches[1].Currency)
+
+	// every line valid in the all_or_nothing mode with the columns named as the fields
+	launchImportReader, err := launch_import_csv.NewCSV(strings.NewReader("account_id,reference_date,type,description,value\n1,2006-03-01,C,Aluguel recebido,100.50\n1,2006-03-02,D,Conta de luz,80\n"), &launch_import_csv.CSVOptions{Header: true})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportReport, err = usecaseCashLaunch.Import(launchImportReader, usecase.CashLaunchImportModeAllOrNothing, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Imported)
+
+	for _, modelCashLaunchImportRow := range modelCashLaunchImportReport.Rows {
+		resultCashLaunch, err := usecaseCashLaunch.GetByID(modelCashLaunchImportRow.LaunchID)
+		assert.Nil(t, err)
+		assert.Equal(t, modelCashLaunchImportRow.LaunchID, resultCashLaunch.ID)
+	}
+
+	// the external_id repeated in the file is a duplicate in the all_or_nothing
+	// mode and only the first rows are kept in the report
+	usecase.CashLaunchImportRowsMax = 2
+	defer func() { usecase.CashLaunchImportRowsMax = 10000 }()
+
+	launchImportReader, err = launch_import_csv.NewCSV(strings.NewReader("account_id,reference_date,type,description,value,external_id\n1,2006-03-03,D,Internet,99.90,N1\n1,2006-03-03,D,Internet,99.90,N1\n1,2006-03-04,D,Telefone,45,N2\n"), &launch_import_csv.CSVOptions{Header: true})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportReport, err = usecaseCashLaunch.Import(launchImportReader, usecase.CashLaunchImportModeAllOrNothing, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Total)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 1, modelCashLaunchImportReport.Duplicated)
+	assert.Len(t, modelCashLaunchImportReport.Rows, 2)
+	assert.True(t, modelCashLaunchImportReport.RowsTruncated)
+	assert.Equal(t, usecase.CashLaunchImportStatusDuplicate, modelCashLaunchImportReport.Rows[1].Status)
+
+	resultCashLaunches, _, err = usecaseCashLaunch.List(modelCashLaunchFilter)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 6)
+
+	for _, resultCashLaunch := range resultCashLaunches {
+		err = usecaseCashLaunch.DeleteByID(resultCashLaunch.ID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashLaunchError := usecase.NewCashLaunch(repositoryInMemoryError.CashLaunch(), repositoryInMemoryError.CashAccount(), repositoryInMemoryError.CashCategory(), repositoryInMemoryError.CashCategoryRule(), repositoryInMemoryError.CashInstallment(), repositoryInMemoryError.CashPeriod(), repositoryInMemoryError.ExchangeRate(), baseCurrencyDefault, nil)
+
+	launchImportReader, err = launch_import_csv.NewCSV(strings.NewReader("account_id,reference_date,type,description,value\n1,2006-03-01,C,Aluguel recebido,100.50\n"), &launch_import_csv.CSVOptions{Header: true})
+	assert.Nil(t, err)
+
+	_, err = usecaseCashLaunchError.Import(launchImportReader, usecase.CashLaunchImportModeBestEffort, modelAuditDefault)
+	assert.NotNil(t, err)
+}
+
+func TestCashLaunchImportOFX(t *testing.T) {
+	fixture, err := os.Open("testdata/cash_launch_import.ofx")
+	assert.Nil(t, err)
+	defer fixture.Close()
+
+	launchImportReader, err := launch_import_ofx.NewOFX(fixture, &launch_import_ofx.OFXOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords := []*model.CashLaunchImportRecord{}
+
+	for {
+		modelCashLaunchImportRecord, err := launchImportReader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		assert.Nil(t, err)
+
+		modelCashLaunchImportRecords = append(modelCashLaunchImportRecords, modelCashLaunchImportRecord)
+	}
+
+	assert.Len(t, modelCashLaunchImportRecords, 4)
+
+	// the SGML of the version 1.x in the CHARSET 1252
+	modelCashLaunch := modelCashLaunchImportRecords[0].CashLaunch
+	assert.Equal(t, 39, modelCashLaunchImportRecords[0].Line)
+	assert.Empty(t, modelCashLaunchImportRecords[0].Errors)
+	assert.Equal(t, int64(2), modelCashLaunch.AccountID)
+	assert.Equal(t, "2005031001", modelCashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 10, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "C", modelCashLaunch.Type)
+	assert.Equal(t, "1500", modelCashLaunch.Value.String())
+	assert.Equal(t, "Depósito em cheque", modelCashLaunch.Description)
+	assert.Equal(t, "BRL", modelCashLaunch.Currency)
+
+	// the NAME without MEMO and the amount with the decimal comma
+	modelCashLaunch = modelCashLaunchImportRecords[1].CashLaunch
+	assert.Equal(t, "D", modelCashLaunch.Type)
+	assert.Equal(t, "89.9", modelCashLaunch.Value.String())
+	assert.Equal(t, "Conta de luz & gás", modelCashLaunch.Description)
+
+	assert.Equal(t, []string{"The FITID is empty"}, modelCashLaunchImportRecords[2].Errors)
+
+	// the XML of the version 2.x in a single line with the currency of the transaction
+	launchImportReader, err = launch_import_ofx.NewOFX(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?><?OFX OFXHEADER="200" VERSION="220"?><OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>BRL</CURDEF><BANKTRANLIST><STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20050315000000.000[-3:BRT]</DTPOSTED><TRNAMT>-25.50</TRNAMT><FITID>CC-1</FITID><NAME>Livraria</NAME><MEMO></MEMO><CURRENCY><CURRATE>1</CURRATE><CURSYM>usd</CURSYM></CURRENCY></STMTTRN><STMTTRN><DTPOSTED>2005</DTPOSTED><TRNAMT>abc</TRNAMT><FITID>CC-2</FITID></STMTTRN></BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`), &launch_import_ofx.OFXOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecord, err := launchImportReader.Read()
+	assert.Nil(t, err)
+	assert.Empty(t, modelCashLaunchImportRecord.Errors)
+	assert.Equal(t, "CC-1", modelCashLaunchImportRecord.CashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 15, 0, 0, 0, 0, time.UTC), modelCashLaunchImportRecord.CashLaunch.ReferenceDate)
+	assert.Equal(t, "D", modelCashLaunchImportRecord.CashLaunch.Type)
+	assert.Equal(t, "25.5", modelCashLaunchImportRecord.CashLaunch.Value.String())
+	assert.Equal(t, "Livraria", modelCashLaunchImportRecord.CashLaunch.Description)
+	assert.Equal(t, "USD", modelCashLaunchImportRecord.CashLaunch.Currency)
+
+	modelCashLaunchImportRecord, err = launchImportReader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"The DTPOSTED is invalid", "The TRNAMT is invalid"}, modelCashLaunchImportRecord.Errors)
+
+	_, err = launchImportReader.Read()
+	assert.Equal(t, io.EOF, err)
+
+	// the truncated file
+	launchImportReader, err = launch_import_ofx.NewOFX(strings.NewReader("<OFX><STMTTRN><FITID>1"), &launch_import_ofx.OFXOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	_, err = launchImportReader.Read()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = launch_import_ofx.NewOFX(strings.NewReader("data,historico,valor\n"), &launch_import_ofx.OFXOptions{AccountID: 2})
+	assert.NotNil(t, err)
+
+	_, err = launch_import_ofx.NewOFX(strings.NewReader("<OFX></OFX>"), &launch_import_ofx.OFXOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestCashLaunchImportDuplicate(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	fixtureImport := func(mode string) *model.CashLaunchImportReport {
+		fixture, err := os.Open("testdata/cash_launch_import.ofx")
+		assert.Nil(t, err)
+		defer fixture.Close()
+
+		launchImportReader, err := launch_import_ofx.NewOFX(fixture, &launch_import_ofx.OFXOptions{AccountID: 2})
+		assert.Nil(t, err)
+
+		modelCashLaunchImportReport, err := usecaseCashLaunch.Import(launchImportReader, mode, modelAuditDefault)
+		assert.Nil(t, err)
+
+		return modelCashLaunchImportReport
+	}
+
+	// the line without FITID fails and the FITID repeated in the file is a duplicate
+	modelCashLaunchImportReport := fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Equal(t, 4, modelCashLaunchImportReport.Total)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 1, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, 1, modelCashLaunchImportReport.Duplicated)
+	assert.Equal(t, usecase.CashLaunchImportStatusDuplicate, modelCashLaunchImportReport.Rows[3].Status)
+
+	modelCashLaunchIDs := []int64{modelCashLaunchImportReport.Rows[0].LaunchID, modelCashLaunchImportReport.Rows[1].LaunchID}
+
+	resultCashLaunch, err := usecaseCashLaunch.GetByID(modelCashLaunchIDs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, "2005031001", resultCashLaunch.ExternalID)
+
+	// the statement imported again only reports the launches already imported,
+	// which are not failures in the all_or_nothing mode
+	modelCashLaunchImportReport = fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Duplicated)
+	assert.Equal(t, modelCashLaunchIDs[0], modelCashLaunchImportReport.Rows[0].LaunchID)
+	assert.Equal(t, modelCashLaunchIDs[1], modelCashLaunchImportReport.Rows[1].LaunchID)
+
+	modelCashLaunchImportReport = fixtureImport(usecase.CashLaunchImportModeAllOrNothing)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 1, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Duplicated)
+
+	// the deleted launch is not imported again
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunchIDs[0], modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashLaunchImportReport = fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Equal(t, usecase.CashLaunchImportStatusDuplicate, modelCashLaunchImportReport.Rows[0].Status)
+
+	// the external_id is only set by the import
+	resultCashLaunch, err = usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     2,
+		ReferenceDate: time.Date(2005, 3, 10, 0, 0, 0, 0, time.UTC),
+		Type:          "C",
+		Description:   "Depósito manual",
+		Value:         modelCashLaunchDefault.Value,
+		ExternalID:    "2005031001",
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, "", resultCashLaunch.ExternalID)
+
+	for _, modelCashLaunchID := range append(modelCashLaunchIDs[1:], resultCashLaunch.ID) {
+		err = usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+}
+
+// cashLaunchImportCNABRecords reads every record of a CNAB file
+func cashLaunchImportCNABRecords(t *testing.T, launchImportReader launch_import.Reader) []*model.CashLaunchImportRecord {
+	modelCashLaunchImportRecords := []*model.CashLaunchImportRecord{}
+
+	for {
+		modelCashLaunchImportRecord, err := launchImportReader.Read()
+
+		if err == io.EOF {
+			return modelCashLaunchImportRecords
+		}
+
+		assert.Nil(t, err)
+
+		modelCashLaunchImportRecords = append(modelCashLaunchImportRecords, modelCashLaunchImportRecord)
+	}
+}
+
+func TestCashLaunchImportCNAB240(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/cash_launch_import_cnab240.ret")
+	assert.Nil(t, err)
+
+	launchImportReader, err := launch_import_cnab.NewCNAB(strings.NewReader(string(fixture)), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords := cashLaunchImportCNABRecords(t, launchImportReader)
+	assert.Len(t, modelCashLaunchImportRecords, 8)
+
+	// the title settled of the segments T and U in the ISO-8859-1
+	modelCashLaunch := modelCashLaunchImportRecords[0].CashLaunch
+	assert.Equal(t, 3, modelCashLaunchImportRecords[0].Line)
+	assert.Empty(t, modelCashLaunchImportRecords[0].Errors)
+	assert.Equal(t, int64(2), modelCashLaunch.AccountID)
+	assert.Equal(t, "341-12345678", modelCashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 10, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "C", modelCashLaunch.Type)
+	assert.Equal(t, "1500", modelCashLaunch.Value.String())
+	assert.Equal(t, "Liquidação título NF-1001 JOÃO DA SILVA", modelCashLaunch.Description)
+
+	// the title only registered is ignored
+	assert.Equal(t, 5, modelCashLaunchImportRecords[1].Line)
+	assert.True(t, modelCashLaunchImportRecords[1].Ignored)
+	assert.Equal(t, []string{"The movement 02 of the title NF-1002 is not a settlement"}, modelCashLaunchImportRecords[1].Errors)
+
+	assert.Equal(t, 7, modelCashLaunchImportRecords[2].Line)
+	assert.Equal(t, []string{"The value paid (positions 78-92) is invalid"}, modelCashLaunchImportRecords[2].Errors)
+
+	// the segment T followed by the batch trailer
+	assert.Equal(t, 9, modelCashLaunchImportRecords[3].Line)
+	assert.Equal(t, []string{"The segment T has no segment U"}, modelCashLaunchImportRecords[3].Errors)
+
+	// the payments of the segments A and J
+	modelCashLaunch = modelCashLaunchImportRecords[4].CashLaunch
+	assert.Equal(t, 12, modelCashLaunchImportRecords[4].Line)
+	assert.Empty(t, modelCashLaunchImportRecords[4].Errors)
+	assert.Equal(t, "341-PG0001", modelCashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 15, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "D", modelCashLaunch.Type)
+	assert.Equal(t, "89.9", modelCashLaunch.Value.String())
+	assert.Equal(t, "Pagamento PG-1 CIA DE LUZ", modelCashLaunch.Description)
+
+	assert.True(t, modelCashLaunchImportRecords[5].Ignored)
+	assert.Equal(t, []string{"The occurrences BD of the payment PG-2 are not of a payment made"}, modelCashLaunchImportRecords[5].Errors)
+
+	modelCashLaunch = modelCashLaunchImportRecords[6].CashLaunch
+	assert.Equal(t, 15, modelCashLaunchImportRecords[6].Line)
+	assert.Equal(t, "341-BOL0001", modelCashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 16, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "250", modelCashLaunch.Value.String())
+	assert.Equal(t, "Pagamento BOL-1 FORNECEDOR EXEMPLO", modelCashLaunch.Description)
+
+	assert.Equal(t, 17, modelCashLaunchImportRecords[7].Line)
+	assert.Equal(t, []string{"The segment O is not mapped"}, modelCashLaunchImportRecords[7].Errors)
+
+	// the file cut before the file trailer
+	lines := strings.SplitAfter(string(fixture), "\n")
+
+	launchImportReader, err = launch_import_cnab.NewCNAB(strings.NewReader(strings.Join(lines[:18], "")), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords = cashLaunchImportCNABRecords(t, launchImportReader)
+	assert.Equal(t, 19, modelCashLaunchImportRecords[len(modelCashLaunchImportRecords)-1].Line)
+	assert.Equal(t, []string{"The file trailer is missing"}, modelCashLaunchImportRecords[len(modelCashLaunchImportRecords)-1].Errors)
+
+	// the trailers with the counts of records wrong
+	fixtureTrailer, err := os.Open("testdata/cash_launch_import_cnab240_trailer.ret")
+	assert.Nil(t, err)
+	defer fixtureTrailer.Close()
+
+	launchImportReader, err = launch_import_cnab.NewCNAB(fixtureTrailer, &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords = cashLaunchImportCNABRecords(t, launchImportReader)
+	assert.Len(t, modelCashLaunchImportRecords, 4)
+	assert.Empty(t, modelCashLaunchImportRecords[0].Errors)
+	assert.Equal(t, []string{"The batch trailer has 000005 records and the batch has 4"}, modelCashLaunchImportRecords[1].Errors)
+	assert.Equal(t, []string{"The file trailer has 000009 records and the file has 6"}, modelCashLaunchImportRecords[2].Errors)
+	assert.Equal(t, 7, modelCashLaunchImportRecords[3].Line)
+	assert.Equal(t, []string{"The record is after the file trailer"}, modelCashLaunchImportRecords[3].Errors)
+
+	// the file header of a remittance file
+	header := []rune(lines[0])
+	header[142] = '1'
+
+	_, err = launch_import_cnab.NewCNAB(strings.NewReader(string(header)), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Equal(t, "The file is not a CNAB 240 return file", err.Error())
+
+	_, err = launch_import_cnab.NewCNAB(strings.NewReader(lines[1]), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Equal(t, "The file header of the CNAB 240 is invalid", err.Error())
+
+	_, err = launch_import_cnab.NewCNAB(strings.NewReader("data,historico,valor\n"), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Equal(t, "The file is not a CNAB 240 or 400", err.Error())
+
+	_, err = launch_import_cnab.NewCNAB(strings.NewReader(string(fixture)), &launch_import_cnab.CNABOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestCashLaunchImportCNAB400(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/cash_launch_import_cnab400.ret")
+	assert.Nil(t, err)
+
+	launchImportReader, err := launch_import_cnab.NewCNAB(strings.NewReader(string(fixture)), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Nil(t, err)
+
+	modelCashLaunchImportRecords := cashLaunchImportCNABRecords(t, launchImportReader)
+	assert.Len(t, modelCashLaunchImportRecords, 4)
+
+	modelCashLaunch := modelCashLaunchImportRecords[0].CashLaunch
+	assert.Equal(t, 2, modelCashLaunchImportRecords[0].Line)
+	assert.Empty(t, modelCashLaunchImportRecords[0].Errors)
+	assert.Equal(t, "237-000000000101", modelCashLaunch.ExternalID)
+	assert.Equal(t, time.Date(2005, 3, 11, 0, 0, 0, 0, time.UTC), modelCashLaunch.ReferenceDate)
+	assert.Equal(t, "C", modelCashLaunch.Type)
+	assert.Equal(t, "1200.5", modelCashLaunch.Value.String())
+	assert.Equal(t, "Liquidação título DUP-2001", modelCashLaunch.Description)
+
+	assert.True(t, modelCashLaunchImportRecords[1].Ignored)
+	assert.Equal(t, []string{"The occurrence 02 of the title DUP-2002 is not a settlement"}, modelCashLaunchImportRecords[1].Errors)
+
+	// without the credit date the date of the occurrence
+	assert.Empty(t, modelCashLaunchImportRecords[2].Errors)
+	assert.Equal(t, time.Date(2005, 3, 12, 0, 0, 0, 0, time.UTC), modelCashLaunchImportRecords[2].CashLaunch.ReferenceDate)
+
+	assert.Equal(t, 5, modelCashLaunchImportRecords[3].Line)
+	assert.Equal(t, []string{"The sequential number 000009 is not 5"}, modelCashLaunchImportRecords[3].Errors)
+
+	// the file header of a remittance file
+	header := strings.Replace(string(fixture), "02RETORNO", "01REMESSA", 1)
+
+	_, err = launch_import_cnab.NewCNAB(strings.NewReader(header), &launch_import_cnab.CNABOptions{AccountID: 2})
+	assert.Equal(t, "The file is not a CNAB 400 return file", err.Error())
+}
+
+func TestCashLaunchImportCNABDuplicate(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	fixtureImport := func(mode string) *model.CashLaunchImportReport {
+		fixture, err := os.Open("testdata/cash_launch_import_cnab240.ret")
+		assert.Nil(t, err)
+		defer fixture.Close()
+
+		launchImportReader, err := launch_import_cnab.NewCNAB(fixture, &launch_import_cnab.CNABOptions{AccountID: 2})
+		assert.Nil(t, err)
+
+		modelCashLaunchImportReport, err := usecaseCashLaunch.Import(launchImportReader, mode, modelAuditDefault)
+		assert.Nil(t, err)
+
+		return modelCashLaunchImportReport
+	}
+
+	// the records ignored are not failures in the all_or_nothing mode, the
+	// records not mapped are
+	modelCashLaunchImportReport := fixtureImport(usecase.CashLaunchImportModeAllOrNothing)
+	assert.Equal(t, 8, modelCashLaunchImportReport.Total)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Skipped)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Ignored)
+	assert.Equal(t, usecase.CashLaunchImportStatusIgnored, modelCashLaunchImportReport.Rows[1].Status)
+
+	modelCashLaunchImportReport = fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Ignored)
+
+	modelCashLaunchIDs := []int64{modelCashLaunchImportReport.Rows[0].LaunchID, modelCashLaunchImportReport.Rows[4].LaunchID, modelCashLaunchImportReport.Rows[6].LaunchID}
+
+	// the return file imported again is kept by the nosso número
+	modelCashLaunchImportReport = fixtureImport(usecase.CashLaunchImportModeBestEffort)
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 3, modelCashLaunchImportReport.Duplicated)
+	assert.Equal(t, modelCashLaunchIDs[0], modelCashLaunchImportReport.Rows[0].LaunchID)
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err := usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+}
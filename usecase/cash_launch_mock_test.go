@@ -11,6 +11,7 @@ import (
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,6 +29,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "ReferenceDateEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:   modelCashLaunchDefault.AccountID,
 				Type:        modelCashLaunchDefault.Type,
 				Description: modelCashLaunchDefault.Description,
 				Value:       modelCashLaunchDefault.Value,
@@ -37,6 +39,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "ReferenceDateBeforeError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMin.AddDate(0, 0, -1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -47,6 +50,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "ReferenceDateAfterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMax.AddDate(0, 0, 1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -57,6 +61,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "TypeEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "",
 				Description:   modelCashLaunchDefault.Description,
@@ -67,6 +72,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "TypeInvalidError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "CC",
 				Description:   modelCashLaunchDefault.Description,
@@ -77,6 +83,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Value:         modelCashLaunchDefault.Value,
@@ -86,6 +93,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionSizeLessError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", modelCashLaunchDefault.Description[0:usecase.CashLaunchDescriptionMinLen-1]),
@@ -96,6 +104,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionSizeGreaterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", strings.Repeat(modelCashLaunchDefault.Description, 10)[0:usecase.CashLaunchDescriptionMaxLen+1]),
@@ -106,6 +115,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "ValueZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -115,10 +125,11 @@ func TestMockCashLaunchInsert(t *testing.T) {
 		{
 			name: "ValueLessZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
-				Value:         -0.01,
+				Value:         decimal.RequireFromString("-0.01"),
 			},
 			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageValueError},
 		},
@@ -153,11 +164,11 @@ func TestMockCashLaunchInsert(t *testing.T) {
 				tt.mockOn(mockRepositoryCashLaunch, tt.inputCashLaunch, tt.wantError)
 			}
 
-			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch, repository_in_memory.NewCashAccount(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategory(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategoryRule(&repository_in_memory.InMemory{}), repository_in_memory.NewCashInstallment(&repository_in_memory.InMemory{}), repository_in_memory.NewCashPeriod(&repository_in_memory.InMemory{}), nil, baseCurrencyDefault, nil)
 
 			modelCashLaunch := *tt.inputCashLaunch
 
-			resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch)
+			resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
 
 			if tt.assert != nil {
 				tt.assert(t, &tt, resultCashLaunch, err)
@@ -166,7 +177,7 @@ func TestMockCashLaunchInsert(t *testing.T) {
 					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
 				}
 
-				if !reflect.DeepEqual(resultCashLaunch, tt.wantCashLaunch) {
+				if !equalJSON(tt.wantCashLaunch, resultCashLaunch) {
 					t.Errorf("Insert() got result = %v, want = %v.", resultCashLaunch, tt.wantCashLaunch)
 				}
 			}
@@ -201,9 +212,9 @@ func TestMockCashLaunchList(t *testing.T) {
 				tt.mockOn(mockRepositoryCashLaunch, tt.wantCashLaunches, tt.wantError)
 			}
 
-			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch, repository_in_memory.NewCashAccount(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategory(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategoryRule(&repository_in_memory.InMemory{}), repository_in_memory.NewCashInstallment(&repository_in_memory.InMemory{}), repository_in_memory.NewCashPeriod(&repository_in_memory.InMemory{}), nil, baseCurrencyDefault, nil)
 
-			resultCashLaunches, err := usecaseCashLaunch.List()
+			resultCashLaunches, _, err := usecaseCashLaunch.List(&model.CashLaunchFilter{})
 
 			if tt.assert != nil {
 				tt.assert(t, &tt, resultCashLaunches, err)
@@ -212,7 +223,7 @@ func TestMockCashLaunchList(t *testing.T) {
 					t.Errorf("List() got error = %v, want = %v.", err, tt.wantError)
 				}
 
-				if !reflect.DeepEqual(resultCashLaunches, tt.wantCashLaunches) {
+				if !equalJSON(tt.wantCashLaunches, resultCashLaunches) {
 					t.Errorf("List() got result = %v, want = %v.", resultCashLaunches, &tt.wantCashLaunches)
 				}
 			}
@@ -255,7 +266,7 @@ func TestMockCashLaunchGetByID(t *testing.T) {
 				tt.mockOn(mockRepositoryCashLaunch, tt.wantCashLaunch, tt.wantError)
 			}
 
-			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(mockRepositoryCashLaunch, repository_in_memory.NewCashAccount(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategory(&repository_in_memory.InMemory{}), repository_in_memory.NewCashCategoryRule(&repository_in_memory.InMemory{}), repository_in_memory.NewCashInstallment(&repository_in_memory.InMemory{}), repository_in_memory.NewCashPeriod(&repository_in_memory.InMemory{}), nil, baseCurrencyDefault, nil)
 
 			resultCashLaunches, err := usecaseCashLaunch.GetByID(tt.inputID)
 
@@ -263,7 +274,7 @@ func TestMockCashLaunchGetByID(t *testing.T) {
 				t.Errorf("GetByID() got error = %v, want = %v.", err, tt.wantError)
 			}
 
-			if !reflect.DeepEqual(resultCashLaunches, tt.wantCashLaunch) {
+			if !equalJSON(tt.wantCashLaunch, resultCashLaunches) {
 				t.Errorf("GetByID() got result = %v, want = %v.", resultCashLaunches, &tt.wantCashLaunch)
 			}
 		})
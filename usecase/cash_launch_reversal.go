@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+)
+
+var (
+	CashLaunchReversalDescriptionPrefix = "ESTORNO "
+
+	CashLaunchReversalMessageReversedError      = "The launch is already reversed"
+	CashLaunchReversalMessageReversalError      = "The launch is a reversal and can not be reversed"
+	CashLaunchReversalMessageReferenceDateError = "The reference_date is before the reference_date of the launch"
+)
+
+// Reverse offsets the launch with a launch of the opposite type, same account,
+// category and values on the reversal date, both sides of a transfer are
+// reversed together. The reversals are returned in the order of the launches.
+func (useCaseCashLaunch *UseCaseCashLaunch) Reverse(modelCashLaunchReversal *model.CashLaunchReversal, modelAudit model.Audit) (model.CashLaunches, error) {
+	modelCashLaunch, err := useCaseCashLaunch.RepositoryCashLaunch.GetByID(modelCashLaunchReversal.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cashLaunchReversalValidate(modelCashLaunchReversal, modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunches := model.CashLaunches{*modelCashLaunch}
+
+	if modelCashLaunch.TransferID != 0 {
+		modelCashLaunches, err = useCaseCashLaunch.RepositoryCashLaunch.List(&model.CashLaunchFilter{
+			TransferID: modelCashLaunch.TransferID,
+			Sort:       "id",
+			Order:      "asc",
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the reversed launches are changed as well, so both periods must be open
+	referenceDates := []time.Time{modelCashLaunchReversal.ReferenceDate}
+
+	for _, modelCashLaunchReversed := range modelCashLaunches {
+		referenceDates = append(referenceDates, modelCashLaunchReversed.ReferenceDate)
+	}
+
+	err = cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, referenceDates...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now().UTC()
+	modelCashLaunchReversals := model.CashLaunches{}
+
+	for _, modelCashLaunchReversed := range modelCashLaunches {
+		modelCashLaunchReversals = append(modelCashLaunchReversals, model.CashLaunch{
+			AccountID:     modelCashLaunchReversed.AccountID,
+			CategoryID:    modelCashLaunchReversed.CategoryID,
+			TransferID:    modelCashLaunchReversed.TransferID,
+			ReversalOfID:  modelCashLaunchReversed.ID,
+			ReferenceDate: modelCashLaunchReversal.ReferenceDate,
+			Type:          cashLaunchReversalType(modelCashLaunchReversed.Type),
+			Description:   modelCashLaunchReversal.Description,
+			Value:         modelCashLaunchReversed.Value,
+			Currency:      modelCashLaunchReversed.Currency,
+			ExchangeRate:  modelCashLaunchReversed.ExchangeRate,
+			BaseValue:     modelCashLaunchReversed.BaseValue,
+			Status:        repository.CashLaunchStatusApproved,
+			UpdatedAt:     createdAt,
+			CreatedAt:     createdAt,
+		})
+	}
+
+	modelCashLaunchReversals, err = useCaseCashLaunch.RepositoryCashLaunch.Reverse(modelCashLaunchReversals, modelAudit)
+
+	// the launch reversed meanwhile
+	if _, ok := err.(repository.ErrDuplicateKey); ok {
+		return nil, ErrModelValidate{Message: CashLaunchReversalMessageReversedError}
+	}
+
+	return modelCashLaunchReversals, cashPeriodRepositoryError(err)
+}
+
+// cashLaunchReversalValidate checks the launch can be reversed and applies the
+// default date and description of the reversal
+func cashLaunchReversalValidate(modelCashLaunchReversal *model.CashLaunchReversal, modelCashLaunch *model.CashLaunch) error {
+	if modelCashLaunch.DeletedAt != nil {
+		return ErrModelValidate{Message: CashLaunchMessageDeletedError}
+	}
+
+	if modelCashLaunch.ReversedByID != 0 {
+		return ErrModelValidate{Message: CashLaunchReversalMessageReversedError}
+	}
+
+	if modelCashLaunch.ReversalOfID != 0 {
+		return ErrModelValidate{Message: CashLaunchReversalMessageReversalError}
+	}
+
+	// only the launches counted in the balances are offset
+	if modelCashLaunch.Status != repository.CashLaunchStatusApproved {
+		return ErrModelValidate{Message: CashLaunchReviewMessageNotApprovedError}
+	}
+
+	messages := []string{}
+
+	if modelCashLaunchReversal.ReferenceDate.IsZero() {
+		modelCashLaunchReversal.ReferenceDate = cashRecurrenceDate(time.Now().UTC())
+	}
+
+	err := cashLaunchReferenceDateValidate(modelCashLaunchReversal.ReferenceDate)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	} else if modelCashLaunchReversal.ReferenceDate.Before(modelCashLaunch.ReferenceDate) {
+		messages = append(messages, CashLaunchReversalMessageReferenceDateError)
+	}
+
+	modelCashLaunchReversal.Description = util.FormatTitle(modelCashLaunchReversal.Description)
+
+	if modelCashLaunchReversal.Description == "" {
+		description := CashLaunchReversalDescriptionPrefix + modelCashLaunch.Description
+
+		// cut whole characters so the description keeps valid utf-8
+		for len(description) > CashLaunchDescriptionMaxLen {
+			_, size := utf8.DecodeLastRuneInString(description)
+			description = description[:len(description)-size]
+		}
+
+		modelCashLaunchReversal.Description = strings.TrimSpace(description)
+	} else if len(modelCashLaunchReversal.Description) < CashLaunchDescriptionMinLen ||
+		len(modelCashLaunchReversal.Description) > CashLaunchDescriptionMaxLen {
+		messages = append(messages, CashLaunchMessageDescriptionSizeError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func cashLaunchReversalType(launchType string) string {
+	if launchType == "C" {
+		return "D"
+	}
+
+	return "C"
+}
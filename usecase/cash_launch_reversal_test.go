@@ -0,0 +1,169 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashLaunchReverse(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryInMemory.CashBalanceDaily())
+
+	referenceDate := time.Date(2015, 03, 10, 00, 00, 00, 000, time.UTC)
+	balanceDate := referenceDate.AddDate(0, 0, 10)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		CategoryID:    2,
+		ReferenceDate: referenceDate,
+		Type:          "D",
+		Description:   "compra estornada",
+		Value:         decimal.RequireFromString("50"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(balanceDate, 1, false, false)
+	assert.Nil(t, err)
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: 999}, modelAuditDefault)
+	assert.Equal(t, repository.ErrNotFound{Message: "not found"}, err)
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID, ReferenceDate: referenceDate.AddDate(0, 0, -1)}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReversalMessageReferenceDateError}, err)
+
+	resultCashLaunches, err := usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID, ReferenceDate: referenceDate.AddDate(0, 0, 5)}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 1)
+
+	modelCashLaunchReversal := resultCashLaunches[0]
+	assert.Equal(t, modelCashLaunch.ID, modelCashLaunchReversal.ReversalOfID)
+	assert.Equal(t, modelCashLaunch.AccountID, modelCashLaunchReversal.AccountID)
+	assert.Equal(t, modelCashLaunch.CategoryID, modelCashLaunchReversal.CategoryID)
+	assert.Equal(t, "C", modelCashLaunchReversal.Type)
+	assert.Equal(t, "ESTORNO COMPRA ESTORNADA", modelCashLaunchReversal.Description)
+	assert.True(t, modelCashLaunch.BaseValue.Equal(modelCashLaunchReversal.BaseValue))
+
+	resultCashLaunch, err := usecaseCashLaunch.GetByID(modelCashLaunch.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashLaunchReversal.ID, resultCashLaunch.ReversedByID)
+
+	// the reversal offsets the launch in the balance
+	resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(balanceDate, 1, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("50")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReversalMessageReversedError}, err)
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunchReversal.ID}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReversalMessageReversalError}, err)
+
+	// the launches linked to a reversal are kept as they are
+	modelCashLaunchUpdate := *modelCashLaunch
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchMessageReversalLinkedError}, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunchReversal.ID, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchMessageReversalLinkedError}, err)
+}
+
+func TestCashLaunchReverseTransfer(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashTransfer := usecase.NewCashTransfer(repositoryInMemory.CashTransfer(), repositoryInMemory.CashAccount(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault)
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryInMemory.CashBalanceDaily())
+
+	referenceDate := time.Date(2015, 04, 10, 00, 00, 00, 000, time.UTC)
+
+	modelCashTransfer := *modelCashTransferDefault
+	modelCashTransfer.ReferenceDate = referenceDate
+
+	resultCashTransfer, err := usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+	assert.Nil(t, err)
+
+	resultCashLaunches, err := usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: resultCashTransfer.Credit.ID, ReferenceDate: referenceDate}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 2)
+
+	// both sides are reversed under a new transfer
+	assert.Equal(t, resultCashTransfer.Debit.ID, resultCashLaunches[0].ReversalOfID)
+	assert.Equal(t, "C", resultCashLaunches[0].Type)
+	assert.Equal(t, resultCashTransfer.Credit.ID, resultCashLaunches[1].ReversalOfID)
+	assert.Equal(t, "D", resultCashLaunches[1].Type)
+	assert.NotZero(t, resultCashLaunches[0].TransferID)
+	assert.NotEqual(t, resultCashTransfer.ID, resultCashLaunches[0].TransferID)
+	assert.Equal(t, resultCashLaunches[0].TransferID, resultCashLaunches[1].TransferID)
+
+	for _, accountID := range []int64{0, modelCashTransfer.FromAccountID, modelCashTransfer.ToAccountID} {
+		resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, accountID, false, false)
+		assert.Nil(t, err)
+		assert.True(t, resultCashBalanceDaily.Value.IsZero(), "account %v", accountID)
+	}
+}
+
+func TestCashLaunchDeleteByIDSoft(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryInMemory.CashBalanceDaily())
+
+	referenceDate := time.Date(2015, 05, 10, 00, 00, 00, 000, time.UTC)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDate,
+		Type:          "C",
+		Description:   "credito excluido",
+		Value:         decimal.RequireFromString("30"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// the deleted launch is kept
+	resultCashLaunch, err := usecaseCashLaunch.GetByID(modelCashLaunch.ID)
+	assert.Nil(t, err)
+	assert.NotNil(t, resultCashLaunch.DeletedAt)
+
+	modelCashLaunchFilter := model.CashLaunchFilter{ReferenceDateFrom: referenceDate, ReferenceDateTo: referenceDate}
+
+	resultCashLaunches, _, err := usecaseCashLaunch.List(&modelCashLaunchFilter)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 0)
+
+	modelCashLaunchFilter.IncludeDeleted = true
+
+	resultCashLaunches, _, err = usecaseCashLaunch.List(&modelCashLaunchFilter)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 1)
+
+	resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+	assert.Nil(t, err)
+	assert.True(t, resultCashBalanceDaily.TotalCredit.IsZero())
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, true, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "30", resultCashBalanceDaily.TotalCredit.String())
+
+	resultCashBalanceDailies, err := usecaseCashBalanceDaily.GetByRangeReferenceDate(&model.CashBalanceDailyRangeReferenceDate{From: referenceDate, To: referenceDate, AccountID: 1})
+	assert.Nil(t, err)
+	assert.Len(t, resultCashBalanceDailies, 0)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+	assert.Equal(t, repository.ErrNotFound{Message: "not found"}, err)
+
+	modelCashLaunchUpdate := *modelCashLaunch
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchMessageDeletedError}, err)
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchMessageDeletedError}, err)
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CashLaunchReviewCommentMaxLen = 255
+
+	CashLaunchReviewMessageNotPendingError    = "The launch is not pending"
+	CashLaunchReviewMessageNotApprovedError   = "The launch is not approved"
+	CashLaunchReviewMessageStatusInvalidError = "The status not in ['approved', 'rejected']"
+	CashLaunchReviewMessageCommentEmptyError  = "The comment is empty"
+	CashLaunchReviewMessageCommentSizeError   = fmt.Sprintf("The comment size is greater than %v", CashLaunchReviewCommentMaxLen)
+
+	CashLaunchMessageListStatusInvalidError = "The param status not in ['pending', 'approved', 'rejected']"
+)
+
+// CashLaunchApprovalThresholds stores by launch type the base value above
+// which the launch waits for approval, a type without threshold is approved
+type CashLaunchApprovalThresholds map[string]decimal.Decimal
+
+// CashLaunchApprovalThresholdsParse reads the thresholds in the TYPE:VALUE
+// format separated by semicolon, as D:10000;C:50000
+func CashLaunchApprovalThresholdsParse(value string) (CashLaunchApprovalThresholds, error) {
+	approvalThresholds := CashLaunchApprovalThresholds{}
+
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+
+		if item == "" {
+			continue
+		}
+
+		fields := strings.Split(item, ":")
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("the approval threshold %v is not in the TYPE:VALUE format", item)
+		}
+
+		launchType := strings.ToUpper(strings.TrimSpace(fields[0]))
+
+		if launchType != "C" && launchType != "D" {
+			return nil, fmt.Errorf("the type of the approval threshold %v not in ['C', 'D']", item)
+		}
+
+		threshold, err := decimal.NewFromString(strings.TrimSpace(fields[1]))
+
+		if err != nil || !threshold.IsPositive() {
+			return nil, fmt.Errorf("the value of the approval threshold %v is not a positive number", item)
+		}
+
+		approvalThresholds[launchType] = threshold
+	}
+
+	return approvalThresholds, nil
+}
+
+// Review approves or rejects a launch pending approval, the approved launch
+// starts to count in the balances and the rejected one never counts
+func (useCaseCashLaunch *UseCaseCashLaunch) Review(modelCashLaunchReview *model.CashLaunchReview, modelAudit model.Audit) (*model.CashLaunch, error) {
+	err := cashLaunchReviewValidate(modelCashLaunchReview)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunch, err := useCaseCashLaunch.RepositoryCashLaunch.GetByID(modelCashLaunchReview.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashLaunch.DeletedAt != nil {
+		return nil, ErrModelValidate{Message: CashLaunchMessageDeletedError}
+	}
+
+	if modelCashLaunch.Status != repository.CashLaunchStatusPending {
+		return nil, ErrModelValidate{Message: CashLaunchReviewMessageNotPendingError}
+	}
+
+	err = cashPeriodOpenValidate(useCaseCashLaunch.RepositoryCashPeriod, modelCashLaunch.ReferenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reviewedAt := time.Now().UTC()
+
+	modelCashLaunch, err = useCaseCashLaunch.RepositoryCashLaunch.Review(&model.CashLaunch{
+		ID:            modelCashLaunchReview.ID,
+		Status:        modelCashLaunchReview.Status,
+		ReviewComment: modelCashLaunchReview.Comment,
+		ReviewedBy:    modelAudit.Actor,
+		ReviewedAt:    &reviewedAt,
+	}, modelAudit)
+
+	// the launch reviewed or deleted meanwhile
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return nil, ErrModelValidate{Message: CashLaunchReviewMessageNotPendingError}
+	}
+
+	return modelCashLaunch, cashPeriodRepositoryError(err)
+}
+
+// cashLaunchReviewValidate checks the status of the review, the rejection
+// must be commented
+func cashLaunchReviewValidate(modelCashLaunchReview *model.CashLaunchReview) error {
+	messages := []string{}
+
+	modelCashLaunchReview.Comment = strings.TrimSpace(modelCashLaunchReview.Comment)
+
+	if modelCashLaunchReview.Status != repository.CashLaunchStatusApproved && modelCashLaunchReview.Status != repository.CashLaunchStatusRejected {
+		messages = append(messages, CashLaunchReviewMessageStatusInvalidError)
+	}
+
+	if modelCashLaunchReview.Status == repository.CashLaunchStatusRejected && modelCashLaunchReview.Comment == "" {
+		messages = append(messages, CashLaunchReviewMessageCommentEmptyError)
+	} else if len(modelCashLaunchReview.Comment) > CashLaunchReviewCommentMaxLen {
+		messages = append(messages, CashLaunchReviewMessageCommentSizeError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+// cashLaunchStatusApply sets the launch pending when its base value is above
+// the threshold of its type, otherwise approved. A change keeps an approved
+// launch approved while its type and base value are the same and sends a
+// rejected launch back to pending, and the review is kept only while the
+// status does not change. The transfers are always approved.
+func cashLaunchStatusApply(approvalThresholds CashLaunchApprovalThresholds, modelCashLaunch *model.CashLaunch, modelCashLaunchCurrent *model.CashLaunch) {
+	status := repository.CashLaunchStatusApproved
+	threshold, ok := approvalThresholds[modelCashLaunch.Type]
+
+	if ok && modelCashLaunch.TransferID == 0 && modelCashLaunch.BaseValue.GreaterThan(threshold) {
+		status = repository.CashLaunchStatusPending
+
+		if modelCashLaunchCurrent != nil &&
+			modelCashLaunchCurrent.Status == repository.CashLaunchStatusApproved &&
+			modelCashLaunchCurrent.Type == modelCashLaunch.Type &&
+			modelCashLaunchCurrent.BaseValue.Equal(modelCashLaunch.BaseValue) {
+			status = repository.CashLaunchStatusApproved
+		}
+	}
+
+	// a rejected launch is approved only by a new review
+	if modelCashLaunchCurrent != nil && modelCashLaunchCurrent.Status == repository.CashLaunchStatusRejected && modelCashLaunch.TransferID == 0 {
+		status = repository.CashLaunchStatusPending
+	}
+
+	modelCashLaunch.Status = status
+	modelCashLaunch.ReviewComment = ""
+	modelCashLaunch.ReviewedBy = ""
+	modelCashLaunch.ReviewedAt = nil
+
+	if modelCashLaunchCurrent != nil && modelCashLaunchCurrent.Status == status {
+		modelCashLaunch.ReviewComment = modelCashLaunchCurrent.ReviewComment
+		modelCashLaunch.ReviewedBy = modelCashLaunchCurrent.ReviewedBy
+		modelCashLaunch.ReviewedAt = modelCashLaunchCurrent.ReviewedAt
+	}
+}
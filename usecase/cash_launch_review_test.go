@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashLaunchApprovalThresholdsParse(t *testing.T) {
+	approvalThresholds, err := usecase.CashLaunchApprovalThresholdsParse(" d:10000 ; C:50000.50;")
+	assert.Nil(t, err)
+	assert.Len(t, approvalThresholds, 2)
+	assert.Equal(t, "10000", approvalThresholds["D"].String())
+	assert.Equal(t, "50000.5", approvalThresholds["C"].String())
+
+	approvalThresholds, err = usecase.CashLaunchApprovalThresholdsParse("")
+	assert.Nil(t, err)
+	assert.Len(t, approvalThresholds, 0)
+
+	for _, value := range []string{"D", "X:100", "D:abc", "C:0", "D:-10", "D:1:2"} {
+		_, err = usecase.CashLaunchApprovalThresholdsParse(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestCashLaunchReview(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	approvalThresholds := usecase.CashLaunchApprovalThresholds{"D": decimal.RequireFromString("1000")}
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, approvalThresholds)
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repositoryInMemory.CashBalanceDaily())
+
+	referenceDate := time.Date(2007, 4, 10, 0, 0, 0, 0, time.UTC)
+	modelAuditReviewer := model.Audit{Actor: "maria", RequestID: "request-review"}
+
+	modelCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+	assert.Nil(t, err)
+
+	// the debit above the threshold waits for approval, the credit has no threshold
+	modelCashLaunchPending, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDate,
+		Type:          "D",
+		Description:   "compra de equipamento",
+		Value:         decimal.RequireFromString("1500"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusPending, modelCashLaunchPending.Status)
+
+	modelCashLaunchApproved, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDate,
+		Type:          "C",
+		Description:   "venda de equipamento",
+		Value:         decimal.RequireFromString("2000"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusApproved, modelCashLaunchApproved.Status)
+
+	// only the approved launches count by default, the projected balance
+	// includes the pending ones
+	resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("2000")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, true)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("500")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	resultCashLaunches, _, err := usecaseCashLaunch.List(&model.CashLaunchFilter{Status: "PENDING", ReferenceDateFrom: referenceDate, ReferenceDateTo: referenceDate})
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 1)
+	assert.Equal(t, modelCashLaunchPending.ID, resultCashLaunches[0].ID)
+
+	_, _, err = usecaseCashLaunch.List(&model.CashLaunchFilter{Status: "waiting"})
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashLaunchMessageListStatusInvalidError}, err)
+
+	// the pending launch is not reversed
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunchPending.ID}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReviewMessageNotApprovedError}, err)
+
+	// the rejection must be commented
+	_, err = usecaseCashLaunch.Review(&model.CashLaunchReview{ID: modelCashLaunchPending.ID, Status: repository.CashLaunchStatusRejected}, modelAuditReviewer)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReviewMessageCommentEmptyError}, err)
+
+	_, err = usecaseCashLaunch.Review(&model.CashLaunchReview{ID: modelCashLaunchApproved.ID, Status: repository.CashLaunchStatusApproved}, modelAuditReviewer)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashLaunchReviewMessageNotPendingError}, err)
+
+	_, err = usecaseCashLaunch.Review(&model.CashLaunchReview{ID: 999, Status: repository.CashLaunchStatusApproved}, modelAuditReviewer)
+	assert.Equal(t, repository.ErrNotFound{Message: "not found"}, err)
+
+	resultCashLaunch, err := usecaseCashLaunch.Review(&model.CashLaunchReview{ID: modelCashLaunchPending.ID, Status: repository.CashLaunchStatusApproved, Comment: " conferido "}, modelAuditReviewer)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusApproved, resultCashLaunch.Status)
+	assert.Equal(t, "conferido", resultCashLaunch.ReviewComment)
+	assert.Equal(t, modelAuditReviewer.Actor, resultCashLaunch.ReviewedBy)
+	assert.NotNil(t, resultCashLaunch.ReviewedAt)
+
+	// the approved launch counts in the balance
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("500")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	resultCashLaunchAudits, err := usecaseCashLaunch.ListAuditByID(modelCashLaunchPending.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchAuditActionApproval, resultCashLaunchAudits[len(resultCashLaunchAudits)-1].Action)
+
+	// a change of the description keeps the approval, a change of the value
+	// above the threshold waits for a new approval
+	modelCashLaunchUpdate := *resultCashLaunch
+	modelCashLaunchUpdate.Description = "compra de equipamento novo"
+
+	resultCashLaunch, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusApproved, resultCashLaunch.Status)
+	assert.Equal(t, modelAuditReviewer.Actor, resultCashLaunch.ReviewedBy)
+
+	modelCashLaunchUpdate = *resultCashLaunch
+	modelCashLaunchUpdate.Value = decimal.RequireFromString("1800")
+
+	resultCashLaunch, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusPending, resultCashLaunch.Status)
+	assert.Equal(t, "", resultCashLaunch.ReviewedBy)
+
+	// the rejected launch never counts in the balances
+	resultCashLaunch, err = usecaseCashLaunch.Review(&model.CashLaunchReview{ID: modelCashLaunchPending.ID, Status: repository.CashLaunchStatusRejected, Comment: "sem nota fiscal"}, modelAuditReviewer)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusRejected, resultCashLaunch.Status)
+	assert.Equal(t, "sem nota fiscal", resultCashLaunch.ReviewComment)
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, true)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("2000")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	// a change of the rejected launch below the threshold waits for a new
+	// review
+	modelCashLaunchUpdate = *resultCashLaunch
+	modelCashLaunchUpdate.Value = decimal.RequireFromString("100")
+
+	resultCashLaunch, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.CashLaunchStatusPending, resultCashLaunch.Status)
+	assert.Equal(t, "", resultCashLaunch.ReviewComment)
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 1, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.Add(decimal.RequireFromString("2000")).String(), resultCashBalanceDaily.ClosingBalance.String())
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunchPending.ID, modelAuditDefault)
+	assert.Nil(t, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunchApproved.ID, modelAuditDefault)
+	assert.Nil(t, err)
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashLaunchError := usecase.NewCashLaunch(repositoryInMemoryError.CashLaunch(), repositoryInMemoryError.CashAccount(), repositoryInMemoryError.CashCategory(), repositoryInMemoryError.CashCategoryRule(), repositoryInMemoryError.CashInstallment(), repositoryInMemoryError.CashPeriod(), repositoryInMemoryError.ExchangeRate(), baseCurrencyDefault, approvalThresholds)
+
+	_, err = usecaseCashLaunchError.Review(&model.CashLaunchReview{ID: modelCashLaunchPending.ID, Status: repository.CashLaunchStatusApproved}, modelAuditReviewer)
+	assert.NotNil(t, err)
+}
@@ -5,19 +5,24 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
 	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
 	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
+var baseCurrencyDefault = "BRL"
+var modelAuditDefault = model.Audit{Actor: "test", RequestID: "test"}
 var modelCashLaunchDefault = &model.CashLaunch{
+	AccountID:     1,
 	ReferenceDate: usecase.CashLaunchReferenceDateMin,
 	Type:          "c",
 	Description:   "Description Test",
-	Value:         0.01,
+	Value:         decimal.RequireFromString("0.01"),
 }
 
 func TestCashLaunchInsert(t *testing.T) {
@@ -30,9 +35,55 @@ func TestCashLaunchInsert(t *testing.T) {
 	}
 
 	tests := []test{
+		{
+			name: "AccountIDEmptyError",
+			inputCashLaunch: &model.CashLaunch{
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageAccountIDEmptyError},
+		},
+		{
+			name: "AccountNotFoundError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     999,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageAccountNotFoundError},
+		},
+		{
+			name: "CategoryIDInvalidError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				CategoryID:    -1,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageCategoryIDInvalidError},
+		},
+		{
+			name: "CategoryNotFoundError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				CategoryID:    999,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageCategoryNotFoundError},
+		},
 		{
 			name: "ReferenceDateEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:   modelCashLaunchDefault.AccountID,
 				Type:        modelCashLaunchDefault.Type,
 				Description: modelCashLaunchDefault.Description,
 				Value:       modelCashLaunchDefault.Value,
@@ -42,6 +93,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "ReferenceDateBeforeError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMin.AddDate(0, 0, -1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -52,6 +104,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "ReferenceDateAfterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMax.AddDate(0, 0, 1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -62,6 +115,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "TypeEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "",
 				Description:   modelCashLaunchDefault.Description,
@@ -72,6 +126,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "TypeInvalidError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "CC",
 				Description:   modelCashLaunchDefault.Description,
@@ -82,6 +137,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Value:         modelCashLaunchDefault.Value,
@@ -91,6 +147,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionSizeLessError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", modelCashLaunchDefault.Description[0:usecase.CashLaunchDescriptionMinLen-1]),
@@ -101,6 +158,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "DescriptionSizeGreaterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", strings.Repeat(modelCashLaunchDefault.Description, 10)[0:usecase.CashLaunchDescriptionMaxLen+1]),
@@ -111,6 +169,7 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "ValueZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -120,13 +179,99 @@ func TestCashLaunchInsert(t *testing.T) {
 		{
 			name: "ValueLessZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
-				Value:         -0.01,
+				Value:         decimal.RequireFromString("-0.01"),
 			},
 			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageValueError},
 		},
+		{
+			name: "CurrencyInvalidError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+				Currency:      "US$",
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageCurrencyInvalidError},
+		},
+		{
+			name: "ExchangeRateNotFoundError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				ReferenceDate: time.Date(2000, 11, 20, 00, 00, 00, 000, time.UTC),
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+				Currency:      "usd",
+			},
+			wantError: usecase.ErrModelValidate{Message: fmt.Sprintf(usecase.CashLaunchMessageExchangeRateNotFoundError, "USD", baseCurrencyDefault)},
+		},
+		{
+			name: "ExchangeRateCurrencyNotFoundError",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				ReferenceDate: time.Date(2012, 03, 05, 00, 00, 00, 000, time.UTC),
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         modelCashLaunchDefault.Value,
+				Currency:      "JPY",
+			},
+			wantError: usecase.ErrModelValidate{Message: fmt.Sprintf(usecase.CashLaunchMessageExchangeRateNotFoundError, "JPY", baseCurrencyDefault)},
+		},
+		{
+			name: "SuccessCurrency",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				ReferenceDate: time.Date(2012, 03, 05, 00, 00, 00, 000, time.UTC),
+				Type:          modelCashLaunchDefault.Type,
+				Description:   modelCashLaunchDefault.Description,
+				Value:         decimal.RequireFromString("100"),
+				Currency:      "USD",
+			},
+			assert: func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashLaunch)
+
+				// the latest rates on or before the reference date are crossed through EUR
+				assert.Equal(t, "USD", resultCashLaunch.Currency)
+				assert.Equal(t, "1.977647", resultCashLaunch.ExchangeRate.String())
+				assert.Equal(t, "197.76", resultCashLaunch.BaseValue.String())
+			},
+		},
+		{
+			name: "CategoryRuleSuccess",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          "d",
+				Description:   "pagamento aluguel",
+				Value:         modelCashLaunchDefault.Value,
+			},
+			assert: func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(2), resultCashLaunch.CategoryID)
+			},
+		},
+		{
+			name: "CategoryRuleSkipSuccess",
+			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
+				CategoryID:    1,
+				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
+				Type:          "d",
+				Description:   "pagamento aluguel",
+				Value:         modelCashLaunchDefault.Value,
+			},
+			assert: func(t *testing.T, tt *test, resultCashLaunch *model.CashLaunch, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), resultCashLaunch.CategoryID)
+			},
+		},
 		{
 			name:            "Success",
 			inputCashLaunch: modelCashLaunchDefault,
@@ -143,6 +288,9 @@ func TestCashLaunchInsert(t *testing.T) {
 				assert.Equal(t, tt.inputCashLaunch.Type, resultCashLaunch.Type)
 				assert.Equal(t, tt.inputCashLaunch.Description, resultCashLaunch.Description)
 				assert.Equal(t, tt.inputCashLaunch.Value, resultCashLaunch.Value)
+				assert.Equal(t, baseCurrencyDefault, resultCashLaunch.Currency)
+				assert.Equal(t, "1", resultCashLaunch.ExchangeRate.String())
+				assert.Equal(t, tt.inputCashLaunch.Value, resultCashLaunch.BaseValue)
 				assert.NotEqual(t, tt.inputCashLaunch.UpdatedAt, resultCashLaunch.UpdatedAt)
 				assert.NotEqual(t, tt.inputCashLaunch.CreatedAt, resultCashLaunch.CreatedAt)
 			},
@@ -153,11 +301,11 @@ func TestCashLaunchInsert(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository, _ := repository_in_memory.NewInMemory(false)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 
 			modelCashLaunch := *tt.inputCashLaunch
 
-			resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch)
+			resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
 
 			if tt.assert != nil {
 				tt.assert(t, &tt, resultCashLaunch, err)
@@ -166,7 +314,7 @@ func TestCashLaunchInsert(t *testing.T) {
 					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
 				}
 
-				if !reflect.DeepEqual(resultCashLaunch, tt.wantCashLaunch) {
+				if !equalJSON(tt.wantCashLaunch, resultCashLaunch) {
 					t.Errorf("Insert() got result = %v, want = %v.", resultCashLaunch, tt.wantCashLaunch)
 				}
 			}
@@ -175,17 +323,114 @@ func TestCashLaunchInsert(t *testing.T) {
 }
 
 func TestCashLaunchList(t *testing.T) {
+	referenceDateFrom := time.Date(2000, 01, 01, 00, 00, 00, 000, time.UTC)
+	referenceDateTo := time.Date(2001, 12, 31, 00, 00, 00, 000, time.UTC)
+
 	type test struct {
 		name             string
-		wantCashLaunches model.CashLaunches
+		inputFilter      *model.CashLaunchFilter
+		wantCashLaunches []int64
+		wantNext         bool
 		wantError        error
-		assert           func(t *testing.T, tt *test, resultCashLaunches model.CashLaunches, err error)
+		assert           func(t *testing.T, tt *test, resultCashLaunches model.CashLaunches, next string, err error)
 	}
 
 	tests := []test{
 		{
-			name:             "Success",
-			wantCashLaunches: repository_in_memory.InMemoryCashLaunches,
+			name: "ParamValidateError",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateTo,
+				ReferenceDateTo:   referenceDateFrom,
+				Type:              "x",
+				ValueFrom:         decimal.NewNullDecimal(decimal.RequireFromString("2")),
+				ValueTo:           decimal.NewNullDecimal(decimal.RequireFromString("1")),
+				Description:       strings.Repeat("a", usecase.CashLaunchDescriptionMaxLen+1),
+				Sort:              "currency",
+				Order:             "up",
+				Limit:             usecase.CashLaunchListLimitMax + 1,
+			},
+			wantError: usecase.ErrParamValidate{Message: strings.Join([]string{
+				usecase.CashLaunchMessageListReferenceDateToSmallerFromError,
+				usecase.CashLaunchMessageListTypeInvalidError,
+				usecase.CashLaunchMessageListValueToSmallerFromError,
+				usecase.CashLaunchMessageListDescriptionSizeError,
+				usecase.CashLaunchMessageListSortInvalidError,
+				usecase.CashLaunchMessageListOrderInvalidError,
+				usecase.CashLaunchMessageListLimitError,
+			}, ";")},
+		},
+		{
+			name:        "NextInvalidError",
+			inputFilter: &model.CashLaunchFilter{Next: "invalid"},
+			wantError:   usecase.ErrParamValidate{Message: usecase.CashLaunchMessageListNextInvalidError},
+		},
+		{
+			name:        "Success",
+			inputFilter: &model.CashLaunchFilter{IncludeDeleted: true},
+			assert: func(t *testing.T, tt *test, resultCashLaunches model.CashLaunches, next string, err error) {
+				assert.Nil(t, err)
+				assert.Empty(t, next)
+				assert.Len(t, resultCashLaunches, len(repository_in_memory.InMemoryCashLaunches))
+
+				for idx := 1; idx < len(resultCashLaunches); idx++ {
+					previous, current := resultCashLaunches[idx-1], resultCashLaunches[idx]
+
+					assert.False(t, current.ReferenceDate.Before(previous.ReferenceDate))
+
+					if current.ReferenceDate.Equal(previous.ReferenceDate) {
+						assert.Greater(t, current.ID, previous.ID)
+					}
+				}
+			},
+		},
+		{
+			name: "SuccessFilterType",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateFrom,
+				ReferenceDateTo:   referenceDateTo,
+				Type:              " d ",
+			},
+			wantCashLaunches: []int64{3, 1},
+		},
+		{
+			name: "SuccessFilterValue",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateFrom,
+				ReferenceDateTo:   referenceDateTo,
+				ValueFrom:         decimal.NewNullDecimal(decimal.RequireFromString("900")),
+				ValueTo:           decimal.NewNullDecimal(decimal.RequireFromString("987.65")),
+			},
+			wantCashLaunches: []int64{2},
+		},
+		{
+			name: "SuccessFilterDescription",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateFrom,
+				ReferenceDateTo:   referenceDateTo,
+				Description:       "inmemory  1",
+			},
+			wantCashLaunches: []int64{3, 1},
+		},
+		{
+			name: "SuccessSortValueDesc",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateFrom,
+				ReferenceDateTo:   referenceDateTo,
+				Sort:              "Value",
+				Order:             "DESC",
+			},
+			wantCashLaunches: []int64{2, 3, 1},
+		},
+		{
+			name: "SuccessPagination",
+			inputFilter: &model.CashLaunchFilter{
+				ReferenceDateFrom: referenceDateFrom,
+				ReferenceDateTo:   referenceDateTo,
+				Sort:              "value",
+				Limit:             2,
+			},
+			wantCashLaunches: []int64{1, 3},
+			wantNext:         true,
 		},
 	}
 
@@ -193,25 +438,81 @@ func TestCashLaunchList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository, _ := repository_in_memory.NewInMemory(false)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 
-			resultCashLaunches, err := usecaseCashLaunch.List()
+			resultCashLaunches, next, err := usecaseCashLaunch.List(tt.inputFilter)
 
 			if tt.assert != nil {
-				tt.assert(t, &tt, resultCashLaunches, err)
+				tt.assert(t, &tt, resultCashLaunches, next, err)
 			} else {
 				if !reflect.DeepEqual(err, tt.wantError) {
 					t.Errorf("List() got error = %v, want = %v.", err, tt.wantError)
 				}
 
-				if !reflect.DeepEqual(resultCashLaunches, tt.wantCashLaunches) {
-					t.Errorf("List() got result = %v, want = %v.", resultCashLaunches, &tt.wantCashLaunches)
+				if !reflect.DeepEqual(cashLaunchIDs(resultCashLaunches), tt.wantCashLaunches) {
+					t.Errorf("List() got result = %v, want = %v.", cashLaunchIDs(resultCashLaunches), tt.wantCashLaunches)
+				}
+
+				if (next != "") != tt.wantNext {
+					t.Errorf("List() got next = %v, want = %v.", next, tt.wantNext)
 				}
 			}
 		})
 	}
 }
 
+func TestCashLaunchListNext(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashLaunchFilter := &model.CashLaunchFilter{
+		ReferenceDateFrom: time.Date(2000, 01, 01, 00, 00, 00, 000, time.UTC),
+		ReferenceDateTo:   time.Date(2001, 12, 31, 00, 00, 00, 000, time.UTC),
+		Sort:              "value",
+		Order:             "desc",
+		Limit:             1,
+	}
+
+	resultIDs := []int64{}
+
+	for page := 0; page < 5; page++ {
+		resultCashLaunches, next, err := usecaseCashLaunch.List(modelCashLaunchFilter)
+
+		assert.Nil(t, err)
+
+		resultIDs = append(resultIDs, cashLaunchIDs(resultCashLaunches)...)
+
+		if next == "" {
+			break
+		}
+
+		modelCashLaunchFilter.Next = next
+	}
+
+	assert.Equal(t, []int64{2, 3, 1}, resultIDs)
+
+	// the token is only valid with the sort and order that generated it
+	modelCashLaunchFilter.Order = "asc"
+
+	_, _, err := usecaseCashLaunch.List(modelCashLaunchFilter)
+
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashLaunchMessageListNextInvalidError}, err)
+}
+
+func cashLaunchIDs(modelCashLaunches model.CashLaunches) []int64 {
+	if modelCashLaunches == nil {
+		return nil
+	}
+
+	ids := []int64{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		ids = append(ids, modelCashLaunch.ID)
+	}
+
+	return ids
+}
+
 func TestCashLaunchGetByID(t *testing.T) {
 	type test struct {
 		name           string
@@ -236,7 +537,7 @@ func TestCashLaunchGetByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository, _ := repository_in_memory.NewInMemory(false)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 
 			resultCashLaunches, err := usecaseCashLaunch.GetByID(tt.inputID)
 
@@ -244,7 +545,7 @@ func TestCashLaunchGetByID(t *testing.T) {
 				t.Errorf("GetByID() got error = %v, want = %v.", err, tt.wantError)
 			}
 
-			if !reflect.DeepEqual(resultCashLaunches, tt.wantCashLaunch) {
+			if !equalJSON(tt.wantCashLaunch, resultCashLaunches) {
 				t.Errorf("GetByID() got result = %v, want = %v.", resultCashLaunches, &tt.wantCashLaunch)
 			}
 		})
@@ -264,6 +565,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "ReferenceDateEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:   modelCashLaunchDefault.AccountID,
 				Type:        modelCashLaunchDefault.Type,
 				Description: modelCashLaunchDefault.Description,
 				Value:       modelCashLaunchDefault.Value,
@@ -273,6 +575,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "ReferenceDateBeforeError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMin.AddDate(0, 0, -1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -283,6 +586,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "ReferenceDateAfterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: usecase.CashLaunchReferenceDateMax.AddDate(0, 0, 1),
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -293,6 +597,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "TypeEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "",
 				Description:   modelCashLaunchDefault.Description,
@@ -303,6 +608,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "TypeInvalidError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          "CC",
 				Description:   modelCashLaunchDefault.Description,
@@ -313,6 +619,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "DescriptionEmptyError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Value:         modelCashLaunchDefault.Value,
@@ -322,6 +629,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "DescriptionSizeLessError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", modelCashLaunchDefault.Description[0:usecase.CashLaunchDescriptionMinLen-1]),
@@ -332,6 +640,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "DescriptionSizeGreaterError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   fmt.Sprintf("%v ", strings.Repeat(modelCashLaunchDefault.Description, 10)[0:usecase.CashLaunchDescriptionMaxLen+1]),
@@ -342,6 +651,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "ValueZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -351,10 +661,11 @@ func TestCashLaunchUpdate(t *testing.T) {
 		{
 			name: "ValueLessZeroError",
 			inputCashLaunch: &model.CashLaunch{
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
-				Value:         -0.01,
+				Value:         decimal.RequireFromString("-0.01"),
 			},
 			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageValueError},
 		},
@@ -367,6 +678,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 			name: "Success",
 			inputCashLaunch: &model.CashLaunch{
 				ID:            1,
+				AccountID:     modelCashLaunchDefault.AccountID,
 				ReferenceDate: modelCashLaunchDefault.ReferenceDate,
 				Type:          modelCashLaunchDefault.Type,
 				Description:   modelCashLaunchDefault.Description,
@@ -395,11 +707,11 @@ func TestCashLaunchUpdate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository, _ := repository_in_memory.NewInMemory(false)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 
 			modelCashLaunch := *tt.inputCashLaunch
 
-			resultCashLaunch, err := usecaseCashLaunch.Update(&modelCashLaunch)
+			resultCashLaunch, err := usecaseCashLaunch.Update(&modelCashLaunch, modelAuditDefault)
 
 			if tt.assert != nil {
 				tt.assert(t, &tt, resultCashLaunch, err)
@@ -408,7 +720,7 @@ func TestCashLaunchUpdate(t *testing.T) {
 					t.Errorf("Update() got error = %v, want = %v.", err, tt.wantError)
 				}
 
-				if !reflect.DeepEqual(resultCashLaunch, tt.wantCashLaunch) {
+				if !equalJSON(tt.wantCashLaunch, resultCashLaunch) {
 					t.Errorf("Update() got result = %v, want = %v.", resultCashLaunch, tt.wantCashLaunch)
 				}
 			}
@@ -416,6 +728,81 @@ func TestCashLaunchUpdate(t *testing.T) {
 	}
 }
 
+func TestCashLaunchUpdateCurrencyKept(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     modelCashLaunchDefault.AccountID,
+		ReferenceDate: time.Date(2012, 03, 06, 00, 00, 00, 000, time.UTC),
+		Type:          "D",
+		Description:   "Currency Kept Test",
+		Value:         decimal.RequireFromString("10"),
+		Currency:      "USD",
+	}, modelAuditDefault)
+
+	assert.Nil(t, err)
+
+	// the currency not informed on update keeps the one of the launch
+	modelCashLaunchUpdate, err := usecaseCashLaunch.Update(&model.CashLaunch{
+		ID:            modelCashLaunch.ID,
+		AccountID:     modelCashLaunch.AccountID,
+		ReferenceDate: modelCashLaunch.ReferenceDate,
+		Type:          modelCashLaunch.Type,
+		Description:   "Currency Kept Test Updated",
+		Value:         decimal.RequireFromString("20"),
+	}, modelAuditDefault)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", modelCashLaunchUpdate.Currency)
+	assert.Equal(t, "1.977647", modelCashLaunchUpdate.ExchangeRate.String())
+	assert.Equal(t, "39.55", modelCashLaunchUpdate.BaseValue.String())
+}
+
+func TestCashLaunchInsertCategoryRuleBaseValue(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashCategoryRule, err := repository.CashCategoryRule().Insert(&model.CashCategoryRule{
+		CategoryID:  3,
+		Pattern:     "CAMBIO BASE",
+		PatternType: "substring",
+		Type:        "D",
+		ValueTo:     decimal.RequireFromString("150"),
+		Priority:    1,
+	})
+
+	assert.Nil(t, err)
+
+	defer repository.CashCategoryRule().DeleteByID(modelCashCategoryRule.ID)
+
+	// USD 100 are 197.76 in the base currency, above the limit of the rule
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     modelCashLaunchDefault.AccountID,
+		ReferenceDate: time.Date(2012, 03, 05, 00, 00, 00, 000, time.UTC),
+		Type:          "D",
+		Description:   "Cambio Base Acima",
+		Value:         decimal.RequireFromString("100"),
+		Currency:      "USD",
+	}, modelAuditDefault)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), modelCashLaunch.CategoryID)
+
+	// USD 70 are 138.44 in the base currency
+	modelCashLaunch, err = usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     modelCashLaunchDefault.AccountID,
+		ReferenceDate: time.Date(2012, 03, 05, 00, 00, 00, 000, time.UTC),
+		Type:          "D",
+		Description:   "Cambio Base Abaixo",
+		Value:         decimal.RequireFromString("70"),
+		Currency:      "USD",
+	}, modelAuditDefault)
+
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashCategoryRule.CategoryID, modelCashLaunch.CategoryID)
+}
+
 func TestCashLaunchDeleteByID(t *testing.T) {
 	type test struct {
 		name      string
@@ -438,9 +825,9 @@ func TestCashLaunchDeleteByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository, _ := repository_in_memory.NewInMemory(false)
 			repositoryCashLaunch := repository.CashLaunch()
-			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch)
+			usecaseCashLaunch := usecase.NewCashLaunch(repositoryCashLaunch, repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
 
-			err := usecaseCashLaunch.DeleteByID(tt.inputID)
+			err := usecaseCashLaunch.DeleteByID(tt.inputID, modelAuditDefault)
 
 			if !reflect.DeepEqual(err, tt.wantError) {
 				t.Errorf("Delete() got error = %v, want = %v.", err, tt.wantError)
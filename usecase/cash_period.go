@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+)
+
+var (
+	CashPeriodStatusOpen        = "open"
+	CashPeriodStatusClosed      = "closed"
+	CashPeriodListMonthsDefault = 12
+	CashPeriodListMonthsMax     = 120
+
+	CashPeriodMessageNotEndedError        = "The period is not ended"
+	CashPeriodMessageClosedError          = "The period is already closed"
+	CashPeriodMessageNotClosedError       = "The period is not closed"
+	CashPeriodMessageReopenForbiddenError = "The actor is not allowed to reopen a period"
+	CashPeriodMessageLaunchClosedError    = "The reference_date %v is in the closed period %v"
+
+	CashPeriodMessageListToSmallerFromError = "The param to is smaller the param from"
+	CashPeriodMessageListRangeError         = fmt.Sprintf("The range is greater than %v months", CashPeriodListMonthsMax)
+)
+
+type CashPeriod interface {
+	List(from time.Time, to time.Time) (model.CashPeriods, error)
+	Close(period time.Time, modelAudit model.Audit) (*model.CashPeriod, error)
+	Reopen(period time.Time, modelAudit model.Audit) (*model.CashPeriod, error)
+}
+
+type UseCaseCashPeriod struct {
+	RepositoryCashPeriod repository.CashPeriod
+	ReopenActors         []string
+}
+
+func NewCashPeriod(repositoryCashPeriod repository.CashPeriod, reopenActors []string) CashPeriod {
+	return &UseCaseCashPeriod{
+		RepositoryCashPeriod: repositoryCashPeriod,
+		ReopenActors:         reopenActors,
+	}
+}
+
+// List returns every month between from and to, by default the last 12 months
+// until the current one, the months never closed are returned as open
+func (useCaseCashPeriod *UseCaseCashPeriod) List(from time.Time, to time.Time) (model.CashPeriods, error) {
+	if to.IsZero() {
+		to = CashPeriodOf(time.Now().UTC())
+	}
+
+	if from.IsZero() {
+		from = to.AddDate(0, -(CashPeriodListMonthsDefault - 1), 0)
+	}
+
+	from = CashPeriodOf(from)
+	to = CashPeriodOf(to)
+
+	if to.Before(from) {
+		return nil, ErrParamValidate{Message: CashPeriodMessageListToSmallerFromError}
+	}
+
+	if !to.Before(from.AddDate(0, CashPeriodListMonthsMax, 0)) {
+		return nil, ErrParamValidate{Message: CashPeriodMessageListRangeError}
+	}
+
+	modelCashPeriodsStored, err := useCaseCashPeriod.RepositoryCashPeriod.List(from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashPeriods := model.CashPeriods{}
+	idx := 0
+
+	for period := from; !period.After(to); period = period.AddDate(0, 1, 0) {
+		if idx < len(modelCashPeriodsStored) && modelCashPeriodsStored[idx].Period.Equal(period) {
+			modelCashPeriods = append(modelCashPeriods, modelCashPeriodsStored[idx])
+			idx++
+			continue
+		}
+
+		modelCashPeriods = append(modelCashPeriods, model.CashPeriod{Period: period, Status: CashPeriodStatusOpen})
+	}
+
+	return modelCashPeriods, nil
+}
+
+// Close locks the launches of an ended month storing the closing balance of
+// all accounts on its last day
+func (useCaseCashPeriod *UseCaseCashPeriod) Close(period time.Time, modelAudit model.Audit) (*model.CashPeriod, error) {
+	period = CashPeriodOf(period)
+	periodEnd := period.AddDate(0, 1, -1)
+	closedAt := time.Now().UTC()
+
+	if !periodEnd.Before(cashRecurrenceDate(closedAt)) {
+		return nil, ErrModelValidate{Message: CashPeriodMessageNotEndedError}
+	}
+
+	modelCashPeriod, err := useCaseCashPeriod.RepositoryCashPeriod.Close(&model.CashPeriod{
+		Period:   period,
+		ClosedBy: modelAudit.Actor,
+		ClosedAt: &closedAt,
+	})
+
+	if _, ok := err.(repository.ErrDuplicateKey); ok {
+		return nil, ErrModelValidate{Message: CashPeriodMessageClosedError}
+	}
+
+	return modelCashPeriod, err
+}
+
+// Reopen unlocks the launches of a closed month, only the actors configured
+// to reopen a period are allowed
+func (useCaseCashPeriod *UseCaseCashPeriod) Reopen(period time.Time, modelAudit model.Audit) (*model.CashPeriod, error) {
+	allowed := false
+
+	for _, actor := range useCaseCashPeriod.ReopenActors {
+		if actor != "" && actor == modelAudit.Actor {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return nil, ErrForbidden{Message: CashPeriodMessageReopenForbiddenError}
+	}
+
+	reopenedAt := time.Now().UTC()
+
+	modelCashPeriod, err := useCaseCashPeriod.RepositoryCashPeriod.Reopen(&model.CashPeriod{
+		Period:     CashPeriodOf(period),
+		ReopenedBy: modelAudit.Actor,
+		ReopenedAt: &reopenedAt,
+	})
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return nil, ErrModelValidate{Message: CashPeriodMessageNotClosedError}
+	}
+
+	return modelCashPeriod, err
+}
+
+// CashPeriodOf returns the period of the date, the first day of its month
+func CashPeriodOf(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// cashPeriodOpenValidate checks none of the reference dates falls in a closed
+// period
+func cashPeriodOpenValidate(repositoryCashPeriod repository.CashPeriod, referenceDates ...time.Time) error {
+	for _, referenceDate := range referenceDates {
+		period := CashPeriodOf(referenceDate)
+
+		modelCashPeriod, err := repositoryCashPeriod.GetByPeriod(period)
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if modelCashPeriod.Status == CashPeriodStatusClosed {
+			return ErrPeriodClosed{Message: fmt.Sprintf(CashPeriodMessageLaunchClosedError, referenceDate.Format("2006-01-02"), period.Format("2006-01"))}
+		}
+	}
+
+	return nil
+}
+
+// cashPeriodRepositoryError returns the closed period error raised by the
+// repository, for a period closed after cashPeriodOpenValidate, as ErrPeriodClosed
+func cashPeriodRepositoryError(err error) error {
+	if errPeriodClosed, ok := err.(repository.ErrPeriodClosed); ok {
+		return ErrPeriodClosed{Message: errPeriodClosed.Message}
+	}
+
+	return err
+}
@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var cashPeriodReopenActorsDefault = []string{"admin"}
+
+func TestCashPeriodList(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashPeriod := usecase.NewCashPeriod(repositoryInMemory.CashPeriod(), cashPeriodReopenActorsDefault)
+
+	_, err := usecaseCashPeriod.Close(time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC), modelAuditDefault)
+	assert.Nil(t, err)
+
+	// the months never closed are listed as open
+	resultCashPeriods, err := usecaseCashPeriod.List(time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2014, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashPeriods, 3)
+
+	for idx, wantStatus := range []string{usecase.CashPeriodStatusOpen, usecase.CashPeriodStatusClosed, usecase.CashPeriodStatusOpen} {
+		assert.Equal(t, time.Date(2014, time.Month(idx+1), 1, 0, 0, 0, 0, time.UTC), resultCashPeriods[idx].Period)
+		assert.Equal(t, wantStatus, resultCashPeriods[idx].Status)
+	}
+
+	// the last 12 months by default
+	resultCashPeriods, err = usecaseCashPeriod.List(time.Time{}, time.Time{})
+	assert.Nil(t, err)
+	assert.Len(t, resultCashPeriods, usecase.CashPeriodListMonthsDefault)
+	assert.Equal(t, usecase.CashPeriodOf(time.Now().UTC()), resultCashPeriods[len(resultCashPeriods)-1].Period)
+
+	_, err = usecaseCashPeriod.List(time.Date(2014, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashPeriodMessageListToSmallerFromError}, err)
+
+	_, err = usecaseCashPeriod.List(time.Date(2004, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashPeriodMessageListRangeError}, err)
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashPeriodError := usecase.NewCashPeriod(repositoryInMemoryError.CashPeriod(), cashPeriodReopenActorsDefault)
+
+	_, err = usecaseCashPeriodError.List(time.Time{}, time.Time{})
+	assert.NotNil(t, err)
+}
+
+func TestCashPeriodCloseReopen(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashPeriod := usecase.NewCashPeriod(repositoryInMemory.CashPeriod(), cashPeriodReopenActorsDefault)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashTransfer := usecase.NewCashTransfer(repositoryInMemory.CashTransfer(), repositoryInMemory.CashAccount(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault)
+
+	period := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
+	referenceDateClosed := time.Date(2015, 3, 10, 0, 0, 0, 0, time.UTC)
+	referenceDateOpen := time.Date(2015, 4, 10, 0, 0, 0, 0, time.UTC)
+	modelAuditAdmin := model.Audit{Actor: "admin", RequestID: "request-reopen"}
+	errPeriodClosed := usecase.ErrPeriodClosed{Message: "The reference_date 2015-03-10 is in the closed period 2015-03"}
+
+	// the current month is not ended yet
+	_, err := usecaseCashPeriod.Close(time.Now().UTC(), modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashPeriodMessageNotEndedError}, err)
+
+	modelCashLaunch, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDateClosed,
+		Type:          "C",
+		Description:   "venda fechada",
+		Value:         decimal.RequireFromString("30"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashLaunchOpen, err := usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDateOpen,
+		Type:          "C",
+		Description:   "venda aberta",
+		Value:         decimal.RequireFromString("40"),
+	}, modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashBalanceDaily, err := repositoryInMemory.CashBalanceDaily().GetByReferenceDate(time.Date(2015, 3, 31, 0, 0, 0, 0, time.UTC), 0, false, false)
+	assert.Nil(t, err)
+
+	// the closing balance of the last day is stored
+	resultCashPeriod, err := usecaseCashPeriod.Close(referenceDateClosed, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, period, resultCashPeriod.Period)
+	assert.Equal(t, usecase.CashPeriodStatusClosed, resultCashPeriod.Status)
+	assert.Equal(t, modelCashBalanceDaily.ClosingBalance.String(), resultCashPeriod.ClosingBalance.String())
+	assert.Equal(t, modelAuditDefault.Actor, resultCashPeriod.ClosedBy)
+	assert.NotNil(t, resultCashPeriod.ClosedAt)
+
+	_, err = usecaseCashPeriod.Close(period, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashPeriodMessageClosedError}, err)
+
+	// no launch changes in the closed period
+	_, err = usecaseCashLaunch.Insert(&model.CashLaunch{
+		AccountID:     1,
+		ReferenceDate: referenceDateClosed,
+		Type:          "D",
+		Description:   "compra fechada",
+		Value:         decimal.RequireFromString("10"),
+	}, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	modelCashLaunchUpdate := *modelCashLaunch
+	modelCashLaunchUpdate.ReferenceDate = referenceDateOpen
+
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	modelCashLaunchUpdate = *modelCashLaunchOpen
+	modelCashLaunchUpdate.ReferenceDate = referenceDateClosed
+
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	_, err = usecaseCashLaunch.Reverse(&model.CashLaunchReversal{ID: modelCashLaunch.ID, ReferenceDate: referenceDateOpen}, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	modelCashTransfer := *modelCashTransferDefault
+	modelCashTransfer.ReferenceDate = referenceDateClosed
+
+	_, err = usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+	assert.Equal(t, errPeriodClosed, err)
+
+	// the open period is still changed
+	modelCashLaunchUpdate = *modelCashLaunchOpen
+	modelCashLaunchUpdate.Value = decimal.RequireFromString("45")
+
+	_, err = usecaseCashLaunch.Update(&modelCashLaunchUpdate, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// only the privileged actors reopen
+	_, err = usecaseCashPeriod.Reopen(period, modelAuditDefault)
+	assert.Equal(t, usecase.ErrForbidden{Message: usecase.CashPeriodMessageReopenForbiddenError}, err)
+
+	resultCashPeriod, err = usecaseCashPeriod.Reopen(period, modelAuditAdmin)
+	assert.Nil(t, err)
+	assert.Equal(t, usecase.CashPeriodStatusOpen, resultCashPeriod.Status)
+	assert.Equal(t, modelAuditAdmin.Actor, resultCashPeriod.ReopenedBy)
+	assert.NotNil(t, resultCashPeriod.ReopenedAt)
+
+	_, err = usecaseCashPeriod.Reopen(period, modelAuditAdmin)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashPeriodMessageNotClosedError}, err)
+
+	_, err = usecaseCashPeriod.Reopen(time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC), modelAuditAdmin)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashPeriodMessageNotClosedError}, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+	assert.Nil(t, err)
+
+	err = usecaseCashLaunch.DeleteByID(modelCashLaunchOpen.ID, modelAuditDefault)
+	assert.Nil(t, err)
+}
+
+func TestCashPeriodMaterialize(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashPeriod := usecase.NewCashPeriod(repositoryInMemory.CashPeriod(), cashPeriodReopenActorsDefault)
+	usecaseCashRecurrence := usecase.NewCashRecurrence(repositoryInMemory.CashRecurrence(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	_, err := usecaseCashPeriod.Close(time.Date(2013, 6, 1, 0, 0, 0, 0, time.UTC), modelAuditDefault)
+	assert.Nil(t, err)
+
+	modelCashRecurrence, err := usecaseCashRecurrence.Insert(&model.CashRecurrence{
+		AccountID:   1,
+		Type:        "D",
+		Description: "ASSINATURA",
+		Value:       decimal.RequireFromString("50"),
+		Frequency:   "monthly",
+		Day:         10,
+		StartDate:   time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2013, 7, 31, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+
+	// the occurrence in the closed period is skipped
+	resultCashLaunches, err := usecaseCashRecurrence.Materialize(time.Date(2013, 8, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	resultReferenceDates := []time.Time{}
+
+	for _, resultCashLaunch := range resultCashLaunches {
+		if resultCashLaunch.RecurrenceID == modelCashRecurrence.ID {
+			resultReferenceDates = append(resultReferenceDates, resultCashLaunch.ReferenceDate)
+		}
+	}
+
+	assert.Equal(t, []time.Time{time.Date(2013, 5, 10, 0, 0, 0, 0, time.UTC), time.Date(2013, 7, 10, 0, 0, 0, 0, time.UTC)}, resultReferenceDates)
+
+	err = usecaseCashRecurrence.DeleteByID(modelCashRecurrence.ID)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,676 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	launch_import "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+)
+
+var (
+	CashReconciliationDateWindowDefault    = 3
+	CashReconciliationDateWindowMax        = 31
+	CashReconciliationMinSimilarityDefault = 0.3
+	CashReconciliationRangeDaysMax         = 366
+
+	CashReconciliationMessageAccountIDEmptyError   = "The param account_id is empty"
+	CashReconciliationMessageAccountNotFoundError  = "The param account_id does not exist"
+	CashReconciliationMessageToSmallerFromError    = "The param to is smaller the param from"
+	CashReconciliationMessageRangeError            = fmt.Sprintf("The range is greater than %v days", CashReconciliationRangeDaysMax)
+	CashReconciliationMessageDateWindowError       = fmt.Sprintf("The param date_window is not between 0 and %v", CashReconciliationDateWindowMax)
+	CashReconciliationMessageMinSimilarityError    = "The param min_similarity is not between 0 and 1"
+	CashStatementLineMessageMatchedError           = "The statement line is already matched"
+	CashStatementLineMessageNotMatchedError        = "The statement line is not matched"
+	CashStatementLineMessageLaunchIDEmptyError     = "The launch_id is empty"
+	CashStatementLineMessageLaunchNotFoundError    = "The launch_id does not exist"
+	CashStatementLineMessageLaunchAccountError     = "The launch is not of the account of the statement line"
+	CashStatementLineMessageLaunchNotApprovedError = "The launch is not approved"
+	CashStatementLineMessageLaunchMatchedError     = "The launch is already matched to another statement line"
+	CashStatementLineMessageDescriptionEmptyError  = "The description is empty"
+	CashStatementLineMessageAccountIDEmptyError    = CashLaunchMessageAccountIDEmptyError
+	CashStatementLineMessageAccountNotFoundError   = CashLaunchMessageAccountNotFoundError
+	CashStatementLineMessageTypeInvalidError       = CashLaunchMessageTypeInvalidError
+	CashStatementLineMessageValueError             = CashLaunchMessageValueError
+	CashStatementLineMessageExternalIDSizeError    = CashLaunchMessageExternalIDSizeError
+	cashReconciliationDescriptionReplacer          = strings.NewReplacer("á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "é", "e", "è", "e", "ê", "e", "ë", "e", "í", "i", "ì", "i", "î", "i", "ï", "i", "ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o", "ú", "u", "ù", "u", "û", "u", "ü", "u", "ç", "c", "ñ", "n")
+)
+
+type CashReconciliation interface {
+	Import(launchImportReader launch_import.Reader) (*model.CashLaunchImportReport, error)
+	AutoMatch(modelCashReconciliationAutoMatch *model.CashReconciliationAutoMatch, modelAudit model.Audit) (model.CashReconciliationMatches, error)
+	Match(modelCashStatementLineMatch *model.CashStatementLineMatch, modelAudit model.Audit) (*model.CashStatementLine, error)
+	Unmatch(id int64) (*model.CashStatementLine, error)
+	Report(accountID int64, from time.Time, to time.Time) (*model.CashReconciliation, error)
+}
+
+type UseCaseCashReconciliation struct {
+	RepositoryCashStatementLine repository.CashStatementLine
+	RepositoryCashLaunch        repository.CashLaunch
+	RepositoryCashAccount       repository.CashAccount
+}
+
+func NewCashReconciliation(repositoryCashStatementLine repository.CashStatementLine, repositoryCashLaunch repository.CashLaunch, repositoryCashAccount repository.CashAccount) CashReconciliation {
+	return &UseCaseCashReconciliation{
+		RepositoryCashStatementLine: repositoryCashStatementLine,
+		RepositoryCashLaunch:        repositoryCashLaunch,
+		RepositoryCashAccount:       repositoryCashAccount,
+	}
+}
+
+// Import stores the lines of a statement apart from the launches, each valid
+// line on its own. A line with the external_id of a line of its account
+// already imported is reported as duplicate, so the same statement can be
+// imported again.
+func (useCaseCashReconciliation *UseCaseCashReconciliation) Import(launchImportReader launch_import.Reader) (*model.CashLaunchImportReport, error) {
+	modelCashLaunchImportReport := &model.CashLaunchImportReport{
+		Mode: CashLaunchImportModeBestEffort,
+		Rows: model.CashLaunchImportRows{},
+	}
+
+	accounts := map[int64]bool{}
+
+	for {
+		modelCashLaunchImportRecord, err := launchImportReader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		modelCashLaunchImportRow := model.CashLaunchImportRow{
+			Line:   modelCashLaunchImportRecord.Line,
+			Status: CashLaunchImportStatusImported,
+			Errors: modelCashLaunchImportRecord.Errors,
+		}
+
+		if modelCashLaunchImportRow.Errors == nil {
+			modelCashLaunchImportRow.Errors = []string{}
+		}
+
+		if modelCashLaunchImportRecord.Ignored {
+			modelCashLaunchImportRow.Status = CashLaunchImportStatusIgnored
+		} else if len(modelCashLaunchImportRow.Errors) == 0 {
+			modelCashStatementLine := cashStatementLineOf(&modelCashLaunchImportRecord.CashLaunch)
+
+			modelCashLaunchImportRow.Errors, err = useCaseCashReconciliation.cashStatementLineValidate(modelCashStatementLine, accounts)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(modelCashLaunchImportRow.Errors) == 0 {
+				modelCashStatementLineInsert, err := useCaseCashReconciliation.RepositoryCashStatementLine.Insert(modelCashStatementLine)
+
+				if _, ok := err.(repository.ErrDuplicateKey); ok {
+					modelCashLaunchImportRow.Status = CashLaunchImportStatusDuplicate
+				} else if err != nil {
+					return nil, err
+				} else {
+					modelCashLaunchImportRow.StatementLineID = modelCashStatementLineInsert.ID
+				}
+			}
+		}
+
+		if len(modelCashLaunchImportRow.Errors) > 0 && modelCashLaunchImportRow.Status == CashLaunchImportStatusImported {
+			modelCashLaunchImportRow.Status = CashLaunchImportStatusFailed
+		}
+
+		cashLaunchImportReportAppend(modelCashLaunchImportReport, modelCashLaunchImportRow)
+	}
+
+	return modelCashLaunchImportReport, nil
+}
+
+// AutoMatch matches the statement lines not matched of the account and range
+// to the approved launches not matched of the same type and value, with the
+// reference date within the date window and the description similarity at
+// least the minimum. The most similar pairs are matched first, then the
+// closest dates.
+func (useCaseCashReconciliation *UseCaseCashReconciliation) AutoMatch(modelCashReconciliationAutoMatch *model.CashReconciliationAutoMatch, modelAudit model.Audit) (model.CashReconciliationMatches, error) {
+	err := useCaseCashReconciliation.cashReconciliationAutoMatchValidate(modelCashReconciliationAutoMatch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountID := modelCashReconciliationAutoMatch.AccountID
+	dateWindow := modelCashReconciliationAutoMatch.DateWindow
+
+	modelCashStatementLines, err := useCaseCashReconciliation.RepositoryCashStatementLine.List(&model.CashStatementLineFilter{
+		AccountID:         accountID,
+		ReferenceDateFrom: modelCashReconciliationAutoMatch.From,
+		ReferenceDateTo:   modelCashReconciliationAutoMatch.To,
+		Unmatched:         true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashReconciliationMatches := model.CashReconciliationMatches{}
+
+	if len(modelCashStatementLines) == 0 {
+		return modelCashReconciliationMatches, nil
+	}
+
+	modelCashLaunches, err := useCaseCashReconciliation.cashReconciliationLaunchesUnmatched(
+		accountID,
+		modelCashReconciliationAutoMatch.From.AddDate(0, 0, -dateWindow),
+		modelCashReconciliationAutoMatch.To.AddDate(0, 0, dateWindow),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		lineIdx    int
+		launchIdx  int
+		similarity float64
+		days       int
+	}
+
+	candidates := []candidate{}
+
+	for lineIdx, modelCashStatementLine := range modelCashStatementLines {
+		for launchIdx, modelCashLaunch := range modelCashLaunches {
+			if modelCashLaunch.Type != modelCashStatementLine.Type || !modelCashLaunch.Value.Equal(modelCashStatementLine.Value) {
+				continue
+			}
+
+			days := int(modelCashLaunch.ReferenceDate.Sub(modelCashStatementLine.ReferenceDate).Hours() / 24)
+
+			if days < 0 {
+				days = -days
+			}
+
+			if days > dateWindow {
+				continue
+			}
+
+			similarity := CashReconciliationSimilarity(modelCashStatementLine.Description, modelCashLaunch.Description)
+
+			if similarity < modelCashReconciliationAutoMatch.MinSimilarity {
+				continue
+			}
+
+			candidates = append(candidates, candidate{lineIdx: lineIdx, launchIdx: launchIdx, similarity: similarity, days: days})
+		}
+	}
+
+	// the lines and the launches are in the order of the reference date and
+	// id, which breaks the ties
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].similarity != candidates[j].similarity {
+			return candidates[i].similarity > candidates[j].similarity
+		}
+
+		return candidates[i].days < candidates[j].days
+	})
+
+	linesMatched := map[int]bool{}
+	launchesMatched := map[int]bool{}
+	matchedAt := time.Now().UTC()
+
+	for _, candidate := range candidates {
+		if linesMatched[candidate.lineIdx] || launchesMatched[candidate.launchIdx] {
+			continue
+		}
+
+		modelCashStatementLine := modelCashStatementLines[candidate.lineIdx]
+		modelCashStatementLine.LaunchID = modelCashLaunches[candidate.launchIdx].ID
+		modelCashStatementLine.MatchType = repository.CashStatementLineMatchTypeAuto
+		modelCashStatementLine.MatchedBy = modelAudit.Actor
+		modelCashStatementLine.MatchedAt = &matchedAt
+
+		modelCashStatementLineMatch, err := useCaseCashReconciliation.RepositoryCashStatementLine.Match(&modelCashStatementLine)
+
+		// the line or the launch matched meanwhile is left as is
+		if _, ok := err.(repository.ErrNotFound); ok {
+			continue
+		}
+
+		if _, ok := err.(repository.ErrDuplicateKey); ok {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		linesMatched[candidate.lineIdx] = true
+		launchesMatched[candidate.launchIdx] = true
+
+		modelCashReconciliationMatches = append(modelCashReconciliationMatches, model.CashReconciliationMatch{
+			StatementLine: *modelCashStatementLineMatch,
+			Launch:        modelCashLaunches[candidate.launchIdx],
+			Similarity:    candidate.similarity,
+		})
+	}
+
+	sort.SliceStable(modelCashReconciliationMatches, func(i, j int) bool {
+		return cashStatementLineLess(&modelCashReconciliationMatches[i].StatementLine, &modelCashReconciliationMatches[j].StatementLine)
+	})
+
+	return modelCashReconciliationMatches, nil
+}
+
+// Match matches the statement line to an approved launch of its account, the
+// type, value and date are not checked as the manual match overrides them
+func (useCaseCashReconciliation *UseCaseCashReconciliation) Match(modelCashStatementLineMatch *model.CashStatementLineMatch, modelAudit model.Audit) (*model.CashStatementLine, error) {
+	if modelCashStatementLineMatch.LaunchID <= 0 {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageLaunchIDEmptyError}
+	}
+
+	modelCashStatementLine, err := useCaseCashReconciliation.RepositoryCashStatementLine.GetByID(modelCashStatementLineMatch.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashStatementLine.LaunchID != 0 {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageMatchedError}
+	}
+
+	modelCashLaunch, err := useCaseCashReconciliation.RepositoryCashLaunch.GetByID(modelCashStatementLineMatch.LaunchID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageLaunchNotFoundError}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []string{}
+
+	if modelCashLaunch.DeletedAt != nil {
+		messages = append(messages, CashLaunchMessageDeletedError)
+	}
+
+	if modelCashLaunch.AccountID != modelCashStatementLine.AccountID {
+		messages = append(messages, CashStatementLineMessageLaunchAccountError)
+	}
+
+	if modelCashLaunch.Status != repository.CashLaunchStatusApproved {
+		messages = append(messages, CashStatementLineMessageLaunchNotApprovedError)
+	}
+
+	if len(messages) > 0 {
+		return nil, ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	matchedAt := time.Now().UTC()
+
+	modelCashStatementLine.LaunchID = modelCashLaunch.ID
+	modelCashStatementLine.MatchType = repository.CashStatementLineMatchTypeManual
+	modelCashStatementLine.MatchedBy = modelAudit.Actor
+	modelCashStatementLine.MatchedAt = &matchedAt
+
+	modelCashStatementLine, err = useCaseCashReconciliation.RepositoryCashStatementLine.Match(modelCashStatementLine)
+
+	if _, ok := err.(repository.ErrDuplicateKey); ok {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageLaunchMatchedError}
+	}
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageMatchedError}
+	}
+
+	return modelCashStatementLine, err
+}
+
+// Unmatch undoes the match of the statement line, automatic or manual
+func (useCaseCashReconciliation *UseCaseCashReconciliation) Unmatch(id int64) (*model.CashStatementLine, error) {
+	modelCashStatementLine, err := useCaseCashReconciliation.RepositoryCashStatementLine.GetByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if modelCashStatementLine.LaunchID == 0 {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageNotMatchedError}
+	}
+
+	modelCashStatementLine, err = useCaseCashReconciliation.RepositoryCashStatementLine.Unmatch(id)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return nil, ErrModelValidate{Message: CashStatementLineMessageNotMatchedError}
+	}
+
+	return modelCashStatementLine, err
+}
+
+// Report returns the statement lines of the account and range matched with
+// their launches, the statement lines not matched and the approved launches
+// of the range not matched to any line
+func (useCaseCashReconciliation *UseCaseCashReconciliation) Report(accountID int64, from time.Time, to time.Time) (*model.CashReconciliation, error) {
+	err := useCaseCashReconciliation.cashReconciliationRangeValidate(accountID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashStatementLines, err := useCaseCashReconciliation.RepositoryCashStatementLine.List(&model.CashStatementLineFilter{
+		AccountID:         accountID,
+		ReferenceDateFrom: from,
+		ReferenceDateTo:   to,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunches, err := useCaseCashReconciliation.cashReconciliationLaunches(accountID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	launchesMatched, err := useCaseCashReconciliation.cashReconciliationLaunchIDsMatched(accountID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashReconciliation := &model.CashReconciliation{
+		AccountID:       accountID,
+		From:            from,
+		To:              to,
+		Matched:         model.CashReconciliationMatches{},
+		UnmatchedBank:   model.CashStatementLines{},
+		UnmatchedLedger: model.CashLaunches{},
+	}
+
+	modelCashLaunchesByID := map[int64]model.CashLaunch{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		modelCashLaunchesByID[modelCashLaunch.ID] = modelCashLaunch
+
+		if !launchesMatched[modelCashLaunch.ID] {
+			modelCashReconciliation.UnmatchedLedger = append(modelCashReconciliation.UnmatchedLedger, modelCashLaunch)
+		}
+	}
+
+	for _, modelCashStatementLine := range modelCashStatementLines {
+		if modelCashStatementLine.LaunchID == 0 {
+			modelCashReconciliation.UnmatchedBank = append(modelCashReconciliation.UnmatchedBank, modelCashStatementLine)
+			continue
+		}
+
+		// the launch out of the range, or no longer approved, is read by id
+		modelCashLaunch, ok := modelCashLaunchesByID[modelCashStatementLine.LaunchID]
+
+		if !ok {
+			modelCashLaunchMatched, err := useCaseCashReconciliation.RepositoryCashLaunch.GetByID(modelCashStatementLine.LaunchID)
+
+			if err != nil {
+				return nil, err
+			}
+
+			modelCashLaunch = *modelCashLaunchMatched
+		}
+
+		modelCashReconciliation.Matched = append(modelCashReconciliation.Matched, model.CashReconciliationMatch{
+			StatementLine: modelCashStatementLine,
+			Launch:        modelCashLaunch,
+		})
+	}
+
+	return modelCashReconciliation, nil
+}
+
+// CashReconciliationSimilarity returns the Dice coefficient of the bigrams of
+// the words of the descriptions, from 0 to 1, without the case, the accents
+// and the punctuation
+func CashReconciliationSimilarity(description string, other string) float64 {
+	bigrams := cashReconciliationBigrams(description)
+	otherBigrams := cashReconciliationBigrams(other)
+
+	total := 0
+
+	for _, count := range bigrams {
+		total += count
+	}
+
+	for _, count := range otherBigrams {
+		total += count
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	common := 0
+
+	for bigram, count := range bigrams {
+		otherCount := otherBigrams[bigram]
+
+		if otherCount < count {
+			count = otherCount
+		}
+
+		common += count
+	}
+
+	return float64(2*common) / float64(total)
+}
+
+// cashReconciliationBigrams counts the pairs of adjacent letters of each word,
+// a word of a single letter counts as itself
+func cashReconciliationBigrams(description string) map[string]int {
+	description = cashReconciliationDescriptionReplacer.Replace(strings.ToLower(description))
+
+	words := strings.FieldsFunc(description, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	bigrams := map[string]int{}
+
+	for _, word := range words {
+		runes := []rune(word)
+
+		if len(runes) == 1 {
+			bigrams[word]++
+			continue
+		}
+
+		for idx := 0; idx < len(runes)-1; idx++ {
+			bigrams[string(runes[idx:idx+2])]++
+		}
+	}
+
+	return bigrams
+}
+
+// cashReconciliationLaunches returns the approved launches of the account and
+// range
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashReconciliationLaunches(accountID int64, from time.Time, to time.Time) (model.CashLaunches, error) {
+	return cashLaunchListAll(useCaseCashReconciliation.RepositoryCashLaunch, &model.CashLaunchFilter{
+		AccountID:         accountID,
+		ReferenceDateFrom: from,
+		ReferenceDateTo:   to,
+		Status:            repository.CashLaunchStatusApproved,
+	})
+}
+
+// cashReconciliationLaunchesUnmatched returns the approved launches of the
+// account and range not matched to any statement line
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashReconciliationLaunchesUnmatched(accountID int64, from time.Time, to time.Time) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashReconciliation.cashReconciliationLaunches(accountID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	launchesMatched, err := useCaseCashReconciliation.cashReconciliationLaunchIDsMatched(accountID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunchesUnmatched := model.CashLaunches{}
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		if !launchesMatched[modelCashLaunch.ID] {
+			modelCashLaunchesUnmatched = append(modelCashLaunchesUnmatched, modelCashLaunch)
+		}
+	}
+
+	return modelCashLaunchesUnmatched, nil
+}
+
+// cashReconciliationLaunchIDsMatched returns the launches matched to a
+// statement line of the account, of any date
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashReconciliationLaunchIDsMatched(accountID int64) (map[int64]bool, error) {
+	modelCashStatementLines, err := useCaseCashReconciliation.RepositoryCashStatementLine.List(&model.CashStatementLineFilter{
+		AccountID: accountID,
+		Matched:   true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	launchesMatched := map[int64]bool{}
+
+	for _, modelCashStatementLine := range modelCashStatementLines {
+		launchesMatched[modelCashStatementLine.LaunchID] = true
+	}
+
+	return launchesMatched, nil
+}
+
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashReconciliationAutoMatchValidate(modelCashReconciliationAutoMatch *model.CashReconciliationAutoMatch) error {
+	messages := []string{}
+
+	if modelCashReconciliationAutoMatch.DateWindow < 0 || modelCashReconciliationAutoMatch.DateWindow > CashReconciliationDateWindowMax {
+		messages = append(messages, CashReconciliationMessageDateWindowError)
+	}
+
+	if modelCashReconciliationAutoMatch.MinSimilarity < 0 || modelCashReconciliationAutoMatch.MinSimilarity > 1 {
+		messages = append(messages, CashReconciliationMessageMinSimilarityError)
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return useCaseCashReconciliation.cashReconciliationRangeValidate(modelCashReconciliationAutoMatch.AccountID, modelCashReconciliationAutoMatch.From, modelCashReconciliationAutoMatch.To)
+}
+
+// cashReconciliationRangeValidate checks the account exists and the range
+// of the reconciliation
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashReconciliationRangeValidate(accountID int64, from time.Time, to time.Time) error {
+	messages := []string{}
+
+	if accountID <= 0 {
+		messages = append(messages, CashReconciliationMessageAccountIDEmptyError)
+	}
+
+	if to.Before(from) {
+		messages = append(messages, CashReconciliationMessageToSmallerFromError)
+	} else if to.Sub(from).Hours()/24 >= float64(CashReconciliationRangeDaysMax) {
+		messages = append(messages, CashReconciliationMessageRangeError)
+	}
+
+	if len(messages) > 0 {
+		return ErrParamValidate{Message: strings.Join(messages, ";")}
+	}
+
+	_, err := useCaseCashReconciliation.RepositoryCashAccount.GetByID(accountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrParamValidate{Message: CashReconciliationMessageAccountNotFoundError}
+	}
+
+	return err
+}
+
+// cashStatementLineValidate returns the validation errors of the line, the
+// accounts checked are kept in the map
+func (useCaseCashReconciliation *UseCaseCashReconciliation) cashStatementLineValidate(modelCashStatementLine *model.CashStatementLine, accounts map[int64]bool) ([]string, error) {
+	messages := []string{}
+
+	if modelCashStatementLine.AccountID <= 0 {
+		messages = append(messages, CashStatementLineMessageAccountIDEmptyError)
+	} else if _, ok := accounts[modelCashStatementLine.AccountID]; !ok {
+		_, err := useCaseCashReconciliation.RepositoryCashAccount.GetByID(modelCashStatementLine.AccountID)
+
+		if _, ok := err.(repository.ErrNotFound); ok {
+			accounts[modelCashStatementLine.AccountID] = false
+		} else if err != nil {
+			return nil, err
+		} else {
+			accounts[modelCashStatementLine.AccountID] = true
+		}
+	}
+
+	if modelCashStatementLine.AccountID > 0 && !accounts[modelCashStatementLine.AccountID] {
+		messages = append(messages, CashStatementLineMessageAccountNotFoundError)
+	}
+
+	err := cashLaunchReferenceDateValidate(modelCashStatementLine.ReferenceDate)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	}
+
+	if modelCashStatementLine.Type != "C" && modelCashStatementLine.Type != "D" {
+		messages = append(messages, CashStatementLineMessageTypeInvalidError)
+	}
+
+	if modelCashStatementLine.Description == "" {
+		messages = append(messages, CashStatementLineMessageDescriptionEmptyError)
+	}
+
+	if !modelCashStatementLine.Value.IsPositive() {
+		messages = append(messages, CashStatementLineMessageValueError)
+	}
+
+	if len(modelCashStatementLine.ExternalID) > CashLaunchExternalIDMaxLen {
+		messages = append(messages, CashStatementLineMessageExternalIDSizeError)
+	}
+
+	return messages, nil
+}
+
+// cashStatementLineOf returns the statement line of the launch read from the
+// file, the description longer than the column is cut
+func cashStatementLineOf(modelCashLaunch *model.CashLaunch) *model.CashStatementLine {
+	description := util.FormatTitle(modelCashLaunch.Description)
+
+	for len(description) > CashLaunchDescriptionMaxLen {
+		_, size := utf8.DecodeLastRuneInString(description)
+		description = description[:len(description)-size]
+	}
+
+	return &model.CashStatementLine{
+		AccountID:     modelCashLaunch.AccountID,
+		ExternalID:    modelCashLaunch.ExternalID,
+		ReferenceDate: modelCashLaunch.ReferenceDate,
+		Type:          util.FormatTextWithoutSpace(util.FormatTitle(modelCashLaunch.Type)),
+		Description:   strings.TrimSpace(description),
+		Value:         modelCashLaunch.Value.Round(2),
+	}
+}
+
+// cashStatementLineLess orders the lines by reference date and id
+func cashStatementLineLess(modelCashStatementLine *model.CashStatementLine, other *model.CashStatementLine) bool {
+	if !modelCashStatementLine.ReferenceDate.Equal(other.ReferenceDate) {
+		return modelCashStatementLine.ReferenceDate.Before(other.ReferenceDate)
+	}
+
+	return modelCashStatementLine.ID < other.ID
+}
@@ -0,0 +1,213 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	launch_import_csv "github.com/CharlesSchiavinato/minsait-challenge-backend/service/launch_import/csv"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var cashReconciliationStatementDefault = "account_id,reference_date,type,description,value,external_id\n" +
+	"2,2008-04-12,D,Pgto conta luz,120,S1\n" +
+	"2,2008-04-10,D,Aluguel abril,120.00,S2\n" +
+	"2,2008-04-21,C,Depósito cliente XPTO,500,S3\n" +
+	"2,2008-04-28,D,Tarifa,10,S4\n" +
+	"2,2008-04-28,X,Tarifa,10,S5\n" +
+	"99,2008-04-28,D,Tarifa,10,S6\n"
+
+func TestCashReconciliationSimilarity(t *testing.T) {
+	type test struct {
+		description string
+		other       string
+		want        float64
+	}
+
+	tests := []test{
+		{description: "CONTA DE LUZ", other: "conta de luz", want: 1},
+		{description: "Depósito, cliente", other: "DEPOSITO CLIENTE", want: 1},
+		{description: "PGTO CONTA LUZ", other: "CONTA DE LUZ", want: 0.75},
+		{description: "ALUGUEL", other: "TARIFA", want: 0},
+		{description: "", other: "TARIFA", want: 0},
+		{description: "", other: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, usecase.CashReconciliationSimilarity(tt.description, tt.other), 0.0001, tt.description+" x "+tt.other)
+	}
+}
+
+func TestCashReconciliation(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashLaunch := usecase.NewCashLaunch(repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashCategoryRule(), repositoryInMemory.CashInstallment(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+	usecaseCashReconciliation := usecase.NewCashReconciliation(repositoryInMemory.CashStatementLine(), repositoryInMemory.CashLaunch(), repositoryInMemory.CashAccount())
+
+	from := time.Date(2008, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2008, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	modelCashLaunchIDs := []int64{}
+
+	for _, modelCashLaunch := range []model.CashLaunch{
+		{AccountID: 2, ReferenceDate: time.Date(2008, 4, 10, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Conta de luz", Value: decimal.NewFromInt(120)},
+		{AccountID: 2, ReferenceDate: time.Date(2008, 4, 11, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Aluguel", Value: decimal.NewFromInt(120)},
+		{AccountID: 2, ReferenceDate: time.Date(2008, 4, 20, 0, 0, 0, 0, time.UTC), Type: "C", Description: "Deposito cliente XPTO", Value: decimal.NewFromInt(500)},
+		{AccountID: 2, ReferenceDate: time.Date(2008, 4, 25, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Padaria", Value: decimal.NewFromInt(50)},
+		{AccountID: 1, ReferenceDate: time.Date(2008, 4, 28, 0, 0, 0, 0, time.UTC), Type: "D", Description: "Tarifa", Value: decimal.NewFromInt(10)},
+	} {
+		resultCashLaunch, err := usecaseCashLaunch.Insert(&modelCashLaunch, modelAuditDefault)
+		assert.Nil(t, err)
+
+		modelCashLaunchIDs = append(modelCashLaunchIDs, resultCashLaunch.ID)
+	}
+
+	statementImport := func() *model.CashLaunchImportReport {
+		launchImportReader, err := launch_import_csv.NewCSV(strings.NewReader(cashReconciliationStatementDefault), &launch_import_csv.CSVOptions{Header: true})
+		assert.Nil(t, err)
+
+		modelCashLaunchImportReport, err := usecaseCashReconciliation.Import(launchImportReader)
+		assert.Nil(t, err)
+
+		return modelCashLaunchImportReport
+	}
+
+	// the valid lines are imported apart from the launches
+	modelCashLaunchImportReport := statementImport()
+	assert.Equal(t, usecase.CashLaunchImportModeBestEffort, modelCashLaunchImportReport.Mode)
+	assert.Equal(t, 6, modelCashLaunchImportReport.Total)
+	assert.Equal(t, 4, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 2, modelCashLaunchImportReport.Failed)
+	assert.Equal(t, []string{usecase.CashStatementLineMessageTypeInvalidError}, modelCashLaunchImportReport.Rows[4].Errors)
+	assert.Equal(t, []string{usecase.CashStatementLineMessageAccountNotFoundError}, modelCashLaunchImportReport.Rows[5].Errors)
+
+	statementLineIDs := []int64{}
+
+	for _, modelCashLaunchImportRow := range modelCashLaunchImportReport.Rows[:4] {
+		assert.Equal(t, int64(0), modelCashLaunchImportRow.LaunchID)
+		statementLineIDs = append(statementLineIDs, modelCashLaunchImportRow.StatementLineID)
+	}
+
+	resultCashLaunches, _, err := usecaseCashLaunch.List(&model.CashLaunchFilter{AccountID: 2, ReferenceDateFrom: from, ReferenceDateTo: to})
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 4)
+
+	// the statement imported again only reports the lines already imported
+	modelCashLaunchImportReport = statementImport()
+	assert.Equal(t, 0, modelCashLaunchImportReport.Imported)
+	assert.Equal(t, 4, modelCashLaunchImportReport.Duplicated)
+
+	_, err = usecaseCashReconciliation.AutoMatch(&model.CashReconciliationAutoMatch{AccountID: 2, From: from, To: to, DateWindow: 32, MinSimilarity: 2}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashReconciliationMessageDateWindowError + ";" + usecase.CashReconciliationMessageMinSimilarityError}, err)
+
+	_, err = usecaseCashReconciliation.AutoMatch(&model.CashReconciliationAutoMatch{AccountID: 99, From: from, To: to}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashReconciliationMessageAccountNotFoundError}, err)
+
+	// the same value of the first launches is told apart by the description
+	resultCashReconciliationMatches, err := usecaseCashReconciliation.AutoMatch(&model.CashReconciliationAutoMatch{
+		AccountID:     2,
+		From:          from,
+		To:            to,
+		DateWindow:    usecase.CashReconciliationDateWindowDefault,
+		MinSimilarity: usecase.CashReconciliationMinSimilarityDefault,
+	}, model.Audit{Actor: "maria"})
+	assert.Nil(t, err)
+	assert.Len(t, resultCashReconciliationMatches, 3)
+	assert.Equal(t, statementLineIDs[1], resultCashReconciliationMatches[0].StatementLine.ID)
+	assert.Equal(t, modelCashLaunchIDs[1], resultCashReconciliationMatches[0].Launch.ID)
+	assert.Equal(t, statementLineIDs[0], resultCashReconciliationMatches[1].StatementLine.ID)
+	assert.Equal(t, modelCashLaunchIDs[0], resultCashReconciliationMatches[1].Launch.ID)
+	assert.InDelta(t, 0.75, resultCashReconciliationMatches[1].Similarity, 0.0001)
+	assert.Equal(t, statementLineIDs[2], resultCashReconciliationMatches[2].StatementLine.ID)
+	assert.Equal(t, modelCashLaunchIDs[2], resultCashReconciliationMatches[2].Launch.ID)
+	assert.Equal(t, repository.CashStatementLineMatchTypeAuto, resultCashReconciliationMatches[2].StatementLine.MatchType)
+	assert.Equal(t, "maria", resultCashReconciliationMatches[2].StatementLine.MatchedBy)
+	assert.NotNil(t, resultCashReconciliationMatches[2].StatementLine.MatchedAt)
+
+	// the lines already matched are not matched again
+	resultCashReconciliationMatches, err = usecaseCashReconciliation.AutoMatch(&model.CashReconciliationAutoMatch{AccountID: 2, From: from, To: to, DateWindow: 31}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashReconciliationMatches, 0)
+
+	// the manual match and unmatch
+	_, err = usecaseCashReconciliation.Unmatch(statementLineIDs[3])
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageNotMatchedError}, err)
+
+	_, err = usecaseCashReconciliation.Unmatch(999999)
+	assert.IsType(t, repository.ErrNotFound{}, err)
+
+	resultCashStatementLine, err := usecaseCashReconciliation.Unmatch(statementLineIDs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), resultCashStatementLine.LaunchID)
+	assert.Equal(t, "", resultCashStatementLine.MatchType)
+	assert.Nil(t, resultCashStatementLine.MatchedAt)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[3]}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageLaunchIDEmptyError}, err)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: 999999, LaunchID: modelCashLaunchIDs[3]}, modelAuditDefault)
+	assert.IsType(t, repository.ErrNotFound{}, err)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[3], LaunchID: 999999}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageLaunchNotFoundError}, err)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[3], LaunchID: modelCashLaunchIDs[4]}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageLaunchAccountError}, err)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[3], LaunchID: modelCashLaunchIDs[1]}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageLaunchMatchedError}, err)
+
+	_, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[1], LaunchID: modelCashLaunchIDs[3]}, modelAuditDefault)
+	assert.Equal(t, usecase.ErrModelValidate{Message: usecase.CashStatementLineMessageMatchedError}, err)
+
+	// the value of the manual match is not checked
+	resultCashStatementLine, err = usecaseCashReconciliation.Match(&model.CashStatementLineMatch{ID: statementLineIDs[3], LaunchID: modelCashLaunchIDs[3]}, modelAuditDefault)
+	assert.Nil(t, err)
+	assert.Equal(t, modelCashLaunchIDs[3], resultCashStatementLine.LaunchID)
+	assert.Equal(t, repository.CashStatementLineMatchTypeManual, resultCashStatementLine.MatchType)
+	assert.Equal(t, modelAuditDefault.Actor, resultCashStatementLine.MatchedBy)
+
+	// the report of the account and range
+	_, err = usecaseCashReconciliation.Report(2, to, from)
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashReconciliationMessageToSmallerFromError}, err)
+
+	_, err = usecaseCashReconciliation.Report(0, from, from.AddDate(2, 0, 0))
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashReconciliationMessageAccountIDEmptyError + ";" + usecase.CashReconciliationMessageRangeError}, err)
+
+	resultCashReconciliation, err := usecaseCashReconciliation.Report(2, from, to)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashReconciliation.Matched, 3)
+	assert.Equal(t, statementLineIDs[1], resultCashReconciliation.Matched[0].StatementLine.ID)
+	assert.Equal(t, modelCashLaunchIDs[1], resultCashReconciliation.Matched[0].Launch.ID)
+	assert.Equal(t, modelCashLaunchIDs[3], resultCashReconciliation.Matched[2].Launch.ID)
+	assert.Len(t, resultCashReconciliation.UnmatchedBank, 1)
+	assert.Equal(t, statementLineIDs[0], resultCashReconciliation.UnmatchedBank[0].ID)
+	assert.Len(t, resultCashReconciliation.UnmatchedLedger, 1)
+	assert.Equal(t, modelCashLaunchIDs[0], resultCashReconciliation.UnmatchedLedger[0].ID)
+
+	// the launch out of the range is still reported with its line
+	resultCashReconciliation, err = usecaseCashReconciliation.Report(2, time.Date(2008, 4, 21, 0, 0, 0, 0, time.UTC), to)
+	assert.Nil(t, err)
+	assert.Len(t, resultCashReconciliation.Matched, 2)
+	assert.Equal(t, modelCashLaunchIDs[2], resultCashReconciliation.Matched[0].Launch.ID)
+	assert.Len(t, resultCashReconciliation.UnmatchedBank, 0)
+	assert.Len(t, resultCashReconciliation.UnmatchedLedger, 0)
+
+	for _, modelCashLaunchID := range modelCashLaunchIDs {
+		err = usecaseCashLaunch.DeleteByID(modelCashLaunchID, modelAuditDefault)
+		assert.Nil(t, err)
+	}
+
+	repositoryInMemoryError, _ := repository_in_memory.NewInMemory(true)
+	usecaseCashReconciliationError := usecase.NewCashReconciliation(repositoryInMemoryError.CashStatementLine(), repositoryInMemoryError.CashLaunch(), repositoryInMemoryError.CashAccount())
+
+	_, err = usecaseCashReconciliationError.Report(2, from, to)
+	assert.NotNil(t, err)
+
+	_, err = usecaseCashReconciliationError.Unmatch(statementLineIDs[1])
+	assert.NotNil(t, err)
+}
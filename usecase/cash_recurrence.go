@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/google/uuid"
+)
+
+var (
+	CashRecurrenceFrequencies = []string{"daily", "weekly", "monthly", "last_business_day"}
+	CashRecurrenceDayMin      = 1
+	CashRecurrenceDayMax      = 31
+
+	CashRecurrenceMessageFrequencyInvalidError    = fmt.Sprintf("The frequency not in ['%v']", strings.Join(CashRecurrenceFrequencies, "', '"))
+	CashRecurrenceMessageDayError                 = fmt.Sprintf("The day is not between %v and %v", CashRecurrenceDayMin, CashRecurrenceDayMax)
+	CashRecurrenceMessageStartDateEmptyError      = "The start_date is empty"
+	CashRecurrenceMessageStartDateBetweenError    = fmt.Sprintf("The start_date value is not between %v and %v", CashLaunchReferenceDateMin, CashLaunchReferenceDateMax)
+	CashRecurrenceMessageEndDateSmallerStartError = "The end_date is smaller the start_date"
+)
+
+type CashRecurrence interface {
+	Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error)
+	List() (model.CashRecurrences, error)
+	GetByID(id int64) (*model.CashRecurrence, error)
+	Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error)
+	DeleteByID(id int64) error
+	Materialize(now time.Time) (model.CashLaunches, error)
+}
+
+type UseCaseCashRecurrence struct {
+	RepositoryCashRecurrence repository.CashRecurrence
+	RepositoryCashAccount    repository.CashAccount
+	RepositoryCashCategory   repository.CashCategory
+	RepositoryCashPeriod     repository.CashPeriod
+	RepositoryExchangeRate   repository.ExchangeRate
+	BaseCurrency             string
+	ApprovalThresholds       CashLaunchApprovalThresholds
+}
+
+func NewCashRecurrence(repositoryCashRecurrence repository.CashRecurrence, repositoryCashAccount repository.CashAccount, repositoryCashCategory repository.CashCategory, repositoryCashPeriod repository.CashPeriod, repositoryExchangeRate repository.ExchangeRate, baseCurrency string, approvalThresholds CashLaunchApprovalThresholds) CashRecurrence {
+	return &UseCaseCashRecurrence{
+		RepositoryCashRecurrence: repositoryCashRecurrence,
+		RepositoryCashAccount:    repositoryCashAccount,
+		RepositoryCashCategory:   repositoryCashCategory,
+		RepositoryCashPeriod:     repositoryCashPeriod,
+		RepositoryExchangeRate:   repositoryExchangeRate,
+		BaseCurrency:             baseCurrency,
+		ApprovalThresholds:       approvalThresholds,
+	}
+}
+
+func (useCaseCashRecurrence *UseCaseCashRecurrence) Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	err := useCaseCashRecurrence.cashRecurrenceValidate(modelCashRecurrence)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashRecurrence.LastDate = time.Time{}
+	modelCashRecurrence.CreatedAt = time.Now().UTC()
+	modelCashRecurrence.UpdatedAt = modelCashRecurrence.CreatedAt
+
+	return useCaseCashRecurrence.RepositoryCashRecurrence.Insert(modelCashRecurrence)
+}
+
+func (useCaseCashRecurrence *UseCaseCashRecurrence) List() (model.CashRecurrences, error) {
+	return useCaseCashRecurrence.RepositoryCashRecurrence.List()
+}
+
+func (useCaseCashRecurrence *UseCaseCashRecurrence) GetByID(id int64) (*model.CashRecurrence, error) {
+	return useCaseCashRecurrence.RepositoryCashRecurrence.GetByID(id)
+}
+
+// Update changes the template for the next occurrences, the launches already
+// created are not changed
+func (useCaseCashRecurrence *UseCaseCashRecurrence) Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	err := useCaseCashRecurrence.cashRecurrenceValidate(modelCashRecurrence)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashRecurrence.UpdatedAt = time.Now().UTC()
+
+	return useCaseCashRecurrence.RepositoryCashRecurrence.Update(modelCashRecurrence)
+}
+
+// DeleteByID stops the template, the launches already created are kept
+func (useCaseCashRecurrence *UseCaseCashRecurrence) DeleteByID(id int64) error {
+	return useCaseCashRecurrence.RepositoryCashRecurrence.DeleteByID(id)
+}
+
+// Materialize creates the launches of every occurrence due until now that
+// comes after the last_date of its template, which also backfills the
+// occurrences missed while the server was down. A template that fails stops
+// on the failed occurrence to be retried on the next run while the others
+// go on, the first error is returned with the launches created. The
+// occurrences in a closed period are skipped. The launches are audited to the
+// system actor under a request id of the run.
+func (useCaseCashRecurrence *UseCaseCashRecurrence) Materialize(now time.Time) (model.CashLaunches, error) {
+	modelCashRecurrences, err := useCaseCashRecurrence.RepositoryCashRecurrence.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	today := cashRecurrenceDate(now.UTC())
+	modelAudit := model.Audit{Actor: AuditActorSystem, RequestID: uuid.New().String()}
+	modelCashLaunches := model.CashLaunches{}
+	var errFirst error
+
+	for idx := range modelCashRecurrences {
+		modelCashRecurrence := &modelCashRecurrences[idx]
+
+		after := modelCashRecurrence.LastDate
+
+		if after.IsZero() {
+			after = modelCashRecurrence.StartDate.AddDate(0, 0, -1)
+		}
+
+		for referenceDate := CashRecurrenceNextDate(modelCashRecurrence, after); !referenceDate.After(today) &&
+			(modelCashRecurrence.EndDate.IsZero() || !referenceDate.After(modelCashRecurrence.EndDate)); referenceDate = CashRecurrenceNextDate(modelCashRecurrence, referenceDate) {
+			modelCashLaunch, err := useCaseCashRecurrence.cashRecurrenceLaunchInsert(modelCashRecurrence, referenceDate, modelAudit)
+
+			if err != nil {
+				// the occurrence was created by another run
+				if _, ok := err.(repository.ErrDuplicateKey); ok {
+					continue
+				}
+
+				if _, ok := err.(ErrPeriodClosed); ok {
+					continue
+				}
+
+				if errFirst == nil {
+					errFirst = fmt.Errorf("recurrence %v on %v: %w", modelCashRecurrence.ID, referenceDate.Format("2006-01-02"), err)
+				}
+
+				break
+			}
+
+			modelCashLaunches = append(modelCashLaunches, *modelCashLaunch)
+		}
+	}
+
+	return modelCashLaunches, errFirst
+}
+
+func (useCaseCashRecurrence *UseCaseCashRecurrence) cashRecurrenceLaunchInsert(modelCashRecurrence *model.CashRecurrence, referenceDate time.Time, modelAudit model.Audit) (*model.CashLaunch, error) {
+	modelCashLaunch := &model.CashLaunch{
+		AccountID:     modelCashRecurrence.AccountID,
+		CategoryID:    modelCashRecurrence.CategoryID,
+		RecurrenceID:  modelCashRecurrence.ID,
+		ReferenceDate: referenceDate,
+		Type:          modelCashRecurrence.Type,
+		Description:   modelCashRecurrence.Description,
+		Value:         modelCashRecurrence.Value,
+		Currency:      modelCashRecurrence.Currency,
+	}
+
+	err := cashPeriodOpenValidate(useCaseCashRecurrence.RepositoryCashPeriod, referenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cashLaunchExchangeRateApply(useCaseCashRecurrence.RepositoryExchangeRate, useCaseCashRecurrence.BaseCurrency, modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the occurrences follow the same approval policy of the launches
+	cashLaunchStatusApply(useCaseCashRecurrence.ApprovalThresholds, modelCashLaunch, nil)
+
+	modelCashLaunch.CreatedAt = time.Now().UTC()
+	modelCashLaunch.UpdatedAt = modelCashLaunch.CreatedAt
+
+	modelCashLaunch, err = useCaseCashRecurrence.RepositoryCashRecurrence.LaunchInsert(modelCashLaunch, modelAudit)
+
+	return modelCashLaunch, cashPeriodRepositoryError(err)
+}
+
+// cashRecurrenceValidate validates the launch payload, the recurrence and
+// the account and category of the template
+func (useCaseCashRecurrence *UseCaseCashRecurrence) cashRecurrenceValidate(modelCashRecurrence *model.CashRecurrence) error {
+	if modelCashRecurrence.Currency == "" {
+		modelCashRecurrence.Currency = useCaseCashRecurrence.BaseCurrency
+	}
+
+	err := cashRecurrenceModelValidate(modelCashRecurrence)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = useCaseCashRecurrence.RepositoryCashAccount.GetByID(modelCashRecurrence.AccountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageAccountNotFoundError}
+	} else if err != nil {
+		return err
+	}
+
+	if modelCashRecurrence.CategoryID == 0 {
+		return nil
+	}
+
+	_, err = useCaseCashRecurrence.RepositoryCashCategory.GetByID(modelCashRecurrence.CategoryID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: CashLaunchMessageCategoryNotFoundError}
+	}
+
+	return err
+}
+
+// CashRecurrenceNextDate returns the first occurrence of the template after
+// the date and not before the start_date. The monthly occurrence on a day
+// beyond the end of the month falls on the last day of the month and the
+// last business day only skips the weekends.
+func CashRecurrenceNextDate(modelCashRecurrence *model.CashRecurrence, after time.Time) time.Time {
+	startDate := cashRecurrenceDate(modelCashRecurrence.StartDate)
+	after = cashRecurrenceDate(after)
+
+	if after.Before(startDate) {
+		after = startDate.AddDate(0, 0, -1)
+	}
+
+	switch modelCashRecurrence.Frequency {
+	case "daily":
+		return after.AddDate(0, 0, 1)
+	case "weekly":
+		weeks := int(after.Sub(startDate).Hours()/24)/7 + 1
+
+		if after.Before(startDate) {
+			weeks = 0
+		}
+
+		return startDate.AddDate(0, 0, 7*weeks)
+	}
+
+	// the candidate of the month of the next day or else of the month after
+	month := time.Date(after.Year(), after.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for {
+		candidate := cashRecurrenceMonthDate(modelCashRecurrence, month)
+
+		if candidate.After(after) {
+			return candidate
+		}
+
+		month = month.AddDate(0, 1, 0)
+	}
+}
+
+// cashRecurrenceMonthDate returns the occurrence of the monthly frequencies
+// in the month
+func cashRecurrenceMonthDate(modelCashRecurrence *model.CashRecurrence, month time.Time) time.Time {
+	lastDay := time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+
+	if modelCashRecurrence.Frequency == "monthly" {
+		if modelCashRecurrence.Day < lastDay.Day() {
+			return time.Date(month.Year(), month.Month(), modelCashRecurrence.Day, 0, 0, 0, 0, time.UTC)
+		}
+
+		return lastDay
+	}
+
+	for lastDay.Weekday() == time.Saturday || lastDay.Weekday() == time.Sunday {
+		lastDay = lastDay.AddDate(0, 0, -1)
+	}
+
+	return lastDay
+}
+
+// cashRecurrenceDate truncates the time to the date
+func cashRecurrenceDate(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func cashRecurrenceModelValidate(modelCashRecurrence *model.CashRecurrence) error {
+	messages := []string{}
+
+	CashRecurrenceModelFormat(modelCashRecurrence)
+
+	if modelCashRecurrence.AccountID <= 0 {
+		messages = append(messages, CashLaunchMessageAccountIDEmptyError)
+	}
+
+	if modelCashRecurrence.CategoryID < 0 {
+		messages = append(messages, CashLaunchMessageCategoryIDInvalidError)
+	}
+
+	if modelCashRecurrence.Type == "" {
+		messages = append(messages, CashLaunchMessageTypeEmptyError)
+	} else if modelCashRecurrence.Type != "C" && modelCashRecurrence.Type != "D" {
+		messages = append(messages, CashLaunchMessageTypeInvalidError)
+	}
+
+	if modelCashRecurrence.Description == "" {
+		messages = append(messages, CashLaunchMessageDescriptionEmptyError)
+	} else if len(modelCashRecurrence.Description) < CashLaunchDescriptionMinLen ||
+		len(modelCashRecurrence.Description) > CashLaunchDescriptionMaxLen {
+		messages = append(messages, CashLaunchMessageDescriptionSizeError)
+	}
+
+	if !modelCashRecurrence.Value.IsPositive() {
+		messages = append(messages, CashLaunchMessageValueError)
+	}
+
+	if !CurrencyValidate(modelCashRecurrence.Currency) {
+		messages = append(messages, CashLaunchMessageCurrencyInvalidError)
+	}
+
+	switch modelCashRecurrence.Frequency {
+	case "monthly":
+		if modelCashRecurrence.Day < CashRecurrenceDayMin || modelCashRecurrence.Day > CashRecurrenceDayMax {
+			messages = append(messages, CashRecurrenceMessageDayError)
+		}
+	case "daily", "weekly", "last_business_day":
+		modelCashRecurrence.Day = 0
+	default:
+		messages = append(messages, CashRecurrenceMessageFrequencyInvalidError)
+	}
+
+	if modelCashRecurrence.StartDate.IsZero() {
+		messages = append(messages, CashRecurrenceMessageStartDateEmptyError)
+	} else if modelCashRecurrence.StartDate.Before(CashLaunchReferenceDateMin) ||
+		modelCashRecurrence.StartDate.After(CashLaunchReferenceDateMax) {
+		messages = append(messages, CashRecurrenceMessageStartDateBetweenError)
+	} else if !modelCashRecurrence.EndDate.IsZero() && modelCashRecurrence.EndDate.Before(modelCashRecurrence.StartDate) {
+		messages = append(messages, CashRecurrenceMessageEndDateSmallerStartError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func CashRecurrenceModelFormat(modelCashRecurrence *model.CashRecurrence) {
+	modelCashRecurrence.Description = util.FormatTitle(modelCashRecurrence.Description)
+	modelCashRecurrence.Type = util.FormatTextWithoutSpace(util.FormatTitle(modelCashRecurrence.Type))
+	modelCashRecurrence.Value = modelCashRecurrence.Value.Round(2)
+	modelCashRecurrence.Currency = util.FormatTextWithoutSpace(util.FormatTitle(modelCashRecurrence.Currency))
+	modelCashRecurrence.Frequency = util.FormatTextWithoutSpace(strings.ToLower(modelCashRecurrence.Frequency))
+
+	if !modelCashRecurrence.StartDate.IsZero() {
+		modelCashRecurrence.StartDate = cashRecurrenceDate(modelCashRecurrence.StartDate)
+	}
+
+	if !modelCashRecurrence.EndDate.IsZero() {
+		modelCashRecurrence.EndDate = cashRecurrenceDate(modelCashRecurrence.EndDate)
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+)
+
+// UseCaseCashRecurrenceCache decorates a CashRecurrence use case evicting the
+// cached daily balances affected by every launch created from a template
+type UseCaseCashRecurrenceCache struct {
+	UseCaseCashRecurrence CashRecurrence
+	Cache                 cache.Cache
+}
+
+func NewCashRecurrenceCache(useCaseCashRecurrence CashRecurrence, cache cache.Cache) CashRecurrence {
+	return &UseCaseCashRecurrenceCache{
+		UseCaseCashRecurrence: useCaseCashRecurrence,
+		Cache:                 cache,
+	}
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) Insert(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	return useCaseCashRecurrenceCache.UseCaseCashRecurrence.Insert(modelCashRecurrence)
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) List() (model.CashRecurrences, error) {
+	return useCaseCashRecurrenceCache.UseCaseCashRecurrence.List()
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) GetByID(id int64) (*model.CashRecurrence, error) {
+	return useCaseCashRecurrenceCache.UseCaseCashRecurrence.GetByID(id)
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) Update(modelCashRecurrence *model.CashRecurrence) (*model.CashRecurrence, error) {
+	return useCaseCashRecurrenceCache.UseCaseCashRecurrence.Update(modelCashRecurrence)
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) DeleteByID(id int64) error {
+	return useCaseCashRecurrenceCache.UseCaseCashRecurrence.DeleteByID(id)
+}
+
+func (useCaseCashRecurrenceCache *UseCaseCashRecurrenceCache) Materialize(now time.Time) (model.CashLaunches, error) {
+	modelCashLaunches, err := useCaseCashRecurrenceCache.UseCaseCashRecurrence.Materialize(now)
+
+	// the launches created before a failure are persisted as well
+	if len(modelCashLaunches) > 0 {
+		errEvict := CashBalanceDailyCacheEvict(useCaseCashRecurrenceCache.Cache)
+
+		if err == nil {
+			err = errEvict
+		}
+	}
+
+	return modelCashLaunches, err
+}
@@ -0,0 +1,260 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashRecurrenceInsert(t *testing.T) {
+	type test struct {
+		name                string
+		inputCashRecurrence *model.CashRecurrence
+		wantError           error
+		assert              func(t *testing.T, tt *test, resultCashRecurrence *model.CashRecurrence, err error)
+	}
+
+	tests := []test{
+		{
+			name:                "EmptyError",
+			inputCashRecurrence: &model.CashRecurrence{},
+			wantError:           usecase.ErrModelValidate{Message: usecase.CashLaunchMessageAccountIDEmptyError + ";" + usecase.CashLaunchMessageTypeEmptyError + ";" + usecase.CashLaunchMessageDescriptionEmptyError + ";" + usecase.CashLaunchMessageValueError + ";" + usecase.CashRecurrenceMessageFrequencyInvalidError + ";" + usecase.CashRecurrenceMessageStartDateEmptyError},
+		},
+		{
+			name: "DayAndEndDateError",
+			inputCashRecurrence: &model.CashRecurrence{
+				AccountID:   1,
+				Type:        "D",
+				Description: "ALUGUEL",
+				Value:       decimal.RequireFromString("1000"),
+				Frequency:   "monthly",
+				Day:         32,
+				StartDate:   time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:     time.Date(2009, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashRecurrenceMessageDayError + ";" + usecase.CashRecurrenceMessageEndDateSmallerStartError},
+		},
+		{
+			name: "AccountNotFoundError",
+			inputCashRecurrence: &model.CashRecurrence{
+				AccountID:   999,
+				Type:        "D",
+				Description: "ALUGUEL",
+				Value:       decimal.RequireFromString("1000"),
+				Frequency:   "daily",
+				StartDate:   time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageAccountNotFoundError},
+		},
+		{
+			name: "Success",
+			inputCashRecurrence: &model.CashRecurrence{
+				AccountID:   1,
+				CategoryID:  2,
+				Type:        "d",
+				Description: "aluguel",
+				Value:       decimal.RequireFromString("1000"),
+				Frequency:   " Weekly ",
+				Day:         10,
+				StartDate:   time.Date(2010, 1, 1, 15, 0, 0, 0, time.UTC),
+			},
+			assert: func(t *testing.T, tt *test, resultCashRecurrence *model.CashRecurrence, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashRecurrence)
+				assert.NotEqual(t, int64(0), resultCashRecurrence.ID)
+				assert.Equal(t, "weekly", resultCashRecurrence.Frequency)
+				assert.Equal(t, 0, resultCashRecurrence.Day)
+				assert.Equal(t, "BRL", resultCashRecurrence.Currency)
+				assert.Equal(t, time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), resultCashRecurrence.StartDate)
+				assert.True(t, resultCashRecurrence.LastDate.IsZero())
+
+				repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+				repositoryInMemory.CashRecurrence().DeleteByID(resultCashRecurrence.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashRecurrence := usecase.NewCashRecurrence(repository.CashRecurrence(), repository.CashAccount(), repository.CashCategory(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+			modelCashRecurrence := *tt.inputCashRecurrence
+
+			resultCashRecurrence, err := usecaseCashRecurrence.Insert(&modelCashRecurrence)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashRecurrence, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashRecurrence)
+			}
+		})
+	}
+}
+
+func TestCashRecurrenceNextDate(t *testing.T) {
+	startDate := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		inputCashRecurrence *model.CashRecurrence
+		inputAfter          time.Time
+		want                time.Time
+	}{
+		{
+			name:                "DailyBeforeStart",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "daily", StartDate: startDate},
+			inputAfter:          time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:                startDate,
+		},
+		{
+			name:                "WeeklyFirst",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "weekly", StartDate: startDate},
+			inputAfter:          startDate.AddDate(0, 0, -1),
+			want:                startDate,
+		},
+		{
+			name:                "WeeklyNext",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "weekly", StartDate: startDate},
+			inputAfter:          time.Date(2022, 1, 12, 0, 0, 0, 0, time.UTC),
+			want:                time.Date(2022, 1, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                "MonthlyEndOfMonth",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "monthly", Day: 31, StartDate: startDate},
+			inputAfter:          time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:                time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                "MonthlyNextMonth",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "monthly", Day: 10, StartDate: startDate},
+			inputAfter:          time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:                time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                "LastBusinessDayWeekend",
+			inputCashRecurrence: &model.CashRecurrence{Frequency: "last_business_day", StartDate: startDate},
+			inputAfter:          time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:                time.Date(2022, 4, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usecase.CashRecurrenceNextDate(tt.inputCashRecurrence, tt.inputAfter))
+		})
+	}
+}
+
+func TestCashRecurrenceMaterialize(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashRecurrence := usecase.NewCashRecurrence(repositoryInMemory.CashRecurrence(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashRecurrence, err := usecaseCashRecurrence.Insert(&model.CashRecurrence{
+		AccountID:   1,
+		CategoryID:  2,
+		Type:        "D",
+		Description: "ALUGUEL",
+		Value:       decimal.RequireFromString("1000"),
+		Frequency:   "monthly",
+		Day:         31,
+		StartDate:   time.Date(2010, 1, 15, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2010, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+
+	// backfills every occurrence missed until now
+	resultCashLaunches, err := usecaseCashRecurrence.Materialize(time.Date(2010, 4, 10, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 3)
+
+	wantReferenceDates := []time.Time{
+		time.Date(2010, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2010, 2, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2010, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	for idx, resultCashLaunch := range resultCashLaunches {
+		assert.Equal(t, wantReferenceDates[idx], resultCashLaunch.ReferenceDate)
+		assert.Equal(t, modelCashRecurrence.ID, resultCashLaunch.RecurrenceID)
+		assert.Equal(t, int64(2), resultCashLaunch.CategoryID)
+
+		// the launches of a run are audited to the system actor
+		resultCashLaunchAudits, err := repositoryInMemory.CashLaunch().ListAuditByID(resultCashLaunch.ID)
+		assert.Nil(t, err)
+		assert.Len(t, resultCashLaunchAudits, 1)
+		assert.Equal(t, usecase.AuditActorSystem, resultCashLaunchAudits[0].Actor)
+		assert.NotEmpty(t, resultCashLaunchAudits[0].RequestID)
+	}
+
+	// a run after a restart does not duplicate the occurrences
+	resultCashLaunches, err = usecaseCashRecurrence.Materialize(time.Date(2010, 4, 10, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 0)
+
+	// the occurrences after the end_date are not created
+	resultCashLaunches, err = usecaseCashRecurrence.Materialize(time.Date(2011, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 9)
+
+	resultCashRecurrence, err := usecaseCashRecurrence.GetByID(modelCashRecurrence.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2010, 12, 31, 0, 0, 0, 0, time.UTC), resultCashRecurrence.LastDate)
+
+	// the launches created are kept when the template is deleted
+	err = usecaseCashRecurrence.DeleteByID(modelCashRecurrence.ID)
+	assert.Nil(t, err)
+
+	modelCashLaunches, err := repositoryInMemory.CashLaunch().List(&model.CashLaunchFilter{})
+	assert.Nil(t, err)
+
+	count := 0
+
+	for _, modelCashLaunch := range modelCashLaunches {
+		if modelCashLaunch.ReferenceDate.Year() == 2010 && modelCashLaunch.Description == "ALUGUEL" {
+			assert.Equal(t, int64(0), modelCashLaunch.RecurrenceID)
+			repositoryInMemory.CashLaunch().DeleteByID(modelCashLaunch.ID, modelAuditDefault)
+			count++
+		}
+	}
+
+	assert.Equal(t, 12, count)
+}
+
+func TestCashRecurrenceMaterializeApprovalThreshold(t *testing.T) {
+	repositoryInMemory, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashRecurrence := usecase.NewCashRecurrence(repositoryInMemory.CashRecurrence(), repositoryInMemory.CashAccount(), repositoryInMemory.CashCategory(), repositoryInMemory.CashPeriod(), repositoryInMemory.ExchangeRate(), baseCurrencyDefault, usecase.CashLaunchApprovalThresholds{"D": decimal.NewFromInt(5000)})
+
+	modelCashRecurrence, err := usecaseCashRecurrence.Insert(&model.CashRecurrence{
+		AccountID:   1,
+		CategoryID:  2,
+		Type:        "D",
+		Description: "FOLHA",
+		Value:       decimal.RequireFromString("6000"),
+		Frequency:   "monthly",
+		Day:         5,
+		StartDate:   time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2012, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+
+	// the occurrence above the threshold waits for approval
+	resultCashLaunches, err := usecaseCashRecurrence.Materialize(time.Date(2012, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, resultCashLaunches, 1)
+	assert.Equal(t, repository.CashLaunchStatusPending, resultCashLaunches[0].Status)
+
+	assert.Nil(t, usecaseCashRecurrence.DeleteByID(modelCashRecurrence.ID))
+	assert.Nil(t, repositoryInMemory.CashLaunch().DeleteByID(resultCashLaunches[0].ID, modelAuditDefault))
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+)
+
+var (
+	CashTransferMessageFromAccountIDEmptyError  = "The from_account_id is empty"
+	CashTransferMessageToAccountIDEmptyError    = "The to_account_id is empty"
+	CashTransferMessageAccountIDEqualError      = "The from_account_id is equal the to_account_id"
+	CashTransferMessageFromAccountNotFoundError = "The from_account_id does not exist"
+	CashTransferMessageToAccountNotFoundError   = "The to_account_id does not exist"
+)
+
+type CashTransfer interface {
+	Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error)
+}
+
+type UseCaseCashTransfer struct {
+	RepositoryCashTransfer repository.CashTransfer
+	RepositoryCashAccount  repository.CashAccount
+	RepositoryCashPeriod   repository.CashPeriod
+	RepositoryExchangeRate repository.ExchangeRate
+	BaseCurrency           string
+}
+
+func NewCashTransfer(repositoryCashTransfer repository.CashTransfer, repositoryCashAccount repository.CashAccount, repositoryCashPeriod repository.CashPeriod, repositoryExchangeRate repository.ExchangeRate, baseCurrency string) CashTransfer {
+	return &UseCaseCashTransfer{
+		RepositoryCashTransfer: repositoryCashTransfer,
+		RepositoryCashAccount:  repositoryCashAccount,
+		RepositoryCashPeriod:   repositoryCashPeriod,
+		RepositoryExchangeRate: repositoryExchangeRate,
+		BaseCurrency:           baseCurrency,
+	}
+}
+
+// Insert creates the debit on the source account and the credit on the
+// destination account of the transfer
+func (useCaseCashTransfer *UseCaseCashTransfer) Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error) {
+	if modelCashTransfer.Currency == "" {
+		modelCashTransfer.Currency = useCaseCashTransfer.BaseCurrency
+	}
+
+	err := cashTransferModelValidate(modelCashTransfer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashTransfer.cashAccountValidate(modelCashTransfer.FromAccountID, CashTransferMessageFromAccountNotFoundError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = useCaseCashTransfer.cashAccountValidate(modelCashTransfer.ToAccountID, CashTransferMessageToAccountNotFoundError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cashPeriodOpenValidate(useCaseCashTransfer.RepositoryCashPeriod, modelCashTransfer.ReferenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunch := model.CashLaunch{
+		ReferenceDate: modelCashTransfer.ReferenceDate,
+		Description:   modelCashTransfer.Description,
+		Value:         modelCashTransfer.Value,
+		Currency:      modelCashTransfer.Currency,
+		// the money stays in the accounts, so a transfer needs no approval
+		Status: repository.CashLaunchStatusApproved,
+	}
+
+	err = cashLaunchExchangeRateApply(useCaseCashTransfer.RepositoryExchangeRate, useCaseCashTransfer.BaseCurrency, &modelCashLaunch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	modelCashLaunch.CreatedAt = time.Now().UTC()
+	modelCashLaunch.UpdatedAt = modelCashLaunch.CreatedAt
+
+	modelCashTransfer.Debit = modelCashLaunch
+	modelCashTransfer.Debit.AccountID = modelCashTransfer.FromAccountID
+	modelCashTransfer.Debit.Type = "D"
+
+	modelCashTransfer.Credit = modelCashLaunch
+	modelCashTransfer.Credit.AccountID = modelCashTransfer.ToAccountID
+	modelCashTransfer.Credit.Type = "C"
+
+	modelCashTransfer, err = useCaseCashTransfer.RepositoryCashTransfer.Insert(modelCashTransfer, modelAudit)
+
+	return modelCashTransfer, cashPeriodRepositoryError(err)
+}
+
+// cashAccountValidate checks the account of one side of the transfer exists
+func (useCaseCashTransfer *UseCaseCashTransfer) cashAccountValidate(accountID int64, message string) error {
+	_, err := useCaseCashTransfer.RepositoryCashAccount.GetByID(accountID)
+
+	if _, ok := err.(repository.ErrNotFound); ok {
+		return ErrModelValidate{Message: message}
+	}
+
+	return err
+}
+
+func cashTransferModelValidate(modelCashTransfer *model.CashTransfer) error {
+	messages := []string{}
+
+	CashTransferModelFormat(modelCashTransfer)
+
+	if modelCashTransfer.FromAccountID <= 0 {
+		messages = append(messages, CashTransferMessageFromAccountIDEmptyError)
+	}
+
+	if modelCashTransfer.ToAccountID <= 0 {
+		messages = append(messages, CashTransferMessageToAccountIDEmptyError)
+	} else if modelCashTransfer.ToAccountID == modelCashTransfer.FromAccountID {
+		messages = append(messages, CashTransferMessageAccountIDEqualError)
+	}
+
+	err := cashLaunchReferenceDateValidate(modelCashTransfer.ReferenceDate)
+
+	if err != nil {
+		messages = append(messages, err.Error())
+	}
+
+	if modelCashTransfer.Description == "" {
+		messages = append(messages, CashLaunchMessageDescriptionEmptyError)
+	} else if len(modelCashTransfer.Description) < CashLaunchDescriptionMinLen ||
+		len(modelCashTransfer.Description) > CashLaunchDescriptionMaxLen {
+		messages = append(messages, CashLaunchMessageDescriptionSizeError)
+	}
+
+	if !modelCashTransfer.Value.IsPositive() {
+		messages = append(messages, CashLaunchMessageValueError)
+	}
+
+	if !CurrencyValidate(modelCashTransfer.Currency) {
+		messages = append(messages, CashLaunchMessageCurrencyInvalidError)
+	}
+
+	if len(messages) > 0 {
+		return ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	return nil
+}
+
+func CashTransferModelFormat(modelCashTransfer *model.CashTransfer) {
+	modelCashTransfer.Description = util.FormatTitle(modelCashTransfer.Description)
+	modelCashTransfer.Value = modelCashTransfer.Value.Round(2)
+	modelCashTransfer.Currency = util.FormatTextWithoutSpace(util.FormatTitle(modelCashTransfer.Currency))
+}
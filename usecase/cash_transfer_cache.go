@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/cache"
+)
+
+// UseCaseCashTransferCache decorates a CashTransfer use case evicting the
+// cached daily balances affected by every transfer written
+type UseCaseCashTransferCache struct {
+	UseCaseCashTransfer CashTransfer
+	Cache               cache.Cache
+}
+
+func NewCashTransferCache(useCaseCashTransfer CashTransfer, cache cache.Cache) CashTransfer {
+	return &UseCaseCashTransferCache{
+		UseCaseCashTransfer: useCaseCashTransfer,
+		Cache:               cache,
+	}
+}
+
+func (useCaseCashTransferCache *UseCaseCashTransferCache) Insert(modelCashTransfer *model.CashTransfer, modelAudit model.Audit) (*model.CashTransfer, error) {
+	modelCashTransferInsert, err := useCaseCashTransferCache.UseCaseCashTransfer.Insert(modelCashTransfer, modelAudit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = CashBalanceDailyCacheEvict(useCaseCashTransferCache.Cache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelCashTransferInsert, nil
+}
@@ -0,0 +1,236 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var modelCashTransferDefault = &model.CashTransfer{
+	FromAccountID: 1,
+	ToAccountID:   2,
+	ReferenceDate: time.Date(2010, 01, 05, 00, 00, 00, 000, time.UTC),
+	Description:   "Transfer Test",
+	Value:         decimal.RequireFromString("100"),
+}
+
+func TestCashTransferInsert(t *testing.T) {
+	type test struct {
+		name              string
+		inputCashTransfer *model.CashTransfer
+		wantError         error
+		assert            func(t *testing.T, tt *test, resultCashTransfer *model.CashTransfer, err error)
+	}
+
+	tests := []test{
+		{
+			name: "AccountIDEmptyError",
+			inputCashTransfer: &model.CashTransfer{
+				ReferenceDate: modelCashTransferDefault.ReferenceDate,
+				Description:   modelCashTransferDefault.Description,
+				Value:         modelCashTransferDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashTransferMessageFromAccountIDEmptyError + ";" + usecase.CashTransferMessageToAccountIDEmptyError},
+		},
+		{
+			name: "AccountIDEqualError",
+			inputCashTransfer: &model.CashTransfer{
+				FromAccountID: 1,
+				ToAccountID:   1,
+				ReferenceDate: modelCashTransferDefault.ReferenceDate,
+				Description:   modelCashTransferDefault.Description,
+				Value:         modelCashTransferDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashTransferMessageAccountIDEqualError},
+		},
+		{
+			name: "ValueZeroError",
+			inputCashTransfer: &model.CashTransfer{
+				FromAccountID: modelCashTransferDefault.FromAccountID,
+				ToAccountID:   modelCashTransferDefault.ToAccountID,
+				ReferenceDate: modelCashTransferDefault.ReferenceDate,
+				Description:   modelCashTransferDefault.Description,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageValueError},
+		},
+		{
+			name: "FromAccountNotFoundError",
+			inputCashTransfer: &model.CashTransfer{
+				FromAccountID: 999,
+				ToAccountID:   modelCashTransferDefault.ToAccountID,
+				ReferenceDate: modelCashTransferDefault.ReferenceDate,
+				Description:   modelCashTransferDefault.Description,
+				Value:         modelCashTransferDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashTransferMessageFromAccountNotFoundError},
+		},
+		{
+			name: "ToAccountNotFoundError",
+			inputCashTransfer: &model.CashTransfer{
+				FromAccountID: modelCashTransferDefault.FromAccountID,
+				ToAccountID:   999,
+				ReferenceDate: modelCashTransferDefault.ReferenceDate,
+				Description:   modelCashTransferDefault.Description,
+				Value:         modelCashTransferDefault.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashTransferMessageToAccountNotFoundError},
+		},
+		{
+			name:              "Success",
+			inputCashTransfer: modelCashTransferDefault,
+			assert: func(t *testing.T, tt *test, resultCashTransfer *model.CashTransfer, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, resultCashTransfer)
+				assert.NotEqual(t, int64(0), resultCashTransfer.ID)
+
+				assert.NotEqual(t, int64(0), resultCashTransfer.Debit.ID)
+				assert.Equal(t, resultCashTransfer.ID, resultCashTransfer.Debit.TransferID)
+				assert.Equal(t, tt.inputCashTransfer.FromAccountID, resultCashTransfer.Debit.AccountID)
+				assert.Equal(t, "D", resultCashTransfer.Debit.Type)
+				assert.Equal(t, "TRANSFER TEST", resultCashTransfer.Debit.Description)
+				assert.Equal(t, baseCurrencyDefault, resultCashTransfer.Debit.Currency)
+				assert.Equal(t, "100", resultCashTransfer.Debit.BaseValue.String())
+
+				assert.NotEqual(t, resultCashTransfer.Debit.ID, resultCashTransfer.Credit.ID)
+				assert.Equal(t, resultCashTransfer.ID, resultCashTransfer.Credit.TransferID)
+				assert.Equal(t, tt.inputCashTransfer.ToAccountID, resultCashTransfer.Credit.AccountID)
+				assert.Equal(t, "C", resultCashTransfer.Credit.Type)
+				assert.Equal(t, "100", resultCashTransfer.Credit.BaseValue.String())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+			usecaseCashTransfer := usecase.NewCashTransfer(repository.CashTransfer(), repository.CashAccount(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault)
+
+			modelCashTransfer := *tt.inputCashTransfer
+
+			resultCashTransfer, err := usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+
+			if tt.assert != nil {
+				tt.assert(t, &tt, resultCashTransfer, err)
+			} else {
+				if !reflect.DeepEqual(err, tt.wantError) {
+					t.Errorf("Insert() got error = %v, want = %v.", err, tt.wantError)
+				}
+
+				assert.Nil(t, resultCashTransfer)
+			}
+		})
+	}
+}
+
+func TestCashTransferBalanceDaily(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashTransfer := usecase.NewCashTransfer(repository.CashTransfer(), repository.CashAccount(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault)
+	usecaseCashBalanceDaily := usecase.NewCashBalanceDaily(repository.CashBalanceDaily())
+
+	referenceDate := time.Date(2010, 02, 05, 00, 00, 00, 000, time.UTC)
+
+	modelCashTransfer := *modelCashTransferDefault
+	modelCashTransfer.ReferenceDate = referenceDate
+
+	_, err := usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+	assert.Nil(t, err)
+
+	// the combined balance nets the transfer out
+	resultCashBalanceDaily, err := usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, 0, false, false)
+	assert.Nil(t, err)
+	assert.True(t, resultCashBalanceDaily.TotalCredit.IsZero())
+	assert.True(t, resultCashBalanceDaily.TotalDebit.IsZero())
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, modelCashTransfer.FromAccountID, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", resultCashBalanceDaily.TotalDebit.String())
+	assert.True(t, resultCashBalanceDaily.TotalCredit.IsZero())
+
+	resultCashBalanceDaily, err = usecaseCashBalanceDaily.GetByReferenceDate(referenceDate, modelCashTransfer.ToAccountID, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", resultCashBalanceDaily.TotalCredit.String())
+	assert.True(t, resultCashBalanceDaily.TotalDebit.IsZero())
+}
+
+func TestCashTransferLaunchUpdate(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+	usecaseCashTransfer := usecase.NewCashTransfer(repository.CashTransfer(), repository.CashAccount(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault)
+	usecaseCashLaunch := usecase.NewCashLaunch(repository.CashLaunch(), repository.CashAccount(), repository.CashCategory(), repository.CashCategoryRule(), repository.CashInstallment(), repository.CashPeriod(), repository.ExchangeRate(), baseCurrencyDefault, nil)
+
+	modelCashTransfer := *modelCashTransferDefault
+
+	resultCashTransfer, err := usecaseCashTransfer.Insert(&modelCashTransfer, modelAuditDefault)
+	assert.Nil(t, err)
+
+	type test struct {
+		name            string
+		inputCashLaunch *model.CashLaunch
+		wantError       error
+	}
+
+	tests := []test{
+		{
+			name: "TypeError",
+			inputCashLaunch: &model.CashLaunch{
+				ID:            resultCashTransfer.Debit.ID,
+				AccountID:     resultCashTransfer.Debit.AccountID,
+				ReferenceDate: resultCashTransfer.Debit.ReferenceDate,
+				Type:          "C",
+				Description:   resultCashTransfer.Debit.Description,
+				Value:         resultCashTransfer.Debit.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageTransferTypeError},
+		},
+		{
+			name: "AccountError",
+			inputCashLaunch: &model.CashLaunch{
+				ID:            resultCashTransfer.Debit.ID,
+				AccountID:     resultCashTransfer.Credit.AccountID,
+				ReferenceDate: resultCashTransfer.Debit.ReferenceDate,
+				Type:          resultCashTransfer.Debit.Type,
+				Description:   resultCashTransfer.Debit.Description,
+				Value:         resultCashTransfer.Debit.Value,
+			},
+			wantError: usecase.ErrModelValidate{Message: usecase.CashLaunchMessageTransferAccountError},
+		},
+		{
+			name: "Success",
+			inputCashLaunch: &model.CashLaunch{
+				ID:            resultCashTransfer.Debit.ID,
+				AccountID:     resultCashTransfer.Debit.AccountID,
+				ReferenceDate: resultCashTransfer.Debit.ReferenceDate.AddDate(0, 0, 1),
+				Type:          resultCashTransfer.Debit.Type,
+				Description:   "Transfer Test Updated",
+				Value:         decimal.RequireFromString("50"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelCashLaunch := *tt.inputCashLaunch
+
+			_, err := usecaseCashLaunch.Update(&modelCashLaunch, modelAuditDefault)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("Update() got error = %v, want = %v.", err, tt.wantError)
+			}
+		})
+	}
+
+	// the other side of the transfer follows the updated launch
+	resultCashLaunch, err := usecaseCashLaunch.GetByID(resultCashTransfer.Credit.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, resultCashTransfer.ID, resultCashLaunch.TransferID)
+	assert.Equal(t, resultCashTransfer.Credit.AccountID, resultCashLaunch.AccountID)
+	assert.Equal(t, "C", resultCashLaunch.Type)
+	assert.Equal(t, resultCashTransfer.ReferenceDate.AddDate(0, 0, 1), resultCashLaunch.ReferenceDate)
+	assert.Equal(t, "TRANSFER TEST UPDATED", resultCashLaunch.Description)
+	assert.Equal(t, "50", resultCashLaunch.BaseValue.String())
+}
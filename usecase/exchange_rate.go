@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	exchange_rate "github.com/CharlesSchiavinato/minsait-challenge-backend/service/exchange_rate"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ExchangeRateMessageCurrencyInvalidError = "The currency %q is not a ISO-4217 code"
+	ExchangeRateMessageRateError            = "The rate of the currency %q is less or equal 0"
+)
+
+type ExchangeRate interface {
+	Import() (model.ExchangeRates, error)
+	GetByReferenceDate(referenceDate time.Time) (model.ExchangeRates, error)
+	GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams *model.ExchangeRateRangeReferenceDate) (model.ExchangeRates, error)
+}
+
+type UseCaseExchangeRate struct {
+	RepositoryExchangeRate repository.ExchangeRate
+	ServiceExchangeRate    exchange_rate.ExchangeRate
+}
+
+func NewExchangeRate(repositoryExchangeRate repository.ExchangeRate, serviceExchangeRate exchange_rate.ExchangeRate) ExchangeRate {
+	return &UseCaseExchangeRate{
+		RepositoryExchangeRate: repositoryExchangeRate,
+		ServiceExchangeRate:    serviceExchangeRate,
+	}
+}
+
+// Import fetches the rates from the provider and stores them
+func (useCaseExchangeRate *UseCaseExchangeRate) Import() (model.ExchangeRates, error) {
+	modelExchangeRates, err := useCaseExchangeRate.ServiceExchangeRate.Fetch()
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []string{}
+	now := time.Now().UTC()
+
+	for idx := range modelExchangeRates {
+		modelExchangeRate := &modelExchangeRates[idx]
+
+		modelExchangeRate.BaseCurrency = util.FormatTextWithoutSpace(util.FormatTitle(modelExchangeRate.BaseCurrency))
+		modelExchangeRate.Currency = util.FormatTextWithoutSpace(util.FormatTitle(modelExchangeRate.Currency))
+		modelExchangeRate.UpdatedAt = now
+		modelExchangeRate.CreatedAt = now
+
+		if !CurrencyValidate(modelExchangeRate.Currency) {
+			messages = append(messages, fmt.Sprintf(ExchangeRateMessageCurrencyInvalidError, modelExchangeRate.Currency))
+		} else if !modelExchangeRate.Rate.IsPositive() {
+			messages = append(messages, fmt.Sprintf(ExchangeRateMessageRateError, modelExchangeRate.Currency))
+		}
+	}
+
+	if len(messages) > 0 {
+		return nil, ErrModelValidate{Message: strings.Join(messages, ";")}
+	}
+
+	err = useCaseExchangeRate.RepositoryExchangeRate.Upsert(modelExchangeRates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return modelExchangeRates, nil
+}
+
+func (useCaseExchangeRate *UseCaseExchangeRate) GetByReferenceDate(referenceDate time.Time) (model.ExchangeRates, error) {
+	err := cashLaunchReferenceDateValidate(referenceDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return useCaseExchangeRate.RepositoryExchangeRate.GetByReferenceDate(referenceDate)
+}
+
+func (useCaseExchangeRate *UseCaseExchangeRate) GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams *model.ExchangeRateRangeReferenceDate) (model.ExchangeRates, error) {
+	err := CashBalanceDailyRangeReferenceDateValidate(&model.CashBalanceDailyRangeReferenceDate{
+		From: exchangeRateRangeReferenceDateParams.From,
+		To:   exchangeRateRangeReferenceDateParams.To,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return useCaseExchangeRate.RepositoryExchangeRate.GetByRangeReferenceDate(exchangeRateRangeReferenceDateParams)
+}
+
+// CurrencyValidate checks the currency is a three letters ISO-4217 code
+func CurrencyValidate(currency string) bool {
+	return len(currency) == 3 && util.IsAlpha(currency)
+}
+
+// ExchangeRateConvert returns the rate converting one unit of the currency
+// from into the currency to, crossing the quotes through their base currency
+func ExchangeRateConvert(modelExchangeRates model.ExchangeRates, currencyFrom, currencyTo string) (decimal.Decimal, bool) {
+	if currencyFrom == currencyTo {
+		return decimal.NewFromInt(1), true
+	}
+
+	rates := map[string]decimal.Decimal{}
+
+	for _, modelExchangeRate := range modelExchangeRates {
+		rates[modelExchangeRate.BaseCurrency] = decimal.NewFromInt(1)
+		rates[modelExchangeRate.Currency] = modelExchangeRate.Rate
+	}
+
+	rateFrom, okFrom := rates[currencyFrom]
+	rateTo, okTo := rates[currencyTo]
+
+	if !okFrom || !okTo || rateFrom.IsZero() {
+		return decimal.Zero, false
+	}
+
+	return rateTo.DivRound(rateFrom, 6), true
+}
@@ -0,0 +1,232 @@
+package usecase_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/model"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository"
+	repository_in_memory "github.com/CharlesSchiavinato/minsait-challenge-backend/service/database/repository/in_memory"
+	exchange_rate "github.com/CharlesSchiavinato/minsait-challenge-backend/service/exchange_rate/ecb"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/usecase"
+	"github.com/CharlesSchiavinato/minsait-challenge-backend/util"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRateImport(t *testing.T) {
+	type test struct {
+		name         string
+		resCode      int
+		resBody      string
+		repoError    bool
+		wantRates    map[string]string
+		wantError    bool
+		wantErrorMsg error
+	}
+
+	fixture, err := os.ReadFile("testdata/eurofxref-daily.xml")
+
+	if err != nil {
+		t.Fatalf("ReadFile() got error = %v", err)
+	}
+
+	tests := []test{
+		{
+			name:      "ServerError",
+			resCode:   http.StatusInternalServerError,
+			wantError: true,
+		},
+		{
+			name:      "MalformedXMLError",
+			resCode:   http.StatusOK,
+			resBody:   "<gesmes:Envelope><Cube>",
+			wantError: true,
+		},
+		{
+			name:      "EmptyEnvelopeError",
+			resCode:   http.StatusOK,
+			resBody:   "<gesmes:Envelope><Cube></Cube></gesmes:Envelope>",
+			wantError: true,
+		},
+		{
+			name:      "ModelValidateError",
+			resCode:   http.StatusOK,
+			resBody:   "<gesmes:Envelope><Cube><Cube time='2023-05-04'><Cube currency='US$' rate='1.1'/><Cube currency='JPY' rate='0'/></Cube></Cube></gesmes:Envelope>",
+			wantError: true,
+			wantErrorMsg: usecase.ErrModelValidate{Message: fmt.Sprintf(usecase.ExchangeRateMessageCurrencyInvalidError, "US$") + ";" +
+				fmt.Sprintf(usecase.ExchangeRateMessageRateError, "JPY")},
+		},
+		{
+			name:      "RepositoryError",
+			resCode:   http.StatusOK,
+			resBody:   string(fixture),
+			repoError: true,
+			wantError: true,
+		},
+		{
+			name:      "Success",
+			resCode:   http.StatusOK,
+			resBody:   string(fixture),
+			wantRates: map[string]string{"USD": "1.1011", "JPY": "148.33", "BRL": "5.4998"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tt.resCode)
+				rw.Write([]byte(tt.resBody))
+			}))
+
+			defer server.Close()
+
+			repository, _ := repository_in_memory.NewInMemory(tt.repoError)
+			serviceExchangeRate := exchange_rate.NewECB(&util.Config{ExchangeRateURL: server.URL})
+
+			usecaseExchangeRate := usecase.NewExchangeRate(repository.ExchangeRate(), serviceExchangeRate)
+
+			resultExchangeRates, err := usecaseExchangeRate.Import()
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Import() got error = %v, want error = %v.", err, tt.wantError)
+			}
+
+			if tt.wantErrorMsg != nil && !reflect.DeepEqual(err, tt.wantErrorMsg) {
+				t.Errorf("Import() got error = %v, want = %v.", err, tt.wantErrorMsg)
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			assert.Equal(t, len(tt.wantRates), len(resultExchangeRates))
+
+			for _, resultExchangeRate := range resultExchangeRates {
+				assert.Equal(t, time.Date(2023, 05, 05, 00, 00, 00, 000, time.UTC), resultExchangeRate.ReferenceDate)
+				assert.Equal(t, "EUR", resultExchangeRate.BaseCurrency)
+				assert.Equal(t, tt.wantRates[resultExchangeRate.Currency], resultExchangeRate.Rate.String())
+			}
+
+			// the rates published on friday are still valid on the weekend
+			storedExchangeRates, err := usecaseExchangeRate.GetByReferenceDate(time.Date(2023, 05, 07, 00, 00, 00, 000, time.UTC))
+
+			assert.Nil(t, err)
+			assert.True(t, equalJSON(resultExchangeRates, storedExchangeRates))
+		})
+	}
+}
+
+func TestExchangeRateGetByReferenceDate(t *testing.T) {
+	type test struct {
+		name               string
+		inputReferenceDate time.Time
+		wantRates          map[string]string
+		wantError          error
+	}
+
+	tests := []test{
+		{
+			name:               "ReferenceDateBeforeError",
+			inputReferenceDate: usecase.CashLaunchReferenceDateMin.AddDate(0, 0, -1),
+			wantError:          usecase.ErrParamValidate{Message: usecase.CashLaunchMessageReferenceDateBetweenError},
+		},
+		{
+			name:               "NotFoundError",
+			inputReferenceDate: time.Date(2000, 11, 20, 00, 00, 00, 000, time.UTC),
+			wantError:          repository.ErrNotFound{Message: "not found"},
+		},
+		{
+			name:               "SuccessPreviousDate",
+			inputReferenceDate: time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+			wantRates:          map[string]string{"USD": "0.8489"},
+		},
+		{
+			name:               "Success",
+			inputReferenceDate: time.Date(2000, 11, 23, 00, 00, 00, 000, time.UTC),
+			wantRates:          map[string]string{"BRL": "1.681", "USD": "0.85"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, _ := repository_in_memory.NewInMemory(false)
+
+			usecaseExchangeRate := usecase.NewExchangeRate(repository.ExchangeRate(), nil)
+
+			resultExchangeRates, err := usecaseExchangeRate.GetByReferenceDate(tt.inputReferenceDate)
+
+			if !reflect.DeepEqual(err, tt.wantError) {
+				t.Errorf("GetByReferenceDate() got error = %v, want = %v.", err, tt.wantError)
+			}
+
+			resultRates := map[string]string{}
+
+			for _, resultExchangeRate := range resultExchangeRates {
+				resultRates[resultExchangeRate.Currency] = resultExchangeRate.Rate.String()
+			}
+
+			if tt.wantRates != nil && !reflect.DeepEqual(resultRates, tt.wantRates) {
+				t.Errorf("GetByReferenceDate() got result = %v, want = %v.", resultRates, tt.wantRates)
+			}
+		})
+	}
+}
+
+func TestExchangeRateGetByRangeReferenceDate(t *testing.T) {
+	repository, _ := repository_in_memory.NewInMemory(false)
+
+	usecaseExchangeRate := usecase.NewExchangeRate(repository.ExchangeRate(), nil)
+
+	_, err := usecaseExchangeRate.GetByRangeReferenceDate(&model.ExchangeRateRangeReferenceDate{
+		From: time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+		To:   time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+	})
+
+	assert.Equal(t, usecase.ErrParamValidate{Message: usecase.CashBalanceDailyRangeReferenceDateToSmallerFromError}, err)
+
+	resultExchangeRates, err := usecaseExchangeRate.GetByRangeReferenceDate(&model.ExchangeRateRangeReferenceDate{
+		From: time.Date(2000, 11, 21, 00, 00, 00, 000, time.UTC),
+		To:   time.Date(2000, 11, 22, 00, 00, 00, 000, time.UTC),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(resultExchangeRates))
+}
+
+func TestExchangeRateConvert(t *testing.T) {
+	modelExchangeRates := model.ExchangeRates{
+		{BaseCurrency: "EUR", Currency: "BRL", Rate: decimal.RequireFromString("5.5")},
+		{BaseCurrency: "EUR", Currency: "USD", Rate: decimal.RequireFromString("1.1")},
+	}
+
+	type test struct {
+		name         string
+		currencyFrom string
+		currencyTo   string
+		wantRate     string
+		wantOk       bool
+	}
+
+	tests := []test{
+		{name: "SameCurrency", currencyFrom: "JPY", currencyTo: "JPY", wantRate: "1", wantOk: true},
+		{name: "FromBaseCurrency", currencyFrom: "EUR", currencyTo: "BRL", wantRate: "5.5", wantOk: true},
+		{name: "ToBaseCurrency", currencyFrom: "USD", currencyTo: "EUR", wantRate: "0.909091", wantOk: true},
+		{name: "CrossCurrency", currencyFrom: "USD", currencyTo: "BRL", wantRate: "5", wantOk: true},
+		{name: "NotFound", currencyFrom: "JPY", currencyTo: "BRL", wantRate: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := usecase.ExchangeRateConvert(modelExchangeRates, tt.currencyFrom, tt.currencyTo)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantRate, rate.String())
+		})
+	}
+}
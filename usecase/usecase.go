@@ -19,3 +19,23 @@ type ErrParamValidate struct {
 func (epv ErrParamValidate) Error() string {
 	return epv.Message
 }
+
+// ErrPeriodClosed denotes failing change of a launch in a closed period.
+type ErrPeriodClosed struct {
+	Message string
+}
+
+// ErrPeriodClosed returns the closed period error.
+func (epc ErrPeriodClosed) Error() string {
+	return epc.Message
+}
+
+// ErrForbidden denotes failing permission of the actor.
+type ErrForbidden struct {
+	Message string
+}
+
+// ErrForbidden returns the permission error.
+func (ef ErrForbidden) Error() string {
+	return ef.Message
+}
@@ -0,0 +1,15 @@
+package usecase_test
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// equalJSON compares the JSON representation of the values, the decimals
+// keep their own scale internally so the same amount is not always DeepEqual
+func equalJSON(expected, actual interface{}) bool {
+	bytesExpected, errExpected := json.Marshal(expected)
+	bytesActual, errActual := json.Marshal(actual)
+
+	return errExpected == nil && errActual == nil && bytes.Equal(bytesExpected, bytesActual)
+}
@@ -7,18 +7,22 @@ import (
 // Config stores all configuration of the application
 // The values are read by viper from a config file or environment variables
 type Config struct {
-	ServerAddress               string `mapstructure:"SERVER_ADDRESS"`
-	ServerAppName               string `mapstructure:"SERVER_APP_NAME"`
-	ServerCORSAllowedOrigins    string `mapstructure:"SERVER_CORS_ALLOWED_ORIGINS"`
-	ServerLogLevel              string `mapstructure:"SERVER_LOG_LEVEL"`
-	ServerLogJSONFormat         bool   `mapstructure:"SERVER_LOG_JSON_FORMAT"`
-	DBDriver                    string `mapstructure:"DB_DRIVER"`
-	DBURL                       string `mapstructure:"DB_URL"`
-	DBMigrationURL              string `mapstructure:"DB_MIGRATION_URL"`
-	CacheURL                    string `mapstructure:"CACHE_URL"`
-	CacheExpiration             string `mapstructure:"CACHE_EXPIRATION"`
-	ExchangeRateURL             string `mapstructure:"EXCHANGE_RATE_URL"`
-	ExchangeRateCronJobSchedule string `mapstructure:"EXCHANGE_RATE_CRON_JOB_SCHEDULE"`
+	ServerAddress                 string `mapstructure:"SERVER_ADDRESS"`
+	ServerAppName                 string `mapstructure:"SERVER_APP_NAME"`
+	ServerCORSAllowedOrigins      string `mapstructure:"SERVER_CORS_ALLOWED_ORIGINS"`
+	ServerLogLevel                string `mapstructure:"SERVER_LOG_LEVEL"`
+	ServerLogJSONFormat           bool   `mapstructure:"SERVER_LOG_JSON_FORMAT"`
+	DBDriver                      string `mapstructure:"DB_DRIVER"`
+	DBURL                         string `mapstructure:"DB_URL"`
+	DBMigrationURL                string `mapstructure:"DB_MIGRATION_URL"`
+	CacheURL                      string `mapstructure:"CACHE_URL"`
+	CacheExpiration               string `mapstructure:"CACHE_EXPIRATION"`
+	ExchangeRateURL               string `mapstructure:"EXCHANGE_RATE_URL"`
+	ExchangeRateCronJobSchedule   string `mapstructure:"EXCHANGE_RATE_CRON_JOB_SCHEDULE"`
+	CashRecurrenceCronJobSchedule string `mapstructure:"CASH_RECURRENCE_CRON_JOB_SCHEDULE"`
+	BaseCurrency                  string `mapstructure:"BASE_CURRENCY"`
+	CashPeriodReopenActors        string `mapstructure:"CASH_PERIOD_REOPEN_ACTORS"`
+	CashLaunchApprovalThresholds  string `mapstructure:"CASH_LAUNCH_APPROVAL_THRESHOLDS"`
 }
 
 // loadConfig reads configurations from file or environment variables
@@ -39,6 +43,12 @@ func LoadConfig(path string) (config *Config, err error) {
 	viper.SetDefault("CACHE_EXPIRATION", "1m")
 	viper.SetDefault("EXCHANGE_RATE_URL", "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml")
 	viper.SetDefault("EXCHANGE_RATE_CRON_JOB_SCHEDULE", "5m")
+	viper.SetDefault("CASH_RECURRENCE_CRON_JOB_SCHEDULE", "1h")
+	viper.SetDefault("BASE_CURRENCY", "BRL")
+	// the reopen actors are matched against the X-User-ID header, trusted as set
+	// by an authenticating proxy in front of the API, none by default
+	viper.SetDefault("CASH_PERIOD_REOPEN_ACTORS", "")
+	viper.SetDefault("CASH_LAUNCH_APPROVAL_THRESHOLDS", "")
 
 	viper.AutomaticEnv()
 